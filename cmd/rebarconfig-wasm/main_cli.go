@@ -0,0 +1,50 @@
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	flags := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	indent := flags.Int("indent", 4, "indentation width for format")
+	flags.Parse(os.Args[2:])
+
+	source, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rebarconfig-wasm: %v\n", err)
+		os.Exit(1)
+	}
+
+	var out string
+	switch os.Args[1] {
+	case "parse":
+		out, err = parseJSON(string(source))
+	case "format":
+		out, err = format(string(source), *indent)
+	case "json":
+		out, err = toJSON(string(source))
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "rebarconfig-wasm %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+	fmt.Println(out)
+}
+
+// usage 打印用法
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: rebarconfig-wasm parse|format|json [-indent N] < rebar.config")
+}
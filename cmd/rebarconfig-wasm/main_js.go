@@ -0,0 +1,51 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"syscall/js"
+)
+
+func main() {
+	js.Global().Set("rebarConfig", js.ValueOf(map[string]interface{}{
+		"parse": js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			// parse 返回 JavaScript 对象而不是 JSON 文本
+			text, err := parseJSON(argString(args, 0))
+			if err != nil {
+				return result(nil, err)
+			}
+			return result(js.Global().Get("JSON").Call("parse", text), nil)
+		}),
+		"format": js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			indent := 4
+			if len(args) > 1 && args[1].Type() == js.TypeNumber {
+				indent = args[1].Int()
+			}
+			text, err := format(argString(args, 0), indent)
+			return result(text, err)
+		}),
+		"toJSON": js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			text, err := toJSON(argString(args, 0))
+			return result(text, err)
+		}),
+	}))
+
+	// 保持运行，使注册的函数可以被调用
+	select {}
+}
+
+// argString 返回第 i 个参数的字符串值，参数缺失时返回空字符串
+func argString(args []js.Value, i int) string {
+	if i >= len(args) {
+		return ""
+	}
+	return args[i].String()
+}
+
+// result 构建 {result, error} 对象
+func result(value interface{}, err error) interface{} {
+	if err != nil {
+		return map[string]interface{}{"result": nil, "error": err.Error()}
+	}
+	return map[string]interface{}{"result": value, "error": nil}
+}
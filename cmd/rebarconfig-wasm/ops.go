@@ -0,0 +1,60 @@
+// Command rebarconfig-wasm 是本解析器的 WebAssembly 入口。
+//
+// js/wasm 构建在 globalThis.rebarConfig 上注册 parse、format 和 toJSON 三个函数，供浏览器和 Node.js 使用:
+//
+//	GOOS=js GOARCH=wasm go build -o rebarconfig.wasm ./cmd/rebarconfig-wasm
+//	cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" .
+//
+//	const go = new Go();
+//	const { instance } = await WebAssembly.instantiateStreaming(fetch("rebarconfig.wasm"), go.importObject);
+//	go.run(instance);
+//	const { result, error } = rebarConfig.format("{deps,[cowboy]}.", 2);
+//
+// 每个函数返回 {result, error} 对象，出错时 result 为 null、error 为错误信息。
+//
+// wasip1 构建（以及本地构建）是命令行程序，从标准输入读取配置，向标准输出写出结果，可以在 wasmtime 或 Node.js 的 WASI 中运行:
+//
+//	GOOS=wasip1 GOARCH=wasm go build -o rebarconfig.wasm ./cmd/rebarconfig-wasm
+//	wasmtime rebarconfig.wasm format -indent 2 < rebar.config
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// parseJSON 解析配置，返回带类型标记的 JSON 项列表（与 json.Marshal(config) 相同，不含 raw 字段）
+func parseJSON(source string) (string, error) {
+	config, err := parser.Parse(source, parser.DiscardRaw())
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// format 解析并格式化配置
+func format(source string, indent int) (string, error) {
+	config, err := parser.Parse(source)
+	if err != nil {
+		return "", err
+	}
+	return config.Format(indent), nil
+}
+
+// toJSON 解析配置，返回 ToMap 转换得到的普通 JSON 对象
+func toJSON(source string) (string, error) {
+	config, err := parser.Parse(source, parser.DiscardRaw())
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(config.ToMap(), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
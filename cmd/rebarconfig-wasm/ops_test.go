@@ -0,0 +1,42 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestOperations tests the functions exported to JavaScript and WASI
+func TestOperations(t *testing.T) {
+	source := `{deps, [{cowboy, "2.9.0"}]}.`
+
+	parsed, err := parseJSON(source)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if !strings.HasPrefix(parsed, `{"terms":[{"type":"tuple"`) {
+		t.Errorf("Unexpected parse output: %s", parsed)
+	}
+
+	formatted, err := format(source, 2)
+	if err != nil {
+		t.Fatalf("Failed to format: %v", err)
+	}
+	if !strings.HasPrefix(formatted, "{deps, [") {
+		t.Errorf("Unexpected format output: %q", formatted)
+	}
+
+	native, err := toJSON(source)
+	if err != nil {
+		t.Fatalf("Failed to convert to JSON: %v", err)
+	}
+	expected := "{\n  \"deps\": {\n    \"cowboy\": \"2.9.0\"\n  }\n}"
+	if native != expected {
+		t.Errorf("Expected %s, got %s", expected, native)
+	}
+
+	for _, op := range []func(string) (string, error){parseJSON, toJSON} {
+		if _, err := op("{deps"); err == nil {
+			t.Error("Expected syntax error")
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Command rebarconfig 是用于查看和分析 rebar.config 文件的命令行工具。
+//
+// 用法:
+//
+//	rebarconfig explain [path]          用文字说明配置的内容（默认 ./rebar.config）
+//	rebarconfig lint [-sarif] [path]    运行 lint 规则，-sarif 时输出 SARIF 2.1.0 日志
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/lint"
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// command 表示一个子命令
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"explain", "explain [path]          describe a rebar.config in prose", runExplain},
+	{"lint", "lint [-sarif] [path]    run lint rules, optionally as a SARIF log", runLint},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == os.Args[1] {
+			if err := cmd.run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "rebarconfig %s: %v\n", cmd.name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "rebarconfig: unknown command %q\n", os.Args[1])
+	usage()
+	os.Exit(2)
+}
+
+// usage 打印所有子命令的用法
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: rebarconfig <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %s\n", cmd.usage)
+	}
+}
+
+// configPath 返回参数中的配置文件路径，未指定时使用 ./rebar.config
+func configPath(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return "rebar.config"
+}
+
+// runExplain 实现 explain 子命令
+func runExplain(args []string) error {
+	config, err := parser.ParseFile(configPath(args))
+	if err != nil {
+		return err
+	}
+	fmt.Print(parser.Explain(config))
+	return nil
+}
+
+// runLint 实现 lint 子命令
+// @pkg 文本输出时每行一条诊断信息，存在 error 级别的问题时以非零状态退出；
+// SARIF 输出总是成功退出，由 code scanning 平台决定如何处理结果
+func runLint(args []string) error {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	sarif := flags.Bool("sarif", false, "write a SARIF 2.1.0 log")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	path := configPath(flags.Args())
+	config, err := parser.ParseFile(path)
+	if err != nil {
+		return err
+	}
+	diagnostics := lint.Run(config)
+	lint.Locate(config.Raw, diagnostics)
+
+	if *sarif {
+		data, err := lint.SARIF(path, lint.DefaultRegistry.Rules(), diagnostics)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	errors := 0
+	for _, d := range diagnostics {
+		if d.Line > 0 {
+			fmt.Printf("%s:%d:%d: %s\n", path, d.Line, d.Column, d)
+		} else {
+			fmt.Printf("%s: %s\n", path, d)
+		}
+		if d.Severity == lint.SeverityError {
+			errors++
+		}
+	}
+	if errors > 0 {
+		return fmt.Errorf("%d error(s) found", errors)
+	}
+	return nil
+}
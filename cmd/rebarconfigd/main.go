@@ -0,0 +1,56 @@
+// Command rebarconfigd 是通过 HTTP 提供 rebar.config 解析、格式化和检查功能的服务。
+// 非 Go 编写的服务可以通过它复用本解析器。
+//
+// 用法:
+//
+//	rebarconfigd [-addr :8080] [-max-bytes 1048576]
+//
+// 接口:
+//
+//	POST /parse       请求体为配置源码，返回带类型标记的 JSON 项列表
+//	POST /format      请求体为配置源码，返回格式化后的源码；?indent=N 指定缩进（默认 4）
+//	POST /validate    请求体为配置源码，返回语法错误和 lint 诊断信息
+//	GET  /healthz     健康检查，返回 ok
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "listen address")
+	maxBytes := flag.Int64("max-bytes", 1<<20, "maximum request body size in bytes")
+	flag.Parse()
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           newServer(*maxBytes),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("rebarconfigd: shutdown: %v", err)
+		}
+	}()
+
+	log.Printf("rebarconfigd: listening on %s", *addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("rebarconfigd: %v", err)
+	}
+}
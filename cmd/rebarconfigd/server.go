@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/lint"
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// server 实现各 HTTP 接口
+type server struct {
+	maxBytes int64
+}
+
+// newServer 创建服务的 HTTP 处理器
+// 输入:
+//   - maxBytes: 请求体的最大字节数，超过时返回 413
+func newServer(maxBytes int64) http.Handler {
+	s := &server{maxBytes: maxBytes}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/parse", s.post(s.handleParse))
+	mux.HandleFunc("/format", s.post(s.handleFormat))
+	mux.HandleFunc("/validate", s.post(s.handleValidate))
+	mux.HandleFunc("/healthz", handleHealth)
+	return mux
+}
+
+// errorResponse 是出错时的响应体
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// validateResponse 是 /validate 的响应体
+type validateResponse struct {
+	Valid       bool              `json:"valid"`
+	Error       string            `json:"error,omitempty"`
+	Diagnostics []lint.Diagnostic `json:"diagnostics"`
+}
+
+// post 包装只接受 POST 请求的处理函数，读取请求体并检查大小限制
+func (s *server) post(handle func(w http.ResponseWriter, r *http.Request, source string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, s.maxBytes+1))
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+		if int64(len(body)) > s.maxBytes {
+			writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse{Error: fmt.Sprintf("request body exceeds %d bytes", s.maxBytes)})
+			return
+		}
+		handle(w, r, string(body))
+	}
+}
+
+// handleParse 返回带类型标记的 JSON 项列表
+func (s *server) handleParse(w http.ResponseWriter, r *http.Request, source string) {
+	config, err := parser.Parse(source, parser.DiscardRaw())
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, config)
+}
+
+// handleFormat 返回格式化后的源码
+func (s *server) handleFormat(w http.ResponseWriter, r *http.Request, source string) {
+	indent := 4
+	if value := r.URL.Query().Get("indent"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 || n > 16 {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: "indent must be an integer between 0 and 16"})
+			return
+		}
+		indent = n
+	}
+
+	config, err := parser.Parse(source)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	io.WriteString(w, config.Format(indent))
+}
+
+// handleValidate 返回语法错误和 lint 诊断信息
+// @pkg 语法错误和诊断信息都以 200 返回，由 valid 字段区分；存在 error 级别的诊断信息时 valid 为 false
+func (s *server) handleValidate(w http.ResponseWriter, r *http.Request, source string) {
+	config, err := parser.Parse(source)
+	if err != nil {
+		writeJSON(w, http.StatusOK, validateResponse{Error: err.Error(), Diagnostics: []lint.Diagnostic{}})
+		return
+	}
+
+	diagnostics := lint.Run(config)
+	lint.Locate(source, diagnostics)
+	response := validateResponse{Valid: true, Diagnostics: diagnostics}
+	if diagnostics == nil {
+		response.Diagnostics = []lint.Diagnostic{}
+	}
+	for _, d := range diagnostics {
+		if d.Severity == lint.SeverityError {
+			response.Valid = false
+		}
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// handleHealth 实现健康检查
+func handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	io.WriteString(w, "ok\n")
+}
+
+// writeJSON 以 JSON 写出响应
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// do sends a request to a test server and returns the recorder
+func do(t *testing.T, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	newServer(64).ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return recorder
+}
+
+// TestParseEndpoint tests the JSON AST response
+func TestParseEndpoint(t *testing.T) {
+	resp := do(t, http.MethodPost, "/parse", `{deps, [cowboy]}.`)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", resp.Code, resp.Body)
+	}
+	expected := `{"terms":[{"type":"tuple","elements":[{"type":"atom","value":"deps"},{"type":"list","elements":[{"type":"atom","value":"cowboy"}]}]}]}`
+	if got := strings.TrimSpace(resp.Body.String()); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+
+	resp = do(t, http.MethodPost, "/parse", `{deps, [}.`)
+	if resp.Code != http.StatusBadRequest || !strings.Contains(resp.Body.String(), "syntax error") {
+		t.Errorf("Expected syntax error, got %d: %s", resp.Code, resp.Body)
+	}
+}
+
+// TestFormatEndpoint tests formatting with a custom indent
+func TestFormatEndpoint(t *testing.T) {
+	resp := do(t, http.MethodPost, "/format?indent=2", `{deps,[cowboy]}.`)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", resp.Code, resp.Body)
+	}
+	if got := resp.Body.String(); !strings.HasPrefix(got, "{deps, [") {
+		t.Errorf("Unexpected formatted output: %q", got)
+	}
+
+	if resp := do(t, http.MethodPost, "/format?indent=x", `{a, b}.`); resp.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid indent, got %d", resp.Code)
+	}
+}
+
+// TestValidateEndpoint tests syntax errors and lint diagnostics
+func TestValidateEndpoint(t *testing.T) {
+	var result struct {
+		Valid       bool   `json:"valid"`
+		Error       string `json:"error"`
+		Diagnostics []struct {
+			RuleID string `json:"rule_id"`
+			Line   int    `json:"line"`
+		} `json:"diagnostics"`
+	}
+
+	resp := do(t, http.MethodPost, "/validate", "{erl_opts, [debug_info,\n no_debug_info]}.")
+	if err := json.Unmarshal(resp.Body.Bytes(), &result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if !result.Valid || len(result.Diagnostics) != 1 || result.Diagnostics[0].RuleID != "erl-opts" || result.Diagnostics[0].Line != 2 {
+		t.Errorf("Unexpected validation result: %s", resp.Body)
+	}
+
+	resp = do(t, http.MethodPost, "/validate", `{a`)
+	result.Valid, result.Error = true, ""
+	if err := json.Unmarshal(resp.Body.Bytes(), &result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if result.Valid || result.Error == "" {
+		t.Errorf("Expected invalid result with error, got %s", resp.Body)
+	}
+}
+
+// TestLimitsAndHealth tests method checks, body limits and the health endpoint
+func TestLimitsAndHealth(t *testing.T) {
+	if resp := do(t, http.MethodGet, "/parse", ""); resp.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405, got %d", resp.Code)
+	}
+	if resp := do(t, http.MethodPost, "/parse", "{deps, ["+strings.Repeat("a, ", 30)+"b]}."); resp.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413, got %d", resp.Code)
+	}
+	if resp := do(t, http.MethodGet, "/healthz", ""); resp.Code != http.StatusOK || resp.Body.String() != "ok\n" {
+		t.Errorf("Unexpected health response: %d %q", resp.Code, resp.Body)
+	}
+}
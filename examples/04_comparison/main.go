@@ -184,23 +184,9 @@ func main() {
 	config2, _ := parser.Parse(`{erl_opts, [debug_info]}. {deps, [{cowboy, "2.9.0"}]}.`)
 	config3, _ := parser.Parse(`{erl_opts, [debug_info]}. {deps, [{cowboy, "2.8.0"}]}.`) // 版本不同
 
-	// 手动比较两个配置
-	fmt.Printf("config1与config2比较: %v (完全相同的配置，应为true)\n", compareConfigs(config1, config2))
-	fmt.Printf("config1与config3比较: %v (依赖版本不同的配置，应为false)\n", compareConfigs(config1, config3))
-}
-
-// compareConfigs 比较两个RebarConfig的内容是否相同
-// 这是一个辅助函数，与parser库内部的同名函数功能类似
-func compareConfigs(c1, c2 *parser.RebarConfig) bool {
-	if len(c1.Terms) != len(c2.Terms) {
-		return false
-	}
-	for i := range c1.Terms {
-		if !c1.Terms[i].Compare(c2.Terms[i]) {
-			return false
-		}
-	}
-	return true
+	// 使用Equal比较两个配置
+	fmt.Printf("config1与config2比较: %v (完全相同的配置，应为true)\n", config1.Equal(config2))
+	fmt.Printf("config1与config3比较: %v (依赖版本不同的配置，应为false)\n", config1.Equal(config3))
 }
 
 // 运行此示例的输出将非常长。以下是关键部分示例：
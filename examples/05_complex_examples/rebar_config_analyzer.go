@@ -23,77 +23,17 @@ func NewAnalyzer(config *parser.RebarConfig) *RebarConfigAnalyzer {
 	}
 }
 
-// GetDependenciesInfo 提取所有依赖项的详细信息
-func (a *RebarConfigAnalyzer) GetDependenciesInfo() []DependencyInfo {
-	deps, ok := a.config.GetDeps()
-	if !ok || len(deps) == 0 {
-		return nil
-	}
-
-	var result []DependencyInfo
-
-	if list, ok := deps[0].(parser.List); ok {
-		for _, dep := range list.Elements {
-			if tuple, ok := dep.(parser.Tuple); ok && len(tuple.Elements) >= 2 {
-				if atom, ok := tuple.Elements[0].(parser.Atom); ok {
-					info := DependencyInfo{
-						Name: atom.Value,
-					}
-
-					// 处理不同类型的依赖
-					switch v := tuple.Elements[1].(type) {
-					case parser.String:
-						// 简单版本依赖, 如 {cowboy, "2.9.0"}
-						info.Type = "version"
-						info.Version = v.Value
-					case parser.Tuple:
-						// 复杂依赖，如 git, hex 等
-						if len(v.Elements) > 0 {
-							if atom, ok := v.Elements[0].(parser.Atom); ok {
-								info.Type = atom.Value
-
-								// 提取更多细节
-								if info.Type == "git" && len(v.Elements) > 1 {
-									if url, ok := v.Elements[1].(parser.String); ok {
-										info.Source = url.Value
-									}
-
-									// 提取git ref (tag, branch, commit)
-									if len(v.Elements) > 2 {
-										if refTuple, ok := v.Elements[2].(parser.Tuple); ok && len(refTuple.Elements) >= 2 {
-											if refType, ok := refTuple.Elements[0].(parser.Atom); ok {
-												info.RefType = refType.Value
-
-												if refValue, ok := refTuple.Elements[1].(parser.String); ok {
-													info.RefValue = refValue.Value
-												}
-											}
-										}
-									}
-								}
-							}
-						}
-					}
-
-					result = append(result, info)
-				}
-			}
+// GetDependenciesInfo 提取基础配置中所有依赖项的详细信息
+func (a *RebarConfigAnalyzer) GetDependenciesInfo() []parser.Dependency {
+	var result []parser.Dependency
+	for _, dep := range a.config.GetDependencies() {
+		if dep.Profile == "" {
+			result = append(result, dep)
 		}
 	}
-
 	return result
 }
 
-// DependencyInfo 表示一个依赖项的详细信息
-type DependencyInfo struct {
-	Name     string // 依赖名称
-	Type     string // 依赖类型: version, git, hex 等
-	Version  string // 如果是直接版本依赖
-	Source   string // 如果是git依赖，指向git URL
-	RefType  string // 如果是git依赖，指向引用类型 (tag, branch, commit)
-	RefValue string // 引用值
-}
-
 // GetProfilesInfo 提取所有profiles的详细信息
 func (a *RebarConfigAnalyzer) GetProfilesInfo() map[string]map[string]interface{} {
 	profiles, ok := a.config.GetProfilesConfig()
@@ -153,57 +93,7 @@ func (a *RebarConfigAnalyzer) FormatConfig(indent int) string {
 
 // CountByTermType 计算不同类型Term的数量
 func (a *RebarConfigAnalyzer) CountByTermType() map[string]int {
-	counts := make(map[string]int)
-
-	// 先处理顶层Term
-	for _, term := range a.config.Terms {
-		// 按照term类型计数
-		typeName := getTermTypeName(term)
-		counts[typeName]++
-
-		// 递归分析子元素
-		countTermsRecursive(term, counts)
-	}
-
-	return counts
-}
-
-// countTermsRecursive 递归计算所有子元素的类型
-func countTermsRecursive(term parser.Term, counts map[string]int) {
-	switch t := term.(type) {
-	case parser.Tuple:
-		for _, elem := range t.Elements {
-			typeName := getTermTypeName(elem)
-			counts[typeName]++
-			countTermsRecursive(elem, counts)
-		}
-	case parser.List:
-		for _, elem := range t.Elements {
-			typeName := getTermTypeName(elem)
-			counts[typeName]++
-			countTermsRecursive(elem, counts)
-		}
-	}
-}
-
-// getTermTypeName 获取Term的类型名称
-func getTermTypeName(term parser.Term) string {
-	switch term.(type) {
-	case parser.Atom:
-		return "Atom"
-	case parser.String:
-		return "String"
-	case parser.Integer:
-		return "Integer"
-	case parser.Float:
-		return "Float"
-	case parser.Tuple:
-		return "Tuple"
-	case parser.List:
-		return "List"
-	default:
-		return "Unknown"
-	}
+	return a.config.Stats().TermCounts
 }
 
 func main() {
@@ -320,7 +210,7 @@ func main() {
 	fmt.Println("\n依赖分类:")
 	byType := make(map[string][]string)
 	for _, dep := range dependencies {
-		byType[dep.Type] = append(byType[dep.Type], dep.Name)
+		byType[string(dep.Source)] = append(byType[string(dep.Source)], dep.Name)
 	}
 
 	for depType, deps := range byType {
@@ -329,9 +219,9 @@ func main() {
 
 	fmt.Println("\nGit依赖详情:")
 	for _, dep := range dependencies {
-		if dep.Type == "git" {
+		if dep.Source == parser.DepGit {
 			fmt.Printf("  %s:\n", dep.Name)
-			fmt.Printf("    URL: %s\n", dep.Source)
+			fmt.Printf("    URL: %s\n", dep.URL)
 			fmt.Printf("    引用类型: %s\n", dep.RefType)
 			fmt.Printf("    引用值: %s\n", dep.RefValue)
 		}
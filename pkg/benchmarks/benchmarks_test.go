@@ -0,0 +1,108 @@
+package benchmarks
+
+import (
+	"testing"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// inputs are the fixture configs measured by every benchmark
+var inputs = []struct {
+	name  string
+	input string
+}{
+	{"Small", Small()},
+	{"Medium", Medium()},
+	{"Synthetic10k", Synthetic(10000)},
+}
+
+func mustParse(tb testing.TB, input string) *parser.RebarConfig {
+	tb.Helper()
+	config, err := parser.Parse(input)
+	if err != nil {
+		tb.Fatalf("Failed to parse fixture: %v", err)
+	}
+	return config
+}
+
+// BenchmarkParse measures parsing of each fixture
+func BenchmarkParse(b *testing.B) {
+	for _, in := range inputs {
+		b.Run(in.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(in.input)))
+			for i := 0; i < b.N; i++ {
+				if _, err := parser.Parse(in.input); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkFormat measures formatting of each parsed fixture
+func BenchmarkFormat(b *testing.B) {
+	for _, in := range inputs {
+		config := mustParse(b, in.input)
+		b.Run(in.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = config.Format(4)
+			}
+		})
+	}
+}
+
+// BenchmarkAccessors measures the common accessor methods
+func BenchmarkAccessors(b *testing.B) {
+	for _, in := range inputs {
+		config := mustParse(b, in.input)
+		b.Run(in.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				config.GetDeps()
+				config.GetErlOpts()
+				config.GetProfilesConfig()
+				config.GetRelxConfig()
+				config.GetTerm("does_not_exist")
+			}
+		})
+	}
+}
+
+// TestAllocationBudgets guards against allocation regressions.
+// The budgets leave roughly 25% headroom over the measured values;
+// lower them when an optimization lands and raise them only deliberately.
+func TestAllocationBudgets(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping allocation budgets in short mode")
+	}
+
+	small, medium, synthetic := inputs[0].input, inputs[1].input, inputs[2].input
+	config := mustParse(t, medium)
+
+	budgets := []struct {
+		name   string
+		budget float64
+		run    func()
+	}{
+		{"Parse/Small", 50, func() { parser.Parse(small) }},
+		{"Parse/Medium", 760, func() { parser.Parse(medium) }},
+		{"Parse/Synthetic10k", 125000, func() { parser.Parse(synthetic) }},
+		{"Format/Medium", 520, func() { config.Format(4) }},
+		{"Accessors/Medium", 0, func() {
+			config.GetDeps()
+			config.GetErlOpts()
+			config.GetProfilesConfig()
+			config.GetTerm("does_not_exist")
+		}},
+	}
+
+	for _, b := range budgets {
+		allocs := testing.AllocsPerRun(5, b.run)
+		t.Logf("%s: %.0f allocs", b.name, allocs)
+		if allocs > b.budget {
+			t.Errorf("%s: %.0f allocs exceeds budget of %.0f", b.name, allocs, b.budget)
+		}
+	}
+}
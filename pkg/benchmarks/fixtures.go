@@ -0,0 +1,58 @@
+// Package benchmarks 提供性能基准测试使用的配置样本。
+// @pkg 包含真实风格的小型、中型配置文件以及可指定依赖数量的合成配置，
+// 供本包的基准测试和分配次数断言使用，也可被其他包的基准测试复用，
+// 以便衡量解析器改动带来的性能变化并及时发现退化。
+package benchmarks
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+)
+
+//go:embed fixtures/*.config
+var fixtures embed.FS
+
+// Small 返回一个典型的小型 rebar.config
+func Small() string {
+	return mustFixture("small.config")
+}
+
+// Medium 返回一个真实风格的中型 rebar.config
+// @pkg 包含 deps、plugins、relx、profiles、dialyzer、overrides 等常见配置项
+func Medium() string {
+	return mustFixture("medium.config")
+}
+
+// Synthetic 生成一个包含 n 个依赖的合成配置
+// @pkg 依赖交替使用 hex 版本和 git 标签两种形式，用于测量大规模输入下的性能
+// 输入:
+//   - n: 依赖数量
+//
+// 输出:
+//   - string: 配置内容
+func Synthetic(n int) string {
+	var b strings.Builder
+	b.WriteString("{erl_opts, [debug_info, warnings_as_errors]}.\n{deps, [\n")
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(",\n")
+		}
+		if i%2 == 0 {
+			fmt.Fprintf(&b, "    {dep_%d, \"%d.%d.%d\"}", i, i%10, i%7, i%3)
+		} else {
+			fmt.Fprintf(&b, "    {dep_%d, {git, \"https://example.com/dep_%d.git\", {tag, \"v%d.%d\"}}}", i, i, i%10, i%7)
+		}
+	}
+	b.WriteString("\n]}.\n")
+	return b.String()
+}
+
+// mustFixture 读取内嵌的样本文件
+func mustFixture(name string) string {
+	content, err := fixtures.ReadFile("fixtures/" + name)
+	if err != nil {
+		panic(err)
+	}
+	return string(content)
+}
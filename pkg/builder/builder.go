@@ -0,0 +1,284 @@
+// Package builder 提供以链式调用生成 rebar.config 的功能。
+// @pkg 适用于脚手架和代码生成工具：按调用顺序记录顶级配置项，同一个键的多次调用合并到同一项中，
+// 最后由 Build 生成可以直接 Format 或 WriteFile 的 RebarConfig。
+//
+// 示例:
+//
+//	config := builder.New().
+//	  ErlOpts("debug_info").
+//	  Dep("cowboy", "2.9.0").
+//	  GitDep("lager", "https://github.com/erlang-lager/lager.git", builder.Tag("3.9.2")).
+//	  Profile("test", builder.New().Dep("meck", "0.9.2")).
+//	  Build()
+//	fmt.Print(config.Format(4))
+package builder
+
+import (
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// Builder 按调用顺序记录配置项
+// @pkg 值为列表的键（如 deps、erl_opts、plugins）由 Append 及其快捷方法追加元素，其余键由 Set 设置；
+// 方法都返回 Builder 本身以便链式调用。零值不可用，应使用 New 创建
+type Builder struct {
+	// keys 是顶级键的出现顺序
+	keys []string
+	// values 是由 Set 设置的非列表值
+	values map[string]parser.Term
+	// lists 是列表值的元素
+	lists map[string][]parser.Term
+	// profiles 是各 profile 的配置，profileNames 是其出现顺序
+	profiles     map[string]*Builder
+	profileNames []string
+}
+
+// Ref 是 git 依赖的版本引用，由 Tag、Branch 或 Commit 创建；零值表示不指定引用
+type Ref struct {
+	// Kind 是引用类型：tag、branch 或 ref
+	Kind string
+	// Value 是标签名、分支名或提交哈希
+	Value string
+}
+
+// Tag 返回指向标签的引用，生成 {tag, "name"}
+func Tag(name string) Ref { return Ref{Kind: "tag", Value: name} }
+
+// Branch 返回指向分支的引用，生成 {branch, "name"}
+func Branch(name string) Ref { return Ref{Kind: "branch", Value: name} }
+
+// Commit 返回指向提交的引用，生成 {ref, "hash"}
+func Commit(hash string) Ref { return Ref{Kind: "ref", Value: hash} }
+
+// New 返回空的 Builder
+func New() *Builder {
+	return &Builder{
+		values:   make(map[string]parser.Term),
+		lists:    make(map[string][]parser.Term),
+		profiles: make(map[string]*Builder),
+	}
+}
+
+// Set 设置键的值，生成 {key, value}
+// @pkg 键已存在时替换其值并保持原有位置；value 是列表时之后仍可用 Append 追加元素
+// 输入:
+//   - key: 顶级键，如 "minimum_otp_vsn"
+//   - value: 值
+//
+// 输出:
+//   - *Builder: Builder 本身
+func (b *Builder) Set(key string, value parser.Term) *Builder {
+	b.touch(key)
+	delete(b.values, key)
+	delete(b.lists, key)
+	if key == "profiles" {
+		b.profiles = make(map[string]*Builder)
+		b.profileNames = nil
+	}
+	if list, ok := value.(parser.List); ok {
+		b.lists[key] = append([]parser.Term{}, list.Elements...)
+	} else {
+		b.values[key] = value
+	}
+	return b
+}
+
+// Append 向值为列表的键追加元素，生成 {key, [elements...]}
+// @pkg 键不存在时创建空列表；键的值由 Set 设置为非列表时会被替换为列表
+// 输入:
+//   - key: 顶级键，如 "project_plugins"
+//   - elements: 要追加的元素
+//
+// 输出:
+//   - *Builder: Builder 本身
+func (b *Builder) Append(key string, elements ...parser.Term) *Builder {
+	b.touch(key)
+	delete(b.values, key)
+	b.lists[key] = append(b.lists[key], elements...)
+	return b
+}
+
+// ErlOpts 向 erl_opts 追加原子选项，如 "debug_info"、"warnings_as_errors"
+// @pkg 带参数的选项（如 {d, 'TEST'}）使用 Append("erl_opts", ...) 追加
+func (b *Builder) ErlOpts(opts ...string) *Builder {
+	return b.Append("erl_opts", atoms(opts)...)
+}
+
+// Plugins 向 plugins 追加插件名
+func (b *Builder) Plugins(names ...string) *Builder {
+	return b.Append("plugins", atoms(names)...)
+}
+
+// Dep 添加 hex 依赖，生成 {name, "version"}；version 为空时只生成 name
+// @pkg 同名依赖已存在时在原位置替换
+func (b *Builder) Dep(name, version string) *Builder {
+	if version == "" {
+		return b.dep(name, parser.NewAtom(name))
+	}
+	return b.dep(name, parser.KV(name, parser.NewString(version)))
+}
+
+// PkgDep 添加包名与应用名不同的 hex 依赖，生成 {name, "version", {pkg, pkg}}
+func (b *Builder) PkgDep(name, version, pkg string) *Builder {
+	return b.dep(name, parser.NewTuple(parser.NewAtom(name), parser.NewString(version), parser.KV("pkg", parser.NewAtom(pkg))))
+}
+
+// GitDep 添加 git 依赖，生成 {name, {git, "url", {tag, "..."}}}；ref 为零值时省略引用
+// @pkg 同名依赖已存在时在原位置替换
+// 示例:
+//
+//	b.GitDep("lager", "https://github.com/erlang-lager/lager.git", builder.Tag("3.9.2"))
+func (b *Builder) GitDep(name, url string, ref Ref) *Builder {
+	source := parser.NewTuple(parser.NewAtom("git"), parser.NewString(url))
+	if ref.Kind != "" {
+		source.Elements = append(source.Elements, parser.KV(ref.Kind, parser.NewString(ref.Value)))
+	}
+	return b.dep(name, parser.KV(name, source))
+}
+
+// Profile 添加 profile，生成 {profiles, [{name, [...]}]}
+// @pkg profile 的内容同样由 Builder 描述；同名 profile 已存在时将新内容合并进去。
+// 之前由 Set("profiles", list) 设置的 {Name, [{Key, Value}, ...]} 项会先转换为 profile 并保留，
+// 无法转换的其他形式的项会被丢弃
+// 输入:
+//   - name: profile 名称，如 "test"
+//   - profile: profile 的内容
+//
+// 输出:
+//   - *Builder: Builder 本身
+func (b *Builder) Profile(name string, profile *Builder) *Builder {
+	b.touch("profiles")
+	delete(b.values, "profiles")
+	if entries, ok := b.lists["profiles"]; ok {
+		delete(b.lists, "profiles")
+		for _, entry := range entries {
+			if name, profile, ok := profileEntry(entry); ok {
+				b.Profile(name, profile)
+			}
+		}
+	}
+	existing, ok := b.profiles[name]
+	if !ok {
+		existing = New()
+		b.profiles[name] = existing
+		b.profileNames = append(b.profileNames, name)
+	}
+	existing.merge(profile)
+	return b
+}
+
+// Build 生成配置
+// @pkg 每个键生成一个顶级项，顺序为键第一次出现的顺序。返回的配置不包含原始文本，
+// 可以直接调用 Format、FormatWith 或 WriteFile
+// 输出:
+//   - *parser.RebarConfig: 生成的配置
+func (b *Builder) Build() *parser.RebarConfig {
+	return &parser.RebarConfig{Terms: b.terms()}
+}
+
+// terms 返回各键对应的 {key, value} 项
+func (b *Builder) terms() []parser.Term {
+	terms := make([]parser.Term, 0, len(b.keys))
+	for _, key := range b.keys {
+		if value, ok := b.values[key]; ok {
+			terms = append(terms, parser.KV(key, value))
+			continue
+		}
+		if key == "profiles" && len(b.profileNames) > 0 {
+			profiles := make([]parser.Term, len(b.profileNames))
+			for i, name := range b.profileNames {
+				profiles[i] = parser.KV(name, parser.NewList(b.profiles[name].terms()...))
+			}
+			terms = append(terms, parser.KV(key, parser.NewList(profiles...)))
+			continue
+		}
+		terms = append(terms, parser.KV(key, parser.NewList(b.lists[key]...)))
+	}
+	return terms
+}
+
+// merge 将 other 中的配置项合并进来：列表值追加，其余值替换，profile 递归合并
+func (b *Builder) merge(other *Builder) {
+	for _, key := range other.keys {
+		switch {
+		case key == "profiles" && len(other.profileNames) > 0:
+			for _, name := range other.profileNames {
+				b.Profile(name, other.profiles[name])
+			}
+		case other.values[key] != nil:
+			b.Set(key, other.values[key])
+		default:
+			b.Append(key, other.lists[key]...)
+		}
+	}
+}
+
+// profileEntry 将 {Name, [{Key, Value}, ...]} 形式的 profile 项转换为 Builder
+func profileEntry(entry parser.Term) (string, *Builder, bool) {
+	tuple, ok := entry.(parser.Tuple)
+	if !ok || len(tuple.Elements) != 2 {
+		return "", nil, false
+	}
+	name, ok := tuple.Elements[0].(parser.Atom)
+	list, isList := tuple.Elements[1].(parser.List)
+	if !ok || !isList {
+		return "", nil, false
+	}
+	profile := New()
+	for _, elem := range list.Elements {
+		kv, ok := elem.(parser.Tuple)
+		if !ok || len(kv.Elements) != 2 {
+			return "", nil, false
+		}
+		key, ok := kv.Elements[0].(parser.Atom)
+		if !ok {
+			return "", nil, false
+		}
+		profile.Set(key.Value, kv.Elements[1])
+	}
+	return name.Value, profile, true
+}
+
+// touch 在键第一次出现时记录其位置
+func (b *Builder) touch(key string) {
+	for _, k := range b.keys {
+		if k == key {
+			return
+		}
+	}
+	b.keys = append(b.keys, key)
+}
+
+// dep 向 deps 添加依赖，同名依赖在原位置替换
+func (b *Builder) dep(name string, dep parser.Term) *Builder {
+	for i, existing := range b.lists["deps"] {
+		if depName(existing) == name {
+			b.lists["deps"][i] = dep
+			return b
+		}
+	}
+	return b.Append("deps", dep)
+}
+
+// depName 返回依赖声明中的应用名
+func depName(dep parser.Term) string {
+	switch d := dep.(type) {
+	case parser.Atom:
+		return d.Value
+	case parser.Tuple:
+		if len(d.Elements) > 0 {
+			if name, ok := d.Elements[0].(parser.Atom); ok {
+				return name.Value
+			}
+		}
+	}
+	return ""
+}
+
+// atoms 将名称转换为原子
+func atoms(names []string) []parser.Term {
+	result := make([]parser.Term, len(names))
+	for i, name := range names {
+		result[i] = parser.NewAtom(name)
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// TestBuild tests that chained calls produce the expected config
+func TestBuild(t *testing.T) {
+	config := New().
+		ErlOpts("debug_info").
+		Dep("cowboy", "2.9.0").
+		GitDep("lager", "https://github.com/erlang-lager/lager.git", Tag("3.9.2")).
+		Set("minimum_otp_vsn", parser.NewString("24")).
+		ErlOpts("warnings_as_errors").
+		Append("erl_opts", parser.KV("d", parser.NewAtom("TEST"))).
+		Dep("recon", "").
+		PkgDep("my_jsx", "3.1.0", "jsx").
+		GitDep("meck", "https://github.com/eproxus/meck.git", Ref{}).
+		Dep("cowboy", "2.10.0").
+		Plugins("rebar3_hex").
+		Profile("test", New().Dep("meck", "0.9.2").ErlOpts("nowarn_export_all")).
+		Profile("prod", New().Set("relx", parser.NewList(parser.KV("dev_mode", parser.NewAtom("false"))))).
+		Profile("test", New().ErlOpts("export_all")).
+		Build()
+
+	expected, err := parser.Parse(`{erl_opts, [debug_info, warnings_as_errors, {d, 'TEST'}]}.
+{deps, [
+    {cowboy, "2.10.0"},
+    {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}},
+    recon,
+    {my_jsx, "3.1.0", {pkg, jsx}},
+    {meck, {git, "https://github.com/eproxus/meck.git"}}
+]}.
+{minimum_otp_vsn, "24"}.
+{plugins, [rebar3_hex]}.
+{profiles, [
+    {test, [{deps, [{meck, "0.9.2"}]}, {erl_opts, [nowarn_export_all, export_all]}]},
+    {prod, [{relx, [{dev_mode, false}]}]}
+]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse expected config: %v", err)
+	}
+
+	if len(config.Terms) != len(expected.Terms) {
+		t.Fatalf("Expected %d terms, got %d:\n%s", len(expected.Terms), len(config.Terms), config.Format(4))
+	}
+	for i := range expected.Terms {
+		if !config.Terms[i].Compare(expected.Terms[i]) {
+			t.Errorf("Term %d: expected %s, got %s", i, expected.Terms[i], config.Terms[i])
+		}
+	}
+
+	reparsed, err := parser.Parse(config.Format(4))
+	if err != nil {
+		t.Fatalf("Failed to parse formatted config: %v", err)
+	}
+	if deps, ok := reparsed.GetDeps(); !ok || len(deps) != 1 {
+		t.Errorf("Expected deps in formatted config, got %v", deps)
+	}
+}
+
+// TestSet tests replacing values in place
+func TestSet(t *testing.T) {
+	config := New().
+		Set("minimum_otp_vsn", parser.NewString("24")).
+		ErlOpts("debug_info").
+		Set("minimum_otp_vsn", parser.NewString("25")).
+		Set("erl_opts", parser.NewList(parser.NewAtom("warnings_as_errors"))).
+		ErlOpts("debug_info").
+		Profile("test", New()).
+		Set("profiles", parser.NewList()).
+		Build()
+
+	expected := `{minimum_otp_vsn, "25"}.
+
+{erl_opts, [warnings_as_errors, debug_info]}.
+
+{profiles, []}.
+`
+	if got := config.Format(4); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+// TestProfileAfterSet tests that profiles set as a list are kept when Profile is called later
+func TestProfileAfterSet(t *testing.T) {
+	config := New().
+		Set("profiles", parser.MustParseTerm(`[{prod, [{relx, [{dev_mode, false}]}]}, {test, [{deps, [meck]}]}]`)).
+		Profile("test", New().ErlOpts("nowarn_export_all")).
+		Profile("docs", New().Dep("edown", "")).
+		Build()
+
+	expected := "{profiles, [{prod, [{relx, [{dev_mode, false}]}]}, {test, [{deps, [meck]}, {erl_opts, [nowarn_export_all]}]}, {docs, [{deps, [edown]}]}]}"
+	if got := config.Terms[0].String(); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// ErlangMkDeps 将配置中的依赖转换为 erlang.mk 的 Makefile 变量
+// @pkg 输出 DEPS 列表和每个依赖的 dep_<name> 行:
+// - hex 依赖为 "hex 版本 [包名]"；erlang.mk 只支持精确版本，版本要求（如 "~> 2.9"）或未指定版本时不输出 dep_ 行，
+// 由 erlang.mk 的包索引解析，并附带注释说明
+// - git 依赖为 "git 地址 引用"，tag、branch、ref 都直接作为引用；git_subdir 为 "git-subfolder 地址 引用 子目录"
+// - hg 依赖为 "hg 地址 引用"，path 依赖为 "ln 路径"
+// - 其他来源输出为注释，需要手工处理
+// 输入:
+//   - config: 解析后的 rebar.config
+//
+// 输出:
+//   - string: 可粘贴到 Makefile 中 include erlang.mk 之前的变量定义
+//
+// 数据样例:
+//
+//	DEPS = cowboy lager
+//	dep_cowboy = hex 2.9.0
+//	dep_lager = git https://github.com/erlang-lager/lager.git 3.9.2
+func ErlangMkDeps(config *parser.RebarConfig) string {
+	deps := configDeps(config)
+	if len(deps) == 0 {
+		return ""
+	}
+
+	var names []string
+	var lines []string
+	for _, d := range deps {
+		if d.source == "other" {
+			lines = append(lines, fmt.Sprintf("# %s: this dependency source is not supported by erlang.mk", d.name))
+			continue
+		}
+		names = append(names, d.name)
+		if line, ok := erlangMkLine(d); ok {
+			lines = append(lines, line)
+		} else if d.version != "" {
+			lines = append(lines, fmt.Sprintf("# %s: requirement %q is resolved from the erlang.mk package index", d.name, d.version))
+		}
+	}
+
+	var b strings.Builder
+	if len(names) > 0 {
+		fmt.Fprintf(&b, "DEPS = %s\n", strings.Join(names, " "))
+	}
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// erlangMkLine 返回依赖的 dep_<name> 行，无法表示时返回 false
+func erlangMkLine(d dep) (string, bool) {
+	var words []string
+	switch d.source {
+	case "hex":
+		if _, err := parser.ParseVersion(d.version); err != nil {
+			return "", false
+		}
+		words = []string{"hex", d.version}
+		if d.pkg != "" {
+			words = append(words, d.pkg)
+		}
+	case "git":
+		if d.subdir != "" {
+			words = []string{"git-subfolder", d.url, d.ref, d.subdir}
+		} else {
+			words = []string{"git", d.url, d.ref}
+		}
+	case "hg":
+		words = []string{"hg", d.url, d.ref}
+	case "path":
+		words = []string{"ln", d.url}
+	default:
+		return "", false
+	}
+
+	var escaped []string
+	for _, word := range words {
+		if word != "" {
+			escaped = append(escaped, makeEscape(word))
+		}
+	}
+	return fmt.Sprintf("dep_%s = %s", d.name, strings.Join(escaped, " ")), true
+}
+
+// makeEscape 转义 Makefile 变量值中的 $
+func makeEscape(s string) string {
+	return strings.ReplaceAll(s, "$", "$$")
+}
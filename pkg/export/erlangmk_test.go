@@ -0,0 +1,60 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// TestErlangMkDeps tests conversion of each dep form to erlang.mk variables
+func TestErlangMkDeps(t *testing.T) {
+	config, err := parser.Parse(`{deps, [
+    recon,
+    {cowboy, "2.9.0"},
+    {jsx, "~> 3.0"},
+    {my_jsx, "3.1.0", {pkg, jsx}},
+    {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}},
+    {meck, {git, "https://github.com/eproxus/meck.git", {branch, "master"}}},
+    {gun, {git, "https://github.com/ninenines/gun.git", {ref, "abc123"}}},
+    {head, {git, "https://example.com/head.git"}},
+    {sub, {git_subdir, "https://example.com/mono.git", {ref, "def456"}, "apps/sub"}},
+    {old, {hg, "https://hg.example.com/old", {tag, "1.0"}}},
+    {local, {path, "../local"}},
+    {odd, {svn, "https://svn.example.com/odd"}}
+]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	expected := `DEPS = recon cowboy jsx my_jsx lager meck gun head sub old local
+dep_cowboy = hex 2.9.0
+# jsx: requirement "~> 3.0" is resolved from the erlang.mk package index
+dep_my_jsx = hex 3.1.0 jsx
+dep_lager = git https://github.com/erlang-lager/lager.git 3.9.2
+dep_meck = git https://github.com/eproxus/meck.git master
+dep_gun = git https://github.com/ninenines/gun.git abc123
+dep_head = git https://example.com/head.git
+dep_sub = git-subfolder https://example.com/mono.git def456 apps/sub
+dep_old = hg https://hg.example.com/old 1.0
+dep_local = ln ../local
+# odd: this dependency source is not supported by erlang.mk
+`
+	if got := ErlangMkDeps(config); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+// TestErlangMkDepsEscape tests escaping of make variable references
+func TestErlangMkDepsEscape(t *testing.T) {
+	config, err := parser.Parse(`{deps, [{x, {git, "https://example.com/$(USER).git", {tag, "1.0"}}}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	expected := "DEPS = x\ndep_x = git https://example.com/$$(USER).git 1.0\n"
+	if got := ErlangMkDeps(config); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+	if got := ErlangMkDeps(&parser.RebarConfig{}); got != "" {
+		t.Errorf("Expected empty output without deps, got %q", got)
+	}
+}
@@ -0,0 +1,80 @@
+// Package export 提供将 rebar 依赖转换为其他构建工具格式的功能。
+// @pkg 支持 Elixir 的 mix.exs 依赖元组（MixDeps）和 erlang.mk 的 DEPS/dep_<name> 变量（ErlangMkDeps），
+// 便于在 rebar3、Mix 和 erlang.mk 之间迁移项目或混合构建。
+//
+// 示例:
+//
+//	config, _ := parser.ParseFile("./rebar.config")
+//	fmt.Print(export.MixDeps(config))
+package export
+
+import (
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// dep 是从依赖声明中提取的与目标格式无关的信息
+type dep struct {
+	// name 是依赖的应用名称
+	name string
+	// source 是来源类别：hex、git、hg、path 或 other
+	source string
+	// pkg 是与 name 不同的 hex 包名，相同时为空
+	pkg string
+	// version 是 hex 依赖的版本要求，未指定时为空
+	version string
+	// url 是版本库地址或本地路径
+	url string
+	// refType 是版本库引用的类型：tag、branch 或 ref；未指定时为空
+	refType string
+	// ref 是版本库引用的值
+	ref string
+	// subdir 是 git_subdir 依赖在版本库中的子目录
+	subdir string
+}
+
+// configDeps 返回配置中声明的依赖，无法识别的声明会被跳过
+func configDeps(config *parser.RebarConfig) []dep {
+	elements, ok := config.GetDeps()
+	if !ok {
+		return nil
+	}
+	list, ok := elements[0].(parser.List)
+	if !ok {
+		return nil
+	}
+	var deps []dep
+	for _, term := range list.Elements {
+		if d, ok := newDep(term); ok {
+			deps = append(deps, d)
+		}
+	}
+	return deps
+}
+
+// newDep 从依赖声明构建依赖信息
+// @pkg rebar2 风格的 {Name, ".*", {git, ...}} 中的版本是正则表达式，不是版本要求，AsDependency 对非 hex 依赖不设置 Version
+func newDep(term parser.Term) (dep, bool) {
+	dependency, ok := parser.AsDependency(term)
+	if !ok {
+		return dep{}, false
+	}
+	d := dep{name: dependency.Name, source: dependency.Family()}
+	switch d.source {
+	case "hex":
+		d.version = dependency.Version
+		if dependency.Package != d.name {
+			d.pkg = dependency.Package
+		}
+	case "git", "hg":
+		d.url = dependency.URL
+		d.refType, d.ref = dependency.RefType, dependency.RefValue
+		if d.refType == "" && d.ref != "" {
+			// rebar2 允许直接写分支名
+			d.refType = "branch"
+		}
+		d.subdir = dependency.Subdir
+	case "path":
+		d.url = dependency.URL
+	}
+	return d, true
+}
@@ -0,0 +1,122 @@
+package export
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// MixDeps 将配置中的依赖转换为 mix.exs 的 deps 函数
+// @pkg 版本要求（如 "~> 2.9"、"2.9.0"）在 rebar3 和 Mix 中语义相同，原样保留；未指定版本的 hex 依赖使用 ">= 0.0.0"，
+// 使用别名的 hex 包带有 hex: 选项；git 依赖转换为 git: 加 tag:、branch: 或 ref: 选项，git_subdir 的子目录转换为 sparse:；
+// path 依赖转换为 path:。Mix 不支持的来源（如 hg）输出为注释，需要手工处理
+// 输入:
+//   - config: 解析后的 rebar.config
+//
+// 输出:
+//   - string: 可粘贴到 mix.exs 中的 defp deps 函数
+//
+// 示例:
+//
+//	fmt.Print(export.MixDeps(config))
+//
+// 数据样例:
+//
+//	defp deps do
+//	  [
+//	    {:cowboy, "~> 2.9"},
+//	    {:lager, git: "https://github.com/erlang-lager/lager.git", tag: "3.9.2"}
+//	  ]
+//	end
+func MixDeps(config *parser.RebarConfig) string {
+	deps := configDeps(config)
+
+	last := -1
+	for i, d := range deps {
+		if mixSupported(d) {
+			last = i
+		}
+	}
+
+	if len(deps) == 0 {
+		return "defp deps do\n  []\nend\n"
+	}
+
+	var b strings.Builder
+	b.WriteString("defp deps do\n  [\n")
+	for i, d := range deps {
+		if !mixSupported(d) {
+			fmt.Fprintf(&b, "    # %s: %s dependencies are not supported by Mix", d.name, d.source)
+			if d.url != "" {
+				fmt.Fprintf(&b, " (%s)", d.url)
+			}
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString("    " + mixTuple(d))
+		if i != last {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("  ]\nend\n")
+	return b.String()
+}
+
+// mixSupported 判断 Mix 是否支持依赖的来源
+func mixSupported(d dep) bool {
+	switch d.source {
+	case "hex", "git", "path":
+		return true
+	}
+	return false
+}
+
+// mixTuple 返回依赖的 Mix 元组
+func mixTuple(d dep) string {
+	parts := []string{elixirAtom(d.name)}
+	switch d.source {
+	case "hex":
+		version := d.version
+		if version == "" {
+			version = ">= 0.0.0"
+		}
+		parts = append(parts, elixirString(version))
+		if d.pkg != "" {
+			parts = append(parts, "hex: "+elixirAtom(d.pkg))
+		}
+	case "git":
+		parts = append(parts, "git: "+elixirString(d.url))
+		switch d.refType {
+		case "tag", "branch", "ref":
+			parts = append(parts, d.refType+": "+elixirString(d.ref))
+		}
+		if d.subdir != "" {
+			parts = append(parts, "sparse: "+elixirString(d.subdir))
+		}
+	case "path":
+		parts = append(parts, "path: "+elixirString(d.url))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// elixirPlainAtom 匹配无需引号的 Elixir 原子
+var elixirPlainAtom = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_@]*[?!]?$`)
+
+// elixirAtom 返回 Elixir 原子字面量，必要时加引号
+func elixirAtom(name string) string {
+	if elixirPlainAtom.MatchString(name) {
+		return ":" + name
+	}
+	return ":" + elixirString(name)
+}
+
+// elixirEscaper 转义 Elixir 字符串中的特殊字符，包括插值开头 #{
+var elixirEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `#{`, `\#{`, "\n", `\n`, "\t", `\t`)
+
+// elixirString 返回 Elixir 双引号字符串字面量
+func elixirString(s string) string {
+	return `"` + elixirEscaper.Replace(s) + `"`
+}
@@ -0,0 +1,62 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// TestMixDeps tests conversion of each dep form to Mix tuples
+func TestMixDeps(t *testing.T) {
+	config, err := parser.Parse(`{deps, [
+    recon,
+    {cowboy, "~> 2.9"},
+    {my_jsx, "3.1.0", {pkg, jsx}},
+    {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}},
+    {meck, ".*", {git, "https://github.com/eproxus/meck.git", "master"}},
+    {sub, {git_subdir, "https://example.com/mono.git", {ref, "abc123"}, "apps/sub"}},
+    {old, {hg, "https://hg.example.com/old"}},
+    {local, {path, "../local"}},
+    'odd-name'
+]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	expected := `defp deps do
+  [
+    {:recon, ">= 0.0.0"},
+    {:cowboy, "~> 2.9"},
+    {:my_jsx, "3.1.0", hex: :jsx},
+    {:lager, git: "https://github.com/erlang-lager/lager.git", tag: "3.9.2"},
+    {:meck, git: "https://github.com/eproxus/meck.git", branch: "master"},
+    {:sub, git: "https://example.com/mono.git", ref: "abc123", sparse: "apps/sub"},
+    # old: hg dependencies are not supported by Mix (https://hg.example.com/old)
+    {:local, path: "../local"},
+    {:"odd-name", ">= 0.0.0"}
+  ]
+end
+`
+	if got := MixDeps(config); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+// TestMixDepsEmpty tests a config without deps
+func TestMixDepsEmpty(t *testing.T) {
+	config, err := parser.Parse(`{erl_opts, [debug_info]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	expected := "defp deps do\n  []\nend\n"
+	if got := MixDeps(config); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+// TestElixirString tests escaping of interpolation and quotes
+func TestElixirString(t *testing.T) {
+	if got := elixirString(`a"b#{c}\`); got != `"a\"b\#{c}\\"` {
+		t.Errorf("Unexpected escaped string: %s", got)
+	}
+}
@@ -0,0 +1,217 @@
+// Package hexclient 提供查询 hex.pm 以检查过期依赖的功能。
+// @pkg 对 rebar.config 中的每个 hex 依赖查询 hex.pm API，报告当前版本、最新版本，
+// 以及满足配置中版本要求的最高版本。网络访问通过 Doer 接口注入，便于测试和使用私有仓库。
+//
+// 示例:
+//
+//	client := hexclient.New()
+//	report, err := client.Outdated(ctx, config, lock)
+//	if err != nil {
+//	  log.Fatal(err)
+//	}
+//	for _, pkg := range report {
+//	  if pkg.IsOutdated() {
+//	    fmt.Printf("%s: %s -> %s\n", pkg.Name, pkg.Current, pkg.Latest)
+//	  }
+//	}
+package hexclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// DefaultBaseURL 是 hex.pm API 的地址
+const DefaultBaseURL = "https://hex.pm/api"
+
+// Doer 执行 HTTP 请求，*http.Client 实现了该接口
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client 是 hex.pm API 客户端
+type Client struct {
+	// BaseURL 是 API 地址，默认为 DefaultBaseURL
+	BaseURL string
+	// HTTP 执行请求，默认为 http.DefaultClient
+	HTTP Doer
+}
+
+// New 创建使用默认地址和 http.DefaultClient 的客户端
+func New() *Client {
+	return &Client{BaseURL: DefaultBaseURL, HTTP: http.DefaultClient}
+}
+
+// Package 是 hex.pm 返回的包信息
+type Package struct {
+	Name string `json:"name"`
+	// Releases 是所有发布的版本，hex.pm 按从新到旧排列
+	Releases []Release `json:"releases"`
+	// LatestStableVersion 是最新的正式版本
+	LatestStableVersion string `json:"latest_stable_version"`
+}
+
+// Release 是包的一个发布版本
+type Release struct {
+	Version string `json:"version"`
+}
+
+// GetPackage 查询包信息
+// 输入:
+//   - ctx: 请求的上下文
+//   - name: hex 包名
+//
+// 输出:
+//   - *Package: 包信息
+//   - error: 请求失败、包不存在或响应无法解析
+func (c *Client) GetPackage(ctx context.Context, name string) (*Package, error) {
+	base := c.BaseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	doer := c.HTTP
+	if doer == nil {
+		doer = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(base, "/")+"/packages/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query package %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("failed to query package %s: unexpected status %s", name, resp.Status)
+	}
+
+	var pkg Package
+	if err := json.NewDecoder(resp.Body).Decode(&pkg); err != nil {
+		return nil, fmt.Errorf("failed to decode package %s: %w", name, err)
+	}
+	return &pkg, nil
+}
+
+// Dep 是一个 hex 依赖的版本状态
+type Dep struct {
+	// Name 是依赖的应用名称
+	Name string
+	// Package 是 hex 包名，使用 {Name, {pkg, Package}} 别名时与 Name 不同
+	Package string
+	// Requirement 是配置中的版本要求，为空表示任意版本
+	Requirement string
+	// Current 是当前使用的版本：优先取锁文件中的版本，否则取精确的版本要求；未知时为空
+	Current string
+	// Latest 是最新的正式版本
+	Latest string
+	// LatestMatching 是满足版本要求的最高正式版本，没有满足的版本时为空
+	LatestMatching string
+}
+
+// IsOutdated 判断是否存在比当前版本更新的正式版本
+// @pkg 当前版本未知时，最新版本不满足版本要求即视为过期
+func (d Dep) IsOutdated() bool {
+	latest, err := parser.ParseVersion(d.Latest)
+	if err != nil {
+		return false
+	}
+	if current, err := parser.ParseVersion(d.Current); err == nil {
+		return latest.Compare(current) > 0
+	}
+	return d.Latest != d.LatestMatching
+}
+
+// Outdated 查询配置中所有 hex 依赖的版本状态
+// @pkg 只检查基础配置中的 deps，git、hg 等其他来源的依赖会被跳过
+// 输入:
+//   - ctx: 请求的上下文
+//   - config: 解析后的 rebar.config
+//   - lock: 解析后的 rebar.lock，用于确定当前版本，可以为 nil
+//
+// 输出:
+//   - []Dep: 每个 hex 依赖的版本状态，按配置中的顺序排列
+//   - error: 任意一个包查询失败时返回错误
+func (c *Client) Outdated(ctx context.Context, config *parser.RebarConfig, lock *parser.LockFile) ([]Dep, error) {
+	var deps []Dep
+	for _, dep := range hexDeps(config) {
+		if lock != nil {
+			if locked, ok := lock.Dep(dep.Name); ok && locked.Source.Kind == "pkg" {
+				dep.Current = locked.Source.Version
+			}
+		}
+		if _, err := parser.ParseVersion(dep.Requirement); dep.Current == "" && err == nil {
+			dep.Current = dep.Requirement
+		}
+
+		pkg, err := c.GetPackage(ctx, dep.Package)
+		if err != nil {
+			return nil, err
+		}
+		dep.Latest, dep.LatestMatching = latestVersions(pkg, dep.Requirement)
+		deps = append(deps, dep)
+	}
+	return deps, nil
+}
+
+// latestVersions 返回最新的正式版本和满足版本要求的最高正式版本
+func latestVersions(pkg *Package, requirement string) (string, string) {
+	req, reqErr := parser.ParseRequirement(requirement)
+
+	var latest, matching *parser.Version
+	for _, release := range pkg.Releases {
+		v, err := parser.ParseVersion(release.Version)
+		if err != nil || v.Pre != "" {
+			continue
+		}
+		if latest == nil || v.Compare(*latest) > 0 {
+			latest = &v
+		}
+		if (requirement == "" || reqErr == nil && req.Match(v)) && (matching == nil || v.Compare(*matching) > 0) {
+			matching = &v
+		}
+	}
+
+	var latestText, matchingText string
+	if pkg.LatestStableVersion != "" {
+		latestText = pkg.LatestStableVersion
+	} else if latest != nil {
+		latestText = latest.String()
+	}
+	if matching != nil {
+		matchingText = matching.String()
+	}
+	return latestText, matchingText
+}
+
+// hexDeps 返回配置中的 hex 依赖
+func hexDeps(config *parser.RebarConfig) []Dep {
+	elements, ok := config.GetDeps()
+	if !ok {
+		return nil
+	}
+	list, ok := elements[0].(parser.List)
+	if !ok {
+		return nil
+	}
+
+	var deps []Dep
+	for _, term := range list.Elements {
+		if dep, ok := parser.AsDependency(term); ok && dep.Source == parser.DepHex {
+			deps = append(deps, Dep{Name: dep.Name, Package: dep.Package, Requirement: dep.Version})
+		}
+	}
+	return deps
+}
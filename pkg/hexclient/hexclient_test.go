@@ -0,0 +1,109 @@
+package hexclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// fakeHex serves canned package responses keyed by request path
+type fakeHex struct {
+	packages map[string]string
+	requests []string
+}
+
+func (f *fakeHex) Do(req *http.Request) (*http.Response, error) {
+	f.requests = append(f.requests, req.URL.String())
+	body, ok := f.packages[req.URL.Path]
+	if !ok {
+		return &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found", Body: io.NopCloser(strings.NewReader(""))}, nil
+	}
+	return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+// TestOutdated tests outdated reporting with an injected transport
+func TestOutdated(t *testing.T) {
+	fake := &fakeHex{packages: map[string]string{
+		"/api/packages/cowboy": `{"name": "cowboy", "latest_stable_version": "2.12.0",
+			"releases": [{"version": "3.0.0-rc.1"}, {"version": "2.12.0"}, {"version": "2.10.0"}, {"version": "2.9.0"}]}`,
+		"/api/packages/jsx":   `{"name": "jsx", "releases": [{"version": "3.1.0"}, {"version": "3.0.0"}, {"version": "2.11.0"}]}`,
+		"/api/packages/recon": `{"name": "recon", "latest_stable_version": "2.5.5", "releases": [{"version": "2.5.5"}]}`,
+	}}
+	client := &Client{BaseURL: "https://hex.example/api/", HTTP: fake}
+
+	config, err := parser.Parse(`{deps, [
+    {cowboy, "~> 2.9.0"},
+    {my_jsx, "~> 2.0", {pkg, jsx}},
+    recon,
+    {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}}
+]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	lock, err := parser.ParseLock(`{"1.2.0", [
+{<<"cowboy">>,{pkg,<<"cowboy">>,<<"2.9.0">>},0},
+{<<"recon">>,{pkg,<<"recon">>,<<"2.5.5">>},0}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse lock: %v", err)
+	}
+
+	deps, err := client.Outdated(context.Background(), config, lock)
+	if err != nil {
+		t.Fatalf("Failed to check outdated deps: %v", err)
+	}
+
+	expected := []Dep{
+		{Name: "cowboy", Package: "cowboy", Requirement: "~> 2.9.0", Current: "2.9.0", Latest: "2.12.0", LatestMatching: "2.9.0"},
+		{Name: "my_jsx", Package: "jsx", Requirement: "~> 2.0", Latest: "3.1.0", LatestMatching: "2.11.0"},
+		{Name: "recon", Package: "recon", Current: "2.5.5", Latest: "2.5.5", LatestMatching: "2.5.5"},
+	}
+	if len(deps) != len(expected) {
+		t.Fatalf("Expected %d deps, got %d: %+v", len(expected), len(deps), deps)
+	}
+	for i := range expected {
+		if deps[i] != expected[i] {
+			t.Errorf("Dep %d: expected %+v, got %+v", i, expected[i], deps[i])
+		}
+	}
+
+	outdated := []bool{true, true, false}
+	for i, want := range outdated {
+		if got := deps[i].IsOutdated(); got != want {
+			t.Errorf("%s: expected outdated=%v, got %v", deps[i].Name, want, got)
+		}
+	}
+	if fake.requests[1] != "https://hex.example/api/packages/jsx" {
+		t.Errorf("Unexpected request URL: %s", fake.requests[1])
+	}
+}
+
+// TestOutdatedErrors tests propagation of lookup failures
+func TestOutdatedErrors(t *testing.T) {
+	config, err := parser.Parse(`{deps, [missing]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	client := &Client{BaseURL: "https://hex.example/api", HTTP: &fakeHex{}}
+	if _, err := client.Outdated(context.Background(), config, nil); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected a 404 error, got %v", err)
+	}
+
+	failing := &Client{HTTP: doerFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("network down")
+	})}
+	if _, err := failing.GetPackage(context.Background(), "cowboy"); err == nil || !strings.Contains(err.Error(), "network down") {
+		t.Errorf("Expected transport error, got %v", err)
+	}
+}
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,36 @@
+package lint
+
+import (
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// Builtin 返回内置规则的提供者
+// @pkg 内置规则:
+// - erl-opts: 报告重复或互相冲突的 erl_opts 选项
+func Builtin() RuleProvider {
+	return NewProvider("builtin",
+		NewRule("erl-opts", "erl_opts should not contain duplicate or conflicting options", checkErlOpts),
+	)
+}
+
+// checkErlOpts 将 CheckErlOpts 的结果转换为诊断信息
+func checkErlOpts(config *parser.RebarConfig) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, issue := range config.CheckErlOpts() {
+		severity := SeverityInfo
+		if issue.Kind == parser.ErlOptsConflict {
+			severity = SeverityWarning
+		}
+		key := "erl_opts"
+		if issue.Profile != "" {
+			key = "profiles"
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: severity,
+			Message:  issue.String(),
+			Key:      key,
+			Term:     issue.Option,
+		})
+	}
+	return diagnostics
+}
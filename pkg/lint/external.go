@@ -0,0 +1,186 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// 进程外插件协议
+//
+// 插件是一个独立的可执行文件，通过子命令与宿主通信:
+//   - "describe": 向标准输出写入 JSON 数组，每个元素为 {"id": ..., "description": ...}
+//   - "check <rule-id>": 从标准输入读取 rebar.config 原文，向标准输出写入 Diagnostic 的 JSON 数组
+//
+// 插件端可以直接调用 Serve 实现该协议。
+
+// ruleInfo 是 describe 子命令输出的规则描述
+type ruleInfo struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
+// LoadExternal 启动进程外插件并读取其规则列表
+// @pkg 返回的 RuleProvider 中的每条规则在 Check 时都会启动一次插件进程，
+// 将配置原文写入其标准输入并读取诊断结果
+// 输入:
+//   - name: 提供者名称
+//   - command: 插件命令及其参数
+//
+// 输出:
+//   - RuleProvider: 插件提供的规则
+//   - error: 启动插件或解析规则列表失败时的错误
+//
+// 示例:
+//
+//	provider, err := lint.LoadExternal("acme", "/opt/lint/acme-rules")
+//	if err != nil {
+//	  log.Fatal(err)
+//	}
+//	lint.Register(provider)
+func LoadExternal(name string, command ...string) (RuleProvider, error) {
+	if len(command) == 0 {
+		return nil, fmt.Errorf("external provider %q: empty command", name)
+	}
+
+	out, err := runExternal(command, nil, "describe")
+	if err != nil {
+		return nil, fmt.Errorf("external provider %q: %w", name, err)
+	}
+
+	var infos []ruleInfo
+	if err := json.Unmarshal(out, &infos); err != nil {
+		return nil, fmt.Errorf("external provider %q: invalid describe output: %w", name, err)
+	}
+
+	rules := make([]Rule, len(infos))
+	for i, info := range infos {
+		rules[i] = externalRule{info: info, command: command}
+	}
+	return NewProvider(name, rules...), nil
+}
+
+// externalRule 是由进程外插件实现的规则
+type externalRule struct {
+	info    ruleInfo
+	command []string
+}
+
+func (r externalRule) ID() string          { return r.info.ID }
+func (r externalRule) Description() string { return r.info.Description }
+
+// Check 调用插件检查配置
+// @pkg 插件执行失败时返回一条 error 级别的诊断信息，而不是静默忽略
+func (r externalRule) Check(config *parser.RebarConfig) []Diagnostic {
+	source := config.Raw
+	if source == "" {
+		// 使用 DiscardRaw 解析的配置没有原文，重新生成等价内容
+		source = config.Format(4)
+	}
+
+	out, err := runExternal(r.command, []byte(source), "check", r.info.ID)
+	if err != nil {
+		return []Diagnostic{{RuleID: r.info.ID, Severity: SeverityError, Message: err.Error()}}
+	}
+
+	var diagnostics []Diagnostic
+	if err := json.Unmarshal(out, &diagnostics); err != nil {
+		return []Diagnostic{{RuleID: r.info.ID, Severity: SeverityError, Message: "invalid plugin output: " + err.Error()}}
+	}
+	return diagnostics
+}
+
+// runExternal 运行插件命令并返回其标准输出
+func runExternal(command []string, stdin []byte, args ...string) ([]byte, error) {
+	cmd := exec.Command(command[0], append(append([]string(nil), command[1:]...), args...)...)
+	cmd.Stdin = bytes.NewReader(stdin)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if stderr.Len() > 0 {
+			return nil, fmt.Errorf("plugin failed: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+		}
+		return nil, fmt.Errorf("plugin failed: %w", err)
+	}
+	return out, nil
+}
+
+// Serve 在插件进程中实现进程外插件协议
+// @pkg 插件的 main 函数可以直接调用 lint.Main(provider)，或在需要自定义输入输出时调用 Serve
+// 输入:
+//   - provider: 插件提供的规则
+//   - args: 命令行参数（不含程序名）
+//   - in: 标准输入
+//   - out: 标准输出
+//
+// 输出:
+//   - error: 协议错误或配置解析错误
+//
+// 示例:
+//
+//	func main() {
+//	  if err := lint.Serve(acme.Provider(), os.Args[1:], os.Stdin, os.Stdout); err != nil {
+//	    fmt.Fprintln(os.Stderr, err)
+//	    os.Exit(1)
+//	  }
+//	}
+func Serve(provider RuleProvider, args []string, in io.Reader, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing command, expected describe or check")
+	}
+
+	switch args[0] {
+	case "describe":
+		rules := provider.Rules()
+		infos := make([]ruleInfo, len(rules))
+		for i, rule := range rules {
+			infos[i] = ruleInfo{ID: rule.ID(), Description: rule.Description()}
+		}
+		return json.NewEncoder(out).Encode(infos)
+
+	case "check":
+		if len(args) < 2 {
+			return fmt.Errorf("check: missing rule id")
+		}
+		config, err := parser.ParseReader(in)
+		if err != nil {
+			return err
+		}
+		for _, rule := range provider.Rules() {
+			if rule.ID() != args[1] {
+				continue
+			}
+			diagnostics := rule.Check(config)
+			for i := range diagnostics {
+				if diagnostics[i].RuleID == "" {
+					diagnostics[i].RuleID = rule.ID()
+				}
+			}
+			if diagnostics == nil {
+				diagnostics = []Diagnostic{}
+			}
+			return json.NewEncoder(out).Encode(diagnostics)
+		}
+		return fmt.Errorf("check: unknown rule %q", args[1])
+
+	default:
+		return fmt.Errorf("unknown command %q, expected describe or check", args[0])
+	}
+}
+
+// Main 是 Serve 的便捷包装，供插件的 main 函数直接调用
+// @pkg 使用进程的命令行参数和标准输入输出，出错时将错误写入标准错误并以状态码 1 退出
+func Main(provider RuleProvider) {
+	if err := Serve(provider, os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
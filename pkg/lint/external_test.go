@@ -0,0 +1,91 @@
+package lint
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// TestMain lets the test binary act as an external lint plugin
+func TestMain(m *testing.M) {
+	if os.Getenv("LINT_TEST_PLUGIN") == "1" {
+		Main(NewProvider("plugin", noDepsRule()))
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// TestExternalProvider tests the out-of-process plugin protocol end to end
+func TestExternalProvider(t *testing.T) {
+	t.Setenv("LINT_TEST_PLUGIN", "1")
+
+	provider, err := LoadExternal("plugin", os.Args[0])
+	if err != nil {
+		t.Fatalf("Failed to load external provider: %v", err)
+	}
+	rules := provider.Rules()
+	if len(rules) != 1 || rules[0].ID() != "no-deps" || rules[0].Description() == "" {
+		t.Fatalf("Unexpected external rules: %v", rules)
+	}
+
+	registry := NewRegistry()
+	if err := registry.Register(provider); err != nil {
+		t.Fatalf("Failed to register external provider: %v", err)
+	}
+
+	config := mustParse(t, `{erl_opts, [debug_info]}.`)
+	diagnostics := registry.Run(config)
+	if len(diagnostics) != 1 || diagnostics[0].RuleID != "no-deps" || diagnostics[0].Message != "no deps declared" {
+		t.Errorf("Unexpected diagnostics: %v", diagnostics)
+	}
+
+	config = mustParse(t, `{deps, []}.`)
+	if diagnostics := registry.Run(config); len(diagnostics) != 0 {
+		t.Errorf("Expected no diagnostics, got %v", diagnostics)
+	}
+
+	// Configs parsed without raw text are re-rendered for the plugin
+	config, err = parser.Parse(`{deps, []}.`, parser.DiscardRaw())
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	if diagnostics := registry.Run(config); len(diagnostics) != 0 {
+		t.Errorf("Expected no diagnostics for discarded raw, got %v", diagnostics)
+	}
+}
+
+// TestLoadExternalErrors tests failures starting an external plugin
+func TestLoadExternalErrors(t *testing.T) {
+	if _, err := LoadExternal("empty"); err == nil {
+		t.Error("Expected error for empty command")
+	}
+	if _, err := LoadExternal("missing", "/nonexistent/plugin"); err == nil {
+		t.Error("Expected error for missing plugin binary")
+	}
+}
+
+// TestServeErrors tests protocol errors reported by Serve
+func TestServeErrors(t *testing.T) {
+	provider := NewProvider("plugin", noDepsRule())
+	tests := []struct {
+		args  []string
+		input string
+		want  string
+	}{
+		{nil, "", "missing command"},
+		{[]string{"bogus"}, "", "unknown command"},
+		{[]string{"check"}, "", "missing rule id"},
+		{[]string{"check", "other"}, "{deps, []}.", "unknown rule"},
+		{[]string{"check", "no-deps"}, "{deps", "syntax error"},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		err := Serve(provider, tt.args, strings.NewReader(tt.input), &out)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Serve(%v) error = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+// Package lint 提供针对 rebar.config 的可扩展检查框架。
+// @pkg 该包定义了稳定的规则接口（Rule、RuleProvider）和注册机制，
+// 组织可以在不修改本仓库的情况下，通过编译期注册或进程外插件提供自己的检查规则。
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// Severity 表示诊断信息的严重程度
+type Severity string
+
+const (
+	// SeverityError 表示必须修复的问题
+	SeverityError Severity = "error"
+	// SeverityWarning 表示可能有问题的配置
+	SeverityWarning Severity = "warning"
+	// SeverityInfo 表示提示信息
+	SeverityInfo Severity = "info"
+)
+
+// Diagnostic 表示一条检查结果
+// @pkg 由规则产生，描述配置中的一个问题
+// Key 是问题所在的顶级配置项名称（如 "deps"），Term 是引发问题的具体项（可为空）；
+// Line 和 Column 是问题在源文件中的位置（从 1 开始），未知时为 0，可以由 Locate 根据 Key 和 Term 填写
+type Diagnostic struct {
+	RuleID   string      `json:"rule_id"`
+	Severity Severity    `json:"severity"`
+	Message  string      `json:"message"`
+	Key      string      `json:"key,omitempty"`
+	Term     parser.Term `json:"-"`
+	Line     int         `json:"line,omitempty"`
+	Column   int         `json:"column,omitempty"`
+}
+
+// String 返回诊断信息的可读形式
+// @pkg 例如 "warning [erl-opts] erl_opts: duplicate option"
+func (d Diagnostic) String() string {
+	if d.Key == "" {
+		return fmt.Sprintf("%s [%s] %s", d.Severity, d.RuleID, d.Message)
+	}
+	return fmt.Sprintf("%s [%s] %s: %s", d.Severity, d.RuleID, d.Key, d.Message)
+}
+
+// Rule 是单条检查规则
+// @pkg 第三方规则需实现此接口；ID 在同一个 Registry 中必须唯一
+type Rule interface {
+	// ID 返回规则的唯一标识，如 "erl-opts"
+	ID() string
+	// Description 返回规则的简短说明
+	Description() string
+	// Check 检查配置并返回发现的问题
+	Check(config *parser.RebarConfig) []Diagnostic
+}
+
+// RuleProvider 提供一组规则
+// @pkg 一个组织或插件通常实现一个 RuleProvider，并通过 Register 注册
+type RuleProvider interface {
+	// Name 返回提供者的唯一名称
+	Name() string
+	// Rules 返回该提供者的全部规则
+	Rules() []Rule
+}
+
+// funcRule 是基于函数实现的 Rule
+type funcRule struct {
+	id          string
+	description string
+	check       func(config *parser.RebarConfig) []Diagnostic
+}
+
+func (r funcRule) ID() string          { return r.id }
+func (r funcRule) Description() string { return r.description }
+
+func (r funcRule) Check(config *parser.RebarConfig) []Diagnostic {
+	return r.check(config)
+}
+
+// NewRule 使用检查函数创建一条规则
+// @pkg 适合实现简单规则，无需定义新类型
+// 输入:
+//   - id: 规则标识
+//   - description: 规则说明
+//   - check: 检查函数
+//
+// 输出:
+//   - Rule: 新的规则
+//
+// 示例:
+//
+//	rule := lint.NewRule("no-deps", "config should declare deps", func(c *parser.RebarConfig) []lint.Diagnostic {
+//	  if _, ok := c.GetDeps(); !ok {
+//	    return []lint.Diagnostic{{Severity: lint.SeverityInfo, Message: "no deps declared"}}
+//	  }
+//	  return nil
+//	})
+func NewRule(id, description string, check func(config *parser.RebarConfig) []Diagnostic) Rule {
+	return funcRule{id: id, description: description, check: check}
+}
+
+// staticProvider 是由固定规则列表组成的 RuleProvider
+type staticProvider struct {
+	name  string
+	rules []Rule
+}
+
+func (p staticProvider) Name() string  { return p.name }
+func (p staticProvider) Rules() []Rule { return p.rules }
+
+// NewProvider 将一组规则包装为 RuleProvider
+// 输入:
+//   - name: 提供者名称
+//   - rules: 规则列表
+//
+// 输出:
+//   - RuleProvider: 新的提供者
+func NewProvider(name string, rules ...Rule) RuleProvider {
+	return staticProvider{name: name, rules: rules}
+}
+
+// Registry 保存已注册的规则提供者
+// @pkg Registry 可以安全地被多个 goroutine 并发使用
+type Registry struct {
+	mu        sync.RWMutex
+	providers []RuleProvider
+}
+
+// NewRegistry 创建一个空的 Registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register 注册一个规则提供者
+// @pkg 提供者名称重复或规则 ID 与已注册的规则冲突时返回错误
+// 输入:
+//   - provider: 要注册的提供者
+//
+// 输出:
+//   - error: 注册失败的原因
+func (r *Registry) Register(provider RuleProvider) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make(map[string]string)
+	for _, existing := range r.providers {
+		if existing.Name() == provider.Name() {
+			return fmt.Errorf("rule provider %q already registered", provider.Name())
+		}
+		for _, rule := range existing.Rules() {
+			ids[rule.ID()] = existing.Name()
+		}
+	}
+
+	for _, rule := range provider.Rules() {
+		if owner, ok := ids[rule.ID()]; ok {
+			return fmt.Errorf("rule %q of provider %q already registered by %q", rule.ID(), provider.Name(), owner)
+		}
+		ids[rule.ID()] = provider.Name()
+	}
+
+	r.providers = append(r.providers, provider)
+	return nil
+}
+
+// Providers 返回已注册的提供者，按注册顺序排列
+func (r *Registry) Providers() []RuleProvider {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]RuleProvider(nil), r.providers...)
+}
+
+// Rules 返回所有已注册的规则，按 ID 排序
+func (r *Registry) Rules() []Rule {
+	var rules []Rule
+	for _, provider := range r.Providers() {
+		rules = append(rules, provider.Rules()...)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID() < rules[j].ID() })
+	return rules
+}
+
+// Run 使用所有已注册的规则检查配置
+// @pkg 诊断信息中未填写 RuleID 的，会自动填入产生它的规则 ID
+// 输入:
+//   - config: 要检查的配置
+//
+// 输出:
+//   - []Diagnostic: 所有规则产生的诊断信息
+func (r *Registry) Run(config *parser.RebarConfig) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, rule := range r.Rules() {
+		for _, d := range rule.Check(config) {
+			if d.RuleID == "" {
+				d.RuleID = rule.ID()
+			}
+			if d.Severity == "" {
+				d.Severity = SeverityWarning
+			}
+			diagnostics = append(diagnostics, d)
+		}
+	}
+	return diagnostics
+}
+
+// DefaultRegistry 是包级别的默认注册表，预先注册了内置规则
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	if err := DefaultRegistry.Register(Builtin()); err != nil {
+		panic(err)
+	}
+}
+
+// Register 向 DefaultRegistry 注册规则提供者
+// @pkg 通常在第三方规则包的 init 函数中调用，实现编译期注册
+//
+// 示例:
+//
+//	func init() {
+//	  if err := lint.Register(lint.NewProvider("acme", myRule)); err != nil {
+//	    panic(err)
+//	  }
+//	}
+func Register(provider RuleProvider) error {
+	return DefaultRegistry.Register(provider)
+}
+
+// Run 使用 DefaultRegistry 中的规则检查配置
+func Run(config *parser.RebarConfig) []Diagnostic {
+	return DefaultRegistry.Run(config)
+}
@@ -0,0 +1,83 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+func noDepsRule() Rule {
+	return NewRule("no-deps", "config should declare deps", func(c *parser.RebarConfig) []Diagnostic {
+		if _, ok := c.GetDeps(); !ok {
+			return []Diagnostic{{Message: "no deps declared"}}
+		}
+		return nil
+	})
+}
+
+// TestRegistry tests registration and running of rule providers
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register(Builtin()); err != nil {
+		t.Fatalf("Failed to register builtin rules: %v", err)
+	}
+	if err := registry.Register(NewProvider("acme", noDepsRule())); err != nil {
+		t.Fatalf("Failed to register acme rules: %v", err)
+	}
+
+	if err := registry.Register(NewProvider("acme", noDepsRule())); err == nil {
+		t.Error("Expected error for duplicate provider name")
+	}
+	if err := registry.Register(NewProvider("other", noDepsRule())); err == nil {
+		t.Error("Expected error for duplicate rule id")
+	}
+
+	rules := registry.Rules()
+	if len(rules) != 2 || rules[0].ID() != "erl-opts" || rules[1].ID() != "no-deps" {
+		t.Fatalf("Unexpected rules: %v", rules)
+	}
+
+	config, err := parser.Parse(`{erl_opts, [debug_info, no_debug_info]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	diagnostics := registry.Run(config)
+	if len(diagnostics) != 2 {
+		t.Fatalf("Expected 2 diagnostics, got %v", diagnostics)
+	}
+	if diagnostics[0].RuleID != "erl-opts" || diagnostics[0].Severity != SeverityWarning || diagnostics[0].Key != "erl_opts" {
+		t.Errorf("Unexpected erl-opts diagnostic: %v", diagnostics[0])
+	}
+	if diagnostics[1].RuleID != "no-deps" || diagnostics[1].Severity != SeverityWarning {
+		t.Errorf("Expected defaults to be filled in, got %v", diagnostics[1])
+	}
+	if s := diagnostics[1].String(); s != "warning [no-deps] no deps declared" {
+		t.Errorf("Unexpected diagnostic string: %s", s)
+	}
+}
+
+// TestDefaultRegistry tests that builtin rules are registered by default
+func TestDefaultRegistry(t *testing.T) {
+	config, err := parser.Parse(`{profiles, [{prod, [{erl_opts, [debug_info, debug_info]}]}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	diagnostics := Run(config)
+	if len(diagnostics) != 1 || diagnostics[0].Key != "profiles" || diagnostics[0].Severity != SeverityInfo {
+		t.Errorf("Unexpected diagnostics: %v", diagnostics)
+	}
+	if !strings.Contains(diagnostics[0].Message, "profile prod") {
+		t.Errorf("Expected message to mention profile, got %q", diagnostics[0].Message)
+	}
+}
+
+func mustParse(t *testing.T, input string) *parser.RebarConfig {
+	t.Helper()
+	config, err := parser.Parse(input)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	return config
+}
@@ -0,0 +1,157 @@
+package lint
+
+import (
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// Locate 根据源码为诊断信息填写位置
+// @pkg 诊断信息只引用 Key 和 Term，本函数在源码的词法单元中查找它们:
+// - 有 Term 时，在 Key 对应的顶级项（没有 Key 时在整个文件）中查找与 Term 词法相同的第一处，位置为其第一个词法单元
+// - 找不到 Term 或没有 Term 时，使用 Key 对应顶级项的位置
+// - 都找不到时位置保持为 0
+//
+// 已有位置（Line 不为 0）的诊断信息不会被修改
+// 输入:
+//   - source: 配置文件的源码
+//   - diagnostics: 要填写位置的诊断信息，原地修改
+//
+// 示例:
+//
+//	diagnostics := lint.Run(config)
+//	lint.Locate(config.Raw, diagnostics)
+func Locate(source string, diagnostics []Diagnostic) {
+	tokens := significantTokens(parser.Tokenize(source))
+	ranges := topLevelRanges(tokens)
+
+	for i := range diagnostics {
+		d := &diagnostics[i]
+		if d.Line != 0 {
+			continue
+		}
+		span, hasKey := ranges[d.Key]
+		if !hasKey {
+			span = [2]int{0, len(tokens)}
+		}
+
+		at := -1
+		if d.Term != nil {
+			at = findTokens(tokens[span[0]:span[1]], significantTokens(parser.Tokenize(d.Term.String())))
+			if at >= 0 {
+				at += span[0]
+			}
+		}
+		if at < 0 && hasKey {
+			at = span[0]
+		}
+		if at >= 0 {
+			d.Line, d.Column = tokens[at].Line, tokens[at].Column
+		}
+	}
+}
+
+// significantTokens 返回去掉空白和注释后的词法单元
+func significantTokens(tokens []parser.Token) []parser.Token {
+	var result []parser.Token
+	for _, tok := range tokens {
+		if tok.Kind != parser.TokenWhitespace && tok.Kind != parser.TokenComment {
+			result = append(result, tok)
+		}
+	}
+	return result
+}
+
+// topLevelRanges 返回每个顶级 {Key, ...} 项在 tokens 中的范围，同名的项取第一个
+func topLevelRanges(tokens []parser.Token) map[string][2]int {
+	ranges := make(map[string][2]int)
+	depth := 0
+	start := -1
+	for i, tok := range tokens {
+		if tok.Kind != parser.TokenPunct {
+			continue
+		}
+		switch tok.Text {
+		case "{", "[", "<<":
+			if tok.Text == "{" && depth == 0 {
+				start = i
+			}
+			depth++
+		case "}", "]", ">>":
+			depth--
+			if depth == 0 && start >= 0 && start+1 < i {
+				key := atomText(tokens[start+1])
+				if _, seen := ranges[key]; !seen && key != "" {
+					ranges[key] = [2]int{start, i + 1}
+				}
+				start = -1
+			}
+		}
+	}
+	return ranges
+}
+
+// findTokens 返回 needle 在 haystack 中第一次出现的下标，未找到时返回 -1
+func findTokens(haystack, needle []parser.Token) int {
+	if len(needle) == 0 {
+		return -1
+	}
+outer:
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		for j, tok := range needle {
+			if !sameToken(haystack[i+j], tok) {
+				continue outer
+			}
+		}
+		return i
+	}
+	return -1
+}
+
+// sameToken 判断两个词法单元是否相同，'abc' 与 abc 视为相同的原子
+func sameToken(a, b parser.Token) bool {
+	if a.Kind != b.Kind {
+		return false
+	}
+	if a.Kind == parser.TokenAtom {
+		return atomText(a) == atomText(b)
+	}
+	return a.Text == b.Text
+}
+
+// atomText 返回原子词法单元的名称，不是原子时返回空字符串
+func atomText(tok parser.Token) string {
+	if tok.Kind != parser.TokenAtom {
+		return ""
+	}
+	if len(tok.Text) >= 2 && tok.Text[0] == '\'' {
+		if atom, err := parser.ParseTerm(tok.Text); err == nil {
+			return atom.(parser.Atom).Value
+		}
+	}
+	return tok.Text
+}
+
+// LockDiagnostics 将 parser.CheckLock 的结果转换为诊断信息
+// @pkg 规则 ID 为 "lock"，配置中缺少锁定、版本或 ref 不一致为 error，多余的锁定依赖为 warning；
+// Term 为依赖名称原子，因此 Locate 可以定位到 deps 中声明该依赖的位置
+// 输入:
+//   - issues: CheckLock 返回的问题
+//
+// 输出:
+//   - []Diagnostic: 诊断信息
+func LockDiagnostics(issues []parser.LockIssue) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, issue := range issues {
+		severity := SeverityError
+		if issue.Kind == parser.LockExtra {
+			severity = SeverityWarning
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			RuleID:   "lock",
+			Severity: severity,
+			Message:  issue.String(),
+			Key:      "deps",
+			Term:     parser.Atom{Value: issue.Dep},
+		})
+	}
+	return diagnostics
+}
@@ -0,0 +1,50 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// TestLocate tests filling diagnostic positions from the source
+func TestLocate(t *testing.T) {
+	source := `%% build options
+{erl_opts, [debug_info,
+            no_debug_info]}.
+
+{deps, [{'cowboy', "2.9.0"}]}.
+`
+	diagnostics := []Diagnostic{
+		{Key: "erl_opts", Term: parser.Atom{Value: "no_debug_info"}},
+		{Key: "deps", Term: parser.Tuple{Elements: []parser.Term{parser.Atom{Value: "cowboy"}, parser.String{Value: "2.9.0"}}}},
+		{Key: "deps", Term: parser.Atom{Value: "missing"}},
+		{Key: "profiles"},
+		{Term: parser.String{Value: "2.9.0"}},
+		{Key: "deps", Line: 9, Column: 9},
+	}
+	Locate(source, diagnostics)
+
+	expected := [][2]int{{3, 13}, {5, 9}, {5, 1}, {0, 0}, {5, 20}, {9, 9}}
+	for i, pos := range expected {
+		if diagnostics[i].Line != pos[0] || diagnostics[i].Column != pos[1] {
+			t.Errorf("Diagnostic %d: expected %d:%d, got %d:%d", i, pos[0], pos[1], diagnostics[i].Line, diagnostics[i].Column)
+		}
+	}
+}
+
+// TestLockDiagnostics tests conversion of lock issues
+func TestLockDiagnostics(t *testing.T) {
+	diagnostics := LockDiagnostics([]parser.LockIssue{
+		{Kind: parser.LockMissing, Dep: "cowboy"},
+		{Kind: parser.LockExtra, Dep: "jsx"},
+	})
+	if len(diagnostics) != 2 {
+		t.Fatalf("Expected 2 diagnostics, got %v", diagnostics)
+	}
+	if d := diagnostics[0]; d.RuleID != "lock" || d.Severity != SeverityError || d.Key != "deps" || d.Message != "missing: cowboy is not in the lock file" {
+		t.Errorf("Unexpected diagnostic: %+v", d)
+	}
+	if diagnostics[1].Severity != SeverityWarning {
+		t.Errorf("Expected extra lock entries to be warnings, got %v", diagnostics[1].Severity)
+	}
+}
@@ -0,0 +1,146 @@
+package lint
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// sarifSchema 是 SARIF 2.1.0 的 JSON Schema 地址
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// sarifLog 是 SARIF 2.1.0 文档中用到的字段
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string     `json:"id"`
+	ShortDescription *sarifText `json:"shortDescription,omitempty"`
+}
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifText       `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+	Region           *sarifRegion  `json:"region,omitempty"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// sarifLevel 将严重程度转换为 SARIF 的 level
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityInfo:
+		return "note"
+	}
+	return "warning"
+}
+
+// SARIF 将诊断信息转换为 SARIF 2.1.0 日志
+// @pkg 输出可以直接上传到 GitHub code scanning 等支持 SARIF 的平台:
+// - rules 中的规则写入 tool.driver.rules（ID 和说明）；诊断信息引用了未列出的规则时自动补充只有 ID 的规则
+// - 每条诊断信息是一个 result，级别 error、warning、info 分别对应 error、warning、note
+// - 位置使用诊断信息的 Line 和 Column，通常先调用 Locate 填写；位置未知时只包含文件
+// 输入:
+//   - file: 结果中引用的文件路径，通常是相对于仓库根目录的路径，如 "rebar.config"
+//   - rules: 参与检查的规则，如 Registry.Rules() 的返回值
+//   - diagnostics: 诊断信息
+//
+// 输出:
+//   - []byte: 缩进格式的 JSON 文档
+//   - error: 序列化错误
+//
+// 示例:
+//
+//	diagnostics := lint.Run(config)
+//	lint.Locate(config.Raw, diagnostics)
+//	data, err := lint.SARIF("rebar.config", lint.DefaultRegistry.Rules(), diagnostics)
+func SARIF(file string, rules []Rule, diagnostics []Diagnostic) ([]byte, error) {
+	driver := sarifDriver{
+		Name:           "erlang-rebar-config-parser",
+		InformationURI: "https://github.com/scagogogo/erlang-rebar-config-parser",
+		Rules:          []sarifRule{},
+	}
+	index := make(map[string]int)
+	for _, rule := range rules {
+		if _, ok := index[rule.ID()]; ok {
+			continue
+		}
+		index[rule.ID()] = len(driver.Rules)
+		driver.Rules = append(driver.Rules, sarifRule{ID: rule.ID(), ShortDescription: &sarifText{Text: rule.Description()}})
+	}
+
+	var missing []string
+	for _, d := range diagnostics {
+		if _, ok := index[d.RuleID]; !ok {
+			index[d.RuleID] = -1
+			missing = append(missing, d.RuleID)
+		}
+	}
+	sort.Strings(missing)
+	for _, id := range missing {
+		index[id] = len(driver.Rules)
+		driver.Rules = append(driver.Rules, sarifRule{ID: id})
+	}
+
+	results := []sarifResult{}
+	for _, d := range diagnostics {
+		location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifact{URI: file}}}
+		if d.Line > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{StartLine: d.Line, StartColumn: d.Column}
+		}
+		results = append(results, sarifResult{
+			RuleID:    d.RuleID,
+			RuleIndex: index[d.RuleID],
+			Level:     sarifLevel(d.Severity),
+			Message:   sarifText{Text: d.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+
+	return json.MarshalIndent(sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}, "", "  ")
+}
@@ -0,0 +1,85 @@
+package lint
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestSARIF tests the SARIF log structure
+func TestSARIF(t *testing.T) {
+	diagnostics := []Diagnostic{
+		{RuleID: "erl-opts", Severity: SeverityWarning, Message: "conflict", Line: 2, Column: 13},
+		{RuleID: "lock", Severity: SeverityError, Message: "missing"},
+		{RuleID: "erl-opts", Severity: SeverityInfo, Message: "duplicate", Line: 3, Column: 1},
+	}
+	data, err := SARIF("rebar.config", Builtin().Rules(), diagnostics)
+	if err != nil {
+		t.Fatalf("Failed to generate SARIF: %v", err)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID               string `json:"id"`
+						ShortDescription *struct {
+							Text string `json:"text"`
+						} `json:"shortDescription"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Message   struct {
+					Text string `json:"text"`
+				} `json:"message"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region *struct {
+							StartLine   int `json:"startLine"`
+							StartColumn int `json:"startColumn"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("Failed to decode SARIF: %v", err)
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("Unexpected SARIF log: %s", data)
+	}
+	run := log.Runs[0]
+	rules := run.Tool.Driver.Rules
+	if len(rules) != 2 || rules[0].ID != "erl-opts" || rules[0].ShortDescription == nil || rules[1].ID != "lock" || rules[1].ShortDescription != nil {
+		t.Errorf("Unexpected rules: %s", data)
+	}
+
+	if len(run.Results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(run.Results))
+	}
+	first := run.Results[0]
+	if first.RuleIndex != 0 || first.Level != "warning" || first.Message.Text != "conflict" {
+		t.Errorf("Unexpected first result: %+v", first)
+	}
+	location := first.Locations[0].PhysicalLocation
+	if location.ArtifactLocation.URI != "rebar.config" || location.Region == nil || location.Region.StartLine != 2 || location.Region.StartColumn != 13 {
+		t.Errorf("Unexpected first location: %+v", location)
+	}
+	if second := run.Results[1]; second.RuleIndex != 1 || second.Level != "error" || second.Locations[0].PhysicalLocation.Region != nil {
+		t.Errorf("Unexpected second result: %+v", second)
+	}
+	if third := run.Results[2]; third.Level != "note" {
+		t.Errorf("Expected info to map to note, got %s", third.Level)
+	}
+}
@@ -3,7 +3,11 @@
 package parser
 
 // GetTerm 根据名称获取配置中的特定项
-// @pkg 通过名称检索配置中的特定顶级项
+// @pkg 通过名称检索配置中的特定顶级项。同一个键出现多次时只返回第一个，与 rebar3 用 proplists 读取配置的行为一致
+// （后面的同名项会被 rebar3 忽略）；需要检查所有出现时使用 GetTerms
+// name 是原子的名称而不是 Erlang 语法，{'quoted-key', ...} 用 GetTerm("quoted-key") 查找，
+// GetTerm("'quoted-key'") 查找的是名称本身带单引号的原子；'foo' 与 foo 是同一个原子，都能用 "foo" 找到。
+// 需要区分两种写法时使用 GetTermExact
 // 输入:
 //   - name: 要查找的项名称
 //
@@ -18,11 +22,68 @@ package parser
 //	  fmt.Println("找到 deps 配置项:", term)
 //	}
 func (c *RebarConfig) GetTerm(name string) (Term, bool) {
+	if i := c.termIndex(name); i >= 0 {
+		return c.Terms[i], true
+	}
+	return nil, false
+}
+
+// GetTerms 返回配置中所有键为 name 的顶级项
+// @pkg file:consult 允许同一个键出现多次，rebar3 只使用第一个（GetTerm 与其余访问方法也是如此），
+// 其余的会被静默忽略。本方法按出现顺序返回所有同名项，便于工具发现并报告第二个 {deps, ...} 这类被忽略的配置块；
+// 不做合并，需要合并时由调用方决定如何处理各项的值
+// 输入:
+//   - name: 要查找的项名称
+//
+// 输出:
+//   - []Term: 所有同名项，按出现顺序排列，没有时为空切片
+//
+// 示例:
+//
+//	if all := config.GetTerms("deps"); len(all) > 1 {
+//	  fmt.Printf("deps 出现了 %d 次，rebar3 只使用第一个\n", len(all))
+//	}
+//
+// 数据样例:
+// 原始配置: {deps, [cowboy]}. {erl_opts, []}. {deps, [jsx]}.
+// 返回: []Term{{deps, [cowboy]}, {deps, [jsx]}}
+func (c *RebarConfig) GetTerms(name string) []Term {
+	terms := []Term{}
+	for _, term := range c.Terms {
+		if hasKey(term, name) {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// GetTermExact 按原子在源码中的写法查找顶级项
+// @pkg key 按 Erlang 原子语法解析："'foo'" 只匹配带引号书写的 {'foo', ...}，"foo" 只匹配裸原子 {foo, ...}。
+// 无法写成裸原子的名称（如 'quoted-key'）只有带引号一种写法，以程序方式构造的未加引号的 Atom{Value: "quoted-key"}
+// 也能用 "'quoted-key'" 找到，与 Atom.String 的输出一致。key 不是合法的原子时返回 false
+// 输入:
+//   - key: 原子的源码写法，如 "'quoted-key'"、"deps"
+//
+// 输出:
+//   - Term: 找到的第一个项
+//   - bool: 是否找到该项
+//
+// 示例:
+//
+//	// {'deps', [...]} 与 {deps, [...]} 并存时区分两者
+//	quoted, ok := config.GetTermExact("'deps'")
+func (c *RebarConfig) GetTermExact(key string) (Term, bool) {
+	parsed, err := ParseTerm(key)
+	if err != nil {
+		return nil, false
+	}
+	atom, ok := parsed.(Atom)
+	if !ok {
+		return nil, false
+	}
 	for _, term := range c.Terms {
-		if tuple, ok := term.(Tuple); ok && len(tuple.Elements) >= 1 {
-			if atom, ok := tuple.Elements[0].(Atom); ok && atom.Value == name {
-				return term, true
-			}
+		if hasKey(term, atom.Value) && term.(Tuple).Elements[0].String() == atom.String() {
+			return term, true
 		}
 	}
 	return nil, false
@@ -57,7 +118,11 @@ func (c *RebarConfig) GetTupleElements(name string) ([]Term, bool) {
 }
 
 // GetDeps 获取 deps 配置（如果存在）
-// @pkg 获取项目依赖配置列表
+// @pkg 获取项目依赖配置列表。指定 profile 时返回按 rebar3 规则合并后的依赖：基础 deps 加上各 profile 的 deps，
+// 同名依赖由 profile 中的声明在原位置取代，新依赖追加到末尾（与 ApplyProfiles 相同）
+// 输入:
+//   - profiles: 按应用顺序排列的 profile 名称，如 "test"；不指定时只返回基础 deps
+//
 // 输出:
 //   - []Term: 依赖项列表
 //   - bool: 是否找到 deps 配置
@@ -65,6 +130,7 @@ func (c *RebarConfig) GetTupleElements(name string) ([]Term, bool) {
 // 示例:
 //
 //	deps, ok := config.GetDeps()
+//	testDeps, _ := config.GetDeps("test")
 //	if ok {
 //	  for _, dep := range deps {
 //	    if depTuple, ok := dep.(Tuple); ok {
@@ -76,7 +142,10 @@ func (c *RebarConfig) GetTupleElements(name string) ([]Term, bool) {
 // 数据样例:
 // 原始配置: {deps, [{cowboy, "2.9.0"}, {jsx, "3.1.0"}]}.
 // 返回: []Term{Tuple{...cowboy...}, Tuple{...jsx...}}, true
-func (c *RebarConfig) GetDeps() ([]Term, bool) {
+func (c *RebarConfig) GetDeps(profiles ...string) ([]Term, bool) {
+	if len(profiles) > 0 {
+		return c.ApplyProfiles(profiles...).GetTupleElements("deps")
+	}
 	return c.GetTupleElements("deps")
 }
 
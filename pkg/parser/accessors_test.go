@@ -57,12 +57,38 @@ func TestGetHelpers(t *testing.T) {
 		if !ok {
 			t.Error("Expected to find 'quoted-key' term using unquoted lookup")
 		}
-		term, ok := configQuoted.GetTerm("'quoted-key'") // Lookup with quotes should ideally fail? Let's assume it should.
-		if ok {
-			// Current implementation finds it because it compares atom values directly.
-			// This might be desired behavior, or might need refinement based on exact requirements.
-			t.Logf("Found 'quoted-key' term using quoted lookup (current behavior): %v", term)
-			// t.Error("Did not expect to find 'quoted-key' term using quoted lookup")
+		if _, ok := configQuoted.GetTerm("'quoted-key'"); ok {
+			t.Error("Did not expect the quotes to be part of the name passed to GetTerm")
+		}
+	})
+	t.Run("GetTermExact", func(t *testing.T) {
+		configExact, err := Parse(`{'deps', [quoted]}. {deps, [bare]}. {'quoted-key', ok}.`)
+		if err != nil {
+			t.Fatalf("Failed to parse: %v", err)
+		}
+		configExact.Terms = append(configExact.Terms, Tuple{Elements: []Term{Atom{Value: "built-key"}, NewAtom("ok")}})
+
+		tests := []struct {
+			key      string
+			expected string
+		}{
+			{"'deps'", "{'deps', [quoted]}"},
+			{"deps", "{deps, [bare]}"},
+			{"'quoted-key'", "{'quoted-key', ok}"},
+			{"'built-key'", "{'built-key', ok}"},
+			{"quoted-key", ""},
+			{"\"deps\"", ""},
+			{"'missing'", ""},
+		}
+		for _, tt := range tests {
+			term, ok := configExact.GetTermExact(tt.key)
+			if ok != (tt.expected != "") || (ok && term.String() != tt.expected) {
+				t.Errorf("GetTermExact(%s): expected %q, got %v, %v", tt.key, tt.expected, term, ok)
+			}
+		}
+
+		if term, _ := configExact.GetTerm("deps"); term.String() != "{'deps', [quoted]}" {
+			t.Errorf("Expected GetTerm to ignore quoting, got %v", term)
 		}
 	})
 
@@ -206,3 +232,27 @@ func TestGetHelpers(t *testing.T) {
 		}
 	})
 }
+
+// TestGetTerms tests that every occurrence of a duplicated key is returned
+func TestGetTerms(t *testing.T) {
+	config, err := Parse(`{deps, [cowboy]}.
+{erl_opts, [debug_info]}.
+{deps, [jsx]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	deps := config.GetTerms("deps")
+	if len(deps) != 2 || deps[0].String() != "{deps, [cowboy]}" || deps[1].String() != "{deps, [jsx]}" {
+		t.Errorf("Expected both deps terms, got %v", deps)
+	}
+
+	first, ok := config.GetTerm("deps")
+	if !ok || first.String() != deps[0].String() {
+		t.Errorf("Expected GetTerm to return the first deps term, got %v", first)
+	}
+
+	if missing := config.GetTerms("relx"); missing == nil || len(missing) != 0 {
+		t.Errorf("Expected an empty slice, got %v", missing)
+	}
+}
@@ -0,0 +1,191 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+)
+
+// Application 表示 .app.src 或 .app 文件中的应用定义
+// @pkg 对应 {application, Name, [Key, ...]} 形式的应用资源文件
+// 数据样例:
+//
+//	{application, my_app, [
+//	  {description, "My application"},
+//	  {vsn, "0.1.0"},
+//	  {applications, [kernel, stdlib, cowboy]},
+//	  {mod, {my_app_app, []}},
+//	  {env, [{port, 8080}]},
+//	  {modules, []}
+//	]}.
+type Application struct {
+	// Name 是应用名称
+	Name string
+	// Vsn 是版本号；{vsn, git} 等非字符串值返回其文本形式
+	Vsn string
+	// Description 是应用描述
+	Description string
+	// Applications 是运行时依赖的应用
+	Applications []string
+	// IncludedApplications 是被包含的应用
+	IncludedApplications []string
+	// Registered 是注册的进程名
+	Registered []string
+	// Modules 是应用包含的模块，.app.src 中通常为空
+	Modules []string
+	// Licenses 是许可证列表
+	Licenses []string
+	// Mod 是应用回调模块，未配置时为空
+	Mod string
+	// ModArgs 是传给回调模块的参数，未配置时为 nil
+	ModArgs Term
+	// Env 是应用环境变量
+	Env map[string]Term
+	// Properties 是所有属性的原始值，以属性名为键，包含上面未单独解析的属性
+	Properties map[string]Term
+}
+
+// ParseAppSrc 解析指定路径的 .app.src 或 .app 文件
+// @pkg 基于现有解析器读取文件并构建 Application
+// 输入:
+//   - path: 文件路径，如 "./src/my_app.app.src"
+//
+// 输出:
+//   - *Application: 应用定义
+//   - error: 读取、语法或结构错误
+//
+// 示例:
+//
+//	app, err := parser.ParseAppSrc("./src/my_app.app.src")
+//	if err != nil {
+//	  log.Fatal(err)
+//	}
+//	fmt.Println(app.Name, app.Vsn, app.Applications)
+func ParseAppSrc(path string) (*Application, error) {
+	terms, err := Consult(path)
+	if err != nil {
+		return nil, err
+	}
+	return applicationFromTerms(terms)
+}
+
+// ParseApplication 解析字符串形式的应用定义
+// @pkg 与 ParseAppSrc 相同，但直接解析字符串内容
+// 输入:
+//   - input: .app.src 或 .app 文件的内容
+//
+// 输出:
+//   - *Application: 应用定义
+//   - error: 语法或结构错误
+func ParseApplication(input string) (*Application, error) {
+	terms, err := ParseConsult(input)
+	if err != nil {
+		return nil, err
+	}
+	return applicationFromTerms(terms)
+}
+
+// applicationFromTerms 从顶级项构建 Application
+func applicationFromTerms(terms []Term) (*Application, error) {
+	if len(terms) != 1 {
+		return nil, fmt.Errorf("invalid application resource: expected exactly one term, got %d", len(terms))
+	}
+
+	tuple, ok := terms[0].(Tuple)
+	if !ok || len(tuple.Elements) != 3 || termName(tuple) != "application" {
+		return nil, fmt.Errorf("invalid application resource: expected {application, Name, Properties}, got %s", terms[0])
+	}
+	name, ok := tuple.Elements[1].(Atom)
+	if !ok {
+		return nil, fmt.Errorf("invalid application resource: name must be an atom, got %s", tuple.Elements[1])
+	}
+	props, ok := tuple.Elements[2].(List)
+	if !ok {
+		return nil, fmt.Errorf("invalid application resource: properties must be a list, got %s", tuple.Elements[2])
+	}
+
+	app := &Application{
+		Name:       name.Value,
+		Env:        make(map[string]Term),
+		Properties: make(map[string]Term),
+	}
+
+	for _, prop := range props.Elements {
+		pair, ok := prop.(Tuple)
+		if !ok || len(pair.Elements) != 2 {
+			return nil, fmt.Errorf("invalid application resource: expected {Key, Value}, got %s", prop)
+		}
+		key, ok := pair.Elements[0].(Atom)
+		if !ok {
+			return nil, fmt.Errorf("invalid application resource: key must be an atom, got %s", pair.Elements[0])
+		}
+		value := pair.Elements[1]
+		app.Properties[key.Value] = value
+
+		var err error
+		switch key.Value {
+		case "vsn":
+			app.Vsn = termText(value)
+		case "description":
+			app.Description = termText(value)
+		case "applications":
+			app.Applications, err = appNames(key.Value, value)
+		case "included_applications":
+			app.IncludedApplications, err = appNames(key.Value, value)
+		case "registered":
+			app.Registered, err = appNames(key.Value, value)
+		case "modules":
+			app.Modules, err = appNames(key.Value, value)
+		case "licenses":
+			app.Licenses, err = appNames(key.Value, value)
+		case "mod":
+			mod, ok := value.(Tuple)
+			if !ok || len(mod.Elements) != 2 {
+				return nil, fmt.Errorf("invalid application resource: mod must be {Module, Args}, got %s", value)
+			}
+			app.Mod = termText(mod.Elements[0])
+			app.ModArgs = mod.Elements[1]
+		case "env":
+			err = app.applyEnv(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return app, nil
+}
+
+// applyEnv 解析 env 属性
+func (a *Application) applyEnv(value Term) error {
+	list, ok := value.(List)
+	if !ok {
+		return fmt.Errorf("invalid application resource: env must be a list, got %s", value)
+	}
+	for _, elem := range list.Elements {
+		pair, ok := elem.(Tuple)
+		if !ok || len(pair.Elements) != 2 {
+			return fmt.Errorf("invalid application resource: expected {Par, Val} in env, got %s", elem)
+		}
+		a.Env[termText(pair.Elements[0])] = pair.Elements[1]
+	}
+	return nil
+}
+
+// appNames 将原子或字符串列表转换为名称列表
+func appNames(key string, value Term) ([]string, error) {
+	list, ok := value.(List)
+	if !ok {
+		return nil, fmt.Errorf("invalid application resource: %s must be a list, got %s", key, value)
+	}
+	names := make([]string, 0, len(list.Elements))
+	for _, elem := range list.Elements {
+		switch elem.(type) {
+		case Atom, String:
+			names = append(names, termText(elem))
+		default:
+			return nil, fmt.Errorf("invalid application resource: unexpected %s entry %s", key, elem)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const sampleAppSrc = `%% -*- erlang -*-
+{application, my_app, [
+    {description, "My application"},
+    {vsn, "0.1.0"},
+    {registered, [my_app_sup]},
+    {mod, {my_app_app, []}},
+    {applications, [kernel, stdlib, cowboy]},
+    {included_applications, [inner]},
+    {env, [{port, 8080}, {host, "localhost"}]},
+    {modules, []},
+    {licenses, ["Apache-2.0"]},
+    {links, [{"GitHub", "https://github.com/example/my_app"}]}
+]}.
+`
+
+// TestParseApplication tests building an Application from app.src content
+func TestParseApplication(t *testing.T) {
+	app, err := ParseApplication(sampleAppSrc)
+	if err != nil {
+		t.Fatalf("Failed to parse application: %v", err)
+	}
+
+	if app.Name != "my_app" || app.Vsn != "0.1.0" || app.Description != "My application" {
+		t.Errorf("Unexpected header fields: %+v", app)
+	}
+	if !reflect.DeepEqual(app.Applications, []string{"kernel", "stdlib", "cowboy"}) {
+		t.Errorf("Unexpected applications: %v", app.Applications)
+	}
+	if !reflect.DeepEqual(app.IncludedApplications, []string{"inner"}) {
+		t.Errorf("Unexpected included applications: %v", app.IncludedApplications)
+	}
+	if !reflect.DeepEqual(app.Registered, []string{"my_app_sup"}) {
+		t.Errorf("Unexpected registered names: %v", app.Registered)
+	}
+	if !reflect.DeepEqual(app.Licenses, []string{"Apache-2.0"}) {
+		t.Errorf("Unexpected licenses: %v", app.Licenses)
+	}
+	if len(app.Modules) != 0 {
+		t.Errorf("Expected no modules, got %v", app.Modules)
+	}
+	if app.Mod != "my_app_app" || !app.ModArgs.Compare(List{Elements: []Term{}}) {
+		t.Errorf("Unexpected mod: %s %v", app.Mod, app.ModArgs)
+	}
+	if port, ok := app.Env["port"]; !ok || !port.Compare(Integer{Value: 8080}) {
+		t.Errorf("Unexpected port env: %v", port)
+	}
+	if _, ok := app.Properties["links"]; !ok {
+		t.Error("Expected links to be kept in Properties")
+	}
+}
+
+// TestParseApplicationVsnForms tests non-string vsn values
+func TestParseApplicationVsnForms(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{application, a, [{vsn, git}]}.`, "git"},
+		{`{application, a, [{vsn, {cmd, "echo 1"}}]}.`, `{cmd, "echo 1"}`},
+		{`{application, a, []}.`, ""},
+	}
+
+	for _, tt := range tests {
+		app, err := ParseApplication(tt.input)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", tt.input, err)
+		}
+		if app.Vsn != tt.expected {
+			t.Errorf("Expected vsn %q, got %q", tt.expected, app.Vsn)
+		}
+	}
+}
+
+// TestParseApplicationErrors tests malformed application resources
+func TestParseApplicationErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ``},
+		{"two terms", `{application, a, []}. {application, b, []}.`},
+		{"not application", `{app, a, []}.`},
+		{"name not atom", `{application, "a", []}.`},
+		{"props not list", `{application, a, props}.`},
+		{"bad property", `{application, a, [vsn]}.`},
+		{"bad key", `{application, a, [{"vsn", "1"}]}.`},
+		{"bad applications", `{application, a, [{applications, kernel}]}.`},
+		{"bad application entry", `{application, a, [{applications, [{kernel}]}]}.`},
+		{"bad mod", `{application, a, [{mod, a_app}]}.`},
+		{"bad env", `{application, a, [{env, [port]}]}.`},
+		{"syntax", `{application, a, [`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseApplication(tt.input); err == nil {
+				t.Errorf("Expected error for %q", tt.input)
+			}
+		})
+	}
+}
+
+// TestParseAppSrc tests reading an app.src file from disk
+func TestParseAppSrc(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "my_app.app.src")
+	if err := os.WriteFile(path, []byte(sampleAppSrc), 0644); err != nil {
+		t.Fatalf("Failed to write app.src: %v", err)
+	}
+
+	app, err := ParseAppSrc(path)
+	if err != nil {
+		t.Fatalf("Failed to parse app.src: %v", err)
+	}
+	if app.Name != "my_app" {
+		t.Errorf("Expected my_app, got %s", app.Name)
+	}
+
+	if _, err := ParseAppSrc(filepath.Join(t.TempDir(), "missing.app.src")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
@@ -0,0 +1,71 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ParseFiles 并发解析多个 rebar.config 文件
+// @pkg 使用固定大小的 goroutine 池解析给定路径的文件，适合分析包含大量配置文件的单体仓库
+// 输入:
+//   - paths: 文件路径列表，重复的路径只解析一次
+//   - concurrency: 最大并发数，小于等于 0 时使用 runtime.GOMAXPROCS(0)
+//
+// 输出:
+//   - map[string]*RebarConfig: 解析成功的配置，以路径为键
+//   - map[string]error: 解析失败的错误，以路径为键
+//
+// 每个路径只会出现在两个结果中的一个里。
+//
+// 示例:
+//
+//	configs, errs := parser.ParseFiles(paths, 8)
+//	for path, err := range errs {
+//	  log.Printf("%s: %v", path, err)
+//	}
+//	fmt.Printf("成功解析 %d 个文件\n", len(configs))
+func ParseFiles(paths []string, concurrency int) (map[string]*RebarConfig, map[string]error) {
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+
+	configs := make(map[string]*RebarConfig, len(paths))
+	errs := make(map[string]error)
+
+	jobs := make(chan string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < concurrency && i < len(paths); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range jobs {
+				config, err := ParseFile(path)
+
+				mu.Lock()
+				if err != nil {
+					errs[path] = err
+				} else {
+					configs[path] = config
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	seen := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		jobs <- path
+	}
+	close(jobs)
+	wg.Wait()
+
+	return configs, errs
+}
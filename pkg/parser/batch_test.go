@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestParseFiles tests concurrent parsing of multiple files
+func TestParseFiles(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for i := 0; i < 20; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("app%d.config", i))
+		content := fmt.Sprintf("{app_name, app%d}.\n{deps, []}.\n", i)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		paths = append(paths, path)
+	}
+
+	invalid := createTempConfigFile(t, "{deps, [")
+	missing := filepath.Join(dir, "missing.config")
+	paths = append(paths, invalid, missing, paths[0])
+
+	for _, concurrency := range []int{0, 1, 4, 100} {
+		t.Run(fmt.Sprintf("concurrency=%d", concurrency), func(t *testing.T) {
+			configs, errs := ParseFiles(paths, concurrency)
+			if len(configs) != 20 {
+				t.Errorf("Expected 20 parsed configs, got %d", len(configs))
+			}
+			if len(errs) != 2 || errs[invalid] == nil || errs[missing] == nil {
+				t.Errorf("Expected errors for invalid and missing files, got %v", errs)
+			}
+			name, ok := configs[paths[7]].GetAppName()
+			if !ok || name != "app7" {
+				t.Errorf("Expected app7, got %q", name)
+			}
+		})
+	}
+
+	configs, errs := ParseFiles(nil, 4)
+	if len(configs) != 0 || len(errs) != 0 {
+		t.Errorf("Expected empty results for no paths")
+	}
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// benchSmallConfig is a typical hand-written rebar.config
+const benchSmallConfig = `
+{erl_opts, [debug_info, warnings_as_errors, {parse_transform, lager_transform}]}.
+{deps, [
+    {cowboy, "2.9.0"},
+    {jsx, "3.1.0"},
+    {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}}
+]}.
+{relx, [
+    {release, {my_app, "0.1.0"}, [my_app, sasl]},
+    {dev_mode, true},
+    {include_erts, false}
+]}.
+{profiles, [
+    {test, [{deps, [{meck, "0.9.2"}]}, {erl_opts, [nowarn_export_all]}]},
+    {prod, [{relx, [{dev_mode, false}, {include_erts, true}]}]}
+]}.
+`
+
+// benchLargeConfig builds a synthetic config with n dependencies
+func benchLargeConfig(n int) string {
+	var b strings.Builder
+	b.WriteString("{erl_opts, [debug_info]}.\n{deps, [\n")
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(",\n")
+		}
+		fmt.Fprintf(&b, "    {dep_%d, {git, \"https://example.com/dep_%d.git\", {tag, \"%d.%d.%d\"}}}", i, i, i%10, i%7, i%3)
+	}
+	b.WriteString("\n]}.\n")
+	return b.String()
+}
+
+func benchmarkParse(b *testing.B, input string) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(input)))
+	for i := 0; i < b.N; i++ {
+		if _, err := Parse(input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkParseSmall measures parsing of a typical rebar.config
+func BenchmarkParseSmall(b *testing.B) {
+	benchmarkParse(b, benchSmallConfig)
+}
+
+// BenchmarkParseLarge measures parsing of a config with many dependencies
+func BenchmarkParseLarge(b *testing.B) {
+	benchmarkParse(b, benchLargeConfig(1000))
+}
+
+// BenchmarkParseStrings measures string literals with and without escapes
+func BenchmarkParseStrings(b *testing.B) {
+	input := strings.Repeat(`{key, "plain string value", "escaped \"value\"\n"}.`+"\n", 200)
+	benchmarkParse(b, input)
+}
+
+// BenchmarkParseNumbers measures integer and float literals
+func BenchmarkParseNumbers(b *testing.B) {
+	input := strings.Repeat("{nums, [1, -42, 3.14, 2.5e-3, 1000000]}.\n", 200)
+	benchmarkParse(b, input)
+}
+
+// BenchmarkParseLargeInterned measures parsing with a shared atom table
+func BenchmarkParseLargeInterned(b *testing.B) {
+	input := benchLargeConfig(1000)
+	table := NewAtomTable()
+	b.ReportAllocs()
+	b.SetBytes(int64(len(input)))
+	for i := 0; i < b.N; i++ {
+		if _, err := Parse(input, WithAtomTable(table)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
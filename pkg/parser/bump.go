@@ -0,0 +1,144 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BumpReport 是 BumpDep 对单个配置的修改报告
+type BumpReport struct {
+	// Changes 是成功修改的依赖，Kind 均为 DepVersionChanged
+	Changes []DepChange
+	// Errors 是匹配但无法按版本修改的依赖，如固定在分支或提交上的 git 依赖，或版本要求排除了目标版本
+	Errors []error
+}
+
+// BumpDep 在一批配置中把指定依赖升级到目标版本
+// @pkg 修改每个配置的基础 deps 和各 profile 中的 deps（配置被原地修改），依赖的写法按 UpdateDepVersion 处理。
+// 新版本保留原有版本要求的语法:
+// - "~> 2.9" 升级到 2.10.0 得到 "~> 2.10"，保留 ~> 后的版本段数；">= 2.9.0" 得到 ">= 2.10.0"，"==" 同理
+// - <、<=、> 和 != 不能随目标版本改写：目标版本已满足要求时保持不变，否则报告错误，如 "< 2.0.0" 排除了 2.10.0
+// - 带 v 前缀的 git 标签（如 "v2.9.0"）得到 "v2.10.0"
+// - 用 and、or 组合的版本要求无法保留语法，替换为精确版本
+//
+// 输入:
+//   - configs: 要修改的配置，以文件路径为键，如 ParseFiles 的结果
+//   - name: 依赖的应用名
+//   - version: 目标版本，如 "2.10.0"
+//
+// 输出:
+//   - map[string]BumpReport: 每个有修改或错误的配置的报告，以路径为键；已是目标版本的依赖不会被报告
+//
+// 示例:
+//
+//	configs, _ := parser.ParseFiles(paths, 8)
+//	for path, report := range parser.BumpDep(configs, "cowboy", "2.10.0") {
+//	  for _, change := range report.Changes {
+//	    fmt.Printf("%s: %s\n", path, change)
+//	  }
+//	}
+func BumpDep(configs map[string]*RebarConfig, name, version string) map[string]BumpReport {
+	reports := make(map[string]BumpReport)
+	for path, config := range configs {
+		report := config.bumpDep(name, version)
+		if len(report.Changes) > 0 || len(report.Errors) > 0 {
+			reports[path] = report
+		}
+	}
+	return reports
+}
+
+// bumpDep 在单个配置中升级依赖
+func (c *RebarConfig) bumpDep(name, version string) BumpReport {
+	var report BumpReport
+	bump := func(profile string, opts ...DepOption) {
+		c.editList(newDepOptions(opts), "deps", false, func(deps []Term) []Term {
+			for i, dep := range deps {
+				if termName(dep) != name {
+					continue
+				}
+				old, _ := newDepShape(dep)
+				requirement, err := bumpRequirement(currentVersion(old), version)
+				if err != nil {
+					report.Errors = append(report.Errors, fmt.Errorf("dependency %s: %w", name, err))
+					continue
+				}
+				updated, err := withDepVersion(dep, requirement)
+				if err != nil {
+					report.Errors = append(report.Errors, err)
+					continue
+				}
+				if sameTerm(updated, dep) {
+					continue
+				}
+				deps[i] = updated
+				shape, _ := newDepShape(updated)
+				report.Changes = append(report.Changes, DepChange{
+					Kind: DepVersionChanged, Profile: profile, Dep: name,
+					From: old.displayVersion(), To: shape.displayVersion(),
+				})
+			}
+			return deps
+		})
+	}
+
+	bump("")
+	for _, profile := range profileEntries(c.Terms) {
+		bump(profile.name, InProfile(profile.name))
+	}
+	return report
+}
+
+// currentVersion 返回依赖当前的版本要求或标签，如 "~> 2.9"、"v3.9.2"
+func currentVersion(dep depShape) string {
+	return strings.TrimPrefix(dep.version, "tag ")
+}
+
+// bumpRequirement 返回保留 current 语法的目标版本
+// @pkg 只有 ~>、>= 和 == 随目标版本改写；<、<=、> 和 != 在目标版本满足时原样返回，否则返回错误
+func bumpRequirement(current, target string) (string, error) {
+	text := strings.TrimSpace(current)
+	if text == "" || strings.Contains(text, " and ") || strings.Contains(text, " or ") {
+		return target, nil
+	}
+
+	prefix := ""
+	for _, op := range []string{"~>", ">=", "<=", "==", "!=", ">", "<"} {
+		if strings.HasPrefix(text, op) {
+			rest := strings.TrimLeft(text[len(op):], " ")
+			prefix, text = text[:len(text)-len(rest)], rest
+			break
+		}
+	}
+
+	switch strings.TrimSpace(prefix) {
+	case "<", "<=", ">", "!=":
+		req, err := ParseRequirement(current)
+		if err != nil {
+			return "", err
+		}
+		v, err := ParseVersion(strings.TrimPrefix(target, "v"))
+		if err != nil {
+			return "", err
+		}
+		if !req.Match(v) {
+			return "", fmt.Errorf("requirement %q excludes %s", current, target)
+		}
+		return current, nil
+	}
+
+	if strings.HasPrefix(text, "v") && !strings.HasPrefix(target, "v") {
+		target = "v" + target
+	}
+	if strings.HasPrefix(prefix, "~>") {
+		// 保留 ~> 后的版本段数：~> 2.9 只约束主版本
+		segments := strings.Count(strings.SplitN(text, "-", 2)[0], ".") + 1
+		parts := strings.SplitN(strings.SplitN(target, "-", 2)[0], ".", 3)
+		if segments < len(parts) {
+			target = strings.Join(parts[:segments], ".")
+		}
+	}
+	return prefix + target, nil
+}
@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestBumpRequirement tests that the requirement syntax is preserved
+func TestBumpRequirement(t *testing.T) {
+	tests := []struct {
+		current  string
+		expected string
+		err      bool
+	}{
+		{"", "2.10.0", false},
+		{"2.9.0", "2.10.0", false},
+		{"~> 2.9", "~> 2.10", false},
+		{"~> 2.9.1", "~> 2.10.0", false},
+		{">= 2.9.0", ">= 2.10.0", false},
+		{"==2.9.0", "==2.10.0", false},
+		{"v2.9.0", "v2.10.0", false},
+		{">= 2.0.0 and < 3.0.0", "2.10.0", false},
+		{"< 3.0.0", "< 3.0.0", false},
+		{"< 2.10.0", "", true},
+		{"<= 2.10.0", "<= 2.10.0", false},
+		{"<= 2.9.0", "", true},
+		{"> 2.9.0", "> 2.9.0", false},
+		{"> 2.10.0", "", true},
+		{"!= 2.9.0", "!= 2.9.0", false},
+		{"!= 2.10.0", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := bumpRequirement(tt.current, "2.10.0")
+		if (err != nil) != tt.err {
+			t.Errorf("bumpRequirement(%q): unexpected error %v", tt.current, err)
+		}
+		if got != tt.expected {
+			t.Errorf("bumpRequirement(%q): expected %q, got %q", tt.current, tt.expected, got)
+		}
+	}
+}
+
+// TestBumpDep tests bumping a dependency across several configs and profiles
+func TestBumpDep(t *testing.T) {
+	inputs := map[string]string{
+		"a/rebar.config": `{deps, [{cowboy, "~> 2.9"}, jsx]}.
+{profiles, [{test, [{deps, [{cowboy, "2.9.0"}]}]}]}.`,
+		"b/rebar.config": `{deps, [{cowboy, {git, "https://github.com/ninenines/cowboy.git", {tag, "v2.9.0"}}}]}.`,
+		"c/rebar.config": `{deps, [{cowboy, {git, "https://github.com/ninenines/cowboy.git", {branch, "master"}}}]}.`,
+		"d/rebar.config": `{deps, [{cowboy, "2.10.0"}]}.`,
+		"e/rebar.config": `{deps, [jsx]}.`,
+	}
+	configs := make(map[string]*RebarConfig)
+	for path, input := range inputs {
+		config, err := Parse(input)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", path, err)
+		}
+		configs[path] = config
+	}
+
+	reports := BumpDep(configs, "cowboy", "2.10.0")
+	if len(reports) != 3 {
+		t.Fatalf("Expected reports for 3 configs, got %v", reports)
+	}
+
+	var changes []string
+	for _, change := range reports["a/rebar.config"].Changes {
+		changes = append(changes, change.String())
+	}
+	expected := "version: cowboy ~> 2.9 -> ~> 2.10\n[test] version: cowboy 2.9.0 -> 2.10.0"
+	if strings.Join(changes, "\n") != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, strings.Join(changes, "\n"))
+	}
+
+	b := reports["b/rebar.config"]
+	if len(b.Changes) != 1 || b.Changes[0].To != "tag v2.10.0" {
+		t.Errorf("Expected tag v2.10.0, got %v", b.Changes)
+	}
+	if deps, _ := configs["b/rebar.config"].GetDeps(); !strings.Contains(deps[0].String(), `{tag, "v2.10.0"}`) {
+		t.Errorf("Expected config to be updated, got %s", deps[0])
+	}
+
+	c := reports["c/rebar.config"]
+	if len(c.Changes) != 0 || len(c.Errors) != 1 {
+		t.Errorf("Expected one error for a branch-pinned dep, got %+v", c)
+	}
+}
+
+// TestBumpDepExcluded tests that upper-bound and exclusion requirements are kept or reported
+func TestBumpDepExcluded(t *testing.T) {
+	config, err := Parse(`{deps, [{cowboy, "< 2.10.0"}]}.
+{profiles, [{test, [{deps, [{cowboy, "!= 2.9.0"}]}]}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	report := BumpDep(map[string]*RebarConfig{"rebar.config": config}, "cowboy", "2.10.0")["rebar.config"]
+	if len(report.Changes) != 0 || len(report.Errors) != 1 {
+		t.Fatalf("Expected one error and no changes, got %+v", report)
+	}
+	if expected := `dependency cowboy: requirement "< 2.10.0" excludes 2.10.0`; report.Errors[0].Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, report.Errors[0])
+	}
+	if deps, _ := config.GetDeps(); deps[0].String() != `[{cowboy, "< 2.10.0"}]` {
+		t.Errorf("Expected deps to be unchanged, got %s", deps[0])
+	}
+}
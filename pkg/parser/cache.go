@@ -0,0 +1,93 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"sync"
+)
+
+// Cache 缓存已解析的配置文件
+// @pkg 以文件路径为键，通过修改时间和大小判断文件（及其同名 .script 文件）是否变化；
+// 修改时间或大小变化但内容的 SHA-256 未变时（如仅被 touch），仍复用已解析的结果。
+// 适合在监视循环中反复解析未变化的配置。Cache 可以安全地被多个 goroutine 并发使用。
+//
+// 注意: 同一文件的多次调用返回同一个 *RebarConfig，调用方不应修改它。
+//
+// 示例:
+//
+//	cache := parser.NewCache()
+//	for range ticker.C {
+//	  config, err := cache.ParseFile("./rebar.config")
+//	  ...
+//	}
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	opts    []ParseOption
+}
+
+// cacheEntry 是单个文件的缓存记录
+type cacheEntry struct {
+	// state 是配置文件及其 .script 文件在上次解析时的状态
+	state  watchState
+	config *RebarConfig
+}
+
+// NewCache 创建一个空的缓存
+// 输入:
+//   - opts: 解析文件时使用的解析选项
+//
+// 输出:
+//   - *Cache: 新的缓存
+func NewCache(opts ...ParseOption) *Cache {
+	return &Cache{
+		entries: make(map[string]cacheEntry),
+		opts:    opts,
+	}
+}
+
+// ParseFile 解析指定路径的文件，文件未变化时直接返回缓存的结果
+// @pkg 先比较修改时间和大小，不同时读取文件并比较内容哈希，只有内容变化才重新解析。
+// 同名的 .script 文件也参与比较；解析通过包级的 ParseFile 进行，因此脚本检测、EvalScript 和 WithMmap 等选项同样生效。
+// 解析失败时不会缓存，也不会移除之前成功的缓存记录
+// 输入:
+//   - path: 文件路径
+//
+// 输出:
+//   - *RebarConfig: 解析后的配置对象
+//   - error: 读取或解析过程中的错误
+func (c *Cache) ParseFile(path string) (*RebarConfig, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[path]
+	c.mu.Unlock()
+
+	state := watchState{config: statFile(path, entry.state.config), script: statFile(path+".script", entry.state.script)}
+	if !ok || !state.sameContent(entry.state) {
+		config, err := ParseFile(path, c.opts...)
+		if err != nil {
+			return nil, err
+		}
+		entry.config = config
+	}
+	entry.state = state
+
+	c.mu.Lock()
+	c.entries[path] = entry
+	c.mu.Unlock()
+
+	return entry.config, nil
+}
+
+// Invalidate 移除指定路径的缓存记录
+func (c *Cache) Invalidate(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, path)
+}
+
+// Len 返回缓存中的文件数量
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestCache tests that unchanged files are served from the cache
+func TestCache(t *testing.T) {
+	path := createTempConfigFile(t, `{deps, [{cowboy, "2.9.0"}]}.`)
+	cache := NewCache()
+
+	first, err := cache.ParseFile(path)
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+	second, err := cache.ParseFile(path)
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+	if first != second {
+		t.Error("Expected cached config for unchanged file")
+	}
+
+	// Touching the file without changing content keeps the cached config
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("Failed to touch file: %v", err)
+	}
+	touched, err := cache.ParseFile(path)
+	if err != nil || touched != first {
+		t.Errorf("Expected cached config after touch, got %p (%v)", touched, err)
+	}
+
+	// Changing the content re-parses
+	if err := os.WriteFile(path, []byte(`{deps, []}.`), 0644); err != nil {
+		t.Fatalf("Failed to rewrite file: %v", err)
+	}
+	changed, err := cache.ParseFile(path)
+	if err != nil {
+		t.Fatalf("Failed to parse changed file: %v", err)
+	}
+	if changed == first || changed.Raw != `{deps, []}.` {
+		t.Error("Expected a fresh config after content change")
+	}
+
+	if cache.Len() != 1 {
+		t.Errorf("Expected 1 cache entry, got %d", cache.Len())
+	}
+	cache.Invalidate(path)
+	if cache.Len() != 0 {
+		t.Errorf("Expected empty cache after invalidation, got %d", cache.Len())
+	}
+}
+
+// TestCacheErrors tests that failures are reported and not cached
+func TestCacheErrors(t *testing.T) {
+	cache := NewCache(DiscardRaw())
+
+	if _, err := cache.ParseFile(filepath.Join(t.TempDir(), "missing.config")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+
+	path := createTempConfigFile(t, `{deps, [`)
+	if _, err := cache.ParseFile(path); err == nil {
+		t.Error("Expected syntax error")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Expected failed parses not to be cached, got %d entries", cache.Len())
+	}
+
+	if err := os.WriteFile(path, []byte(`{deps, []}.`), 0644); err != nil {
+		t.Fatalf("Failed to rewrite file: %v", err)
+	}
+	config, err := cache.ParseFile(path)
+	if err != nil || config.Raw != "" {
+		t.Errorf("Expected parse with cache options, got %v, %v", config, err)
+	}
+}
+
+// TestCacheScript tests that the cache applies the same script handling as ParseFile
+func TestCacheScript(t *testing.T) {
+	path := writeScriptProject(t, `{deps, []}.`, `CONFIG ++ [{erl_opts, [debug_info]}].`)
+
+	var scriptErr *ScriptError
+	if _, err := NewCache().ParseFile(path); !errors.As(err, &scriptErr) {
+		t.Fatalf("Expected *ScriptError, got %v", err)
+	}
+
+	cache := NewCache(EvalScript(nil))
+	config, err := cache.ParseFile(path)
+	if err != nil {
+		t.Fatalf("Failed to parse with EvalScript: %v", err)
+	}
+	if len(config.Terms) != 2 {
+		t.Fatalf("Expected 2 terms from the script, got %d", len(config.Terms))
+	}
+
+	// Changing only the script invalidates the cached result
+	if err := os.WriteFile(path+".script", []byte(`CONFIG.`), 0644); err != nil {
+		t.Fatalf("Failed to rewrite script: %v", err)
+	}
+	config, err = cache.ParseFile(path)
+	if err != nil {
+		t.Fatalf("Failed to parse changed script: %v", err)
+	}
+	if len(config.Terms) != 1 {
+		t.Errorf("Expected 1 term after script change, got %d", len(config.Terms))
+	}
+}
@@ -0,0 +1,82 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"sort"
+	"strings"
+)
+
+// Canonical 返回配置的规范字节表示
+// @pkg 输出只由 Terms 决定，与源码的空白、注释、数字写法以及运行的机器无关，适合计算内容哈希、缓存键和签名:
+// - 每个顶级项占一行，以 ".\n" 结尾，项内没有空白（同 Compact）
+// - 顶级项、列表和元组保持原有顺序，因为顺序在 Erlang 中有意义
+// - 映射的键值对按 Erlang 项顺序排序，重复的键只保留最后一个（与 Erlang 构造映射一致）
+// - 原子只在需要时加引号，整数使用十进制，浮点数使用最短且可精确还原的写法，KeepLiterals 记录的原始写法被忽略
+// 输出可以被 Parse 重新解析，且重新解析后的规范表示不变
+// 输出:
+//   - []byte: 规范表示
+//
+// 示例:
+//
+//	config, _ := parser.ParseFile("./rebar.config")
+//	sum := sha256.Sum256(config.Canonical())
+//
+// 数据样例:
+//
+//	{dist, #{port => 0.5e1, host => "localhost"}}. % comment
+//	的规范表示为
+//	{dist,#{host=>"localhost",port=>5.0}}.
+func (c *RebarConfig) Canonical() []byte {
+	var b strings.Builder
+	for _, term := range c.Terms {
+		writeCompact(&b, canonicalTerm(term))
+		b.WriteString(".\n")
+	}
+	return []byte(b.String())
+}
+
+// canonicalTerm 返回映射已排序去重、原子引号标记和字面量原始写法已清除的项副本
+func canonicalTerm(term Term) Term {
+	switch t := term.(type) {
+	case Atom:
+		return Atom{Value: t.Value}
+	case String:
+		return String{Value: t.Value}
+	case Integer:
+		return Integer{Value: t.Value}
+	case Float:
+		return Float{Value: t.Value}
+	case Tuple:
+		return Tuple{Elements: canonicalTerms(t.Elements)}
+	case List:
+		return List{Elements: canonicalTerms(t.Elements)}
+	case Map:
+		pairs := make([]MapPair, len(t.Pairs))
+		for i, pair := range t.Pairs {
+			pairs[i] = MapPair{Key: canonicalTerm(pair.Key), Value: canonicalTerm(pair.Value)}
+		}
+		sort.SliceStable(pairs, func(i, j int) bool {
+			return erlangCompare(pairs[i].Key, pairs[j].Key) < 0
+		})
+		unique := pairs[:0]
+		for _, pair := range pairs {
+			if n := len(unique); n > 0 && erlangCompare(unique[n-1].Key, pair.Key) == 0 {
+				unique[n-1] = pair
+				continue
+			}
+			unique = append(unique, pair)
+		}
+		return Map{Pairs: unique}
+	}
+	return term
+}
+
+// canonicalTerms 返回每个元素的规范副本
+func canonicalTerms(terms []Term) []Term {
+	result := make([]Term, len(terms))
+	for i, term := range terms {
+		result[i] = canonicalTerm(term)
+	}
+	return result
+}
@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestCanonical tests that Canonical ignores layout, comments, literal spelling and map order
+func TestCanonical(t *testing.T) {
+	a, err := Parse(`%% settings
+{dist, #{port => 0.5e1, host => "localhost", port => 10}}.
+{erl_opts, [debug_info,
+            {d, 'TEST', true}]}.   % trailing
+{'deps', [{cowboy, "2.9.0"}]}.
+`, KeepLiterals())
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	b, err := Parse(`{dist,#{host=>"localhost",port=>10}}.
+{erl_opts,[debug_info,{d,'TEST',true}]}.
+{deps,[{cowboy,"2.9.0"}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	expected := `{dist,#{host=>"localhost",port=>10}}.
+{erl_opts,[debug_info,{d,'TEST',true}]}.
+{deps,[{cowboy,"2.9.0"}]}.
+`
+	if got := string(a.Canonical()); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+	if !bytes.Equal(a.Canonical(), b.Canonical()) {
+		t.Errorf("Expected equal canonical forms, got:\n%s\n%s", a.Canonical(), b.Canonical())
+	}
+
+	reparsed, err := Parse(string(a.Canonical()))
+	if err != nil {
+		t.Fatalf("Failed to parse canonical form: %v", err)
+	}
+	if !bytes.Equal(reparsed.Canonical(), a.Canonical()) {
+		t.Errorf("Expected canonical form to be stable, got:\n%s", reparsed.Canonical())
+	}
+
+	c, _ := Parse(`{dist, #{port => 10, host => "localhost"}}. {deps, []}.`)
+	d, _ := Parse(`{deps, []}. {dist, #{port => 10, host => "localhost"}}.`)
+	if bytes.Equal(c.Canonical(), d.Canonical()) {
+		t.Errorf("Expected top-level order to be significant")
+	}
+}
@@ -0,0 +1,99 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// AlignComments 将源码中的行尾注释对齐到同一列
+// @pkg Format 和 FormatWith 不保留注释，保留注释的输出来自 FormatPreserving，因此对齐作为独立的一步作用于源码文本:
+// - 只调整代码之后的 % 注释，单独成行的注释和字符串中的 % 不受影响
+// - column 大于 0 时注释从第 column 列（从 1 开始，按字符计）开始；代码超过该列时与代码之间保留一个空格
+// - column 不大于 0 时，连续多行的行尾注释作为一组，对齐到组内最长代码之后一个空格处
+// 输入:
+//   - source: 源码
+//   - column: 注释的起始列，不大于 0 时按组自动对齐
+//
+// 输出:
+//   - string: 对齐后的源码
+//
+// 示例:
+//
+//	config, _ := parser.ParseFile("./rebar.config")
+//	// ... 修改 config.Terms ...
+//	text := parser.AlignComments(config.FormatPreserving(4), 40)
+//
+// 数据样例:
+//
+//	{deps, [{cowboy, "2.9.0"}, % web
+//	        jsx % json
+//	]}.
+//	按自动对齐输出为
+//	{deps, [{cowboy, "2.9.0"}, % web
+//	        jsx                % json
+//	]}.
+func AlignComments(source string, column int) string {
+	type trailing struct {
+		start   int // 代码之后空白的起始偏移
+		comment int // 注释的起始偏移
+		line    int
+		width   int // 代码的宽度（字符数）
+	}
+
+	var comments []trailing
+	for _, tok := range Tokenize(source) {
+		if tok.Kind != TokenComment {
+			continue
+		}
+		lineStart := strings.LastIndexByte(source[:tok.Offset], '\n') + 1
+		code := strings.TrimRight(source[lineStart:tok.Offset], " \t")
+		if code == "" {
+			continue
+		}
+		comments = append(comments, trailing{
+			start:   lineStart + len(code),
+			comment: tok.Offset,
+			line:    tok.Line,
+			width:   utf8.RuneCountInString(code),
+		})
+	}
+
+	targets := make([]int, len(comments))
+	for i := 0; i < len(comments); {
+		j := i + 1
+		if column <= 0 {
+			for j < len(comments) && comments[j].line == comments[j-1].line+1 {
+				j++
+			}
+		}
+		target := column - 1
+		if column <= 0 {
+			for _, c := range comments[i:j] {
+				if c.width+1 > target {
+					target = c.width + 1
+				}
+			}
+		}
+		for k := i; k < j; k++ {
+			targets[k] = target
+		}
+		i = j
+	}
+
+	var b strings.Builder
+	b.Grow(len(source))
+	last := 0
+	for i, c := range comments {
+		b.WriteString(source[last:c.start])
+		padding := targets[i] - c.width
+		if padding < 1 {
+			padding = 1
+		}
+		b.WriteString(strings.Repeat(" ", padding))
+		last = c.comment
+	}
+	b.WriteString(source[last:])
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package parser
+
+import "testing"
+
+// TestAlignComments tests fixed-column and grouped alignment of trailing comments
+func TestAlignComments(t *testing.T) {
+	source := `%% deps
+{deps, [
+    {cowboy, "2.9.0"}, % web server
+    jsx,   % json
+    {meck, "0.9.2"},% mocks
+
+    %% standalone comment
+    {lager, "3.9.2"} % "100% logging"
+]}. % end
+{name, "50% off"}.
+`
+
+	tests := []struct {
+		name     string
+		column   int
+		expected string
+	}{
+		{
+			name:   "fixed column",
+			column: 30,
+			expected: `%% deps
+{deps, [
+    {cowboy, "2.9.0"},       % web server
+    jsx,                     % json
+    {meck, "0.9.2"},         % mocks
+
+    %% standalone comment
+    {lager, "3.9.2"}         % "100% logging"
+]}.                          % end
+{name, "50% off"}.
+`,
+		},
+		{
+			name:   "column before code",
+			column: 10,
+			expected: `%% deps
+{deps, [
+    {cowboy, "2.9.0"}, % web server
+    jsx, % json
+    {meck, "0.9.2"}, % mocks
+
+    %% standalone comment
+    {lager, "3.9.2"} % "100% logging"
+]}.      % end
+{name, "50% off"}.
+`,
+		},
+		{
+			name:   "grouped",
+			column: 0,
+			expected: `%% deps
+{deps, [
+    {cowboy, "2.9.0"}, % web server
+    jsx,               % json
+    {meck, "0.9.2"},   % mocks
+
+    %% standalone comment
+    {lager, "3.9.2"} % "100% logging"
+]}.                  % end
+{name, "50% off"}.
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AlignComments(source, tt.column); got != tt.expected {
+				t.Errorf("Expected:\n%s\nGot:\n%s", tt.expected, got)
+			}
+		})
+	}
+
+	if got := AlignComments("{a, b}.\n", 0); got != "{a, b}.\n" {
+		t.Errorf("Expected source without comments to be unchanged, got %q", got)
+	}
+}
@@ -0,0 +1,102 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Compact 返回配置的紧凑表示
+// @pkg 每个顶级项占一行，项内没有多余的空格和换行，适合嵌入生成的代码、测试数据或通过网络传输；
+// 输出可以被 Parse 重新解析
+// 输出:
+//   - string: 紧凑格式的配置
+//
+// 示例:
+//
+//	config, _ := parser.ParseFile("./rebar.config")
+//	fmt.Print(config.Compact())
+//
+// 数据样例:
+//
+//	{erl_opts,[debug_info]}.
+//	{deps,[{cowboy,"2.9.0"},{jsx,"3.1.0"}]}.
+func (c *RebarConfig) Compact() string {
+	var b strings.Builder
+	for _, term := range c.Terms {
+		writeCompact(&b, term)
+		b.WriteString(".\n")
+	}
+	return b.String()
+}
+
+// Compact 返回原子的紧凑表示
+func (a Atom) Compact() string { return compactString(a) }
+
+// Compact 返回字符串的紧凑表示
+func (s String) Compact() string { return compactString(s) }
+
+// Compact 返回整数的紧凑表示
+func (i Integer) Compact() string { return compactString(i) }
+
+// Compact 返回浮点数的紧凑表示
+func (f Float) Compact() string { return compactString(f) }
+
+// Compact 返回二进制的紧凑表示
+func (b Binary) Compact() string { return compactString(b) }
+
+// Compact 返回元组的紧凑表示，如 {cowboy,"2.9.0"}
+func (t Tuple) Compact() string { return compactString(t) }
+
+// Compact 返回列表的紧凑表示，如 [debug_info,warnings_as_errors]
+func (l List) Compact() string { return compactString(l) }
+
+// Compact 返回映射的紧凑表示，如 #{a=>1}
+func (m Map) Compact() string { return compactString(m) }
+
+// compactString 返回项的紧凑表示
+func compactString(term Term) string {
+	var b strings.Builder
+	writeCompact(&b, term)
+	return b.String()
+}
+
+// writeCompact 以不含空白的形式写出项，与 Erlang 的 ~w 输出一致
+func writeCompact(b *strings.Builder, term Term) {
+	switch t := term.(type) {
+	case String:
+		b.WriteString(quoteString(t.Value))
+	case Integer:
+		b.WriteString(strconv.FormatInt(t.Value, 10))
+	case Tuple:
+		writeCompactSeq(b, '{', '}', t.Elements)
+	case List:
+		writeCompactSeq(b, '[', ']', t.Elements)
+	case Map:
+		b.WriteString("#{")
+		for i, pair := range t.Pairs {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeCompact(b, pair.Key)
+			b.WriteString("=>")
+			writeCompact(b, pair.Value)
+		}
+		b.WriteByte('}')
+	default:
+		b.WriteString(term.String())
+	}
+}
+
+// writeCompactSeq 写出逗号分隔且不含空白的元素序列
+func writeCompactSeq(b *strings.Builder, open, close byte, elements []Term) {
+	b.WriteByte(open)
+	for i, elem := range elements {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writeCompact(b, elem)
+	}
+	b.WriteByte(close)
+}
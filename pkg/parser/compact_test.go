@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"testing"
+)
+
+// TestCompact tests the one-line-per-term rendering
+func TestCompact(t *testing.T) {
+	input := `%% comment
+{erl_opts, [debug_info,
+            {d, 'TEST'}]}.
+
+{deps, [
+    {cowboy, "2.9.0"},
+    {name, <<"bin">>, #{ratio => 1.5, "k" => -3}}
+]}.
+{msg, "say \"hi\"\n"}.
+`
+	config, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	expected := `{erl_opts,[debug_info,{d,'TEST'}]}.
+{deps,[{cowboy,"2.9.0"},{name,<<"bin">>,#{ratio=>1.5,"k"=>-3}}]}.
+{msg,"say \"hi\"\n"}.
+`
+	got := config.Compact()
+	if got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+
+	reparsed, err := Parse(got)
+	if err != nil {
+		t.Fatalf("Failed to parse compact output: %v", err)
+	}
+	for i := range config.Terms {
+		if !config.Terms[i].Compare(reparsed.Terms[i]) {
+			t.Errorf("Term %d changed after round trip: %s vs %s", i, config.Terms[i], reparsed.Terms[i])
+		}
+	}
+}
+
+// TestTermCompact tests Compact on individual terms
+func TestTermCompact(t *testing.T) {
+	tests := []struct {
+		term     interface{ Compact() string }
+		expected string
+	}{
+		{Atom{Value: "ok"}, "ok"},
+		{Atom{Value: "my-app", IsQuoted: true}, "'my-app'"},
+		{String{Value: "a\tb"}, `"a\tb"`},
+		{Integer{Value: -7}, "-7"},
+		{Tuple{}, "{}"},
+		{List{Elements: []Term{Integer{Value: 1}, List{}}}, "[1,[]]"},
+		{Map{}, "#{}"},
+	}
+	for _, tt := range tests {
+		if got := tt.term.Compact(); got != tt.expected {
+			t.Errorf("Expected %s, got %s", tt.expected, got)
+		}
+	}
+}
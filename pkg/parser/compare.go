@@ -0,0 +1,180 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+// CompareOptions 是 CompareWith 的比较选项
+// @pkg 零值与 Term.Compare 相同，即 Erlang 的 =:=（忽略原子引号和字面量写法）
+type CompareOptions struct {
+	// Numeric 按数值比较整数与浮点数，与 Erlang 的 == 一致，如 2 与 2.0 相等；
+	// 与 Erlang 一致，映射的键仍按类型精确匹配，#{1 => a} 与 #{1.0 => a} 不相等
+	Numeric bool
+	// Proplists 把属性列表视为无序的键值集合，如 [debug_info, {d, 'TEST'}] 与 [{d, 'TEST'}, debug_info] 相等。
+	// 属性列表是每个元素都是原子或首元素为原子的元组的列表；同一个键的多个条目仍按顺序比较，
+	// 因为 proplists:get_value/2 等函数只取第一个
+	Proplists bool
+}
+
+// CompareWith 按选项比较两个项是否相等
+// @pkg 递归比较元组、列表和映射中的元素，选项对所有层级生效
+// 输入:
+//   - a, b: 要比较的项
+//   - opts: 比较选项
+//
+// 输出:
+//   - bool: 是否相等
+//
+// 示例:
+//
+//	a := parser.MustParseTerm(`{timeout, 1}`)
+//	b := parser.MustParseTerm(`{timeout, 1.0}`)
+//	parser.CompareWith(a, b, parser.CompareOptions{Numeric: true}) // true
+func CompareWith(a, b Term, opts CompareOptions) bool {
+	switch x := a.(type) {
+	case Tuple:
+		y, ok := b.(Tuple)
+		return ok && compareElements(x.Elements, y.Elements, opts)
+	case List:
+		y, ok := b.(List)
+		if ok && opts.Proplists && isProplist(x.Elements) && isProplist(y.Elements) {
+			return compareProplists(x.Elements, y.Elements, opts)
+		}
+		return ok && compareElements(x.Elements, y.Elements, opts)
+	case Map:
+		y, ok := b.(Map)
+		if !ok || len(x.Pairs) != len(y.Pairs) {
+			return false
+		}
+		for _, pair := range x.Pairs {
+			value, ok := y.Get(pair.Key)
+			if !ok || !CompareWith(pair.Value, value, opts) {
+				return false
+			}
+		}
+		return true
+	case Integer, Float:
+		if opts.Numeric && isNumber(b) {
+			return compareTerms(a, b, false) == 0
+		}
+	}
+	return a.Compare(b)
+}
+
+// CompareNumeric 比较两个项是否相等，整数与浮点数按数值比较
+// @pkg 等同于 CompareWith(a, b, CompareOptions{Numeric: true})，适合比较只在 1 与 1.0 上不同的配置
+//
+// 示例:
+//
+//	parser.CompareNumeric(parser.NewInteger(2), parser.NewFloat(2.0)) // true
+func CompareNumeric(a, b Term) bool {
+	return CompareWith(a, b, CompareOptions{Numeric: true})
+}
+
+// EqualAsProplist 比较两个项是否相等，属性列表中不同键的顺序不影响结果
+// @pkg 等同于 CompareWith(a, b, CompareOptions{Proplists: true})，对各层嵌套的属性列表都生效，
+// 适合比较 {erl_opts, [a, b]} 与 {erl_opts, [b, a]} 这样语义相同的配置
+//
+// 示例:
+//
+//	a := parser.MustParseTerm(`{erl_opts, [debug_info, {i, "include"}]}`)
+//	b := parser.MustParseTerm(`{erl_opts, [{i, "include"}, debug_info]}`)
+//	parser.EqualAsProplist(a, b) // true
+func EqualAsProplist(a, b Term) bool {
+	return CompareWith(a, b, CompareOptions{Proplists: true})
+}
+
+// Equal 判断两个配置是否相等
+// @pkg 两个配置的顶级项数量相同，且按顺序逐个用 Compare 比较都相等时返回 true：
+// 忽略 Raw、原子引号、字面量写法和映射中键值对的顺序，不忽略列表和顶级项的顺序以及整数与浮点数的区别
+// 输入:
+//   - other: 要比较的配置
+//
+// 输出:
+//   - bool: 是否相等
+//
+// 示例:
+//
+//	a, _ := parser.Parse(`{deps, [{cowboy, "2.9.0"}]}.`)
+//	b, _ := parser.Parse(`{'deps', [{cowboy, "2.9.0"}]}. % same deps`)
+//	a.Equal(b) // true
+func (c *RebarConfig) Equal(other *RebarConfig) bool {
+	return c.EqualWith(other, CompareOptions{})
+}
+
+// EqualWith 按选项判断两个配置是否相等
+// @pkg 顶级项按 CompareWith 比较；启用 Proplists 时顶级项本身也视为属性列表，顺序不影响结果
+// 输入:
+//   - other: 要比较的配置
+//   - opts: 比较选项
+//
+// 输出:
+//   - bool: 是否相等
+//
+// 示例:
+//
+//	a.EqualWith(b, parser.CompareOptions{Numeric: true, Proplists: true})
+func (c *RebarConfig) EqualWith(other *RebarConfig, opts CompareOptions) bool {
+	return CompareWith(List{Elements: c.Terms}, List{Elements: other.Terms}, opts)
+}
+
+// compareElements 按选项逐个比较两组元素
+func compareElements(a, b []Term, opts CompareOptions) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !CompareWith(a[i], b[i], opts) {
+			return false
+		}
+	}
+	return true
+}
+
+// isProplist 检查列表元素是否都是原子或首元素为原子的元组
+func isProplist(elements []Term) bool {
+	for _, elem := range elements {
+		if _, ok := elem.(Atom); ok {
+			continue
+		}
+		if tuple, ok := elem.(Tuple); ok && len(tuple.Elements) > 0 {
+			if _, ok := tuple.Elements[0].(Atom); ok {
+				continue
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// compareProplists 按键分组比较两个属性列表，组内保持原有顺序
+func compareProplists(a, b []Term, opts CompareOptions) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	groups := func(elements []Term) map[string][]Term {
+		result := make(map[string][]Term)
+		for _, elem := range elements {
+			name := termName(elem)
+			result[name] = append(result[name], elem)
+		}
+		return result
+	}
+	ga, gb := groups(a), groups(b)
+	if len(ga) != len(gb) {
+		return false
+	}
+	for name, entries := range ga {
+		if !compareElements(entries, gb[name], opts) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNumber 检查项是否是整数或浮点数
+func isNumber(term Term) bool {
+	switch term.(type) {
+	case Integer, Float:
+		return true
+	}
+	return false
+}
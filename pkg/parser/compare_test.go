@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"testing"
+)
+
+// TestCompareWith tests equality with and without numeric comparison
+func TestCompareWith(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		exact   bool
+		numeric bool
+	}{
+		{"same integers", `1`, `1`, true, true},
+		{"integer and float", `2`, `2.0`, false, true},
+		{"different values", `2`, `2.5`, false, false},
+		{"nested", `{timeout, [1, {retry, 3}]}`, `{timeout, [1.0, {retry, 3.0}]}`, false, true},
+		{"map values", `#{a => 1}`, `#{a => 1.0}`, false, true},
+		{"map keys stay exact", `#{1 => a}`, `#{1.0 => a}`, false, false},
+		{"quoted atoms", `'a'`, `a`, true, true},
+		{"number and atom", `1`, `one`, false, false},
+		{"list length", `[1]`, `[1.0, 2]`, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := MustParseTerm(tt.a), MustParseTerm(tt.b)
+			if got := CompareWith(a, b, CompareOptions{}); got != tt.exact {
+				t.Errorf("Expected exact comparison %v, got %v", tt.exact, got)
+			}
+			if got := a.Compare(b); got != tt.exact {
+				t.Errorf("Expected Compare %v, got %v", tt.exact, got)
+			}
+			if got := CompareNumeric(a, b); got != tt.numeric {
+				t.Errorf("Expected numeric comparison %v, got %v", tt.numeric, got)
+			}
+			if got := CompareNumeric(b, a); got != tt.numeric {
+				t.Errorf("Expected symmetric numeric comparison %v, got %v", tt.numeric, got)
+			}
+		})
+	}
+}
+
+// TestEqualAsProplist tests order-insensitive comparison of property lists
+func TestEqualAsProplist(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{"reordered atoms", `{erl_opts, [a, b]}`, `{erl_opts, [b, a]}`, true},
+		{"reordered tuples", `[debug_info, {i, "include"}, {d, 'TEST'}]`, `[{d, 'TEST'}, debug_info, {i, "include"}]`, true},
+		{"nested", `[{profiles, [{test, [{deps, [meck, proper]}]}, {prod, []}]}]`, `[{profiles, [{prod, []}, {test, [{deps, [proper, meck]}]}]}]`, true},
+		{"same key keeps order", `[{d, 'A'}, {d, 'B'}]`, `[{d, 'B'}, {d, 'A'}]`, false},
+		{"different values", `[{i, "a"}, b]`, `[b, {i, "c"}]`, false},
+		{"missing entry", `[a, b, c]`, `[c, b]`, false},
+		{"duplicated entry", `[a, a, b]`, `[a, b, b]`, false},
+		{"not a proplist", `[1, 2]`, `[2, 1]`, false},
+		{"tuples stay ordered", `{a, b}`, `{b, a}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := MustParseTerm(tt.a), MustParseTerm(tt.b)
+			if got := EqualAsProplist(a, b); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+			if got := EqualAsProplist(b, a); got != tt.expected {
+				t.Errorf("Expected symmetric result %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	numeric := CompareOptions{Numeric: true, Proplists: true}
+	if !CompareWith(MustParseTerm(`[{timeout, 1}, {retries, 3}]`), MustParseTerm(`[{retries, 3.0}, {timeout, 1}]`), numeric) {
+		t.Errorf("Expected options to combine")
+	}
+}
+
+// TestConfigEqual tests Equal and EqualWith on whole configs
+func TestConfigEqual(t *testing.T) {
+	base, err := Parse(`{erl_opts, [debug_info, {d, 'TEST'}]}.
+{deps, [{cowboy, "2.9.0"}]}.
+{shell, [{timeout, 1}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		equal     bool
+		numeric   bool
+		proplists bool
+	}{
+		{"formatting only", `{'erl_opts', [debug_info, {d, 'TEST'}]}. % comment
+{deps, [{cowboy, "2.9.0"}]}. {shell, [{timeout, 1}]}.`, true, true, true},
+		{"float", `{erl_opts, [debug_info, {d, 'TEST'}]}.
+{deps, [{cowboy, "2.9.0"}]}.
+{shell, [{timeout, 1.0}]}.`, false, true, false},
+		{"reordered", `{shell, [{timeout, 1}]}.
+{deps, [{cowboy, "2.9.0"}]}.
+{erl_opts, [{d, 'TEST'}, debug_info]}.`, false, false, true},
+		{"different", `{erl_opts, [debug_info, {d, 'TEST'}]}.
+{deps, [{cowboy, "2.10.0"}]}.
+{shell, [{timeout, 1}]}.`, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			if got := base.Equal(other); got != tt.equal {
+				t.Errorf("Expected Equal %v, got %v", tt.equal, got)
+			}
+			if got := base.EqualWith(other, CompareOptions{Numeric: true}); got != tt.numeric {
+				t.Errorf("Expected numeric EqualWith %v, got %v", tt.numeric, got)
+			}
+			if got := base.EqualWith(other, CompareOptions{Proplists: true}); got != tt.proplists {
+				t.Errorf("Expected proplist EqualWith %v, got %v", tt.proplists, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+// 构造函数
+// @pkg 以程序方式构造项时代替结构体字面量，使测试和代码生成更易读。构造出的项与解析同样源码得到的项一致，
+// 例如 NewTuple() 的 Elements 是空切片而不是 nil
+//
+// 示例:
+//
+//	dep := parser.KV("cowboy", parser.NewString("2.9.0"))
+//	deps := parser.KV("deps", parser.NewList(dep, parser.NewAtom("jsx")))
+//	// deps.String() 为 {deps, [{cowboy, "2.9.0"}, jsx]}
+
+// NewAtom 返回原子，名称不能作为裸原子书写时 String() 会自动加引号
+func NewAtom(value string) Atom {
+	return Atom{Value: value}
+}
+
+// NewString 返回字符串
+func NewString(value string) String {
+	return String{Value: value}
+}
+
+// NewBinary 返回内容为 value 的二进制
+func NewBinary(value string) Binary {
+	return Binary{Value: value}
+}
+
+// NewInteger 返回整数
+func NewInteger(value int64) Integer {
+	return Integer{Value: value}
+}
+
+// NewFloat 返回浮点数
+func NewFloat(value float64) Float {
+	return Float{Value: value}
+}
+
+// NewTuple 返回由 elements 组成的元组，元素切片会被复制
+func NewTuple(elements ...Term) Tuple {
+	return Tuple{Elements: append([]Term{}, elements...)}
+}
+
+// NewList 返回由 elements 组成的列表，元素切片会被复制
+func NewList(elements ...Term) List {
+	return List{Elements: append([]Term{}, elements...)}
+}
+
+// NewMap 返回由 pairs 组成的映射，键值对切片会被复制
+func NewMap(pairs ...MapPair) Map {
+	return Map{Pairs: append([]MapPair{}, pairs...)}
+}
+
+// KV 返回键为原子 key 的二元组 {key, value}，即属性列表中的一项
+// 输入:
+//   - key: 原子名称
+//   - value: 值
+//
+// 输出:
+//   - Tuple: {key, value}
+//
+// 示例:
+//
+//	parser.KV("minimum_otp_vsn", parser.NewString("24")) // {minimum_otp_vsn, "24"}
+func KV(key string, value Term) Tuple {
+	return Tuple{Elements: []Term{Atom{Value: key}, value}}
+}
+
+// MustParseTerm 与 ParseTerm 相同，但在解析失败时 panic
+// @pkg 用于测试和源码中固定的字面量，输入来自外部时应使用 ParseTerm
+// 示例:
+//
+//	opts := parser.MustParseTerm(`[debug_info, {d, 'TEST'}]`)
+func MustParseTerm(input string) Term {
+	term, err := ParseTerm(input)
+	if err != nil {
+		panic("parser: MustParseTerm(" + input + "): " + err.Error())
+	}
+	return term
+}
@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestConstructors tests that constructed terms equal the parsed equivalents
+func TestConstructors(t *testing.T) {
+	tests := []struct {
+		name   string
+		term   Term
+		source string
+	}{
+		{"atom", NewAtom("deps"), `deps`},
+		{"quoted atom", NewAtom("my-app"), `'my-app'`},
+		{"string", NewString("2.9.0"), `"2.9.0"`},
+		{"binary", NewBinary("cowboy"), `<<"cowboy">>`},
+		{"integer", NewInteger(-42), `-42`},
+		{"float", NewFloat(0.5), `0.5`},
+		{"empty tuple", NewTuple(), `{}`},
+		{"empty list", NewList(), `[]`},
+		{"map", NewMap(MapPair{Key: NewAtom("port"), Value: NewInteger(8080)}), `#{port => 8080}`},
+		{"kv", KV("deps", NewList(KV("cowboy", NewString("2.9.0")), NewAtom("jsx"))), `{deps, [{cowboy, "2.9.0"}, jsx]}`},
+		{"nested", NewTuple(NewAtom("d"), NewAtom("TEST"), NewAtom("true")), `{d, 'TEST', true}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := MustParseTerm(tt.source)
+			if !tt.term.Compare(parsed) {
+				t.Errorf("Expected %s to equal %s", tt.term, parsed)
+			}
+			if got := tt.term.String(); got != tt.source {
+				t.Errorf("Expected %q, got %q", tt.source, got)
+			}
+		})
+	}
+
+	if !reflect.DeepEqual(NewList(), MustParseTerm(`[]`)) {
+		t.Errorf("Expected NewList() to be identical to a parsed empty list")
+	}
+
+	elements := []Term{NewAtom("a")}
+	list := NewList(elements...)
+	elements[0] = NewAtom("b")
+	if !list.Elements[0].Compare(NewAtom("a")) {
+		t.Errorf("Expected NewList to copy its elements, got %s", list)
+	}
+}
+
+// TestMustParseTerm tests that MustParseTerm panics on invalid input
+func TestMustParseTerm(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for invalid input")
+		}
+	}()
+	MustParseTerm(`{unterminated`)
+}
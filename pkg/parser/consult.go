@@ -0,0 +1,68 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"io"
+)
+
+// Consult 读取由点号结尾的 Erlang 项组成的任意文件
+// @pkg 与 Erlang 的 file:consult/1 对应。解析器并不限于 rebar.config，
+// sys.config、rebar.lock、.app.src 等文件都由同样的语法构成；
+// 这里直接返回顶级项，不包装为 RebarConfig，也不保留原始内容
+// 输入:
+//   - path: 文件路径
+//   - opts: 可选的解析选项，如 WithMmap()
+//
+// 输出:
+//   - []Term: 文件中的顶级项
+//   - error: 读取或解析错误
+//
+// 示例:
+//
+//	terms, err := parser.Consult("./config/vm.config")
+//	if err != nil {
+//	  log.Fatal(err)
+//	}
+//	fmt.Println(len(terms))
+func Consult(path string, opts ...ParseOption) ([]Term, error) {
+	config, err := ParseFile(path, append(opts, DiscardRaw())...)
+	if err != nil {
+		return nil, err
+	}
+	return config.Terms, nil
+}
+
+// ParseConsult 解析字符串中由点号结尾的 Erlang 项
+// @pkg 与 Consult 相同，但直接解析字符串内容
+// 输入:
+//   - input: Erlang 项文本，如 "{a, 1}. [b]."
+//   - opts: 可选的解析选项
+//
+// 输出:
+//   - []Term: 顶级项
+//   - error: 解析错误
+func ParseConsult(input string, opts ...ParseOption) ([]Term, error) {
+	config, err := Parse(input, append(opts, DiscardRaw())...)
+	if err != nil {
+		return nil, err
+	}
+	return config.Terms, nil
+}
+
+// ConsultReader 以流式方式读取 reader 中由点号结尾的 Erlang 项
+// @pkg 与 Consult 相同，但从 io.Reader 读取，内存占用只与最大的单个顶级项有关
+// 输入:
+//   - r: 提供 Erlang 项文本的 reader
+//   - opts: 可选的解析选项
+//
+// 输出:
+//   - []Term: 顶级项
+//   - error: 读取或解析错误
+func ConsultReader(r io.Reader, opts ...ParseOption) ([]Term, error) {
+	config, err := ParseReader(r, append(opts, DiscardRaw())...)
+	if err != nil {
+		return nil, err
+	}
+	return config.Terms, nil
+}
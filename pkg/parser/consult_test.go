@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestConsult tests the consult entry points on a non-rebar file
+func TestConsult(t *testing.T) {
+	input := `%% vm settings
+{nodes, ['a@host', 'b@host']}.
+"free text".
+42.
+`
+	expected := []Term{
+		Tuple{Elements: []Term{Atom{Value: "nodes"}, List{Elements: []Term{
+			Atom{Value: "a@host", IsQuoted: true}, Atom{Value: "b@host", IsQuoted: true},
+		}}}},
+		String{Value: "free text"},
+		Integer{Value: 42},
+	}
+
+	check := func(name string, terms []Term, err error) {
+		if err != nil {
+			t.Fatalf("%s: Failed to consult: %v", name, err)
+		}
+		if len(terms) != len(expected) {
+			t.Fatalf("%s: Expected %d terms, got %d", name, len(expected), len(terms))
+		}
+		for i := range expected {
+			if !terms[i].Compare(expected[i]) {
+				t.Errorf("%s: Term %d: expected %s, got %s", name, i, expected[i], terms[i])
+			}
+		}
+	}
+
+	terms, err := ParseConsult(input)
+	check("ParseConsult", terms, err)
+
+	terms, err = ConsultReader(strings.NewReader(input))
+	check("ConsultReader", terms, err)
+
+	path := filepath.Join(t.TempDir(), "nodes.config")
+	if err := os.WriteFile(path, []byte(input), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	terms, err = Consult(path)
+	check("Consult", terms, err)
+
+	if _, err := ParseConsult(`{a, `); err == nil {
+		t.Error("Expected syntax error")
+	}
+	if _, err := ConsultReader(strings.NewReader(`{a, `)); err == nil {
+		t.Error("Expected syntax error from reader")
+	}
+	if _, err := Consult(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
@@ -0,0 +1,226 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"strconv"
+)
+
+// Cursor 指向项树中的一个节点，并记录到根的父节点链
+// @pkg 用于在编辑器和检查工具中浏览项树，如报告任意节点所在的顶级配置项。
+// 元组和列表的子节点是其元素，映射的子节点是各键值对的值（Index 为键值对的下标），其他项没有子节点。
+// 游标是只读的视图，修改项不会更新已有的游标
+type Cursor struct {
+	term     Term
+	parent   *Cursor
+	index    int
+	siblings []Term
+	path     string
+}
+
+// NewCursor 返回指向 term 的根游标
+// @pkg 根游标没有父节点和兄弟节点，Index 为 0，Path 为空
+//
+// 示例:
+//
+//	cur := parser.NewCursor(term)
+//	for _, child := range cur.Children() {
+//	  fmt.Println(child.Path(), child.Term())
+//	}
+func NewCursor(term Term) *Cursor {
+	return &Cursor{term: term, siblings: []Term{term}}
+}
+
+// Cursors 返回指向各顶级项的游标
+// @pkg 顶级项之间互为兄弟节点，路径为 [i]，父节点为 nil
+//
+// 示例:
+//
+//	for _, top := range config.Cursors() {
+//	  top.Inspect(func(cur *parser.Cursor) bool {
+//	    if atom, ok := cur.Term().(parser.Atom); ok && atom.Value == "warnings_as_errors" {
+//	      fmt.Printf("%s (in %s)\n", cur.Path(), cur.TopLevelKey())
+//	    }
+//	    return true
+//	  })
+//	}
+func (c *RebarConfig) Cursors() []*Cursor {
+	cursors := make([]*Cursor, len(c.Terms))
+	for i, term := range c.Terms {
+		cursors[i] = &Cursor{term: term, index: i, siblings: c.Terms, path: "[" + strconv.Itoa(i) + "]"}
+	}
+	return cursors
+}
+
+// Term 返回游标指向的项
+func (cur *Cursor) Term() Term {
+	return cur.term
+}
+
+// Parent 返回父节点的游标，根游标和顶级项返回 nil
+func (cur *Cursor) Parent() *Cursor {
+	return cur.parent
+}
+
+// Index 返回节点在父节点（或顶级项）中的下标
+func (cur *Cursor) Index() int {
+	return cur.index
+}
+
+// Path 返回从根到节点的路径，语法与 TermDiff.Path 相同，如 [1]{1}[0] 表示第 2 个顶级项的值中的第一个元素
+func (cur *Cursor) Path() string {
+	return cur.path
+}
+
+// Root 返回最上层的祖先，即所在的顶级项（或 NewCursor 的根）
+func (cur *Cursor) Root() *Cursor {
+	root := cur
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root
+}
+
+// TopLevelKey 返回节点所在顶级项的键，如 deps 列表中的节点返回 "deps"；顶级项不是 {Key, ...} 形式时返回空字符串
+func (cur *Cursor) TopLevelKey() string {
+	root := cur.Root().term
+	if _, ok := root.(Tuple); !ok {
+		return ""
+	}
+	return termName(root)
+}
+
+// Children 返回所有子节点的游标
+func (cur *Cursor) Children() []*Cursor {
+	children := childTerms(cur.term)
+	cursors := make([]*Cursor, len(children))
+	for i := range children {
+		cursors[i] = cur.child(children, i)
+	}
+	return cursors
+}
+
+// Child 返回第 i 个子节点的游标，下标越界时返回 nil
+func (cur *Cursor) Child(i int) *Cursor {
+	children := childTerms(cur.term)
+	if i < 0 || i >= len(children) {
+		return nil
+	}
+	return cur.child(children, i)
+}
+
+// NextSibling 返回下一个兄弟节点的游标，没有时返回 nil
+func (cur *Cursor) NextSibling() *Cursor {
+	return cur.sibling(cur.index + 1)
+}
+
+// PrevSibling 返回上一个兄弟节点的游标，没有时返回 nil
+func (cur *Cursor) PrevSibling() *Cursor {
+	return cur.sibling(cur.index - 1)
+}
+
+// Inspect 按先序深度优先遍历以该节点为根的子树
+// @pkg fn 返回 false 时不再遍历该节点的子节点
+func (cur *Cursor) Inspect(fn func(*Cursor) bool) {
+	if !fn(cur) {
+		return
+	}
+	for _, child := range cur.Children() {
+		child.Inspect(fn)
+	}
+}
+
+// sibling 返回下标为 i 的兄弟节点的游标
+func (cur *Cursor) sibling(i int) *Cursor {
+	if i < 0 || i >= len(cur.siblings) {
+		return nil
+	}
+	if cur.parent != nil {
+		return cur.parent.child(cur.siblings, i)
+	}
+	return &Cursor{term: cur.siblings[i], index: i, siblings: cur.siblings, path: "[" + strconv.Itoa(i) + "]"}
+}
+
+// child 返回指向 children[i] 的子游标
+func (cur *Cursor) child(children []Term, i int) *Cursor {
+	var segment string
+	switch t := cur.term.(type) {
+	case Tuple:
+		segment = "{" + strconv.Itoa(i) + "}"
+	case List:
+		segment = "[" + strconv.Itoa(i) + "]"
+	case Map:
+		segment = "#{" + t.Pairs[i].Key.String() + "}"
+	}
+	return &Cursor{term: children[i], parent: cur, index: i, siblings: children, path: cur.path + segment}
+}
+
+// childTerms 返回项的子节点
+func childTerms(term Term) []Term {
+	switch t := term.(type) {
+	case Tuple:
+		return t.Elements
+	case List:
+		return t.Elements
+	case Map:
+		values := make([]Term, len(t.Pairs))
+		for i, pair := range t.Pairs {
+			values[i] = pair.Value
+		}
+		return values
+	}
+	return nil
+}
+
+// TermAt 返回覆盖源码中指定位置的最内层项的游标
+// @pkg 位置按 Raw 计算，游标指向的是 Raw 中原有的项（修改 Terms 不影响结果），路径与 Cursors 相同，
+// 可用于编辑器的悬停提示和跳转。位置落在元组或列表的括号、逗号或空白上时返回该元组或列表；
+// 映射和二进制作为整体，不再细分到其内部
+// 输入:
+//   - offset: Raw 中的字节偏移
+//
+// 输出:
+//   - *Cursor: 指向最内层项的游标
+//   - bool: 位置是否在某个顶级项内；Raw 为空、无法解析或位置在顶级项之外（如注释中）时为 false
+//
+// 示例:
+//
+//	if cur, ok := config.TermAt(offset); ok {
+//	  fmt.Printf("%s in %s: %s\n", cur.Path(), cur.TopLevelKey(), cur.Term())
+//	}
+func (c *RebarConfig) TermAt(offset int) (*Cursor, bool) {
+	if c.Raw == "" {
+		return nil, false
+	}
+	original, err := Parse(c.Raw, DiscardRaw())
+	if err != nil {
+		return nil, false
+	}
+	spans, ok := sourceSpans(c.Raw)
+	if !ok || len(spans) != len(original.Terms) {
+		return nil, false
+	}
+
+	for i, top := range original.Cursors() {
+		if offset < spans[i].start || offset >= spans[i].end {
+			continue
+		}
+		cur, span := top, spans[i]
+		for span.seq {
+			next := -1
+			for j, child := range span.children {
+				if offset >= child.start && offset < child.end {
+					next = j
+					break
+				}
+			}
+			child := cur.Child(next)
+			if child == nil {
+				break
+			}
+			cur, span = child, span.children[next]
+		}
+		return cur, true
+	}
+	return nil, false
+}
@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCursorNavigation tests parent, sibling and child navigation
+func TestCursorNavigation(t *testing.T) {
+	config, err := Parse(`{erl_opts, [debug_info]}.
+{deps, [{cowboy, "2.9.0"}, jsx]}.
+{relx, #{release => app}}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	tops := config.Cursors()
+	if len(tops) != 3 {
+		t.Fatalf("Expected 3 cursors, got %d", len(tops))
+	}
+
+	deps := tops[0].NextSibling()
+	if deps.Path() != "[1]" || deps.TopLevelKey() != "deps" || deps.Parent() != nil {
+		t.Errorf("Unexpected top-level cursor: %s %s", deps.Path(), deps.TopLevelKey())
+	}
+	if tops[0].PrevSibling() != nil || tops[2].NextSibling() != nil {
+		t.Errorf("Expected no siblings beyond the ends")
+	}
+
+	version := deps.Child(1).Child(0).Child(1)
+	if version.Path() != "[1]{1}[0]{1}" || version.Term().String() != `"2.9.0"` {
+		t.Errorf("Unexpected cursor %s: %s", version.Path(), version.Term())
+	}
+	if version.Index() != 1 || version.TopLevelKey() != "deps" || version.Root().Index() != 1 {
+		t.Errorf("Unexpected position for %s", version.Path())
+	}
+
+	jsx := version.Parent().NextSibling()
+	if jsx.Path() != "[1]{1}[1]" || jsx.Term().String() != "jsx" || jsx.Parent().Term().String() != `[{cowboy, "2.9.0"}, jsx]` {
+		t.Errorf("Unexpected sibling %s: %s", jsx.Path(), jsx.Term())
+	}
+	if jsx.Child(0) != nil || len(jsx.Children()) != 0 {
+		t.Errorf("Expected atoms to have no children")
+	}
+
+	release := tops[2].Child(1).Child(0)
+	if release.Path() != "[2]{1}#{release}" || release.Term().String() != "app" {
+		t.Errorf("Unexpected map child %s: %s", release.Path(), release.Term())
+	}
+}
+
+// TestCursorInspect tests walking with cursors and pruning subtrees
+func TestCursorInspect(t *testing.T) {
+	root := NewCursor(MustParseTerm(`{a, [b, {c, d}], e}`))
+	if root.Path() != "" || root.TopLevelKey() != "a" || root.NextSibling() != nil {
+		t.Errorf("Unexpected root cursor")
+	}
+
+	var paths []string
+	root.Inspect(func(cur *Cursor) bool {
+		paths = append(paths, cur.Path())
+		_, isTuple := cur.Term().(Tuple)
+		return cur.Parent() == nil || !isTuple
+	})
+
+	expected := []string{"", "{0}", "{1}", "{1}[0]", "{1}[1]", "{2}"}
+	if len(paths) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, paths)
+			break
+		}
+	}
+}
+
+// TestTermAt tests finding the innermost term at a source offset
+func TestTermAt(t *testing.T) {
+	src := `%% deps
+{deps, [{cowboy, "2.9.0"}, jsx]}.
+{relx, [{release, {app, "0.1.0"}, [app]}]}.
+`
+	config, err := Parse(src)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	tests := []struct {
+		needle string
+		path   string
+		term   string
+		key    string
+	}{
+		{`"2.9.0"`, "[0]{1}[0]{1}", `"2.9.0"`, "deps"},
+		{`jsx`, "[0]{1}[1]", "jsx", "deps"},
+		{`, jsx`, "[0]{1}", `[{cowboy, "2.9.0"}, jsx]`, "deps"},
+		{`{deps`, "[0]", `{deps, [{cowboy, "2.9.0"}, jsx]}`, "deps"},
+		{`"0.1.0"`, "[1]{1}[0]{1}{1}", `"0.1.0"`, "relx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.needle, func(t *testing.T) {
+			cur, ok := config.TermAt(strings.Index(src, tt.needle))
+			if !ok {
+				t.Fatalf("Expected a term at %q", tt.needle)
+			}
+			if cur.Path() != tt.path || cur.Term().String() != tt.term || cur.TopLevelKey() != tt.key {
+				t.Errorf("Expected %s %s (%s), got %s %s (%s)", tt.path, tt.term, tt.key, cur.Path(), cur.Term(), cur.TopLevelKey())
+			}
+		})
+	}
+
+	for _, offset := range []int{0, strings.Index(src, "}.") + 1, len(src), -1} {
+		if _, ok := config.TermAt(offset); ok {
+			t.Errorf("Expected no term at offset %d", offset)
+		}
+	}
+
+	config.Raw = ""
+	if _, ok := config.TermAt(10); ok {
+		t.Errorf("Expected no term without Raw")
+	}
+}
@@ -0,0 +1,70 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"reflect"
+)
+
+// Decode 将单个项解码为类型 T 的值
+// @pkg Unmarshal 的泛型版本，解码规则与 Unmarshal 相同，调用方在编译期即可获得具体类型
+// 输入:
+//   - term: 要解码的项
+//
+// 输出:
+//   - T: 解码后的值
+//   - error: 类型不匹配时返回 *UnmarshalError
+//
+// 示例:
+//
+//	type Release struct {
+//	  Kind string
+//	  App  struct{ Name, Version string }
+//	  Apps []string
+//	}
+//	config, _ := parser.Parse(`{release, {my_app, "0.1.0"}, [my_app, sasl]}.`)
+//	release, err := parser.Decode[Release](config.Terms[0])
+func Decode[T any](term Term) (T, error) {
+	var value T
+	err := decodeTerm(term, reflect.ValueOf(&value).Elem(), "")
+	return value, err
+}
+
+// DecodeList 将列表或元组的每个元素解码为类型 T 的值
+// @pkg 适用于 deps、plugins 等由同一形状的元素组成的列表
+// 输入:
+//   - term: 列表或元组
+//
+// 输出:
+//   - []T: 解码后的值，与元素一一对应
+//   - error: term 不是列表或元组，或任意元素类型不匹配时返回 *UnmarshalError
+//
+// 示例:
+//
+//	type Dep struct{ Name, Version string }
+//	deps, _ := config.GetDeps()
+//	list, err := parser.DecodeList[Dep](deps[0])
+func DecodeList[T any](term Term) ([]T, error) {
+	var values []T
+	err := decodeTerm(term, reflect.ValueOf(&values).Elem(), "")
+	return values, err
+}
+
+// DecodeMap 将属性列表或映射解码为键为字符串、值为类型 T 的映射
+// @pkg 单独的原子 Key 对应 true，重复的键取第一个
+// 输入:
+//   - term: 属性列表或映射
+//
+// 输出:
+//   - map[string]T: 解码后的映射
+//   - error: term 不是属性列表或映射，或任意值类型不匹配时返回 *UnmarshalError
+//
+// 示例:
+//
+//	vars, _ := config.GetTupleElements("overlay_vars")
+//	values, err := parser.DecodeMap[string](vars[0])
+func DecodeMap[T any](term Term) (map[string]T, error) {
+	var values map[string]T
+	err := decodeTerm(term, reflect.ValueOf(&values).Elem(), "")
+	return values, err
+}
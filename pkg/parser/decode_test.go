@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestDecode tests the generic decoding helpers
+func TestDecode(t *testing.T) {
+	config, err := Parse(`{deps, [recon, {cowboy, "2.9.0"}]}.
+{release, {my_app, "0.1.0"}, [my_app, sasl]}.
+{overlay_vars, [{port, 8080}, {workers, 4}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	type dep struct{ Name, Version string }
+	deps, _ := config.GetDeps()
+	list, err := DecodeList[dep](deps[0])
+	if err != nil {
+		t.Fatalf("Failed to decode deps: %v", err)
+	}
+	if !reflect.DeepEqual(list, []dep{{Name: "recon"}, {Name: "cowboy", Version: "2.9.0"}}) {
+		t.Errorf("Unexpected deps: %+v", list)
+	}
+
+	type release struct {
+		Kind string
+		App  struct{ Name, Version string }
+		Apps []string
+	}
+	rel, err := Decode[release](config.Terms[1])
+	if err != nil {
+		t.Fatalf("Failed to decode release: %v", err)
+	}
+	if rel.Kind != "release" || rel.App.Version != "0.1.0" || !reflect.DeepEqual(rel.Apps, []string{"my_app", "sasl"}) {
+		t.Errorf("Unexpected release: %+v", rel)
+	}
+
+	vars, _ := config.GetTupleElements("overlay_vars")
+	values, err := DecodeMap[int](vars[0])
+	if err != nil {
+		t.Fatalf("Failed to decode overlay_vars: %v", err)
+	}
+	if !reflect.DeepEqual(values, map[string]int{"port": 8080, "workers": 4}) {
+		t.Errorf("Unexpected overlay_vars: %v", values)
+	}
+
+	if _, err := DecodeList[int](deps[0]); err == nil || err.Error() != "cannot unmarshal recon into Go value of type int at [0]" {
+		t.Errorf("Expected type mismatch error, got %v", err)
+	}
+	if _, err := Decode[string](Integer{Value: 1}); err == nil {
+		t.Errorf("Expected error decoding integer into string")
+	}
+}
@@ -0,0 +1,205 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+)
+
+// DepChangeKind 表示依赖变化的类别
+type DepChangeKind string
+
+const (
+	// DepAdded 表示新增的依赖
+	DepAdded DepChangeKind = "added"
+	// DepRemoved 表示被删除的依赖
+	DepRemoved DepChangeKind = "removed"
+	// DepVersionChanged 表示来源不变，版本要求或 tag/branch/ref 发生了变化
+	DepVersionChanged DepChangeKind = "version"
+	// DepSourceChanged 表示来源类别或地址发生了变化，如 hex 改为 git
+	DepSourceChanged DepChangeKind = "source"
+)
+
+// DepChange 描述两个配置之间一个依赖的变化
+type DepChange struct {
+	Kind DepChangeKind
+	// Profile 是依赖所在的 profile，基础配置中的 deps 为空
+	Profile string
+	// Dep 是依赖名称
+	Dep string
+	// From 和 To 是变化前后的版本（DepVersionChanged）或来源（DepSourceChanged）；
+	// 新增和删除时为依赖的描述，如 "hex 2.9.0"
+	From, To string
+}
+
+// String 返回变化的可读描述
+// @pkg 例如 "version: cowboy 2.9.0 -> 2.10.0"、"[test] added: meck (hex 0.9.2)"
+func (c DepChange) String() string {
+	var text string
+	switch c.Kind {
+	case DepAdded:
+		text = fmt.Sprintf("added: %s (%s)", c.Dep, c.To)
+	case DepRemoved:
+		text = fmt.Sprintf("removed: %s (%s)", c.Dep, c.From)
+	default:
+		text = fmt.Sprintf("%s: %s %s -> %s", c.Kind, c.Dep, c.From, c.To)
+	}
+	if c.Profile != "" {
+		text = "[" + c.Profile + "] " + text
+	}
+	return text
+}
+
+// DiffDeps 比较两个配置中声明的依赖
+// @pkg 按依赖名称匹配，理解依赖的各种写法，因此只改变写法（如 jsx 与 {jsx, ""}、引号原子）不会被报告:
+// - 来源由 hex、git、git_subdir、hg、path 等类别及地址（hex 依赖为包名）确定，来源不同时报告 DepSourceChanged
+// - 来源相同时比较 hex 的版本要求或版本库的 {tag, ...}、{branch, ...}、{ref, ...}，不同时报告 DepVersionChanged
+//
+// 先比较基础配置中的 deps，再按出现顺序比较各 profile 中的 deps（profile 本身新增或删除时其依赖全部视为新增或删除）。
+// 每组内先按 a 中的顺序列出删除和修改，再按 b 中的顺序列出新增
+// 输入:
+//   - a: 旧配置
+//   - b: 新配置
+//
+// 输出:
+//   - []DepChange: 依赖的变化，没有变化时为空
+//
+// 示例:
+//
+//	old, _ := parser.Parse(oldText)
+//	current, _ := parser.ParseFile("./rebar.config")
+//	for _, change := range parser.DiffDeps(old, current) {
+//	  fmt.Println(change)
+//	}
+func DiffDeps(a, b *RebarConfig) []DepChange {
+	changes := diffDepLists("", listElements(a, "deps"), listElements(b, "deps"))
+
+	aProfiles, bProfiles := profileEntries(a.Terms), profileEntries(b.Terms)
+	var names []string
+	seen := make(map[string]bool)
+	for _, entries := range [][]profileEntry{aProfiles, bProfiles} {
+		for _, entry := range entries {
+			if !seen[entry.name] {
+				seen[entry.name] = true
+				names = append(names, entry.name)
+			}
+		}
+	}
+	for _, name := range names {
+		changes = append(changes, diffDepLists(name, profileDeps(aProfiles, name), profileDeps(bProfiles, name))...)
+	}
+	return changes
+}
+
+// profileDeps 返回指定 profile 中声明的依赖
+func profileDeps(entries []profileEntry, name string) []Term {
+	for _, entry := range entries {
+		if entry.name == name {
+			deps, _ := listValue(entry.terms, "deps")
+			return deps
+		}
+	}
+	return nil
+}
+
+// diffDepLists 比较两组依赖
+func diffDepLists(profile string, a, b []Term) []DepChange {
+	before := make(map[string]depShape)
+	for _, term := range a {
+		if dep, ok := newDepShape(term); ok {
+			if _, dup := before[dep.name]; !dup {
+				before[dep.name] = dep
+			}
+		}
+	}
+	after := make(map[string]depShape)
+	var added []DepChange
+	for _, term := range b {
+		dep, ok := newDepShape(term)
+		if !ok {
+			continue
+		}
+		if _, dup := after[dep.name]; dup {
+			continue
+		}
+		after[dep.name] = dep
+		if _, ok := before[dep.name]; !ok {
+			added = append(added, DepChange{Kind: DepAdded, Profile: profile, Dep: dep.name, To: dep.String()})
+		}
+	}
+
+	var changes []DepChange
+	reported := make(map[string]bool)
+	for _, term := range a {
+		old, ok := newDepShape(term)
+		if !ok || reported[old.name] {
+			continue
+		}
+		reported[old.name] = true
+		dep, ok := after[old.name]
+		switch {
+		case !ok:
+			changes = append(changes, DepChange{Kind: DepRemoved, Profile: profile, Dep: old.name, From: old.String()})
+		case old.source != dep.source:
+			changes = append(changes, DepChange{Kind: DepSourceChanged, Profile: profile, Dep: old.name, From: old.source, To: dep.source})
+		case old.version != dep.version:
+			changes = append(changes, DepChange{Kind: DepVersionChanged, Profile: profile, Dep: old.name, From: old.displayVersion(), To: dep.displayVersion()})
+		}
+	}
+	return append(changes, added...)
+}
+
+// depShape 是依赖声明中与写法无关的来源和版本
+type depShape struct {
+	name string
+	// source 是来源类别及地址，如 "hex"、"hex jsx"（包名与应用名不同）、"git https://..."
+	source string
+	// version 是 hex 的版本要求或版本库引用，如 "2.9.0"、"tag 3.9.2"；未指定时为空
+	version string
+}
+
+// newDepShape 从依赖声明中提取来源和版本
+func newDepShape(term Term) (depShape, bool) {
+	dep, ok := AsDependency(term)
+	if !ok {
+		return depShape{}, false
+	}
+	shape := depShape{name: dep.Name, source: string(dep.Source)}
+	switch dep.Source {
+	case DepHex:
+		if dep.Package != dep.Name {
+			shape.source += " " + dep.Package
+		}
+		shape.version = dep.Version
+	case DepGit, DepGitSubdir, DepHg, DepPath:
+		if dep.URL != "" {
+			shape.source += " " + dep.URL
+		}
+		if dep.Subdir != "" {
+			shape.source += " " + dep.Subdir
+		}
+		shape.version = dep.RefValue
+		if dep.RefType != "" {
+			shape.version = dep.RefType + " " + dep.RefValue
+		}
+	default:
+		shape.source = compactString(dep.SourceTerm)
+	}
+	return shape, true
+}
+
+// String 返回依赖的描述，如 "hex 2.9.0"、"git https://... tag 3.9.2"
+func (d depShape) String() string {
+	if d.version == "" {
+		return d.source
+	}
+	return d.source + " " + d.version
+}
+
+// displayVersion 返回用于显示的版本，未指定时为 "any"
+func (d depShape) displayVersion() string {
+	if d.version == "" {
+		return "any"
+	}
+	return d.version
+}
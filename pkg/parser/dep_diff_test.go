@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDiffDeps tests added, removed, version and source changes including profiles
+func TestDiffDeps(t *testing.T) {
+	a, err := Parse(`{deps, [
+    jsx,
+    {cowboy, "2.9.0"},
+    {lager, "3.9.2"},
+    {gun, {git, "https://github.com/ninenines/gun.git", {tag, "1.3.3"}}},
+    {my_jsx, "3.0.0", {pkg, jsx}},
+    recon
+]}.
+{profiles, [
+    {test, [{deps, [{meck, "0.9.0"}]}]},
+    {old, [{deps, [proper]}]}
+]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	b, err := Parse(`{deps, [
+    {'jsx', ""},
+    {cowboy, "2.10.0"},
+    {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}},
+    {gun, {git, "https://github.com/ninenines/gun.git", {tag, "2.0.0"}}},
+    {my_jsx, "3.0.0", {pkg, jsone}},
+    {hackney, "1.18.1"}
+]}.
+{profiles, [
+    {test, [{deps, [{meck, "0.9.2"}, proper]}]},
+    {prod, [{deps, [{recon, "2.5.3"}]}]}
+]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	expected := []string{
+		"version: cowboy 2.9.0 -> 2.10.0",
+		"source: lager hex -> git https://github.com/erlang-lager/lager.git",
+		"version: gun tag 1.3.3 -> tag 2.0.0",
+		"source: my_jsx hex jsx -> hex jsone",
+		"removed: recon (hex)",
+		"added: hackney (hex 1.18.1)",
+		"[test] version: meck 0.9.0 -> 0.9.2",
+		"[test] added: proper (hex)",
+		"[old] removed: proper (hex)",
+		"[prod] added: recon (hex 2.5.3)",
+	}
+	var got []string
+	for _, change := range DiffDeps(a, b) {
+		got = append(got, change.String())
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected:\n%s\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+
+	if changes := DiffDeps(a, a); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+}
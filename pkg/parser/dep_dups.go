@@ -0,0 +1,156 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+)
+
+// DuplicateDepKind 表示重复依赖的类别
+type DuplicateDepKind string
+
+const (
+	// DepDuplicate 表示完全相同的依赖声明出现了多次
+	DepDuplicate DuplicateDepKind = "duplicate"
+	// DepConflict 表示同名依赖的声明不同，如版本或来源不同
+	DepConflict DuplicateDepKind = "conflict"
+)
+
+// DuplicateDep 描述一个重复声明的依赖
+// @pkg Profile 和 PreviousProfile 为空字符串表示基础配置中的 deps
+type DuplicateDep struct {
+	Kind DuplicateDepKind
+	// Name 是依赖名称
+	Name            string
+	Profile         string
+	Dep             Term
+	PreviousProfile string
+	Previous        Term
+}
+
+// String 返回问题的可读描述
+// @pkg 例如 `conflict: {jsx, "3.0.0"} (profile test) conflicts with {jsx, "3.1.0"} (base)`
+func (d DuplicateDep) String() string {
+	verb := "duplicates"
+	if d.Kind == DepConflict {
+		verb = "conflicts with"
+	}
+	return fmt.Sprintf("%s: %s (%s) %s %s (%s)", d.Kind, d.Dep, scopeName(d.Profile), verb, d.Previous, scopeName(d.PreviousProfile))
+}
+
+// FindDuplicateDeps 查找重复声明的依赖
+// @pkg 报告两种情况:
+// - 同一个 deps 列表（基础配置或某个 profile）中同名的依赖出现多次
+// - profile 中声明了基础配置中已有的依赖：声明相同时为 DepDuplicate，不同时为 DepConflict（应用 profile 时 profile 中的声明生效）
+//
+// 依赖按名称比较，只改变写法（如引号原子）的声明视为相同
+// 输出:
+//   - []DuplicateDep: 发现的问题，先列出基础配置，再按出现顺序列出各 profile
+//
+// 示例:
+//
+//	for _, dup := range config.FindDuplicateDeps() {
+//	  fmt.Println(dup)
+//	}
+func (c *RebarConfig) FindDuplicateDeps() []DuplicateDep {
+	type seenDep struct {
+		profile string
+		dep     Term
+	}
+
+	var dups []DuplicateDep
+	var baseSeen map[string]seenDep
+
+	check := func(profile string, deps []Term) map[string]seenDep {
+		seen := make(map[string]seenDep)
+		for _, dep := range deps {
+			name := termName(dep)
+			if name == "" {
+				continue
+			}
+			prev, ok := seen[name]
+			if !ok {
+				prev, ok = baseSeen[name]
+			}
+			if ok {
+				kind := DepDuplicate
+				if !dep.Compare(prev.dep) {
+					kind = DepConflict
+				}
+				dups = append(dups, DuplicateDep{kind, name, profile, dep, prev.profile, prev.dep})
+			}
+			if _, ok := seen[name]; !ok {
+				seen[name] = seenDep{profile, dep}
+			}
+		}
+		return seen
+	}
+
+	baseSeen = check("", listElementsOf(c.Terms, "deps"))
+	for _, profile := range profileEntries(c.Terms) {
+		check(profile.name, listElementsOf(profile.terms, "deps"))
+	}
+	return dups
+}
+
+// DedupDeps 删除多余的依赖声明
+// @pkg 按以下优先级处理 FindDuplicateDeps 报告的问题:
+// - 同一个 deps 列表中同名的依赖只保留第一个声明，与 rebar3 安装依赖时先声明者优先一致
+// - profile 中与基础配置完全相同的声明是多余的，从 profile 中删除
+// - profile 中与基础配置不同的声明是有意的覆盖（应用 profile 时 profile 优先），保持不变
+//
+// 输出:
+//   - []DuplicateDep: 被删除的声明，Dep 为被删除的项
+//
+// 示例:
+//
+//	removed := config.DedupDeps()
+//	fmt.Printf("removed %d duplicate deps\n", len(removed))
+func (c *RebarConfig) DedupDeps() []DuplicateDep {
+	var removed []DuplicateDep
+	base := make(map[string]Term)
+
+	dedup := func(profile string, opts ...DepOption) {
+		c.editList(newDepOptions(opts), "deps", false, func(deps []Term) []Term {
+			kept := make([]Term, 0, len(deps))
+			seen := make(map[string]Term)
+			for _, dep := range deps {
+				name := termName(dep)
+				if name == "" {
+					kept = append(kept, dep)
+					continue
+				}
+				if prev, ok := seen[name]; ok {
+					kind := DepDuplicate
+					if !dep.Compare(prev) {
+						kind = DepConflict
+					}
+					removed = append(removed, DuplicateDep{kind, name, profile, dep, profile, prev})
+					continue
+				}
+				if prev, ok := base[name]; ok && profile != "" && dep.Compare(prev) {
+					removed = append(removed, DuplicateDep{DepDuplicate, name, profile, dep, "", prev})
+					continue
+				}
+				seen[name] = dep
+				kept = append(kept, dep)
+			}
+			if profile == "" {
+				base = seen
+			}
+			return kept
+		})
+	}
+
+	dedup("")
+	for _, profile := range profileEntries(c.Terms) {
+		dedup(profile.name, InProfile(profile.name))
+	}
+	return removed
+}
+
+// listElementsOf 返回属性列表中 {key, [...]} 的列表元素
+func listElementsOf(terms []Term, key string) []Term {
+	elements, _ := listValue(terms, key)
+	return elements
+}
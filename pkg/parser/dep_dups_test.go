@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+const duplicateDepsConfig = `{deps, [{jsx, "3.1.0"}, {cowboy, "2.9.0"}, {'jsx', "3.1.0"}, {cowboy, "2.10.0"}]}.
+{profiles, [
+    {test, [{deps, [{jsx, "3.1.0"}, {meck, "0.9.2"}, meck]}]},
+    {prod, [{deps, [{cowboy, "2.11.0"}]}]}
+]}.
+`
+
+// TestFindDuplicateDeps tests detection within a list and between base and profiles
+func TestFindDuplicateDeps(t *testing.T) {
+	config, err := Parse(duplicateDepsConfig)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	expected := []string{
+		`duplicate: {'jsx', "3.1.0"} (base) duplicates {jsx, "3.1.0"} (base)`,
+		`conflict: {cowboy, "2.10.0"} (base) conflicts with {cowboy, "2.9.0"} (base)`,
+		`duplicate: {jsx, "3.1.0"} (profile test) duplicates {jsx, "3.1.0"} (base)`,
+		`conflict: meck (profile test) conflicts with {meck, "0.9.2"} (profile test)`,
+		`conflict: {cowboy, "2.11.0"} (profile prod) conflicts with {cowboy, "2.9.0"} (base)`,
+	}
+	var got []string
+	for _, dup := range config.FindDuplicateDeps() {
+		got = append(got, dup.String())
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected:\n%s\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+// TestDedupDeps tests the documented precedence when removing duplicates
+func TestDedupDeps(t *testing.T) {
+	config, err := Parse(duplicateDepsConfig)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	removed := config.DedupDeps()
+	if len(removed) != 4 {
+		t.Errorf("Expected 4 removed declarations, got %v", removed)
+	}
+
+	expected, _ := Parse(`{deps, [{jsx, "3.1.0"}, {cowboy, "2.9.0"}]}.
+{profiles, [
+    {test, [{deps, [{meck, "0.9.2"}]}]},
+    {prod, [{deps, [{cowboy, "2.11.0"}]}]}
+]}.
+`)
+	if !compareConfigs(config, expected) {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected.Format(4), config.Format(4))
+	}
+
+	var remaining []string
+	for _, dup := range config.FindDuplicateDeps() {
+		remaining = append(remaining, dup.String())
+	}
+	if len(remaining) != 1 || !strings.Contains(remaining[0], "profile prod") {
+		t.Errorf("Expected only the intentional prod override to remain, got %v", remaining)
+	}
+	if config.DedupDeps() != nil {
+		t.Error("Expected nothing left to remove")
+	}
+}
@@ -0,0 +1,271 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+)
+
+// DepOption 是依赖和插件的编辑选项
+// @pkg 默认编辑顶级的 deps 或插件列表，使用 InProfile 改为编辑某个 profile 中的列表
+type DepOption func(*depOptions)
+
+// depOptions 保存依赖编辑选项的值
+type depOptions struct {
+	profile string
+	project bool
+}
+
+// InProfile 让编辑作用于指定 profile 中的列表，如 {profiles, [{Name, [{deps, [...]}]}]}
+// 输入:
+//   - name: profile 名称，如 "test"
+//
+// 示例:
+//
+//	config.AddDep(parser.KV("meck", parser.NewString("0.9.2")), parser.InProfile("test"))
+func InProfile(name string) DepOption {
+	return func(o *depOptions) {
+		o.profile = name
+	}
+}
+
+// AddDep 添加依赖
+// @pkg 同名依赖已存在时在原位置替换，否则追加到列表末尾；deps（以及 profile）不存在时会被创建。
+// dep 可以是依赖声明的任意形式，如 jsx、{cowboy, "2.9.0"}、{lager, {git, "...", {tag, "3.9.2"}}}
+// 输入:
+//   - dep: 依赖声明
+//   - opts: 编辑选项，如 InProfile("test")
+//
+// 输出:
+//   - error: dep 不是原子或首元素为原子的元组
+//
+// 示例:
+//
+//	err := config.AddDep(parser.MustParseTerm(`{jsx, "3.1.0"}`))
+func (c *RebarConfig) AddDep(dep Term, opts ...DepOption) error {
+	name := termName(dep)
+	if name == "" {
+		return fmt.Errorf("dependency %s has no name", dep)
+	}
+	c.editList(newDepOptions(opts), "deps", true, func(deps []Term) []Term {
+		for i, existing := range deps {
+			if termName(existing) == name {
+				deps[i] = dep
+				return deps
+			}
+		}
+		return append(deps, dep)
+	})
+	return nil
+}
+
+// RemoveDep 删除依赖
+// 输入:
+//   - name: 依赖的应用名
+//   - opts: 编辑选项，如 InProfile("test")
+//
+// 输出:
+//   - bool: 是否删除了依赖；依赖不存在时配置不变
+func (c *RebarConfig) RemoveDep(name string, opts ...DepOption) bool {
+	removed := false
+	c.editList(newDepOptions(opts), "deps", false, func(deps []Term) []Term {
+		kept := deps[:0]
+		for _, dep := range deps {
+			if termName(dep) == name {
+				removed = true
+				continue
+			}
+			kept = append(kept, dep)
+		}
+		return kept
+	})
+	return removed
+}
+
+// UpdateDepVersion 修改依赖的版本
+// @pkg 按依赖的形式修改对应的位置，其余部分保持不变:
+// - hex 依赖：jsx 变为 {jsx, "版本"}，{jsx, "旧版本"} 和 {my_jsx, "旧版本", {pkg, jsx}} 替换版本，{my_jsx, {pkg, jsx}} 补上版本
+// - git、git_subdir 和 hg 依赖：替换 {tag, "..."} 中的标签；固定在分支或提交上的依赖无法按版本修改，返回错误
+// - rebar2 形式 {name, "版本正则", {git, ...}}：替换来源中的标签
+// 输入:
+//   - name: 依赖的应用名
+//   - version: 新版本，如 "2.10.0"
+//   - opts: 编辑选项，如 InProfile("test")
+//
+// 输出:
+//   - error: 依赖不存在，或依赖的形式不支持按版本修改
+//
+// 示例:
+//
+//	if err := config.UpdateDepVersion("cowboy", "2.10.0"); err != nil {
+//	  log.Fatal(err)
+//	}
+func (c *RebarConfig) UpdateDepVersion(name, version string, opts ...DepOption) error {
+	var err error
+	found := false
+	c.editList(newDepOptions(opts), "deps", false, func(deps []Term) []Term {
+		for i, dep := range deps {
+			if termName(dep) != name {
+				continue
+			}
+			found = true
+			var updated Term
+			if updated, err = withDepVersion(dep, version); err == nil {
+				deps[i] = updated
+			}
+			break
+		}
+		return deps
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("dependency %s not found", name)
+	}
+	return nil
+}
+
+// newDepOptions 根据选项列表构造选项值
+func newDepOptions(opts []DepOption) depOptions {
+	var o depOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// editList 用 edit 修改选项指定位置的 {key, [...]} 列表
+// @pkg create 为 false 时列表不存在则不做修改并返回 false；edit 接收的是列表的副本
+func (c *RebarConfig) editList(o depOptions, key string, create bool, edit func([]Term) []Term) bool {
+	elements, ok := c.listAt(o, key)
+	if !ok && !create {
+		return false
+	}
+	if o.profile == "" {
+		c.Terms = setListValue(c.Terms, key, edit(elements))
+		return true
+	}
+
+	profiles, _ := listValue(c.Terms, "profiles")
+	profile, _ := listValue(profiles, o.profile)
+	profile = setListValue(profile, key, edit(elements))
+	profiles = setListValue(profiles, o.profile, profile)
+	c.Terms = setListValue(c.Terms, "profiles", profiles)
+	return true
+}
+
+// listAt 返回选项指定位置的 {key, [...]} 列表元素副本，以及该列表是否存在
+func (c *RebarConfig) listAt(o depOptions, key string) ([]Term, bool) {
+	if o.profile == "" {
+		elements, i := listValue(c.Terms, key)
+		return elements, i >= 0
+	}
+	profiles, pi := listValue(c.Terms, "profiles")
+	profile, i := listValue(profiles, o.profile)
+	elements, ei := listValue(profile, key)
+	return elements, pi >= 0 && i >= 0 && ei >= 0
+}
+
+// listValue 返回属性列表中 {key, [...]} 的列表元素副本和该项的下标
+// @pkg 不存在时下标为 -1；值不是列表时元素为空，下标仍指向该项
+func listValue(terms []Term, key string) ([]Term, int) {
+	for i, term := range terms {
+		tuple, ok := term.(Tuple)
+		if !ok || len(tuple.Elements) != 2 || !hasKey(tuple, key) {
+			continue
+		}
+		if list, ok := tuple.Elements[1].(List); ok {
+			return append([]Term{}, list.Elements...), i
+		}
+		return nil, i
+	}
+	return nil, -1
+}
+
+// setListValue 返回将 {key, [...]} 设置为 elements 后的属性列表副本，不存在时追加到末尾
+func setListValue(terms []Term, key string, elements []Term) []Term {
+	return setValue(terms, key, NewList(elements...))
+}
+
+// setValue 返回将 {key, value} 设置为 value 后的属性列表副本，不存在时追加到末尾
+func setValue(terms []Term, key string, value Term) []Term {
+	_, i := listValue(terms, key)
+	result := append([]Term{}, terms...)
+	if i < 0 {
+		return append(result, KV(key, value))
+	}
+	result[i] = Tuple{Elements: []Term{terms[i].(Tuple).Elements[0], value}}
+	return result
+}
+
+// withDepVersion 返回版本修改为 version 的依赖声明
+func withDepVersion(dep Term, version string) (Term, error) {
+	name := termName(dep)
+	switch d := dep.(type) {
+	case Atom:
+		return Tuple{Elements: []Term{d, NewString(version)}}, nil
+	case Tuple:
+		if len(d.Elements) < 2 {
+			break
+		}
+		elements := append([]Term{}, d.Elements...)
+		switch v := elements[1].(type) {
+		case String, Binary:
+			if len(elements) >= 3 {
+				if source, ok := elements[2].(Tuple); ok && isSourceTuple(source) {
+					tagged, err := withSourceTag(name, source, version)
+					elements[2] = tagged
+					return Tuple{Elements: elements}, err
+				}
+			}
+			if _, ok := v.(Binary); ok {
+				elements[1] = NewBinary(version)
+			} else {
+				elements[1] = NewString(version)
+			}
+			return Tuple{Elements: elements}, nil
+		case Tuple:
+			if termName(v) == "pkg" && len(v.Elements) >= 3 {
+				source := append([]Term{}, v.Elements...)
+				if _, ok := source[2].(Binary); ok {
+					source[2] = NewBinary(version)
+				} else {
+					source[2] = NewString(version)
+				}
+				elements[1] = Tuple{Elements: source}
+				return Tuple{Elements: elements}, nil
+			}
+			if termName(v) == "pkg" {
+				return Tuple{Elements: append([]Term{elements[0], NewString(version)}, elements[1:]...)}, nil
+			}
+			if isSourceTuple(v) {
+				tagged, err := withSourceTag(name, v, version)
+				elements[1] = tagged
+				return Tuple{Elements: elements}, err
+			}
+		}
+	}
+	return dep, fmt.Errorf("dependency %s has an unsupported form: %s", name, dep)
+}
+
+// isSourceTuple 检查元组是否是 git、git_subdir 或 hg 来源
+func isSourceTuple(source Tuple) bool {
+	switch termName(source) {
+	case "git", "git_subdir", "hg":
+		return true
+	}
+	return false
+}
+
+// withSourceTag 返回 {tag, "..."} 替换为 version 的来源元组
+func withSourceTag(name string, source Tuple, version string) (Term, error) {
+	if len(source.Elements) >= 3 {
+		if ref, ok := source.Elements[2].(Tuple); ok && len(ref.Elements) == 2 && termName(ref) == "tag" {
+			elements := append([]Term{}, source.Elements...)
+			elements[2] = Tuple{Elements: []Term{ref.Elements[0], NewString(version)}}
+			return Tuple{Elements: elements}, nil
+		}
+	}
+	return source, fmt.Errorf("dependency %s is not pinned to a tag", name)
+}
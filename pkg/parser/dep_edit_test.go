@@ -0,0 +1,105 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestUpdateDepVersion tests version updates across hex and source dep shapes
+func TestUpdateDepVersion(t *testing.T) {
+	config, err := Parse(`{deps, [
+    jsx,
+    {cowboy, "2.9.0"},
+    {my_jsx, "3.0.0", {pkg, jsx}},
+    {alias, {pkg, jsx}},
+    {pinned, {pkg, jsx, <<"3.0.0">>}},
+    {bin, <<"1.0.0">>},
+    {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.1"}}},
+    {sub, {git_subdir, "https://example.com/mono.git", {tag, "v1"}, "apps/sub"}},
+    {old, ".*", {git, "https://example.com/old.git", {tag, "0.1"}}},
+    {meck, {git, "https://github.com/eproxus/meck.git", {branch, "master"}}},
+    {head, {git, "https://example.com/head.git"}}
+]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	for _, name := range []string{"jsx", "cowboy", "my_jsx", "alias", "pinned", "bin", "lager", "sub", "old"} {
+		if err := config.UpdateDepVersion(name, "9.9.9"); err != nil {
+			t.Errorf("Failed to update %s: %v", name, err)
+		}
+	}
+	for _, name := range []string{"meck", "head", "missing"} {
+		if err := config.UpdateDepVersion(name, "9.9.9"); err == nil {
+			t.Errorf("Expected error updating %s", name)
+		}
+	}
+
+	expected := MustParseTerm(`[
+    {jsx, "9.9.9"},
+    {cowboy, "9.9.9"},
+    {my_jsx, "9.9.9", {pkg, jsx}},
+    {alias, "9.9.9", {pkg, jsx}},
+    {pinned, {pkg, jsx, <<"9.9.9">>}},
+    {bin, <<"9.9.9">>},
+    {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "9.9.9"}}},
+    {sub, {git_subdir, "https://example.com/mono.git", {tag, "9.9.9"}, "apps/sub"}},
+    {old, ".*", {git, "https://example.com/old.git", {tag, "9.9.9"}}},
+    {meck, {git, "https://github.com/eproxus/meck.git", {branch, "master"}}},
+    {head, {git, "https://example.com/head.git"}}
+]`)
+	deps, _ := config.GetDeps()
+	if !deps[0].Compare(expected) {
+		t.Errorf("Expected %s, got %s", expected, deps[0])
+	}
+}
+
+// TestAddRemoveDep tests adding and removing deps at the top level and in profiles
+func TestAddRemoveDep(t *testing.T) {
+	config, err := Parse(`{erl_opts, [debug_info]}.
+{deps, [{cowboy, "2.9.0"}]}.
+{profiles, [{test, [{erl_opts, [nowarn_export_all]}]}]}.
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	original := config.Terms[1]
+
+	if err := config.AddDep(MustParseTerm(`{jsx, "3.1.0"}`)); err != nil {
+		t.Fatalf("Failed to add dep: %v", err)
+	}
+	if err := config.AddDep(MustParseTerm(`{cowboy, "2.10.0"}`)); err != nil {
+		t.Fatalf("Failed to replace dep: %v", err)
+	}
+	if err := config.AddDep(MustParseTerm(`{meck, "0.9.2"}`), InProfile("test")); err != nil {
+		t.Fatalf("Failed to add profile dep: %v", err)
+	}
+	if err := config.AddDep(NewAtom("proper"), InProfile("prop")); err != nil {
+		t.Fatalf("Failed to add dep to new profile: %v", err)
+	}
+	if err := config.AddDep(NewString("nameless")); err == nil {
+		t.Error("Expected error for dep without a name")
+	}
+	if !config.RemoveDep("proper", InProfile("prop")) {
+		t.Error("Expected RemoveDep to remove proper")
+	}
+	if config.RemoveDep("proper", InProfile("missing")) || config.RemoveDep("missing") {
+		t.Error("Expected RemoveDep to report missing deps")
+	}
+
+	expected := `{erl_opts, [debug_info]}.
+{deps, [{cowboy, "2.10.0"}, {jsx, "3.1.0"}]}.
+{profiles, [{test, [{erl_opts, [nowarn_export_all]}, {deps, [{meck, "0.9.2"}]}]}, {prop, [{deps, []}]}]}.
+`
+	var b strings.Builder
+	for _, term := range config.Terms {
+		b.WriteString(term.String() + ".\n")
+	}
+	if got := b.String(); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+
+	if !original.Compare(MustParseTerm(`{deps, [{cowboy, "2.9.0"}]}`)) {
+		t.Errorf("Expected the original term to be left untouched, got %s", original)
+	}
+}
@@ -0,0 +1,215 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"strings"
+)
+
+// DepTransform 是依赖声明的转换，用于 TransformDeps
+// @pkg 返回转换后的声明；不适用的声明原样返回
+type DepTransform func(dep Term) Term
+
+// TransformDeps 对配置中的依赖和插件声明依次应用转换
+// @pkg 作用于基础配置和各 profile 中的 deps、plugins 和 project_plugins 列表，
+// 适合把一批项目的配置统一为相同的写法
+// 输入:
+//   - transforms: 依次应用的转换，如 ModernizeDep、HTTPSGitDep
+//
+// 输出:
+//   - int: 被修改的声明数量
+//
+// 示例:
+//
+//	changed := config.TransformDeps(parser.ModernizeDep, parser.ShortHexDep, parser.HTTPSGitDep)
+//	fmt.Printf("normalized %d deps\n", changed)
+func (c *RebarConfig) TransformDeps(transforms ...DepTransform) int {
+	changed := 0
+	transform := func(opts ...DepOption) {
+		for _, key := range []string{"deps", "plugins", "project_plugins"} {
+			c.editList(newDepOptions(opts), key, false, func(deps []Term) []Term {
+				for i, dep := range deps {
+					result := dep
+					for _, t := range transforms {
+						result = t(result)
+					}
+					if !sameTerm(result, dep) {
+						deps[i] = result
+						changed++
+					}
+				}
+				return deps
+			})
+		}
+	}
+
+	transform()
+	for _, profile := range profileEntries(c.Terms) {
+		transform(InProfile(profile.name))
+	}
+	return changed
+}
+
+// ModernizeDep 把 rebar2 形式的版本库依赖转换为 rebar3 形式
+// @pkg {Name, "版本正则", {git, Url, Ref}} 变为 {Name, {git, Url, Ref}}，rebar3 忽略版本正则；
+// git_subdir 和 hg 来源同样处理，带选项列表的四元组（如 [raw]）保持不变
+//
+// 数据样例:
+// {lager, ".*", {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}} 变为
+// {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}}
+func ModernizeDep(dep Term) Term {
+	tuple, ok := dep.(Tuple)
+	if !ok || len(tuple.Elements) != 3 || termName(tuple) == "" {
+		return dep
+	}
+	if _, ok := versionText(tuple.Elements[1]); !ok {
+		return dep
+	}
+	source, ok := tuple.Elements[2].(Tuple)
+	if !ok || !isSourceTuple(source) {
+		return dep
+	}
+	return Tuple{Elements: []Term{tuple.Elements[0], source}}
+}
+
+// ExplicitHexDep 把 hex 依赖转换为显式指定包名的形式
+// @pkg jsx 变为 {jsx, {pkg, jsx}}，{jsx, "3.1.0"} 变为 {jsx, "3.1.0", {pkg, jsx}}，
+// 即 rebar3 接受的 {Name, Vsn, {pkg, PkgName}} 形式；已显式指定包名和非 hex 依赖保持不变
+func ExplicitHexDep(dep Term) Term {
+	switch d := dep.(type) {
+	case Atom:
+		return Tuple{Elements: []Term{d, Tuple{Elements: []Term{NewAtom("pkg"), d}}}}
+	case Tuple:
+		if len(d.Elements) != 2 {
+			break
+		}
+		name, ok := d.Elements[0].(Atom)
+		if !ok {
+			break
+		}
+		if _, ok := versionText(d.Elements[1]); ok {
+			return Tuple{Elements: []Term{name, d.Elements[1], Tuple{Elements: []Term{NewAtom("pkg"), name}}}}
+		}
+	}
+	return dep
+}
+
+// ShortHexDep 把包名与应用名相同的 hex 依赖转换为简短形式，是 ExplicitHexDep 的逆转换
+// @pkg {jsx, {pkg, jsx}} 变为 jsx，{jsx, "3.1.0", {pkg, jsx}} 变为 {jsx, "3.1.0"}；
+// 包名与应用名不同时无法省略包名，保持不变
+func ShortHexDep(dep Term) Term {
+	tuple, ok := dep.(Tuple)
+	if !ok || len(tuple.Elements) < 2 || len(tuple.Elements) > 3 {
+		return dep
+	}
+	name, ok := tuple.Elements[0].(Atom)
+	if !ok {
+		return dep
+	}
+	pkg, ok := tuple.Elements[len(tuple.Elements)-1].(Tuple)
+	if !ok || len(pkg.Elements) != 2 || termName(pkg) != "pkg" {
+		return dep
+	}
+	if pkgName, _ := lockText(pkg.Elements[1]); pkgName != name.Value {
+		return dep
+	}
+	if len(tuple.Elements) == 2 {
+		return name
+	}
+	if _, ok := versionText(tuple.Elements[1]); !ok {
+		return dep
+	}
+	return Tuple{Elements: []Term{name, tuple.Elements[1]}}
+}
+
+// HTTPSGitDep 把 git 和 git_subdir 来源的地址转换为 https 地址，见 HTTPSGitURL
+// @pkg 同时适用于 rebar3 形式和 rebar2 形式的依赖，地址保持原来的字符串或二进制类型
+func HTTPSGitDep(dep Term) Term {
+	tuple, ok := dep.(Tuple)
+	if !ok || termName(tuple) == "" {
+		return dep
+	}
+	for i, elem := range tuple.Elements[1:] {
+		source, ok := elem.(Tuple)
+		if !ok || len(source.Elements) < 2 {
+			continue
+		}
+		if kind := termName(source); kind != "git" && kind != "git_subdir" {
+			continue
+		}
+		var url Term
+		switch u := source.Elements[1].(type) {
+		case String:
+			url = NewString(HTTPSGitURL(u.Value))
+		case Binary:
+			url = NewBinary(HTTPSGitURL(u.Value))
+		default:
+			continue
+		}
+		if sameTerm(url, source.Elements[1]) {
+			continue
+		}
+		sourceElements := append([]Term{}, source.Elements...)
+		sourceElements[1] = url
+		elements := append([]Term{}, tuple.Elements...)
+		elements[i+1] = Tuple{Elements: sourceElements}
+		return Tuple{Elements: elements}
+	}
+	return dep
+}
+
+// HTTPSGitURL 把 git 仓库地址转换为 https 地址
+// @pkg 支持以下形式，其他地址（如本地路径）原样返回:
+// - git@github.com:user/repo.git 变为 https://github.com/user/repo.git
+// - ssh://git@github.com/user/repo.git、git+ssh://... 去掉用户名和端口
+// - git://github.com/user/repo.git 和 http://github.com/user/repo.git 替换协议
+//
+// 输入:
+//   - url: 仓库地址
+//
+// 输出:
+//   - string: https 地址
+func HTTPSGitURL(url string) string {
+	for _, scheme := range []string{"ssh://", "git+ssh://", "git://", "http://"} {
+		if !strings.HasPrefix(url, scheme) {
+			continue
+		}
+		rest := strings.TrimPrefix(url, scheme)
+		host, path := rest, ""
+		if i := strings.IndexByte(rest, '/'); i >= 0 {
+			host, path = rest[:i], rest[i:]
+		}
+		if i := strings.LastIndexByte(host, '@'); i >= 0 {
+			host = host[i+1:]
+		}
+		if scheme != "http://" {
+			if i := strings.IndexByte(host, ':'); i >= 0 {
+				host = host[:i]
+			}
+		}
+		return "https://" + host + path
+	}
+
+	// scp 形式：[user@]host:path，host 中不含 '/'，单个字母视为 Windows 盘符
+	if !strings.Contains(url, "://") {
+		if i := strings.IndexByte(url, ':'); i > 1 && !strings.Contains(url[:i], "/") {
+			host := url[:i]
+			if j := strings.LastIndexByte(host, '@'); j >= 0 {
+				host = host[j+1:]
+			}
+			return "https://" + host + "/" + strings.TrimPrefix(url[i+1:], "/")
+		}
+	}
+	return url
+}
+
+// versionText 返回字符串或二进制形式的版本
+func versionText(term Term) (string, bool) {
+	switch t := term.(type) {
+	case String:
+		return t.Value, true
+	case Binary:
+		return t.Value, true
+	}
+	return "", false
+}
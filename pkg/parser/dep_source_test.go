@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"testing"
+)
+
+// TestDepTransforms tests each dependency transform on single declarations
+func TestDepTransforms(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform DepTransform
+		input     string
+		expected  string
+	}{
+		{"modernize git", ModernizeDep, `{lager, ".*", {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}}`, `{lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}}`},
+		{"modernize hg", ModernizeDep, `{dep, "", {hg, "https://example.com/dep", {tag, "1.0"}}}`, `{dep, {hg, "https://example.com/dep", {tag, "1.0"}}}`},
+		{"modernize keeps raw", ModernizeDep, `{dep, ".*", {git, "url", {tag, "1.0"}}, [raw]}`, `{dep, ".*", {git, "url", {tag, "1.0"}}, [raw]}`},
+		{"modernize keeps pkg", ModernizeDep, `{my_jsx, "3.1.0", {pkg, jsx}}`, `{my_jsx, "3.1.0", {pkg, jsx}}`},
+		{"explicit atom", ExplicitHexDep, `jsx`, `{jsx, {pkg, jsx}}`},
+		{"explicit version", ExplicitHexDep, `{jsx, "3.1.0"}`, `{jsx, "3.1.0", {pkg, jsx}}`},
+		{"explicit keeps git", ExplicitHexDep, `{jsx, {git, "url", {tag, "1.0"}}}`, `{jsx, {git, "url", {tag, "1.0"}}}`},
+		{"short atom", ShortHexDep, `{jsx, {pkg, jsx}}`, `jsx`},
+		{"short version", ShortHexDep, `{jsx, "3.1.0", {pkg, jsx}}`, `{jsx, "3.1.0"}`},
+		{"short keeps renamed", ShortHexDep, `{my_jsx, "3.1.0", {pkg, jsx}}`, `{my_jsx, "3.1.0", {pkg, jsx}}`},
+		{"https scp", HTTPSGitDep, `{dep, {git, "git@github.com:user/dep.git", {branch, "main"}}}`, `{dep, {git, "https://github.com/user/dep.git", {branch, "main"}}}`},
+		{"https binary", HTTPSGitDep, `{dep, {git_subdir, <<"git://github.com/user/dep.git">>, {tag, "1.0"}, "apps/dep"}}`, `{dep, {git_subdir, <<"https://github.com/user/dep.git">>, {tag, "1.0"}, "apps/dep"}}`},
+		{"https legacy", HTTPSGitDep, `{dep, ".*", {git, "http://example.com/dep.git", "master"}}`, `{dep, ".*", {git, "https://example.com/dep.git", "master"}}`},
+		{"https keeps hg", HTTPSGitDep, `{dep, {hg, "http://example.com/dep"}}`, `{dep, {hg, "http://example.com/dep"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.transform(MustParseTerm(tt.input))
+			if got.String() != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestHTTPSGitURL tests the supported URL forms
+func TestHTTPSGitURL(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"git@github.com:user/repo.git", "https://github.com/user/repo.git"},
+		{"github.com:user/repo.git", "https://github.com/user/repo.git"},
+		{"ssh://git@github.com:22/user/repo.git", "https://github.com/user/repo.git"},
+		{"git+ssh://git@gitlab.com/group/repo", "https://gitlab.com/group/repo"},
+		{"git://github.com/user/repo.git", "https://github.com/user/repo.git"},
+		{"http://example.com:8080/repo.git", "https://example.com:8080/repo.git"},
+		{"https://github.com/user/repo.git", "https://github.com/user/repo.git"},
+		{"/srv/git/repo.git", "/srv/git/repo.git"},
+	}
+
+	for _, tt := range tests {
+		if got := HTTPSGitURL(tt.input); got != tt.expected {
+			t.Errorf("HTTPSGitURL(%q): expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+// TestTransformDeps tests applying transforms to all dependency lists
+func TestTransformDeps(t *testing.T) {
+	config, err := Parse(`{deps, [
+    {lager, ".*", {git, "git://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}},
+    {jsx, "3.1.0", {pkg, jsx}},
+    cowboy
+]}.
+{plugins, [{rebar3_hex, {git, "git@github.com:erlef/rebar3_hex.git", {branch, "main"}}}]}.
+{profiles, [{test, [{deps, [{meck, {pkg, meck}}]}]}]}.
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	changed := config.TransformDeps(ModernizeDep, ShortHexDep, HTTPSGitDep)
+	if changed != 4 {
+		t.Errorf("Expected 4 changed declarations, got %d", changed)
+	}
+
+	expected, _ := Parse(`{deps, [
+    {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}},
+    {jsx, "3.1.0"},
+    cowboy
+]}.
+{plugins, [{rebar3_hex, {git, "https://github.com/erlef/rebar3_hex.git", {branch, "main"}}}]}.
+{profiles, [{test, [{deps, [meck]}]}]}.
+`)
+	if !compareConfigs(config, expected) {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected.Format(4), config.Format(4))
+	}
+
+	if changed := config.TransformDeps(ModernizeDep, ShortHexDep, HTTPSGitDep); changed != 0 {
+		t.Errorf("Expected transforms to be idempotent, got %d changes", changed)
+	}
+}
@@ -0,0 +1,172 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+// DepSourceKind 表示依赖来源的类别
+// @pkg 除下列常量外，未知的源元组（如插件提供的 {svn, ...}）以其首个原子作为类别
+type DepSourceKind string
+
+const (
+	// DepHex 是 hex 包，包括 jsx、{jsx, "3.1.0"} 和 {jsx, {pkg, Name}} 等写法
+	DepHex DepSourceKind = "hex"
+	// DepGit 是 git 版本库 {git, Url, Ref}
+	DepGit DepSourceKind = "git"
+	// DepGitSubdir 是 git 版本库中的子目录 {git_subdir, Url, Ref, Dir}
+	DepGitSubdir DepSourceKind = "git_subdir"
+	// DepHg 是 mercurial 版本库 {hg, Url, Ref}
+	DepHg DepSourceKind = "hg"
+	// DepPath 是本地目录 {path, Dir}
+	DepPath DepSourceKind = "path"
+)
+
+// Dependency 是依赖声明的结构化表示
+// @pkg 数据样例:
+// - {cowboy, "2.9.0"}: Source 为 hex，Version 为 "2.9.0"
+// - {jsx, {pkg, jsx_fork}}: Source 为 hex，Package 为 "jsx_fork"
+// - {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}}: Source 为 git，URL 为地址，RefType 为 "tag"，RefValue 为 "3.9.2"
+type Dependency struct {
+	// Name 是依赖的应用名称
+	Name string
+	// Source 是来源类别
+	Source DepSourceKind
+	// Package 是 hex 包名，未使用 {pkg, ...} 别名时与 Name 相同；非 hex 依赖为空
+	Package string
+	// Version 是 hex 包的版本要求，如 "2.9.0"、"~> 2.9"；未指定或非 hex 依赖时为空
+	Version string
+	// URL 是版本库地址，path 依赖为目录
+	URL string
+	// RefType 是版本库引用的类别，如 "tag"、"branch"、"ref"；引用直接写为字符串或未指定时为空
+	RefType string
+	// RefValue 是版本库引用的值，如 "3.9.2"
+	RefValue string
+	// Subdir 是 git_subdir 依赖在版本库中的子目录
+	Subdir string
+	// Profile 是依赖所在的 profile，基础配置中的 deps 为空
+	Profile string
+	// Term 是原始的依赖声明
+	Term Term
+	// SourceTerm 是原始的源元组，如 {git, ...}、{pkg, ...}；只写版本或名称的 hex 依赖为 nil
+	SourceTerm Term
+}
+
+// AsDependency 将依赖声明转换为 Dependency
+// @pkg 声明可以是原子 Name，或 {Name, ...} 形式的元组；元组中第一个字符串（或二进制）为 hex 的版本要求，
+// 第一个元组为来源。hex 包的别名写法 {Name, {pkg, RealName}}、{Name, Vsn, {pkg, RealName}} 和
+// {Name, {pkg, RealName, Vsn}} 中，Package 为实际获取的包 RealName。Profile 为空
+// 输入:
+//   - term: 依赖声明，如 {cowboy, "2.9.0"}
+//
+// 输出:
+//   - Dependency: 结构化的依赖
+//   - bool: term 是否是依赖声明
+//
+// 示例:
+//
+//	dep, ok := parser.AsDependency(parser.MustParseTerm(`{lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}}`))
+//	// dep.Source == parser.DepGit, dep.RefType == "tag", dep.RefValue == "3.9.2"
+func AsDependency(term Term) (Dependency, bool) {
+	name := termName(term)
+	if name == "" {
+		return Dependency{}, false
+	}
+	dep := Dependency{Name: name, Source: DepHex, Package: name, Term: term}
+	tuple, ok := term.(Tuple)
+	if !ok {
+		return dep, true
+	}
+
+	for _, elem := range tuple.Elements[1:] {
+		if text, ok := lockText(elem); ok {
+			if _, isAtom := elem.(Atom); !isAtom {
+				dep.Version = text
+			}
+			continue
+		}
+		source, ok := elem.(Tuple)
+		if !ok {
+			continue
+		}
+		dep.SourceTerm = source
+		kind := termName(source)
+		switch kind {
+		case "pkg":
+			if len(source.Elements) >= 2 {
+				if pkg, ok := lockText(source.Elements[1]); ok {
+					dep.Package = pkg
+				}
+			}
+			if len(source.Elements) >= 3 {
+				dep.Version, _ = lockText(source.Elements[2])
+			}
+		default:
+			dep.Source, dep.Package, dep.Version = DepSourceKind(kind), "", ""
+			if len(source.Elements) >= 2 {
+				dep.URL, _ = lockText(source.Elements[1])
+			}
+			if len(source.Elements) >= 3 {
+				if ref, ok := source.Elements[2].(Tuple); ok && len(ref.Elements) == 2 {
+					dep.RefType = termName(ref)
+					dep.RefValue, _ = lockText(ref.Elements[1])
+				} else {
+					dep.RefValue, _ = lockText(source.Elements[2])
+				}
+			}
+			if kind == "git_subdir" && len(source.Elements) >= 4 {
+				dep.Subdir, _ = lockText(source.Elements[3])
+			}
+		}
+		break
+	}
+	return dep, true
+}
+
+// Family 返回来源的大类，用于统计和展示
+// @pkg 大类为 hex、git（包括 git_subdir）、hg、path 或 other（其他未知的源元组）
+// 输出:
+//   - string: 来源大类
+func (d Dependency) Family() string {
+	switch d.Source {
+	case DepHex, DepGit, DepHg, DepPath:
+		return string(d.Source)
+	case DepGitSubdir:
+		return string(DepGit)
+	}
+	return "other"
+}
+
+// GetDependencies 返回配置中声明的所有依赖
+// @pkg 先按顺序返回基础配置 deps 中的依赖，再按出现顺序返回各 profile 中的依赖（Profile 为 profile 名称）；
+// 不是依赖声明的元素会被跳过。不合并 profile，合并后的依赖列表使用 GetDeps(profile)
+// 输出:
+//   - []Dependency: 所有依赖，没有时为空切片
+//
+// 示例:
+//
+//	for _, dep := range config.GetDependencies() {
+//	  switch dep.Source {
+//	  case parser.DepHex:
+//	    fmt.Printf("%s %s (hex)\n", dep.Name, dep.Version)
+//	  case parser.DepGit:
+//	    fmt.Printf("%s %s %s %s\n", dep.Name, dep.URL, dep.RefType, dep.RefValue)
+//	  }
+//	}
+func (c *RebarConfig) GetDependencies() []Dependency {
+	deps := dependencies("", listElements(c, "deps"))
+	for _, profile := range profileEntries(c.Terms) {
+		list, _ := listValue(profile.terms, "deps")
+		deps = append(deps, dependencies(profile.name, list)...)
+	}
+	return deps
+}
+
+// dependencies 转换一组依赖声明
+func dependencies(profile string, terms []Term) []Dependency {
+	deps := []Dependency{}
+	for _, term := range terms {
+		if dep, ok := AsDependency(term); ok {
+			dep.Profile = profile
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
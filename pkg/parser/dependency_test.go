@@ -0,0 +1,134 @@
+package parser
+
+import (
+	"testing"
+)
+
+// TestAsDependency tests converting the different dependency forms
+func TestAsDependency(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Dependency
+	}{
+		{"bare atom", `jsx`, Dependency{Name: "jsx", Source: DepHex, Package: "jsx"}},
+		{"hex version", `{cowboy, "2.9.0"}`, Dependency{Name: "cowboy", Source: DepHex, Package: "cowboy", Version: "2.9.0"}},
+		{"hex binary version", `{cowboy, <<"~> 2.9">>}`, Dependency{Name: "cowboy", Source: DepHex, Package: "cowboy", Version: "~> 2.9"}},
+		{"hex alias", `{jsx, {pkg, jsx_fork}}`, Dependency{Name: "jsx", Source: DepHex, Package: "jsx_fork"}},
+		{"hex alias with version", `{jsx, "3.1.0", {pkg, jsx_fork}}`, Dependency{Name: "jsx", Source: DepHex, Package: "jsx_fork", Version: "3.1.0"}},
+		{"hex alias with version in pkg", `{jsx, {pkg, jsx_fork, <<"3.1.0">>}}`, Dependency{Name: "jsx", Source: DepHex, Package: "jsx_fork", Version: "3.1.0"}},
+		{"hex alias with quoted package", `{my_app, "1.0", {pkg, 'real-app'}}`, Dependency{Name: "my_app", Source: DepHex, Package: "real-app", Version: "1.0"}},
+		{"single element tuple", `{meck}`, Dependency{Name: "meck", Source: DepHex, Package: "meck"}},
+		{"git tag", `{lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}}`,
+			Dependency{Name: "lager", Source: DepGit, URL: "https://github.com/erlang-lager/lager.git", RefType: "tag", RefValue: "3.9.2"}},
+		{"git branch", `{meck, {git, "git://github.com/eproxus/meck.git", {branch, "master"}}}`,
+			Dependency{Name: "meck", Source: DepGit, URL: "git://github.com/eproxus/meck.git", RefType: "branch", RefValue: "master"}},
+		{"git string ref", `{meck, {git, "https://github.com/eproxus/meck.git", "abc123"}}`,
+			Dependency{Name: "meck", Source: DepGit, URL: "https://github.com/eproxus/meck.git", RefValue: "abc123"}},
+		{"legacy git", `{meck, ".*", {git, "https://github.com/eproxus/meck.git", {ref, "abc123"}}}`,
+			Dependency{Name: "meck", Source: DepGit, URL: "https://github.com/eproxus/meck.git", RefType: "ref", RefValue: "abc123"}},
+		{"git subdir", `{app, {git_subdir, "https://example.com/repo.git", {branch, "main"}, "apps/app"}}`,
+			Dependency{Name: "app", Source: DepGitSubdir, URL: "https://example.com/repo.git", RefType: "branch", RefValue: "main", Subdir: "apps/app"}},
+		{"hg", `{app, {hg, "https://example.com/repo", {tag, "1.0"}}}`,
+			Dependency{Name: "app", Source: DepHg, URL: "https://example.com/repo", RefType: "tag", RefValue: "1.0"}},
+		{"path", `{app, {path, "../app"}}`, Dependency{Name: "app", Source: DepPath, URL: "../app"}},
+		{"unknown source", `{app, {svn, "https://example.com/svn"}}`, Dependency{Name: "app", Source: "svn", URL: "https://example.com/svn"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := MustParseTerm(tt.input)
+			dep, ok := AsDependency(term)
+			if !ok {
+				t.Fatalf("Expected %s to be a dependency", tt.input)
+			}
+			if dep.Term == nil || !dep.Term.Compare(term) {
+				t.Errorf("Expected Term to be the declaration, got %v", dep.Term)
+			}
+			var source Term
+			if tuple, ok := term.(Tuple); ok {
+				if last, ok := tuple.Elements[len(tuple.Elements)-1].(Tuple); ok {
+					source = last
+				}
+			}
+			if (source == nil) != (dep.SourceTerm == nil) || (source != nil && !source.Compare(dep.SourceTerm)) {
+				t.Errorf("Expected SourceTerm %v, got %v", source, dep.SourceTerm)
+			}
+			dep.Term, dep.SourceTerm = nil, nil
+			if dep != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, dep)
+			}
+		})
+	}
+
+	for _, input := range []string{`"jsx"`, `42`, `{"jsx", "1.0"}`} {
+		if _, ok := AsDependency(MustParseTerm(input)); ok {
+			t.Errorf("Did not expect %s to be a dependency", input)
+		}
+	}
+}
+
+// TestGetDependencies tests collecting base and profile dependencies
+func TestGetDependencies(t *testing.T) {
+	config, err := Parse(`{deps, [
+    {cowboy, "2.9.0"},
+    {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}},
+    "not a dep"
+]}.
+{profiles, [
+    {test, [{deps, [meck, {cowboy, "2.10.0"}]}]},
+    {prod, [{relx, []}]}
+]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	deps := config.GetDependencies()
+	expected := []struct {
+		name, profile string
+		source        DepSourceKind
+	}{
+		{"cowboy", "", DepHex},
+		{"lager", "", DepGit},
+		{"meck", "test", DepHex},
+		{"cowboy", "test", DepHex},
+	}
+	if len(deps) != len(expected) {
+		t.Fatalf("Expected %d deps, got %+v", len(expected), deps)
+	}
+	for i, e := range expected {
+		if deps[i].Name != e.name || deps[i].Profile != e.profile || deps[i].Source != e.source {
+			t.Errorf("Dep %d: expected %s/%s/%s, got %+v", i, e.name, e.profile, e.source, deps[i])
+		}
+	}
+	if deps[3].Version != "2.10.0" {
+		t.Errorf("Expected the test profile's cowboy version, got %q", deps[3].Version)
+	}
+
+	empty, _ := Parse(`{erl_opts, []}.`)
+	if deps := empty.GetDependencies(); deps == nil || len(deps) != 0 {
+		t.Errorf("Expected an empty slice, got %v", deps)
+	}
+}
+
+// TestDependencyFamily tests grouping dependency sources
+func TestDependencyFamily(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{jsx, {pkg, jsx_fork}}`, "hex"},
+		{`{app, {git_subdir, "https://example.com/repo.git", {branch, "main"}, "apps/app"}}`, "git"},
+		{`{app, {hg, "https://example.com/repo"}}`, "hg"},
+		{`{app, {path, "../app"}}`, "path"},
+		{`{app, {svn, "https://example.com/svn"}}`, "other"},
+		{`{app, {}}`, "other"},
+	}
+
+	for _, tt := range tests {
+		dep, _ := AsDependency(MustParseTerm(tt.input))
+		if got := dep.Family(); got != tt.expected {
+			t.Errorf("Family(%s): expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
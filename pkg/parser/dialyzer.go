@@ -0,0 +1,129 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+// DialyzerConfig 表示 rebar.config 中的 dialyzer 配置
+// @pkg 对应 {dialyzer, [Option, ...]}，未配置的选项为零值，此时 rebar3 使用其默认值（注释中注明）
+// 数据样例:
+//
+//	{dialyzer, [
+//	  {warnings, [unmatched_returns, error_handling]},
+//	  {plt_apps, all_deps},
+//	  {plt_extra_apps, [ssl, inets]},
+//	  {plt_location, local},
+//	  {base_plt_apps, [erts, kernel, stdlib]}
+//	]}.
+type DialyzerConfig struct {
+	// Warnings 是额外开启的警告，如 unmatched_returns
+	Warnings []string
+	// GetWarnings 表示是否报告 PLT 中应用的警告，对应 get_warnings
+	GetWarnings bool
+	// PltApps 是加入项目 PLT 的应用范围：top_level_deps（默认）、all_deps 或 all_apps
+	PltApps string
+	// PltExtraApps 是额外加入项目 PLT 的应用
+	PltExtraApps []string
+	// PltLocation 是项目 PLT 的位置：local（默认，即 _build 目录）或目录路径
+	PltLocation string
+	// PltPrefix 是项目 PLT 文件名的前缀，默认为 rebar3
+	PltPrefix string
+	// BasePltApps 是基础 PLT 中的应用，默认为 erts、kernel 和 stdlib
+	BasePltApps []string
+	// BasePltLocation 是基础 PLT 的位置：global（默认，即 rebar3 的缓存目录）或目录路径
+	BasePltLocation string
+	// BasePltPrefix 是基础 PLT 文件名的前缀，默认为 rebar3
+	BasePltPrefix string
+	// Options 是其他未识别的选项，以选项名为键
+	Options map[string]Term
+}
+
+// GetDialyzerConfig 解析 dialyzer 配置
+// @pkg 选项按属性列表的规则读取（同名选项取第一个，裸原子视为 true）。配置中没有 dialyzer 时返回所有字段为零值的配置
+// 输出:
+//   - *DialyzerConfig: dialyzer 配置
+//   - error: 选项的类型不正确时为 *PathError，如 "dialyzer/plt_apps: expected atom, got \"all\""
+//
+// 示例:
+//
+//	dialyzer, err := config.GetDialyzerConfig()
+//	if err != nil {
+//	  log.Fatal(err)
+//	}
+//	fmt.Println(dialyzer.Warnings, dialyzer.PltExtraApps)
+func (c *RebarConfig) GetDialyzerConfig() (*DialyzerConfig, error) {
+	dialyzer := &DialyzerConfig{Options: make(map[string]Term)}
+	opts, err := c.GetListAt("dialyzer")
+	if isMissing(err) {
+		return dialyzer, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	props := Proplist{Elements: opts}
+	for _, key := range props.Keys() {
+		path := []string{"dialyzer", key}
+		switch key {
+		case "warnings":
+			dialyzer.Warnings, err = c.atomListAt(path)
+		case "get_warnings":
+			dialyzer.GetWarnings, err = c.GetBoolAt(path...)
+		case "plt_apps":
+			dialyzer.PltApps, err = c.GetAtomAt(path...)
+		case "plt_extra_apps":
+			dialyzer.PltExtraApps, err = c.atomListAt(path)
+		case "plt_location":
+			dialyzer.PltLocation, err = c.locationAt(path)
+		case "plt_prefix":
+			dialyzer.PltPrefix, err = c.GetStringAt(path...)
+		case "base_plt_apps":
+			dialyzer.BasePltApps, err = c.atomListAt(path)
+		case "base_plt_location":
+			dialyzer.BasePltLocation, err = c.locationAt(path)
+		case "base_plt_prefix":
+			dialyzer.BasePltPrefix, err = c.GetStringAt(path...)
+		default:
+			if value, ok := props.Get(key); ok {
+				dialyzer.Options[key] = value
+			}
+		}
+		if err != nil && !isMissing(err) {
+			return nil, err
+		}
+	}
+	return dialyzer, nil
+}
+
+// atomListAt 按路径获取原子列表
+func (c *RebarConfig) atomListAt(path []string) ([]string, error) {
+	elements, err := c.GetListAt(path...)
+	if err != nil {
+		return nil, err
+	}
+	atoms := make([]string, len(elements))
+	for i, elem := range elements {
+		atom, ok := elem.(Atom)
+		if !ok {
+			return nil, &PathError{Path: path, Term: List{Elements: elements}, Expected: "list of atoms"}
+		}
+		atoms[i] = atom.Value
+	}
+	return atoms, nil
+}
+
+// locationAt 按路径获取 PLT 位置，即原子（local、global）或目录字符串
+func (c *RebarConfig) locationAt(path []string) (string, error) {
+	value, err := c.GetAt(path...)
+	if err != nil {
+		return "", err
+	}
+	if text, ok := lockText(value); ok {
+		return text, nil
+	}
+	return "", &PathError{Path: path, Term: value, Expected: "atom or string"}
+}
+
+// isMissing 检查错误是否表示路径不存在
+func isMissing(err error) bool {
+	pathErr, ok := err.(*PathError)
+	return ok && pathErr.Term == nil
+}
@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestGetDialyzerConfig tests decoding the dialyzer options
+func TestGetDialyzerConfig(t *testing.T) {
+	config, err := Parse(`{dialyzer, [
+    {warnings, [unmatched_returns, error_handling]},
+    get_warnings,
+    {plt_apps, all_deps},
+    {plt_extra_apps, [ssl, inets]},
+    {plt_location, local},
+    {plt_prefix, "my_app"},
+    {base_plt_apps, [erts, kernel, stdlib, crypto]},
+    {base_plt_location, "/tmp/plts"},
+    {base_plt_prefix, "base"},
+    {exclude_mods, [my_generated]},
+    {warnings, [ignored]}
+]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	dialyzer, err := config.GetDialyzerConfig()
+	if err != nil {
+		t.Fatalf("Failed to decode dialyzer config: %v", err)
+	}
+	if strings.Join(dialyzer.Warnings, ",") != "unmatched_returns,error_handling" {
+		t.Errorf("Unexpected warnings: %v", dialyzer.Warnings)
+	}
+	if !dialyzer.GetWarnings {
+		t.Errorf("Expected get_warnings to be true")
+	}
+	if dialyzer.PltApps != "all_deps" || strings.Join(dialyzer.PltExtraApps, ",") != "ssl,inets" {
+		t.Errorf("Unexpected plt apps: %s %v", dialyzer.PltApps, dialyzer.PltExtraApps)
+	}
+	if dialyzer.PltLocation != "local" || dialyzer.PltPrefix != "my_app" {
+		t.Errorf("Unexpected plt location: %s %s", dialyzer.PltLocation, dialyzer.PltPrefix)
+	}
+	if strings.Join(dialyzer.BasePltApps, ",") != "erts,kernel,stdlib,crypto" || dialyzer.BasePltLocation != "/tmp/plts" || dialyzer.BasePltPrefix != "base" {
+		t.Errorf("Unexpected base plt: %v %s %s", dialyzer.BasePltApps, dialyzer.BasePltLocation, dialyzer.BasePltPrefix)
+	}
+	if len(dialyzer.Options) != 1 || dialyzer.Options["exclude_mods"].String() != "[my_generated]" {
+		t.Errorf("Unexpected options: %v", dialyzer.Options)
+	}
+}
+
+// TestGetDialyzerConfigDefaults tests a config without dialyzer options
+func TestGetDialyzerConfigDefaults(t *testing.T) {
+	config, err := Parse(`{erl_opts, [debug_info]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	dialyzer, err := config.GetDialyzerConfig()
+	if err != nil {
+		t.Fatalf("Failed to decode dialyzer config: %v", err)
+	}
+	if dialyzer.Warnings != nil || dialyzer.PltApps != "" || dialyzer.GetWarnings || len(dialyzer.Options) != 0 {
+		t.Errorf("Expected an empty config, got %+v", dialyzer)
+	}
+}
+
+// TestGetDialyzerConfigErrors tests the errors for options of the wrong type
+func TestGetDialyzerConfigErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{dialyzer, warnings}.`, "dialyzer: expected list, got warnings"},
+		{`{dialyzer, [{warnings, unmatched_returns}]}.`, "dialyzer/warnings: expected list, got unmatched_returns"},
+		{`{dialyzer, [{plt_extra_apps, [ssl, "inets"]}]}.`, `dialyzer/plt_extra_apps: expected list of atoms, got [ssl, "inets"]`},
+		{`{dialyzer, [{plt_apps, "all_deps"}]}.`, `dialyzer/plt_apps: expected atom, got "all_deps"`},
+		{`{dialyzer, [{plt_location, 1}]}.`, "dialyzer/plt_location: expected atom or string, got 1"},
+		{`{dialyzer, [{get_warnings, yes}]}.`, "dialyzer/get_warnings: expected boolean, got yes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			config, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			_, err = config.GetDialyzerConfig()
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// TermDiff 描述两个项第一处不同的位置
+type TermDiff struct {
+	// Path 是不同之处的路径，由以下部分组成，根项本身不同时为空:
+	// - [i]: 列表的第 i 个元素（从 0 开始）
+	// - {i}: 元组的第 i 个元素（从 0 开始）
+	// - #{key}: 映射中键为 key 的值
+	// - name: RebarConfig.DiffPath 中顶级配置项 {name, Value} 的值
+	Path string
+	// A 和 B 是该位置上的两个项，一方缺少该元素时为 nil
+	A, B Term
+}
+
+// String 返回不同之处的描述
+// @pkg 例如 `deps[2]{1}: "2.9.0" != "2.8.0"`、`deps[3]: {jsx, "3.1.0"} != <missing>`
+func (d TermDiff) String() string {
+	path := d.Path
+	if path == "" {
+		path = "<root>"
+	}
+	return fmt.Sprintf("%s: %s != %s", path, diffText(d.A), diffText(d.B))
+}
+
+// diffText 返回用于描述的项文本，缺少的项为 <missing>
+func diffText(term Term) string {
+	if term == nil {
+		return "<missing>"
+	}
+	return term.String()
+}
+
+// DiffPath 找出两个项第一处不同的位置
+// @pkg 相等的判断与 Compare 一致（忽略原子引号和字面量写法，映射无序）；
+// 元组和列表按位置比较，长度不同时报告较短一方缺少的第一个元素，映射按 a 中键的顺序比较，再报告 b 中多出的键
+// 输入:
+//   - a, b: 要比较的项
+//
+// 输出:
+//   - TermDiff: 第一处不同
+//   - bool: 是否有不同，两个项相等时为 false
+//
+// 示例:
+//
+//	if diff, ok := parser.DiffPath(expected, got); ok {
+//	  t.Errorf("terms differ at %s", diff)
+//	}
+func DiffPath(a, b Term) (TermDiff, bool) {
+	return diffPath("", a, b)
+}
+
+// DiffPath 找出两个配置第一处不同的位置
+// @pkg 逐个比较顶级配置项；两个配置中对应位置都是同名的 {name, Value} 时路径以 name 开头并比较其值，
+// 否则以 [i] 表示第 i 个顶级项
+// 输入:
+//   - other: 要比较的配置
+//
+// 输出:
+//   - TermDiff: 第一处不同，如 `deps[0]{1}: "2.9.0" != "2.10.0"`
+//   - bool: 是否有不同
+func (c *RebarConfig) DiffPath(other *RebarConfig) (TermDiff, bool) {
+	for i := 0; i < len(c.Terms) || i < len(other.Terms); i++ {
+		if i >= len(c.Terms) || i >= len(other.Terms) {
+			return missingDiff("["+strconv.Itoa(i)+"]", c.Terms, other.Terms, i), true
+		}
+		a, b := c.Terms[i], other.Terms[i]
+		if name := termName(a); name != "" && isKeyValue(a) && isKeyValue(b) && hasKey(b.(Tuple), name) {
+			if diff, ok := diffPath(name, a.(Tuple).Elements[1], b.(Tuple).Elements[1]); ok {
+				return diff, true
+			}
+			continue
+		}
+		if diff, ok := diffPath("["+strconv.Itoa(i)+"]", a, b); ok {
+			return diff, true
+		}
+	}
+	return TermDiff{}, false
+}
+
+// isKeyValue 检查项是否是 {Atom, Value} 形式的二元组
+func isKeyValue(term Term) bool {
+	tuple, ok := term.(Tuple)
+	if !ok || len(tuple.Elements) != 2 {
+		return false
+	}
+	_, ok = tuple.Elements[0].(Atom)
+	return ok
+}
+
+// diffPath 在 path 处比较两个项
+func diffPath(path string, a, b Term) (TermDiff, bool) {
+	switch x := a.(type) {
+	case Tuple:
+		if y, ok := b.(Tuple); ok {
+			return diffSeq(path, "{", "}", x.Elements, y.Elements)
+		}
+	case List:
+		if y, ok := b.(List); ok {
+			return diffSeq(path, "[", "]", x.Elements, y.Elements)
+		}
+	case Map:
+		if y, ok := b.(Map); ok {
+			return diffMap(path, x, y)
+		}
+	}
+	if a.Compare(b) {
+		return TermDiff{}, false
+	}
+	return TermDiff{Path: path, A: a, B: b}, true
+}
+
+// diffSeq 逐个比较元组或列表的元素
+func diffSeq(path, open, close string, a, b []Term) (TermDiff, bool) {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		elemPath := path + open + strconv.Itoa(i) + close
+		if i >= len(a) || i >= len(b) {
+			return missingDiff(elemPath, a, b, i), true
+		}
+		if diff, ok := diffPath(elemPath, a[i], b[i]); ok {
+			return diff, true
+		}
+	}
+	return TermDiff{}, false
+}
+
+// missingDiff 返回一方缺少第 i 个元素时的不同
+func missingDiff(path string, a, b []Term, i int) TermDiff {
+	diff := TermDiff{Path: path}
+	if i < len(a) {
+		diff.A = a[i]
+	}
+	if i < len(b) {
+		diff.B = b[i]
+	}
+	return diff
+}
+
+// diffMap 按键比较两个映射
+func diffMap(path string, a, b Map) (TermDiff, bool) {
+	for _, pair := range a.Pairs {
+		keyPath := path + "#{" + pair.Key.String() + "}"
+		value, _ := a.Get(pair.Key)
+		other, ok := b.Get(pair.Key)
+		if !ok {
+			return TermDiff{Path: keyPath, A: value}, true
+		}
+		if diff, ok := diffPath(keyPath, value, other); ok {
+			return diff, true
+		}
+	}
+	for _, pair := range b.Pairs {
+		if _, ok := a.Get(pair.Key); !ok {
+			value, _ := b.Get(pair.Key)
+			return TermDiff{Path: path + "#{" + pair.Key.String() + "}", B: value}, true
+		}
+	}
+	return TermDiff{}, false
+}
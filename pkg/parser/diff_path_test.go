@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"testing"
+)
+
+// TestDiffPath tests locating the first difference between two terms
+func TestDiffPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected string
+	}{
+		{"equal", `{deps, [{cowboy, "2.9.0"}]}`, `{'deps', [{cowboy, "2.9.0"}]}`, ""},
+		{"root", `1`, `2`, `<root>: 1 != 2`},
+		{"nested", `[a, b, {cowboy, "2.9.0"}]`, `[a, b, {cowboy, "2.8.0"}]`, `[2]{1}: "2.9.0" != "2.8.0"`},
+		{"type", `{a, 1}`, `{a, 1.0}`, `{1}: 1 != 1.0`},
+		{"longer list", `[a, b, c]`, `[a, b]`, `[2]: c != <missing>`},
+		{"shorter tuple", `{a}`, `{a, b}`, `{1}: <missing> != b`},
+		{"map value", `#{port => 8080, host => "a"}`, `#{host => "a", port => 8081}`, `#{port}: 8080 != 8081`},
+		{"map key missing", `#{a => 1}`, `#{b => 1}`, `#{a}: 1 != <missing>`},
+		{"map extra key", `#{a => 1}`, `#{a => 1, b => 2}`, `#{b}: <missing> != 2`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, ok := DiffPath(MustParseTerm(tt.a), MustParseTerm(tt.b))
+			if ok != (tt.expected != "") {
+				t.Fatalf("Expected difference %v, got %v (%s)", tt.expected != "", ok, diff)
+			}
+			if ok && diff.String() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, diff.String())
+			}
+		})
+	}
+}
+
+// TestConfigDiffPath tests that config paths start with the top-level key
+func TestConfigDiffPath(t *testing.T) {
+	a, err := Parse(`{erl_opts, [debug_info]}.
+{deps, [{cowboy, "2.9.0"}, jsx]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	b, err := Parse(`{erl_opts, [debug_info]}.
+{deps, [{cowboy, "2.10.0"}, jsx]}.
+{minimum_otp_vsn, "25"}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	diff, ok := a.DiffPath(b)
+	if !ok || diff.String() != `deps[0]{1}: "2.9.0" != "2.10.0"` {
+		t.Errorf("Unexpected diff: %v, %v", diff, ok)
+	}
+
+	b.Terms[1] = a.Terms[1]
+	diff, ok = a.DiffPath(b)
+	if !ok || diff.String() != `[2]: <missing> != {minimum_otp_vsn, "25"}` {
+		t.Errorf("Unexpected diff: %v, %v", diff, ok)
+	}
+
+	if _, ok := a.DiffPath(a); ok {
+		t.Errorf("Expected no difference for equal configs")
+	}
+}
@@ -0,0 +1,107 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+// 编辑顶级项
+// @pkg 以下方法按键修改 Terms，键的含义与 GetTerm 相同：首元素为该原子的元组。
+// 未被修改的项保持原有顺序和位置，修改后可以用 FormatPreserving 写回，只有变动的项会被重新渲染
+//
+// 示例:
+//
+//	config, _ := parser.ParseFile("./rebar.config")
+//	config.SetTerm("minimum_otp_vsn", parser.NewString("25"))
+//	config.DeleteTerm("post_hooks")
+//	os.WriteFile("./rebar.config", []byte(config.FormatPreserving(4)), 0644)
+
+// SetTerm 将键的值设置为 value，即写入 {name, value}
+// @pkg 键已存在时在原位置替换第一个匹配的项，否则追加到末尾
+// 输入:
+//   - name: 键，如 "minimum_otp_vsn"
+//   - value: 值
+//
+// 示例:
+//
+//	config.SetTerm("erl_opts", parser.NewList(parser.NewAtom("debug_info")))
+func (c *RebarConfig) SetTerm(name string, value Term) {
+	c.Upsert(KV(name, value))
+}
+
+// ReplaceTerm 在原位置用 term 替换键为 name 的第一个项
+// @pkg term 的键可以与 name 不同，可用于重命名配置项
+// 输入:
+//   - name: 要替换的项的键
+//   - term: 新的项
+//
+// 输出:
+//   - bool: 是否找到并替换了该项；未找到时配置不变
+func (c *RebarConfig) ReplaceTerm(name string, term Term) bool {
+	i := c.termIndex(name)
+	if i < 0 {
+		return false
+	}
+	c.Terms[i] = term
+	return true
+}
+
+// DeleteTerm 删除键为 name 的全部项
+// @pkg 键重复出现时全部删除，避免之后 GetTerm 返回被遮蔽的旧值；其余项的顺序不变
+// 输入:
+//   - name: 要删除的项的键
+//
+// 输出:
+//   - bool: 是否删除了至少一个项
+func (c *RebarConfig) DeleteTerm(name string) bool {
+	terms := make([]Term, 0, len(c.Terms))
+	for _, term := range c.Terms {
+		if !hasKey(term, name) {
+			terms = append(terms, term)
+		}
+	}
+	if len(terms) == len(c.Terms) {
+		return false
+	}
+	c.Terms = terms
+	return true
+}
+
+// Upsert 按 term 自身的键插入或替换顶级项
+// @pkg term 是首元素为原子的元组时，替换同键的第一个项，不存在则追加到末尾；其他项直接追加
+// 输入:
+//   - term: 完整的顶级项，如 {deps, [...]}
+//
+// 输出:
+//   - bool: 是否替换了已有的项
+//
+// 示例:
+//
+//	config.Upsert(parser.MustParseTerm(`{shell, [{apps, [my_app]}]}`))
+func (c *RebarConfig) Upsert(term Term) bool {
+	if tuple, ok := term.(Tuple); ok {
+		if len(tuple.Elements) > 0 {
+			if key, ok := tuple.Elements[0].(Atom); ok && c.ReplaceTerm(key.Value, term) {
+				return true
+			}
+		}
+	}
+	c.Terms = append(c.Terms, term)
+	return false
+}
+
+// termIndex 返回键为 name 的第一个顶级项的下标，不存在时返回 -1
+func (c *RebarConfig) termIndex(name string) int {
+	for i, term := range c.Terms {
+		if hasKey(term, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasKey 检查项是否是首元素为原子 name 的元组
+func hasKey(term Term, name string) bool {
+	if tuple, ok := term.(Tuple); ok && len(tuple.Elements) > 0 {
+		atom, ok := tuple.Elements[0].(Atom)
+		return ok && atom.Value == name
+	}
+	return false
+}
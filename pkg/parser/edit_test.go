@@ -0,0 +1,69 @@
+package parser
+
+import "testing"
+
+// TestEditTerms tests SetTerm, ReplaceTerm, DeleteTerm and Upsert
+func TestEditTerms(t *testing.T) {
+	config, err := Parse(`{erl_opts, [debug_info]}.
+{deps, []}.
+{post_hooks, []}.
+{minimum_otp_vsn, "24"}.
+{post_hooks, [{compile, "make"}]}.
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	config.SetTerm("minimum_otp_vsn", NewString("25"))
+	config.SetTerm("plugins", NewList(NewAtom("rebar3_hex")))
+	if !config.ReplaceTerm("deps", KV("project_deps", NewList())) {
+		t.Error("Expected ReplaceTerm to find deps")
+	}
+	if config.ReplaceTerm("missing", KV("missing", NewList())) {
+		t.Error("Expected ReplaceTerm to report a missing key")
+	}
+	if !config.DeleteTerm("post_hooks") {
+		t.Error("Expected DeleteTerm to remove post_hooks")
+	}
+	if config.DeleteTerm("post_hooks") {
+		t.Error("Expected second DeleteTerm to report nothing removed")
+	}
+	if !config.Upsert(MustParseTerm(`{erl_opts, [debug_info, warnings_as_errors]}`)) {
+		t.Error("Expected Upsert to replace erl_opts")
+	}
+	if config.Upsert(MustParseTerm(`{shell, [{apps, [my_app]}]}`)) {
+		t.Error("Expected Upsert to append shell")
+	}
+
+	expected := `{erl_opts, [debug_info, warnings_as_errors]}.
+{project_deps, []}.
+{minimum_otp_vsn, "25"}.
+{plugins, [rebar3_hex]}.
+{shell, [{apps, [my_app]}]}.
+`
+	if got := config.FormatWith(FormatOptions{Indent: 4, BlankLines: BlankLinesNone}); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+// TestEditPreserving tests that edits keep untouched text when written back
+func TestEditPreserving(t *testing.T) {
+	config, err := Parse(`%% build settings
+{erl_opts, [debug_info]}. % keep
+
+{minimum_otp_vsn, "24"}.
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	config.SetTerm("minimum_otp_vsn", NewString("25"))
+
+	expected := `%% build settings
+{erl_opts, [debug_info]}. % keep
+
+{minimum_otp_vsn, "25"}.
+`
+	if got := config.FormatPreserving(4); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+}
@@ -0,0 +1,250 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+)
+
+// ElvisConfig 表示 elvis.config 中的 elvis 配置
+// @pkg elvis.config 的结构为:
+//
+//	[{elvis, [
+//	  {config, [
+//	    #{dirs => ["src/**"], filter => "*.erl", ruleset => erl_files,
+//	      rules => [{elvis_style, line_length, #{limit => 100}}]}
+//	  ]},
+//	  {output_format, plain}
+//	]}].
+type ElvisConfig struct {
+	// Groups 是 config 中的检查组，按出现顺序排列
+	Groups []ElvisGroup
+	// Options 是 elvis 下除 config 以外的选项，如 output_format、verbose
+	Options map[string]Term
+}
+
+// ElvisGroup 表示 elvis config 中的一个检查组
+type ElvisGroup struct {
+	// Dirs 是要检查的目录
+	Dirs []string
+	// Filter 是文件名过滤模式，如 "*.erl"
+	Filter string
+	// Ruleset 是使用的预定义规则集，如 erl_files；未配置时为空
+	Ruleset string
+	// Rules 是在规则集之外单独配置的规则
+	Rules []ElvisRule
+	// Ignore 是忽略的模块或路径，保留原始项
+	Ignore []Term
+}
+
+// ElvisRule 表示一条 elvis 规则配置
+// @pkg 对应 {Module, Rule}、{Module, Rule, Options} 或 {Module, Rule, disable}
+type ElvisRule struct {
+	// Module 是规则所在模块，如 elvis_style
+	Module string
+	// Name 是规则名称，如 line_length
+	Name string
+	// Options 是规则选项，通常是映射；未配置或规则被禁用时为 nil
+	Options Term
+	// Disabled 表示规则被禁用
+	Disabled bool
+}
+
+// ParseElvisConfig 解析 elvis.config 格式的字符串
+// 输入:
+//   - input: elvis.config 的内容
+//
+// 输出:
+//   - *ElvisConfig: elvis 配置
+//   - error: 语法或结构错误
+//
+// 示例:
+//
+//	elvis, err := parser.ParseElvisConfig(content)
+//	for _, group := range elvis.Groups {
+//	  fmt.Println(group.Ruleset, group.Dirs, len(group.Rules))
+//	}
+func ParseElvisConfig(input string) (*ElvisConfig, error) {
+	terms, err := ParseConsult(input)
+	if err != nil {
+		return nil, err
+	}
+	return elvisFromTerms(terms)
+}
+
+// ParseElvisConfigFile 解析指定路径的 elvis.config 文件
+// 输入:
+//   - path: 文件路径，如 "./elvis.config"
+//
+// 输出:
+//   - *ElvisConfig: elvis 配置
+//   - error: 读取、语法或结构错误
+func ParseElvisConfigFile(path string) (*ElvisConfig, error) {
+	terms, err := Consult(path)
+	if err != nil {
+		return nil, err
+	}
+	return elvisFromTerms(terms)
+}
+
+// Rulesets 返回所有检查组使用的规则集，按首次出现的顺序排列且不重复
+func (e *ElvisConfig) Rulesets() []string {
+	var rulesets []string
+	seen := make(map[string]bool)
+	for _, group := range e.Groups {
+		if group.Ruleset != "" && !seen[group.Ruleset] {
+			seen[group.Ruleset] = true
+			rulesets = append(rulesets, group.Ruleset)
+		}
+	}
+	return rulesets
+}
+
+// Dirs 返回所有检查组的目录，按首次出现的顺序排列且不重复
+func (e *ElvisConfig) Dirs() []string {
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, group := range e.Groups {
+		for _, dir := range group.Dirs {
+			if !seen[dir] {
+				seen[dir] = true
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	return dirs
+}
+
+// Rule 在检查组中按模块和名称查找规则
+// 输入:
+//   - module: 规则所在模块，如 "elvis_style"
+//   - name: 规则名称，如 "line_length"
+//
+// 输出:
+//   - ElvisRule: 找到的规则
+//   - bool: 是否找到
+func (g ElvisGroup) Rule(module, name string) (ElvisRule, bool) {
+	for _, rule := range g.Rules {
+		if rule.Module == module && rule.Name == name {
+			return rule, true
+		}
+	}
+	return ElvisRule{}, false
+}
+
+// elvisFromTerms 从顶级项中找到 {elvis, [...]} 并构建 ElvisConfig
+func elvisFromTerms(terms []Term) (*ElvisConfig, error) {
+	if len(terms) != 1 {
+		return nil, fmt.Errorf("invalid elvis.config: expected exactly one term, got %d", len(terms))
+	}
+	apps, ok := terms[0].(List)
+	if !ok {
+		return nil, fmt.Errorf("invalid elvis.config: expected a list, got %s", terms[0])
+	}
+
+	var options List
+	found := false
+	for _, app := range apps.Elements {
+		if tuple, ok := app.(Tuple); ok && len(tuple.Elements) == 2 && termName(tuple) == "elvis" {
+			if options, ok = tuple.Elements[1].(List); !ok {
+				return nil, fmt.Errorf("invalid elvis.config: elvis options must be a list, got %s", tuple.Elements[1])
+			}
+			found = true
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("invalid elvis.config: no elvis entry")
+	}
+
+	elvis := &ElvisConfig{Options: make(map[string]Term)}
+	for _, opt := range options.Elements {
+		pair, ok := opt.(Tuple)
+		if !ok || len(pair.Elements) != 2 {
+			return nil, fmt.Errorf("invalid elvis.config: expected {Key, Value}, got %s", opt)
+		}
+		key := termName(pair)
+		if key != "config" {
+			elvis.Options[key] = pair.Elements[1]
+			continue
+		}
+
+		groups, ok := pair.Elements[1].(List)
+		if !ok {
+			return nil, fmt.Errorf("invalid elvis.config: config must be a list, got %s", pair.Elements[1])
+		}
+		for _, g := range groups.Elements {
+			group, err := elvisGroup(g)
+			if err != nil {
+				return nil, err
+			}
+			elvis.Groups = append(elvis.Groups, group)
+		}
+	}
+	return elvis, nil
+}
+
+// elvisGroup 解析单个检查组映射
+func elvisGroup(term Term) (ElvisGroup, error) {
+	m, ok := term.(Map)
+	if !ok {
+		return ElvisGroup{}, fmt.Errorf("invalid elvis.config: group must be a map, got %s", term)
+	}
+
+	var group ElvisGroup
+	for _, pair := range m.Pairs {
+		switch termName(pair.Key) {
+		case "dirs":
+			list, ok := pair.Value.(List)
+			if !ok {
+				return ElvisGroup{}, fmt.Errorf("invalid elvis.config: dirs must be a list, got %s", pair.Value)
+			}
+			for _, dir := range list.Elements {
+				group.Dirs = append(group.Dirs, termText(dir))
+			}
+		case "filter":
+			group.Filter = termText(pair.Value)
+		case "ruleset":
+			group.Ruleset = termText(pair.Value)
+		case "ignore":
+			if list, ok := pair.Value.(List); ok {
+				group.Ignore = list.Elements
+			}
+		case "rules":
+			list, ok := pair.Value.(List)
+			if !ok {
+				return ElvisGroup{}, fmt.Errorf("invalid elvis.config: rules must be a list, got %s", pair.Value)
+			}
+			for _, r := range list.Elements {
+				rule, err := elvisRule(r)
+				if err != nil {
+					return ElvisGroup{}, err
+				}
+				group.Rules = append(group.Rules, rule)
+			}
+		}
+	}
+	return group, nil
+}
+
+// elvisRule 解析 {Module, Rule}、{Module, Rule, Options} 形式的规则
+func elvisRule(term Term) (ElvisRule, error) {
+	tuple, ok := term.(Tuple)
+	if !ok || len(tuple.Elements) < 2 || len(tuple.Elements) > 3 {
+		return ElvisRule{}, fmt.Errorf("invalid elvis.config: expected {Module, Rule[, Options]}, got %s", term)
+	}
+	module, ok1 := tuple.Elements[0].(Atom)
+	name, ok2 := tuple.Elements[1].(Atom)
+	if !ok1 || !ok2 {
+		return ElvisRule{}, fmt.Errorf("invalid elvis.config: rule module and name must be atoms, got %s", term)
+	}
+
+	rule := ElvisRule{Module: module.Value, Name: name.Value}
+	if len(tuple.Elements) == 3 {
+		if atom, ok := tuple.Elements[2].(Atom); ok && atom.Value == "disable" {
+			rule.Disabled = true
+		} else {
+			rule.Options = tuple.Elements[2]
+		}
+	}
+	return rule, nil
+}
@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const sampleElvisConfig = `[
+ {elvis, [
+   {config, [
+     #{dirs => ["src/**", "test/**"],
+       filter => "*.erl",
+       ruleset => erl_files,
+       ignore => [my_generated_mod],
+       rules => [
+         {elvis_style, line_length, #{limit => 100}},
+         {elvis_style, no_tabs},
+         {elvis_style, god_modules, disable}
+       ]},
+     #{dirs => ["."], filter => "rebar.config", ruleset => rebar_config},
+     #{dirs => ["src/**"], filter => "*.hrl", ruleset => hrl_files}
+   ]},
+   {output_format, colors},
+   {verbose, true}
+ ]}
+].
+`
+
+// TestParseElvisConfig tests extracting groups, rulesets and rules
+func TestParseElvisConfig(t *testing.T) {
+	elvis, err := ParseElvisConfig(sampleElvisConfig)
+	if err != nil {
+		t.Fatalf("Failed to parse elvis.config: %v", err)
+	}
+
+	if len(elvis.Groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %d", len(elvis.Groups))
+	}
+	if !reflect.DeepEqual(elvis.Rulesets(), []string{"erl_files", "rebar_config", "hrl_files"}) {
+		t.Errorf("Unexpected rulesets: %v", elvis.Rulesets())
+	}
+	if !reflect.DeepEqual(elvis.Dirs(), []string{"src/**", "test/**", "."}) {
+		t.Errorf("Unexpected dirs: %v", elvis.Dirs())
+	}
+	if !elvis.Options["output_format"].Compare(Atom{Value: "colors"}) {
+		t.Errorf("Unexpected output_format: %v", elvis.Options["output_format"])
+	}
+
+	group := elvis.Groups[0]
+	if group.Filter != "*.erl" || len(group.Rules) != 3 || len(group.Ignore) != 1 {
+		t.Errorf("Unexpected first group: %+v", group)
+	}
+
+	lineLength, ok := group.Rule("elvis_style", "line_length")
+	if !ok {
+		t.Fatal("Expected line_length rule")
+	}
+	limit, _ := lineLength.Options.(Map).Get(Atom{Value: "limit"})
+	if !limit.Compare(Integer{Value: 100}) {
+		t.Errorf("Unexpected line_length limit: %v", limit)
+	}
+
+	noTabs, _ := group.Rule("elvis_style", "no_tabs")
+	if noTabs.Options != nil || noTabs.Disabled {
+		t.Errorf("Unexpected no_tabs rule: %+v", noTabs)
+	}
+	god, _ := group.Rule("elvis_style", "god_modules")
+	if !god.Disabled {
+		t.Error("Expected god_modules to be disabled")
+	}
+	if _, ok := group.Rule("elvis_style", "missing"); ok {
+		t.Error("Expected missing rule not to be found")
+	}
+}
+
+// TestParseElvisConfigErrors tests malformed elvis configs
+func TestParseElvisConfigErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ``},
+		{"not a list", `{elvis, []}.`},
+		{"no elvis", `[{other, []}].`},
+		{"options not list", `[{elvis, config}].`},
+		{"bad option", `[{elvis, [verbose]}].`},
+		{"config not list", `[{elvis, [{config, #{}}]}].`},
+		{"group not map", `[{elvis, [{config, [{dirs, ["src"]}]}]}].`},
+		{"bad dirs", `[{elvis, [{config, [#{dirs => "src"}]}]}].`},
+		{"rules not list", `[{elvis, [{config, [#{rules => none}]}]}].`},
+		{"bad rule", `[{elvis, [{config, [#{rules => [line_length]}]}]}].`},
+		{"rule not atoms", `[{elvis, [{config, [#{rules => [{"elvis_style", line_length}]}]}]}].`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseElvisConfig(tt.input); err == nil {
+				t.Errorf("Expected error for %q", tt.input)
+			}
+		})
+	}
+}
+
+// TestParseElvisConfigFile tests reading elvis.config from disk
+func TestParseElvisConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "elvis.config")
+	if err := os.WriteFile(path, []byte(sampleElvisConfig), 0644); err != nil {
+		t.Fatalf("Failed to write elvis.config: %v", err)
+	}
+
+	elvis, err := ParseElvisConfigFile(path)
+	if err != nil {
+		t.Fatalf("Failed to parse elvis.config: %v", err)
+	}
+	if len(elvis.Groups) != 3 {
+		t.Errorf("Expected 3 groups, got %d", len(elvis.Groups))
+	}
+	if _, err := ParseElvisConfigFile(filepath.Join(t.TempDir(), "missing.config")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
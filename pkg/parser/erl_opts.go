@@ -0,0 +1,218 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErlOptsIssueKind 表示 erl_opts 问题的类别
+type ErlOptsIssueKind string
+
+const (
+	// ErlOptsDuplicate 表示同一作用域内重复出现的选项
+	ErlOptsDuplicate ErlOptsIssueKind = "duplicate"
+	// ErlOptsConflict 表示互相冲突的选项，如 debug_info 与 no_debug_info
+	ErlOptsConflict ErlOptsIssueKind = "conflict"
+)
+
+// ErlOptsIssue 描述 erl_opts 中发现的一个问题
+// @pkg 记录问题类别、出现问题的选项以及与之重复或冲突的先前选项
+// Profile 和 PreviousProfile 为空字符串表示基础配置（不在任何 profile 中）
+type ErlOptsIssue struct {
+	Kind            ErlOptsIssueKind
+	Profile         string
+	Option          Term
+	PreviousProfile string
+	Previous        Term
+}
+
+// String 返回问题的可读描述
+// @pkg 例如 "conflict: no_debug_info (profile prod) conflicts with debug_info (base)"
+func (i ErlOptsIssue) String() string {
+	verb := "duplicates"
+	if i.Kind == ErlOptsConflict {
+		verb = "conflicts with"
+	}
+	return fmt.Sprintf("%s: %s (%s) %s %s (%s)",
+		i.Kind, i.Option, scopeName(i.Profile), verb, i.Previous, scopeName(i.PreviousProfile))
+}
+
+// scopeName 返回作用域的显示名称
+func scopeName(profile string) string {
+	if profile == "" {
+		return "base"
+	}
+	return "profile " + profile
+}
+
+// CheckErlOpts 检查基础配置和各 profile 中的 erl_opts
+// @pkg 检测同一作用域内重复的选项，以及任意作用域之间互相冲突的选项
+// 跨作用域的完全相同选项不会被报告，因为 rebar3 合并时会自动去重
+// 输出:
+//   - []ErlOptsIssue: 发现的问题列表，按出现顺序排列
+//
+// 示例:
+//
+//	for _, issue := range config.CheckErlOpts() {
+//	  fmt.Println(issue)
+//	}
+//
+// 数据样例:
+// 原始配置:
+//
+//	{erl_opts, [debug_info, {parse_transform, lager_transform}, {parse_transform, lager_transform}]}.
+//	{profiles, [{prod, [{erl_opts, [no_debug_info]}]}]}.
+//
+// 返回: 一个 duplicate 问题（parse_transform）和一个 conflict 问题（no_debug_info 与 debug_info）
+func (c *RebarConfig) CheckErlOpts() []ErlOptsIssue {
+	type seenOpt struct {
+		profile string
+		opt     Term
+	}
+
+	var issues []ErlOptsIssue
+	var baseSeen map[string]seenOpt
+
+	check := func(profile string, opts []Term) map[string]seenOpt {
+		seen := make(map[string]seenOpt)
+		for _, opt := range opts {
+			key := erlOptKey(opt)
+
+			if prev, ok := seen[key]; ok {
+				kind := ErlOptsDuplicate
+				if !opt.Compare(prev.opt) {
+					kind = ErlOptsConflict
+				}
+				issues = append(issues, ErlOptsIssue{kind, profile, opt, prev.profile, prev.opt})
+			} else if prev, ok := baseSeen[key]; ok && !opt.Compare(prev.opt) {
+				issues = append(issues, ErlOptsIssue{ErlOptsConflict, profile, opt, prev.profile, prev.opt})
+			}
+			seen[key] = seenOpt{profile, opt}
+		}
+		return seen
+	}
+
+	baseSeen = check("", erlOptsList(c.Terms))
+	for _, profile := range profileEntries(c.Terms) {
+		check(profile.name, erlOptsList(profile.terms))
+	}
+
+	return issues
+}
+
+// NormalizeErlOpts 合并并规范化若干 erl_opts 列表
+// @pkg 按优先级从低到高传入选项列表（如基础配置在前，profile 在后）
+// 规则与 rebar3 一致，后出现的定义优先:
+// - 完全相同的选项只保留第一次出现的位置
+// - 互相冲突的选项（如 debug_info 与 no_debug_info、同名但值不同的 {d, Macro, Value}）
+// 由后出现的选项取代先前的选项，并占据先前选项的位置
+// 输入:
+//   - lists: 按优先级从低到高排列的选项列表
+//
+// 输出:
+//   - []Term: 规范化后的选项列表
+//
+// 示例:
+//
+//	base, _ := config.GetErlOpts()
+//	opts := parser.NormalizeErlOpts(base[0].(parser.List).Elements)
+//
+// 数据样例:
+// 输入: [debug_info, warnings_as_errors, debug_info], [no_debug_info]
+// 返回: [no_debug_info, warnings_as_errors]
+func NormalizeErlOpts(lists ...[]Term) []Term {
+	result := []Term{}
+	index := make(map[string]int)
+
+	for _, opts := range lists {
+		for _, opt := range opts {
+			key := erlOptKey(opt)
+			if i, ok := index[key]; ok {
+				result[i] = opt
+				continue
+			}
+			index[key] = len(result)
+			result = append(result, opt)
+		}
+	}
+
+	return result
+}
+
+// erlOptKey 返回用于比较 erl_opts 选项的键
+// @pkg 同一个键的两个选项要么重复，要么互相冲突:
+// - 原子 X、no_X 共用键 X；nowarn_X 与 warn_X 共用键 warn_X
+// - {d, Macro} 与 {d, Macro, Value} 共用键 d:Macro
+// - 其他选项以其完整文本作为键
+func erlOptKey(opt Term) string {
+	switch t := opt.(type) {
+	case Atom:
+		name := t.Value
+		if strings.HasPrefix(name, "nowarn_") {
+			name = strings.TrimPrefix(name, "no")
+		} else {
+			name = strings.TrimPrefix(name, "no_")
+		}
+		return "flag:" + name
+	case Tuple:
+		if len(t.Elements) >= 2 {
+			if head, ok := t.Elements[0].(Atom); ok && head.Value == "d" {
+				return "d:" + t.Elements[1].String()
+			}
+		}
+	}
+	return "term:" + opt.String()
+}
+
+// erlOptsList 从一组顶级项中取出 erl_opts 列表的元素
+func erlOptsList(terms []Term) []Term {
+	view := RebarConfig{Terms: terms}
+	elements, ok := view.GetErlOpts()
+	if !ok {
+		return nil
+	}
+	if list, ok := elements[0].(List); ok {
+		return list.Elements
+	}
+	return nil
+}
+
+// profileEntry 表示 profiles 配置中的一个 profile
+type profileEntry struct {
+	name  string
+	terms []Term
+}
+
+// profileEntries 按出现顺序返回 profiles 配置中的所有 profile
+// @pkg 每个 profile 的形式为 {Name, [Option, ...]}，不符合该形式的项会被跳过
+func profileEntries(terms []Term) []profileEntry {
+	view := RebarConfig{Terms: terms}
+	elements, ok := view.GetProfilesConfig()
+	if !ok {
+		return nil
+	}
+	list, ok := elements[0].(List)
+	if !ok {
+		return nil
+	}
+
+	var entries []profileEntry
+	for _, elem := range list.Elements {
+		tuple, ok := elem.(Tuple)
+		if !ok || len(tuple.Elements) != 2 {
+			continue
+		}
+		name, ok := tuple.Elements[0].(Atom)
+		if !ok {
+			continue
+		}
+		opts, ok := tuple.Elements[1].(List)
+		if !ok {
+			continue
+		}
+		entries = append(entries, profileEntry{name: name.Value, terms: opts.Elements})
+	}
+	return entries
+}
@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCheckErlOpts tests detection of duplicate and conflicting erl_opts
+func TestCheckErlOpts(t *testing.T) {
+	input := `
+{erl_opts, [debug_info, {parse_transform, lager_transform}, {parse_transform, lager_transform}, {d, 'TEST', 1}]}.
+{profiles, [
+    {prod, [{erl_opts, [no_debug_info, {d, 'TEST', 2}]}]},
+    {test, [{erl_opts, [debug_info, nowarn_export_all, warn_export_all]}]}
+]}.
+`
+	config, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	issues := config.CheckErlOpts()
+	expected := []struct {
+		kind    ErlOptsIssueKind
+		profile string
+		option  string
+	}{
+		{ErlOptsDuplicate, "", "{parse_transform, lager_transform}"},
+		{ErlOptsConflict, "prod", "no_debug_info"},
+		{ErlOptsConflict, "prod", "{d, 'TEST', 2}"},
+		{ErlOptsConflict, "test", "warn_export_all"},
+	}
+
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %d: %v", len(expected), len(issues), issues)
+	}
+	for i, exp := range expected {
+		if issues[i].Kind != exp.kind || issues[i].Profile != exp.profile || issues[i].Option.String() != exp.option {
+			t.Errorf("Issue %d: expected %s/%s/%s, got %v", i, exp.kind, exp.profile, exp.option, issues[i])
+		}
+	}
+
+	if s := issues[1].String(); !strings.Contains(s, "profile prod") || !strings.Contains(s, "base") {
+		t.Errorf("Unexpected issue description: %s", s)
+	}
+}
+
+// TestCheckErlOptsClean tests that a config without problems reports nothing
+func TestCheckErlOptsClean(t *testing.T) {
+	config, err := Parse(`{erl_opts, [debug_info]}. {profiles, [{test, [{erl_opts, [debug_info]}]}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	if issues := config.CheckErlOpts(); len(issues) != 0 {
+		t.Errorf("Expected no issues, got %v", issues)
+	}
+
+	empty, _ := Parse(`{deps, []}.`)
+	if issues := empty.CheckErlOpts(); len(issues) != 0 {
+		t.Errorf("Expected no issues without erl_opts, got %v", issues)
+	}
+}
+
+// TestNormalizeErlOpts tests deduplication with later-wins precedence
+func TestNormalizeErlOpts(t *testing.T) {
+	base := []Term{
+		Atom{Value: "debug_info"},
+		Atom{Value: "warnings_as_errors"},
+		Atom{Value: "debug_info"},
+		Tuple{Elements: []Term{Atom{Value: "d"}, Atom{Value: "TEST"}}},
+	}
+	profile := []Term{
+		Atom{Value: "no_debug_info"},
+		Tuple{Elements: []Term{Atom{Value: "d"}, Atom{Value: "TEST"}, Atom{Value: "true"}}},
+		Atom{Value: "nowarn_export_all"},
+	}
+
+	got := List{Elements: NormalizeErlOpts(base, profile)}
+	want := "[no_debug_info, warnings_as_errors, {d, 'TEST', true}, nowarn_export_all]"
+	if got.String() != want {
+		t.Errorf("NormalizeErlOpts = %s, want %s", got, want)
+	}
+
+	if got := NormalizeErlOpts(); len(got) != 0 {
+		t.Errorf("Expected empty result, got %v", got)
+	}
+}
@@ -0,0 +1,496 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// External Term Format 的标签，见 https://www.erlang.org/doc/apps/erts/erl_ext_dist.html
+const (
+	etfVersion        = 131
+	etfCompressed     = 80
+	etfNewFloat       = 70
+	etfSmallInteger   = 97
+	etfInteger        = 98
+	etfFloat          = 99
+	etfAtom           = 100
+	etfSmallTuple     = 104
+	etfLargeTuple     = 105
+	etfNil            = 106
+	etfString         = 107
+	etfList           = 108
+	etfBinary         = 109
+	etfSmallBig       = 110
+	etfLargeBig       = 111
+	etfSmallAtom      = 115
+	etfMap            = 116
+	etfAtomUTF8       = 118
+	etfSmallAtomUTF8  = 119
+	etfMaxStringBytes = 65535
+)
+
+// EncodeETF 将项编码为 Erlang 外部项格式（term_to_binary 的结果）
+// @pkg 输出以版本号 131 开头，可以直接交给 binary_to_term/1 解码:
+// - 原子使用 UTF-8 原子标签，整数按大小使用 SMALL_INTEGER、INTEGER 或 SMALL_BIG，浮点数使用 NEW_FLOAT
+// - 字符串是字符列表：所有字符都不超过 255 时使用 STRING 标签，否则编码为整数列表
+// - 元组、列表、映射和二进制使用对应的标签
+//
+// 输入:
+//   - term: 要编码的项
+//
+// 输出:
+//   - []byte: 编码结果
+//
+// 示例:
+//
+//	data := parser.EncodeETF(parser.Atom{Value: "ok"})
+//	// data 为 []byte{131, 119, 2, 'o', 'k'}
+func EncodeETF(term Term) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(etfVersion)
+	encodeETF(&buf, term)
+	return buf.Bytes()
+}
+
+// encodeETF 写入项的编码（不含版本号）
+func encodeETF(buf *bytes.Buffer, term Term) {
+	switch t := term.(type) {
+	case Atom:
+		if len(t.Value) < 256 {
+			buf.WriteByte(etfSmallAtomUTF8)
+			buf.WriteByte(byte(len(t.Value)))
+		} else {
+			buf.WriteByte(etfAtomUTF8)
+			writeUint16(buf, len(t.Value))
+		}
+		buf.WriteString(t.Value)
+
+	case Integer:
+		encodeETFInteger(buf, t.Value)
+
+	case Float:
+		buf.WriteByte(etfNewFloat)
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], math.Float64bits(t.Value))
+		buf.Write(b[:])
+
+	case String:
+		encodeETFString(buf, t.Value)
+
+	case Binary:
+		buf.WriteByte(etfBinary)
+		writeUint32(buf, len(t.Value))
+		buf.WriteString(t.Value)
+
+	case Tuple:
+		if len(t.Elements) < 256 {
+			buf.WriteByte(etfSmallTuple)
+			buf.WriteByte(byte(len(t.Elements)))
+		} else {
+			buf.WriteByte(etfLargeTuple)
+			writeUint32(buf, len(t.Elements))
+		}
+		for _, elem := range t.Elements {
+			encodeETF(buf, elem)
+		}
+
+	case List:
+		if len(t.Elements) == 0 {
+			buf.WriteByte(etfNil)
+			return
+		}
+		buf.WriteByte(etfList)
+		writeUint32(buf, len(t.Elements))
+		for _, elem := range t.Elements {
+			encodeETF(buf, elem)
+		}
+		buf.WriteByte(etfNil)
+
+	case Map:
+		buf.WriteByte(etfMap)
+		writeUint32(buf, len(t.Pairs))
+		for _, pair := range t.Pairs {
+			encodeETF(buf, pair.Key)
+			encodeETF(buf, pair.Value)
+		}
+
+	default:
+		// 其他 Term 实现按其 Erlang 文本编码为二进制
+		encodeETF(buf, Binary{Value: term.String()})
+	}
+}
+
+// encodeETFInteger 写入整数的编码
+func encodeETFInteger(buf *bytes.Buffer, value int64) {
+	switch {
+	case value >= 0 && value <= 255:
+		buf.WriteByte(etfSmallInteger)
+		buf.WriteByte(byte(value))
+	case value >= math.MinInt32 && value <= math.MaxInt32:
+		buf.WriteByte(etfInteger)
+		var b [4]byte
+		binary.BigEndian.PutUint32(b[:], uint32(int32(value)))
+		buf.Write(b[:])
+	default:
+		sign := byte(0)
+		magnitude := uint64(value)
+		if value < 0 {
+			sign = 1
+			magnitude = uint64(-(value + 1)) + 1
+		}
+		var digits []byte
+		for ; magnitude > 0; magnitude >>= 8 {
+			digits = append(digits, byte(magnitude))
+		}
+		buf.WriteByte(etfSmallBig)
+		buf.WriteByte(byte(len(digits)))
+		buf.WriteByte(sign)
+		buf.Write(digits)
+	}
+}
+
+// encodeETFString 写入字符串（字符列表）的编码
+func encodeETFString(buf *bytes.Buffer, value string) {
+	if value == "" {
+		buf.WriteByte(etfNil)
+		return
+	}
+
+	latin1 := make([]byte, 0, len(value))
+	for _, r := range value {
+		if r > 255 {
+			latin1 = nil
+			break
+		}
+		latin1 = append(latin1, byte(r))
+	}
+	if latin1 != nil && len(latin1) <= etfMaxStringBytes {
+		buf.WriteByte(etfString)
+		writeUint16(buf, len(latin1))
+		buf.Write(latin1)
+		return
+	}
+
+	buf.WriteByte(etfList)
+	writeUint32(buf, utf8.RuneCountInString(value))
+	for _, r := range value {
+		encodeETFInteger(buf, int64(r))
+	}
+	buf.WriteByte(etfNil)
+}
+
+// writeUint16 写入大端序的 16 位长度
+func writeUint16(buf *bytes.Buffer, n int) {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], uint16(n))
+	buf.Write(b[:])
+}
+
+// writeUint32 写入大端序的 32 位长度
+func writeUint32(buf *bytes.Buffer, n int) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(n))
+	buf.Write(b[:])
+}
+
+// DecodeETF 解码 Erlang 外部项格式的数据（term_to_binary 的结果）
+// @pkg 支持原子、整数（不超过 64 位）、浮点数、字符串、列表、元组、映射、二进制，以及压缩格式。
+// STRING 标签解码为 String，因此 Erlang 中的小整数列表（如 [1,2,3]）也会被解码为字符串；
+// 原子在需要时设置 IsQuoted，以便 String() 输出合法的 Erlang 语法
+// 输入:
+//   - data: 以版本号 131 开头的数据
+//
+// 输出:
+//   - Term: 解码后的项
+//   - error: 数据不完整、含有不支持的标签（如 pid、非正规列表）、末尾有多余数据，
+//     嵌套超过文本解析的深度上限，或压缩数据声明的解压大小超过 64 MB
+//
+// 示例:
+//
+//	term, err := parser.DecodeETF([]byte{131, 104, 2, 119, 2, 'o', 'k', 97, 1})
+//	fmt.Println(term) // 输出: {ok, 1}
+func DecodeETF(data []byte) (Term, error) {
+	if len(data) == 0 || data[0] != etfVersion {
+		return nil, fmt.Errorf("invalid ETF: missing version byte %d", etfVersion)
+	}
+	d := &etfDecoder{data: data[1:]}
+
+	if len(d.data) > 0 && d.data[0] == etfCompressed {
+		if len(d.data) < 5 {
+			return nil, fmt.Errorf("invalid ETF: truncated compressed header")
+		}
+		size := binary.BigEndian.Uint32(d.data[1:5])
+		if size > maxETFInflatedSize {
+			return nil, fmt.Errorf("invalid ETF: compressed data declares %d bytes, limit is %d", size, maxETFInflatedSize)
+		}
+		r, err := zlib.NewReader(bytes.NewReader(d.data[5:]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ETF: %w", err)
+		}
+		inflated, err := io.ReadAll(io.LimitReader(r, int64(size)+1))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ETF: %w", err)
+		}
+		if uint32(len(inflated)) != size {
+			return nil, fmt.Errorf("invalid ETF: compressed size mismatch")
+		}
+		d.data = inflated
+	}
+
+	term, err := d.decode()
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data) {
+		return nil, fmt.Errorf("invalid ETF: %d trailing bytes", len(d.data)-d.pos)
+	}
+	return term, nil
+}
+
+// maxETFInflatedSize 是压缩格式解压后允许的最大字节数，防止不可信的输入声明巨大的大小耗尽内存
+const maxETFInflatedSize = 64 << 20
+
+// etfDecoder 保存解码位置
+type etfDecoder struct {
+	data []byte
+	pos  int
+	// depth 是当前元组、列表和映射的嵌套深度，上限与文本解析相同（maxNestingDepth）
+	depth int
+}
+
+// take 读取 n 个字节
+func (d *etfDecoder) take(n int) ([]byte, error) {
+	if n < 0 || d.pos+n > len(d.data) {
+		return nil, fmt.Errorf("invalid ETF: unexpected end of data at offset %d", d.pos)
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+// length 读取 size 字节（1、2 或 4）的大端序长度
+func (d *etfDecoder) length(size int) (int, error) {
+	b, err := d.take(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return int(b[0]), nil
+	case 2:
+		return int(binary.BigEndian.Uint16(b)), nil
+	}
+	return int(binary.BigEndian.Uint32(b)), nil
+}
+
+// decode 解码一个项
+func (d *etfDecoder) decode() (Term, error) {
+	tag, err := d.take(1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag[0] {
+	case etfSmallAtom, etfSmallAtomUTF8, etfAtom, etfAtomUTF8:
+		size := 2
+		if tag[0] == etfSmallAtom || tag[0] == etfSmallAtomUTF8 {
+			size = 1
+		}
+		n, err := d.length(size)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.take(n)
+		if err != nil {
+			return nil, err
+		}
+		value := string(b)
+		if tag[0] == etfAtom || tag[0] == etfSmallAtom {
+			value = latin1String(b)
+		}
+		return atomTerm(value), nil
+
+	case etfSmallInteger:
+		b, err := d.take(1)
+		if err != nil {
+			return nil, err
+		}
+		return Integer{Value: int64(b[0])}, nil
+
+	case etfInteger:
+		b, err := d.take(4)
+		if err != nil {
+			return nil, err
+		}
+		return Integer{Value: int64(int32(binary.BigEndian.Uint32(b)))}, nil
+
+	case etfSmallBig, etfLargeBig:
+		size := 1
+		if tag[0] == etfLargeBig {
+			size = 4
+		}
+		n, err := d.length(size)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.take(n + 1)
+		if err != nil {
+			return nil, err
+		}
+		return bigInteger(b[0], b[1:])
+
+	case etfNewFloat:
+		b, err := d.take(8)
+		if err != nil {
+			return nil, err
+		}
+		return Float{Value: math.Float64frombits(binary.BigEndian.Uint64(b))}, nil
+
+	case etfFloat:
+		b, err := d.take(31)
+		if err != nil {
+			return nil, err
+		}
+		value, err := strconv.ParseFloat(strings.TrimRight(string(b), "\x00"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ETF: bad float %q", strings.TrimRight(string(b), "\x00"))
+		}
+		return Float{Value: value}, nil
+
+	case etfString:
+		n, err := d.length(2)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.take(n)
+		if err != nil {
+			return nil, err
+		}
+		return String{Value: latin1String(b)}, nil
+
+	case etfBinary:
+		n, err := d.length(4)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.take(n)
+		if err != nil {
+			return nil, err
+		}
+		return Binary{Value: string(b)}, nil
+
+	case etfSmallTuple, etfLargeTuple:
+		size := 1
+		if tag[0] == etfLargeTuple {
+			size = 4
+		}
+		n, err := d.length(size)
+		if err != nil {
+			return nil, err
+		}
+		elements, err := d.decodeN(n)
+		if err != nil {
+			return nil, err
+		}
+		return Tuple{Elements: elements}, nil
+
+	case etfNil:
+		return List{Elements: []Term{}}, nil
+
+	case etfList:
+		n, err := d.length(4)
+		if err != nil {
+			return nil, err
+		}
+		elements, err := d.decodeN(n)
+		if err != nil {
+			return nil, err
+		}
+		tail, err := d.take(1)
+		if err != nil {
+			return nil, err
+		}
+		if tail[0] != etfNil {
+			return nil, fmt.Errorf("invalid ETF: improper lists are not supported")
+		}
+		return List{Elements: elements}, nil
+
+	case etfMap:
+		n, err := d.length(4)
+		if err != nil {
+			return nil, err
+		}
+		items, err := d.decodeN(2 * n)
+		if err != nil {
+			return nil, err
+		}
+		pairs := make([]MapPair, n)
+		for i := range pairs {
+			pairs[i] = MapPair{Key: items[2*i], Value: items[2*i+1]}
+		}
+		return Map{Pairs: pairs}, nil
+	}
+	return nil, fmt.Errorf("invalid ETF: unsupported tag %d at offset %d", tag[0], d.pos-1)
+}
+
+// decodeN 解码 n 个连续的项
+func (d *etfDecoder) decodeN(n int) ([]Term, error) {
+	if n > len(d.data)-d.pos {
+		// 每个项至少占一个字节
+		return nil, fmt.Errorf("invalid ETF: unexpected end of data at offset %d", d.pos)
+	}
+	if d.depth >= maxNestingDepth {
+		return nil, fmt.Errorf("invalid ETF: nesting too deep at offset %d", d.pos)
+	}
+	d.depth++
+	defer func() { d.depth-- }()
+
+	terms := make([]Term, n)
+	for i := range terms {
+		term, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		terms[i] = term
+	}
+	return terms, nil
+}
+
+// bigInteger 将小端序的大整数转换为 Integer，超出 int64 范围时返回错误
+func bigInteger(sign byte, digits []byte) (Term, error) {
+	var magnitude uint64
+	for i := len(digits) - 1; i >= 0; i-- {
+		if magnitude > math.MaxUint64>>8 {
+			return nil, fmt.Errorf("invalid ETF: integer exceeds 64 bits")
+		}
+		magnitude = magnitude<<8 | uint64(digits[i])
+	}
+	if sign == 0 {
+		if magnitude > math.MaxInt64 {
+			return nil, fmt.Errorf("invalid ETF: integer exceeds 64 bits")
+		}
+		return Integer{Value: int64(magnitude)}, nil
+	}
+	if magnitude > 1<<63 {
+		return nil, fmt.Errorf("invalid ETF: integer exceeds 64 bits")
+	}
+	return Integer{Value: int64(-magnitude)}, nil
+}
+
+// latin1String 将 Latin-1 字节转换为 UTF-8 字符串
+func latin1String(b []byte) string {
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
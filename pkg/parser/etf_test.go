@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"bytes"
+	"compress/zlib"
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestEncodeETF tests encoding against term_to_binary output
+func TestEncodeETF(t *testing.T) {
+	tests := []struct {
+		term     Term
+		expected []byte
+	}{
+		{Atom{Value: "abc"}, []byte{131, 119, 3, 'a', 'b', 'c'}},
+		{Integer{Value: 1}, []byte{131, 97, 1}},
+		{Integer{Value: -1}, []byte{131, 98, 255, 255, 255, 255}},
+		{Integer{Value: 1 << 40}, []byte{131, 110, 6, 0, 0, 0, 0, 0, 0, 1}},
+		{Integer{Value: -(1 << 40)}, []byte{131, 110, 6, 1, 0, 0, 0, 0, 0, 1}},
+		{Float{Value: 3.5}, []byte{131, 70, 64, 12, 0, 0, 0, 0, 0, 0}},
+		{String{Value: "hi"}, []byte{131, 107, 0, 2, 'h', 'i'}},
+		{String{Value: ""}, []byte{131, 106}},
+		{String{Value: "ā"}, []byte{131, 108, 0, 0, 0, 1, 98, 0, 0, 1, 1, 106}},
+		{Binary{Value: "x"}, []byte{131, 109, 0, 0, 0, 1, 'x'}},
+		{Tuple{Elements: []Term{Atom{Value: "a"}, Integer{Value: 1}}}, []byte{131, 104, 2, 119, 1, 'a', 97, 1}},
+		{List{Elements: []Term{Atom{Value: "a"}}}, []byte{131, 108, 0, 0, 0, 1, 119, 1, 'a', 106}},
+		{List{}, []byte{131, 106}},
+		{Map{Pairs: []MapPair{{Key: Atom{Value: "a"}, Value: Integer{Value: 1}}}}, []byte{131, 116, 0, 0, 0, 1, 119, 1, 'a', 97, 1}},
+	}
+
+	for _, tt := range tests {
+		if got := EncodeETF(tt.term); !bytes.Equal(got, tt.expected) {
+			t.Errorf("EncodeETF(%s): expected %v, got %v", tt.term, tt.expected, got)
+		}
+	}
+}
+
+// TestETFRoundTrip tests that parsed configs survive an ETF round trip
+func TestETFRoundTrip(t *testing.T) {
+	config, err := Parse(`{erl_opts, [debug_info, {d, 'TEST'}, {i, "include"}]}.
+{deps, [{'my-dep', {git, "https://example.com/x.git", {tag, "1.0"}}}]}.
+{vars, #{port => 8080, big => 9007199254740993, neg => -70000, ratio => 0.25, name => <<"app">>}}.
+{empty, {}}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	for _, term := range config.Terms {
+		decoded, err := DecodeETF(EncodeETF(term))
+		if err != nil {
+			t.Fatalf("Failed to decode %s: %v", term, err)
+		}
+		if decoded.String() != term.String() {
+			t.Errorf("Round trip: expected %s, got %s", term, decoded)
+		}
+	}
+
+	for _, value := range []int64{math.MaxInt64, math.MinInt64, math.MinInt32, math.MaxInt32 + 1} {
+		decoded, err := DecodeETF(EncodeETF(Integer{Value: value}))
+		if err != nil || !decoded.Compare(Integer{Value: value}) {
+			t.Errorf("Round trip of %d produced %v, %v", value, decoded, err)
+		}
+	}
+}
+
+// TestDecodeETF tests decoding of legacy, compressed and invalid data
+func TestDecodeETF(t *testing.T) {
+	// Legacy latin-1 atom and old-style float
+	float := append([]byte{131, 104, 2, 100, 0, 2, 'o', 'k', 99}, []byte("1.50000000000000000000e+00")...)
+	float = append(float, make([]byte, 31-len("1.50000000000000000000e+00"))...)
+	term, err := DecodeETF(float)
+	if err != nil || term.String() != "{ok, 1.5}" {
+		t.Errorf("Expected {ok, 1.5}, got %v, %v", term, err)
+	}
+
+	// Compressed payload
+	payload := EncodeETF(String{Value: strings.Repeat("a", 100)})[1:]
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	w.Write(payload)
+	w.Close()
+	data := append([]byte{131, 80, 0, 0, 0, byte(len(payload))}, buf.Bytes()...)
+	term, err = DecodeETF(data)
+	if err != nil || term.(String).Value != strings.Repeat("a", 100) {
+		t.Errorf("Failed to decode compressed data: %v, %v", term, err)
+	}
+
+	errorTests := []struct {
+		data     []byte
+		expected string
+	}{
+		{[]byte{}, "missing version byte"},
+		{[]byte{131, 104, 2, 97, 1}, "unexpected end of data"},
+		{[]byte{131, 97, 1, 97}, "1 trailing bytes"},
+		{[]byte{131, 103}, "unsupported tag 103"},
+		{[]byte{131, 108, 0, 0, 0, 1, 97, 1, 97, 2}, "improper lists"},
+		{[]byte{131, 110, 9, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, "exceeds 64 bits"},
+		{[]byte{131, 108, 255, 255, 255, 255}, "unexpected end of data"},
+	}
+	for _, tt := range errorTests {
+		_, err := DecodeETF(tt.data)
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("DecodeETF(%v): expected error containing %q, got %v", tt.data, tt.expected, err)
+		}
+	}
+}
+
+// TestDecodeETFLimits tests that hostile input is rejected instead of exhausting the stack or memory
+func TestDecodeETFLimits(t *testing.T) {
+	// Deeply nested 1-tuples, compressed so the payload is small
+	nested := bytes.Repeat([]byte{104, 1}, maxNestingDepth+1)
+	nested = append(nested, 97, 1)
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	w.Write(nested)
+	w.Close()
+	size := len(nested)
+	data := append([]byte{131, 80, byte(size >> 24), byte(size >> 16), byte(size >> 8), byte(size)}, buf.Bytes()...)
+	if _, err := DecodeETF(data); err == nil || !strings.Contains(err.Error(), "nesting too deep") {
+		t.Errorf("Expected a nesting error, got %v", err)
+	}
+
+	// Nesting at the limit still decodes
+	ok := append(bytes.Repeat([]byte{104, 1}, maxNestingDepth), 97, 1)
+	if _, err := DecodeETF(append([]byte{131}, ok...)); err != nil {
+		t.Errorf("Expected nesting at the limit to decode, got %v", err)
+	}
+
+	// Declared inflated size above the limit
+	huge := []byte{131, 80, 0xff, 0xff, 0xff, 0xff, 0x78, 0x9c}
+	if _, err := DecodeETF(huge); err == nil || !strings.Contains(err.Error(), "limit") {
+		t.Errorf("Expected a size limit error, got %v", err)
+	}
+}
@@ -0,0 +1,280 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Report 是对 rebar.config 的结构化概要
+// @pkg 由 Explain 生成，汇总应用名称、OTP 要求、依赖、profiles、发布定义、插件和钩子
+// String 方法将其渲染为便于阅读的文字说明
+type Report struct {
+	// AppName 是应用名称，未配置时为空
+	AppName string
+	// MinimumOTPVersion 是 minimum_otp_vsn 的值，未配置时为空
+	MinimumOTPVersion string
+	// DepCount 是基础配置中的依赖数量
+	DepCount int
+	// DepsByKind 按来源类别（hex、git、hg、path、other）统计基础配置中的依赖数量
+	DepsByKind map[string]int
+	// Profiles 是各 profile 的概要，按出现顺序排列
+	Profiles []ProfileSummary
+	// Releases 是 relx 中定义的发布
+	Releases []ReleaseSummary
+	// Plugins 是 plugins 中声明的插件名称
+	Plugins []string
+	// ProjectPlugins 是 project_plugins 中声明的插件名称
+	ProjectPlugins []string
+	// Hooks 是 provider_hooks、pre_hooks 和 post_hooks 中定义的钩子描述
+	Hooks []string
+}
+
+// ProfileSummary 是单个 profile 的概要
+// @pkg Keys 是该 profile 相对基础配置覆盖或新增的配置项名称
+type ProfileSummary struct {
+	Name     string
+	Keys     []string
+	DepCount int
+}
+
+// ReleaseSummary 是 relx 中单个发布定义的概要
+type ReleaseSummary struct {
+	Name    string
+	Version string
+	Apps    []string
+}
+
+// Explain 生成配置的结构化概要
+// @pkg 基于类型化访问方法汇总配置，帮助不熟悉项目的人快速了解一个 rebar.config
+// 输入:
+//   - config: 已解析的配置
+//
+// 输出:
+//   - Report: 配置概要，调用 String() 可得到文字说明
+//
+// 示例:
+//
+//	config, _ := parser.ParseFile("./rebar.config")
+//	fmt.Println(parser.Explain(config))
+func Explain(config *RebarConfig) Report {
+	report := Report{DepsByKind: make(map[string]int)}
+
+	report.AppName, _ = config.GetAppName()
+	if elements, ok := config.GetTupleElements("minimum_otp_vsn"); ok {
+		report.MinimumOTPVersion = termText(elements[0])
+	}
+
+	for _, dep := range listElements(config, "deps") {
+		report.DepCount++
+		report.DepsByKind[depKind(dep)]++
+	}
+
+	for _, profile := range profileEntries(config.Terms) {
+		summary := ProfileSummary{Name: profile.name}
+		view := &RebarConfig{Terms: profile.terms}
+		for _, term := range profile.terms {
+			if name := termName(term); name != "" {
+				summary.Keys = append(summary.Keys, name)
+			}
+		}
+		summary.DepCount = len(listElements(view, "deps"))
+		report.Profiles = append(report.Profiles, summary)
+	}
+
+	for _, item := range listElements(config, "relx") {
+		if release, ok := explainRelease(item); ok {
+			report.Releases = append(report.Releases, release)
+		}
+	}
+
+	for _, plugin := range listElements(config, "plugins") {
+		report.Plugins = append(report.Plugins, termName(plugin))
+	}
+	for _, plugin := range listElements(config, "project_plugins") {
+		report.ProjectPlugins = append(report.ProjectPlugins, termName(plugin))
+	}
+
+	report.Hooks = explainHooks(config)
+	return report
+}
+
+// String 将概要渲染为文字说明
+// @pkg 输出为多行英文文本，每个部分一段，没有内容的部分会被省略
+func (r Report) String() string {
+	var b strings.Builder
+
+	if r.AppName != "" {
+		fmt.Fprintf(&b, "Application %s.\n", r.AppName)
+	}
+	if r.MinimumOTPVersion != "" {
+		fmt.Fprintf(&b, "Requires OTP %s or newer.\n", r.MinimumOTPVersion)
+	}
+
+	if r.DepCount == 0 {
+		b.WriteString("Declares no dependencies.\n")
+	} else {
+		kinds := make([]string, 0, len(r.DepsByKind))
+		for kind := range r.DepsByKind {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		parts := make([]string, len(kinds))
+		for i, kind := range kinds {
+			parts[i] = fmt.Sprintf("%d %s", r.DepsByKind[kind], kind)
+		}
+		fmt.Fprintf(&b, "Declares %d %s (%s).\n", r.DepCount, plural(r.DepCount, "dependency", "dependencies"), strings.Join(parts, ", "))
+	}
+
+	for _, profile := range r.Profiles {
+		fmt.Fprintf(&b, "Profile %s", profile.Name)
+		if len(profile.Keys) == 0 {
+			b.WriteString(" changes nothing")
+		} else {
+			fmt.Fprintf(&b, " overrides %s", strings.Join(profile.Keys, ", "))
+		}
+		if profile.DepCount > 0 {
+			fmt.Fprintf(&b, " and adds %d %s", profile.DepCount, plural(profile.DepCount, "dependency", "dependencies"))
+		}
+		b.WriteString(".\n")
+	}
+
+	for _, release := range r.Releases {
+		fmt.Fprintf(&b, "Release %s %s bundles %s.\n", release.Name, release.Version, strings.Join(release.Apps, ", "))
+	}
+
+	if len(r.Plugins) > 0 {
+		fmt.Fprintf(&b, "Uses plugins: %s.\n", strings.Join(r.Plugins, ", "))
+	}
+	if len(r.ProjectPlugins) > 0 {
+		fmt.Fprintf(&b, "Uses project plugins: %s.\n", strings.Join(r.ProjectPlugins, ", "))
+	}
+	for _, hook := range r.Hooks {
+		fmt.Fprintf(&b, "Hook: %s.\n", hook)
+	}
+
+	return b.String()
+}
+
+// explainRelease 解析 {release, {Name, Vsn}, [App, ...]} 形式的发布定义
+func explainRelease(term Term) (ReleaseSummary, bool) {
+	tuple, ok := term.(Tuple)
+	if !ok || len(tuple.Elements) < 3 || termName(tuple) != "release" {
+		return ReleaseSummary{}, false
+	}
+
+	nameVsn, ok := tuple.Elements[1].(Tuple)
+	if !ok || len(nameVsn.Elements) != 2 {
+		return ReleaseSummary{}, false
+	}
+
+	release := ReleaseSummary{
+		Name:    termText(nameVsn.Elements[0]),
+		Version: termText(nameVsn.Elements[1]),
+	}
+	if apps, ok := tuple.Elements[2].(List); ok {
+		for _, app := range apps.Elements {
+			if name := termName(app); name != "" {
+				release.Apps = append(release.Apps, name)
+			} else {
+				release.Apps = append(release.Apps, app.String())
+			}
+		}
+	}
+	return release, true
+}
+
+// explainHooks 汇总 provider_hooks、pre_hooks 和 post_hooks
+// @pkg 例如 "pre compile runs {pc, compile}" 和 "pre_hooks compile runs \"make\""
+func explainHooks(config *RebarConfig) []string {
+	var hooks []string
+
+	for _, phase := range listElements(config, "provider_hooks") {
+		phaseTuple, ok := phase.(Tuple)
+		if !ok || len(phaseTuple.Elements) != 2 {
+			continue
+		}
+		entries, ok := phaseTuple.Elements[1].(List)
+		if !ok {
+			continue
+		}
+		for _, entry := range entries.Elements {
+			if hook, ok := entry.(Tuple); ok && len(hook.Elements) == 2 {
+				hooks = append(hooks, fmt.Sprintf("%s %s runs %s", termName(phaseTuple), termText(hook.Elements[0]), hook.Elements[1]))
+			}
+		}
+	}
+
+	for _, key := range []string{"pre_hooks", "post_hooks"} {
+		for _, entry := range listElements(config, key) {
+			hook, ok := entry.(Tuple)
+			if !ok || len(hook.Elements) < 2 {
+				continue
+			}
+			command := hook.Elements[len(hook.Elements)-1]
+			hooks = append(hooks, fmt.Sprintf("%s %s runs %s", key, termText(hook.Elements[len(hook.Elements)-2]), command))
+		}
+	}
+
+	return hooks
+}
+
+// listElements 返回 {name, [...]} 形式顶级项中列表的元素
+// @pkg 未找到该项或其值不是列表时返回 nil
+func listElements(config *RebarConfig, name string) []Term {
+	elements, ok := config.GetTupleElements(name)
+	if !ok {
+		return nil
+	}
+	if list, ok := elements[0].(List); ok {
+		return list.Elements
+	}
+	return nil
+}
+
+// termName 返回项的名称
+// @pkg 原子返回其值，首元素为原子的元组返回该原子的值，其他情况返回空字符串
+func termName(term Term) string {
+	switch t := term.(type) {
+	case Atom:
+		return t.Value
+	case Tuple:
+		if len(t.Elements) > 0 {
+			if atom, ok := t.Elements[0].(Atom); ok {
+				return atom.Value
+			}
+		}
+	}
+	return ""
+}
+
+// termText 返回项的文本值
+// @pkg 字符串和原子返回其值（不带引号），其他类型返回 String() 的结果
+func termText(term Term) string {
+	switch t := term.(type) {
+	case String:
+		return t.Value
+	case Atom:
+		return t.Value
+	default:
+		return term.String()
+	}
+}
+
+// depKind 返回依赖项的来源大类，见 Dependency.Family；不是依赖声明时为 other
+func depKind(dep Term) string {
+	if d, ok := AsDependency(dep); ok {
+		return d.Family()
+	}
+	return "other"
+}
+
+// plural 根据数量返回单数或复数形式
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return singular
+	}
+	return pluralForm
+}
@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestExplain tests the structured summary of a config
+func TestExplain(t *testing.T) {
+	input := `
+{app_name, my_app}.
+{minimum_otp_vsn, "24.0"}.
+{deps, [
+    cowlib,
+    {cowboy, "2.9.0"},
+    {jsx, {pkg, jsx_fork}},
+    {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}},
+    {local, {path, "../local"}},
+    {legacy, ".*", {hg, "https://example.com/legacy", "default"}}
+]}.
+{plugins, [rebar3_hex, {rebar3_auto, "0.4.0"}]}.
+{project_plugins, [erlfmt]}.
+{provider_hooks, [{pre, [{compile, {pc, compile}}]}]}.
+{pre_hooks, [{compile, "make -C c_src"}]}.
+{relx, [
+    {release, {my_app, "0.1.0"}, [my_app, sasl]},
+    {dev_mode, true}
+]}.
+{profiles, [
+    {test, [{deps, [{meck, "0.9.2"}]}, {erl_opts, [nowarn_export_all]}]},
+    {empty, []}
+]}.
+`
+	config, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	report := Explain(config)
+
+	if report.AppName != "my_app" || report.MinimumOTPVersion != "24.0" {
+		t.Errorf("Unexpected app name or OTP version: %q %q", report.AppName, report.MinimumOTPVersion)
+	}
+	if report.DepCount != 6 {
+		t.Errorf("Expected 6 deps, got %d", report.DepCount)
+	}
+	wantKinds := map[string]int{"hex": 3, "git": 1, "path": 1, "hg": 1}
+	for kind, n := range wantKinds {
+		if report.DepsByKind[kind] != n {
+			t.Errorf("Expected %d %s deps, got %d", n, kind, report.DepsByKind[kind])
+		}
+	}
+	if len(report.Profiles) != 2 || report.Profiles[0].DepCount != 1 || strings.Join(report.Profiles[0].Keys, ",") != "deps,erl_opts" {
+		t.Errorf("Unexpected profiles: %+v", report.Profiles)
+	}
+	if len(report.Releases) != 1 || report.Releases[0].Version != "0.1.0" || len(report.Releases[0].Apps) != 2 {
+		t.Errorf("Unexpected releases: %+v", report.Releases)
+	}
+	if strings.Join(report.Plugins, ",") != "rebar3_hex,rebar3_auto" || strings.Join(report.ProjectPlugins, ",") != "erlfmt" {
+		t.Errorf("Unexpected plugins: %v %v", report.Plugins, report.ProjectPlugins)
+	}
+	if len(report.Hooks) != 2 {
+		t.Errorf("Expected 2 hooks, got %v", report.Hooks)
+	}
+
+	text := report.String()
+	for _, want := range []string{
+		"Application my_app.",
+		"Requires OTP 24.0 or newer.",
+		"Declares 6 dependencies (1 git, 3 hex, 1 hg, 1 path).",
+		"Profile test overrides deps, erl_opts and adds 1 dependency.",
+		"Profile empty changes nothing.",
+		"Release my_app 0.1.0 bundles my_app, sasl.",
+		"Uses plugins: rebar3_hex, rebar3_auto.",
+		"Hook: pre compile runs {pc, compile}.",
+		`Hook: pre_hooks compile runs "make -C c_src".`,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected explanation to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+// TestExplainEmpty tests the summary of an empty config
+func TestExplainEmpty(t *testing.T) {
+	config, _ := Parse("")
+	text := Explain(config).String()
+	if text != "Declares no dependencies.\n" {
+		t.Errorf("Unexpected explanation for empty config: %q", text)
+	}
+}
+
+// TestExplainEmptyDepTuple tests that an empty tuple in deps does not panic
+func TestExplainEmptyDepTuple(t *testing.T) {
+	config, err := Parse(`{deps, [{}, jsx]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	report := Explain(config)
+	if report.DepCount != 2 || report.DepsByKind["other"] != 1 || report.DepsByKind["hex"] != 1 {
+		t.Errorf("Unexpected dependency kinds: %v", report.DepsByKind)
+	}
+}
@@ -0,0 +1,119 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+// FindAll 返回项中所有满足条件的节点
+// @pkg 按先序深度优先遍历（即源码中的出现顺序），包括 term 本身、元组和列表的元素以及映射的键和值；
+// 满足条件的节点的子节点同样会被检查
+// 输入:
+//   - term: 要搜索的项
+//   - match: 判断节点是否满足条件的函数
+//
+// 输出:
+//   - []Term: 满足条件的节点，没有时为空切片
+//
+// 示例:
+//
+//	strings := parser.FindAll(term, func(t parser.Term) bool {
+//	  _, ok := t.(parser.String)
+//	  return ok
+//	})
+func FindAll(term Term, match func(Term) bool) []Term {
+	found := []Term{}
+	inspect(term, func(t Term) {
+		if match(t) {
+			found = append(found, t)
+		}
+	})
+	return found
+}
+
+// FindAll 返回配置中所有满足条件的节点，搜索所有顶级项的整棵树（包括各 profile），规则与 FindAll 相同
+// 输入:
+//   - match: 判断节点是否满足条件的函数
+//
+// 输出:
+//   - []Term: 满足条件的节点，按出现顺序排列
+//
+// 示例:
+//
+//	// 找出所有 profile 中的 {d, 'DEBUG', _}
+//	p := parser.MustCompilePattern(`{d, 'DEBUG', _}`)
+//	defines := config.FindAll(func(t parser.Term) bool {
+//	  _, ok := p.Match(t)
+//	  return ok
+//	})
+func (c *RebarConfig) FindAll(match func(Term) bool) []Term {
+	found := []Term{}
+	for _, term := range c.Terms {
+		found = append(found, FindAll(term, match)...)
+	}
+	return found
+}
+
+// FindTuplesNamed 返回配置中所有首元素为原子 name 的元组
+// @pkg 搜索所有顶级项的整棵树，如 FindTuplesNamed("d") 返回基础配置和各 profile 中 erl_opts 里的所有宏定义
+// 输入:
+//   - name: 元组首元素的原子名
+//
+// 输出:
+//   - []Term: 匹配的元组，按出现顺序排列
+//
+// 示例:
+//
+//	for _, def := range config.FindTuplesNamed("d") {
+//	  fmt.Println(def)
+//	}
+func (c *RebarConfig) FindTuplesNamed(name string) []Term {
+	return c.FindAll(func(t Term) bool {
+		_, ok := t.(Tuple)
+		return ok && hasKey(t, name)
+	})
+}
+
+// FindTupleByKey 返回配置中所有首元素为原子 name 的元组及其位置
+// @pkg 与 FindTuplesNamed 相同，但返回游标，可以通过 Path、Parent 和 TopLevelKey 区分出现在不同层级的同名配置项，
+// 如基础配置和 profiles 中的 erl_opts、relx 中的 {overlay, ...}
+// 输入:
+//   - name: 元组首元素的原子名
+//
+// 输出:
+//   - []*Cursor: 指向匹配元组的游标，按出现顺序排列
+//
+// 示例:
+//
+//	for _, cur := range config.FindTupleByKey("erl_opts") {
+//	  fmt.Printf("%s: %s\n", cur.Path(), cur.Term())
+//	}
+func (c *RebarConfig) FindTupleByKey(name string) []*Cursor {
+	found := []*Cursor{}
+	for _, top := range c.Cursors() {
+		top.Inspect(func(cur *Cursor) bool {
+			if _, ok := cur.Term().(Tuple); ok && hasKey(cur.Term(), name) {
+				found = append(found, cur)
+			}
+			return true
+		})
+	}
+	return found
+}
+
+// inspect 按先序深度优先遍历项的所有节点
+func inspect(term Term, fn func(Term)) {
+	fn(term)
+	switch t := term.(type) {
+	case Tuple:
+		for _, elem := range t.Elements {
+			inspect(elem, fn)
+		}
+	case List:
+		for _, elem := range t.Elements {
+			inspect(elem, fn)
+		}
+	case Map:
+		for _, pair := range t.Pairs {
+			inspect(pair.Key, fn)
+			inspect(pair.Value, fn)
+		}
+	}
+}
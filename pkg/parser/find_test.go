@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestFindAll tests searching nested terms in document order
+func TestFindAll(t *testing.T) {
+	term := MustParseTerm(`{a, [1, {b, 2.5}, #{3 => "x"}], "y"}`)
+	numbers := FindAll(term, isNumber)
+
+	var got []string
+	for _, n := range numbers {
+		got = append(got, n.String())
+	}
+	if strings.Join(got, " ") != "1 2.5 3" {
+		t.Errorf("Expected 1 2.5 3, got %v", got)
+	}
+
+	if found := FindAll(term, func(Term) bool { return false }); found == nil || len(found) != 0 {
+		t.Errorf("Expected an empty slice, got %v", found)
+	}
+}
+
+// TestConfigFindAll tests searching across profiles
+func TestConfigFindAll(t *testing.T) {
+	config, err := Parse(`{erl_opts, [debug_info, {d, 'DEBUG', true}, {d, 'TEST'}]}.
+{profiles, [
+    {test, [{erl_opts, [{d, 'DEBUG', false}]}]},
+    {prod, [{erl_opts, [no_debug_info]}]}
+]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	p := MustCompilePattern(`{d, 'DEBUG', _}`)
+	debug := config.FindAll(func(t Term) bool {
+		_, ok := p.Match(t)
+		return ok
+	})
+	if len(debug) != 2 || debug[0].String() != "{d, 'DEBUG', true}" || debug[1].String() != "{d, 'DEBUG', false}" {
+		t.Errorf("Unexpected matches: %v", debug)
+	}
+
+	defines := config.FindTuplesNamed("d")
+	if len(defines) != 3 {
+		t.Errorf("Expected 3 defines, got %v", defines)
+	}
+
+	erlOpts := config.FindTuplesNamed("erl_opts")
+	if len(erlOpts) != 3 {
+		t.Errorf("Expected 3 erl_opts entries, got %v", erlOpts)
+	}
+}
+
+// TestFindTupleByKey tests that nested matches are returned with their paths
+func TestFindTupleByKey(t *testing.T) {
+	config, err := Parse(`{erl_opts, [debug_info]}.
+{profiles, [
+    {test, [{erl_opts, [nowarn_export_all]}]},
+    {prod, [{relx, [{dev_mode, false}]}]}
+]}.
+{relx, [{release, {app, "0.1.0"}, [app]}, {dev_mode, true}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	tests := []struct {
+		key   string
+		paths []string
+	}{
+		{"erl_opts", []string{"[0]", "[1]{1}[0]{1}[0]"}},
+		{"dev_mode", []string{"[1]{1}[1]{1}[0]{1}[0]", "[2]{1}[1]"}},
+		{"missing", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			found := config.FindTupleByKey(tt.key)
+			var paths []string
+			for _, cur := range found {
+				paths = append(paths, cur.Path())
+			}
+			if strings.Join(paths, " ") != strings.Join(tt.paths, " ") {
+				t.Errorf("Expected %v, got %v", tt.paths, paths)
+			}
+		})
+	}
+
+	devMode := config.FindTupleByKey("dev_mode")
+	if devMode[0].TopLevelKey() != "profiles" || devMode[1].TopLevelKey() != "relx" || devMode[1].Term().String() != "{dev_mode, true}" {
+		t.Errorf("Unexpected cursors: %v", devMode)
+	}
+}
@@ -0,0 +1,198 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"sort"
+	"strings"
+)
+
+// FormatOptions 是 FormatWith 的格式化选项
+// @pkg 零值与 Format(0) 相同；排序只影响输出，不修改配置本身
+type FormatOptions struct {
+	// Indent 是每级缩进的空格数
+	Indent int
+	// SortDeps 按名称排序 deps、plugins 和 project_plugins 列表中的条目，包括各 profile 中的列表
+	SortDeps bool
+	// SortKeys 按键名排序顶级配置项、profiles 中的各 profile 以及每个 profile 中的配置项
+	SortKeys bool
+	// Align 对齐分行列表中连续的 {key, value} 元素的值，如 relx 和 profiles 中的配置项
+	Align bool
+	// Policies 按顶级配置项的键指定排版方式，如 {"deps": LayoutElementPerLine, "profiles": LayoutExpanded}；
+	// 未列出的键使用 LayoutAuto
+	Policies map[string]Layout
+	// BlankLines 是顶级项之间的空行规则，默认每两项之间一个空行
+	BlankLines BlankLinePolicy
+	// Literals 原样输出数字和字符串在源码中的写法（需要使用 KeepLiterals 解析），如保留 2.10 和 1.0e3
+	Literals bool
+}
+
+// sortedLists 是 SortDeps 排序的列表
+var sortedLists = map[string]bool{"deps": true, "plugins": true, "project_plugins": true}
+
+// FormatWith 按选项返回配置的格式化字符串表示
+// @pkg 排序使用稳定排序，名称相同的条目保持原有顺序，便于在合并分支时得到一致的结果
+// 输入:
+//   - opts: 格式化选项
+//
+// 输出:
+//   - string: 格式化后的配置字符串
+//
+// 示例:
+//
+//	formatted := config.FormatWith(parser.FormatOptions{Indent: 4, SortDeps: true, SortKeys: true})
+func (c *RebarConfig) FormatWith(opts FormatOptions) string {
+	terms := c.Terms
+	if opts.SortDeps || opts.SortKeys {
+		terms = sortSection(terms, opts, true)
+	}
+	f := formatter{opts: opts}
+	if opts.BlankLines == BlankLinesPreserve {
+		f.grouped = c.grouping(terms)
+	}
+	return f.terms(terms)
+}
+
+// BlankLinePolicy 是顶级项之间的空行规则，用于 FormatOptions.BlankLines
+type BlankLinePolicy int
+
+const (
+	// BlankLinesOne 在每两个顶级项之间输出一个空行，与 Format 相同
+	BlankLinesOne BlankLinePolicy = iota
+	// BlankLinesNone 不输出空行，每个顶级项紧接着前一项
+	BlankLinesNone
+	// BlankLinesPreserve 保留原文中的分组：在 Raw 中相邻且之间没有空行的项仍然紧挨着输出，
+	// 其他项之间（包括新增的项、排序后不再相邻的项）输出一个空行
+	BlankLinesPreserve
+)
+
+// grouping 返回 terms 中每一项是否与前一项在原文中属于同一组（相邻且之间没有空行）
+// @pkg 每一项对应到原文中第一个尚未使用且完全相同的项，找不到时对应到键相同的项（如修改了值的配置项）；
+// Raw 为空或无法解析时返回 nil
+func (c *RebarConfig) grouping(terms []Term) []bool {
+	if c.Raw == "" {
+		return nil
+	}
+	original, err := Parse(c.Raw, DiscardRaw())
+	if err != nil {
+		return nil
+	}
+	spans, ok := sourceSpans(c.Raw)
+	if !ok || len(spans) != len(original.Terms) {
+		return nil
+	}
+
+	used := make([]bool, len(original.Terms))
+	find := func(term Term) int {
+		for j, orig := range original.Terms {
+			if !used[j] && sameTerm(orig, term) {
+				return j
+			}
+		}
+		if name := termName(term); name != "" {
+			for j, orig := range original.Terms {
+				if !used[j] && termName(orig) == name {
+					return j
+				}
+			}
+		}
+		return -1
+	}
+
+	grouped := make([]bool, len(terms))
+	prev := -1
+	for i, term := range terms {
+		j := find(term)
+		if j >= 0 {
+			used[j] = true
+			grouped[i] = i > 0 && prev >= 0 && j == prev+1 && !hasBlankLine(c.Raw[spans[prev].end:spans[j].start])
+		}
+		prev = j
+	}
+	return grouped
+}
+
+// hasBlankLine 检查两个项之间的原文中是否有空行
+func hasBlankLine(gap string) bool {
+	lines := strings.Split(gap, "\n")
+	if len(lines) < 3 {
+		return false
+	}
+	for _, line := range lines[1 : len(lines)-1] {
+		if strings.TrimSpace(line) == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// sortSection 返回排序后的配置项列表，top 表示是否为顶层（决定是否处理 profiles）
+func sortSection(terms []Term, opts FormatOptions, top bool) []Term {
+	sorted := make([]Term, len(terms))
+	for i, term := range terms {
+		sorted[i] = sortEntry(term, opts, top)
+	}
+	if opts.SortKeys {
+		sortByName(sorted)
+	}
+	return sorted
+}
+
+// sortEntry 对 {Key, List} 形式配置项的列表内容排序
+func sortEntry(term Term, opts FormatOptions, top bool) Term {
+	tuple, ok := term.(Tuple)
+	if !ok || len(tuple.Elements) != 2 {
+		return term
+	}
+	list, ok := tuple.Elements[1].(List)
+	if !ok {
+		return term
+	}
+
+	key := termName(tuple)
+	var elements []Term
+	switch {
+	case opts.SortDeps && sortedLists[key]:
+		elements = append([]Term(nil), list.Elements...)
+		sortByName(elements)
+	case top && key == "profiles":
+		elements = make([]Term, len(list.Elements))
+		for i, profile := range list.Elements {
+			elements[i] = sortProfile(profile, opts)
+		}
+		if opts.SortKeys {
+			sortByName(elements)
+		}
+	default:
+		return term
+	}
+	return Tuple{Elements: []Term{tuple.Elements[0], List{Elements: elements}}}
+}
+
+// sortProfile 对 {Name, [Entries]} 形式的 profile 内容排序
+func sortProfile(term Term, opts FormatOptions) Term {
+	tuple, ok := term.(Tuple)
+	if !ok || len(tuple.Elements) != 2 {
+		return term
+	}
+	list, ok := tuple.Elements[1].(List)
+	if !ok {
+		return term
+	}
+	return Tuple{Elements: []Term{tuple.Elements[0], List{Elements: sortSection(list.Elements, opts, false)}}}
+}
+
+// sortByName 按 termName 稳定排序，没有名称的项按其文本排序
+func sortByName(terms []Term) {
+	sort.SliceStable(terms, func(i, j int) bool {
+		return sortName(terms[i]) < sortName(terms[j])
+	})
+}
+
+// sortName 返回排序使用的名称
+func sortName(term Term) string {
+	if name := termName(term); name != "" {
+		return name
+	}
+	return strings.TrimPrefix(term.String(), "'")
+}
@@ -0,0 +1,297 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFormatWithSorting tests sorting of deps, plugins and keys
+func TestFormatWithSorting(t *testing.T) {
+	config, err := Parse(`{plugins, [rebar3_hex, {pc, "1.0"}]}.
+{deps, [{jsx, "3.1.0"}, cowboy, {'Alpha', "1"}]}.
+{profiles, [{test, [{deps, [proper, meck]}, {erl_opts, [nowarn_export_all]}]}, {prod, [{relx, []}]}]}.
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	original := config.Format(0)
+
+	tests := []struct {
+		name     string
+		opts     FormatOptions
+		expected string
+	}{
+		{
+			name:     "no sorting",
+			opts:     FormatOptions{},
+			expected: original,
+		},
+		{
+			name: "sort deps",
+			opts: FormatOptions{SortDeps: true},
+			expected: `{plugins, [{pc, "1.0"}, rebar3_hex]}.
+
+{deps, [{'Alpha', "1"}, cowboy, {jsx, "3.1.0"}]}.
+
+{profiles, [{test, [{deps, [meck, proper]}, {erl_opts, [nowarn_export_all]}]}, {prod, [{relx, []}]}]}.
+`,
+		},
+		{
+			name: "sort keys",
+			opts: FormatOptions{SortKeys: true},
+			expected: `{deps, [{jsx, "3.1.0"}, cowboy, {'Alpha', "1"}]}.
+
+{plugins, [rebar3_hex, {pc, "1.0"}]}.
+
+{profiles, [{prod, [{relx, []}]}, {test, [{deps, [proper, meck]}, {erl_opts, [nowarn_export_all]}]}]}.
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := config.FormatWith(tt.opts); got != tt.expected {
+				t.Errorf("Expected:\n%s\nGot:\n%s", tt.expected, got)
+			}
+		})
+	}
+
+	if config.Format(0) != original {
+		t.Error("Expected FormatWith not to modify the config")
+	}
+}
+
+// TestFormatWithLiterals tests that the Literals option re-emits recorded source text
+func TestFormatWithLiterals(t *testing.T) {
+	config, err := Parse(`{app, [{vsn, 2.10}, {ratio, 1.0e3}, {retries, 007}, {name, "\x41bc"}, {plain, 1.5}]}.`, KeepLiterals())
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	canonical := "{app, [\n    {vsn, 2.1},\n    {ratio, 1000.0},\n    {retries, 7},\n    {name, \"Abc\"},\n    {plain, 1.5}\n  ]}.\n"
+	if got := config.FormatWith(FormatOptions{Indent: 2}); got != canonical {
+		t.Errorf("Expected:\n%s\nGot:\n%s", canonical, got)
+	}
+
+	literal := "{app, [\n    {vsn, 2.10},\n    {ratio, 1.0e3},\n    {retries, 007},\n    {name, \"\\x41bc\"},\n    {plain, 1.5}\n  ]}.\n"
+	if got := config.FormatWith(FormatOptions{Indent: 2, Literals: true}); got != literal {
+		t.Errorf("Expected:\n%s\nGot:\n%s", literal, got)
+	}
+
+	// 修改后的值不再使用旧的写法
+	list := config.Terms[0].(Tuple).Elements[1].(List)
+	vsn := list.Elements[0].(Tuple)
+	vsn.Elements[1] = Float{Value: 2.11, Text: "2.10"}
+	edited := "{app, [\n    {vsn, 2.11},\n    {ratio, 1.0e3},\n    {retries, 007},\n    {name, \"\\x41bc\"},\n    {plain, 1.5}\n  ]}.\n"
+	if got := config.FormatWith(FormatOptions{Indent: 2, Literals: true}); got != edited {
+		t.Errorf("Expected:\n%s\nGot:\n%s", edited, got)
+	}
+
+	// FormatPreserving 不受记录的写法影响
+	if got := config.FormatPreserving(2); got != `{app, [{vsn, 2.11}, {ratio, 1.0e3}, {retries, 007}, {name, "\x41bc"}, {plain, 1.5}]}.` {
+		t.Errorf("Unexpected FormatPreserving result: %s", got)
+	}
+}
+
+// TestFormatWithAlign tests that Align lines up the values of {key, value} blocks
+func TestFormatWithAlign(t *testing.T) {
+	config, err := Parse(`{relx, [{release, {my_app, "0.1.0"}, [my_app, sasl]}, {dev_mode, true}, {include_erts, false}, {'extended-start', true}, {vm_args, "config/vm.args"}, debug_info, {sys_config, "config/sys.config"}, {mode, dev}]}.
+{erl_opts, [debug_info]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	expected := `{relx, [
+    {release, {my_app, "0.1.0"}, [my_app, sasl]},
+    {dev_mode,         true},
+    {include_erts,     false},
+    {'extended-start', true},
+    {vm_args,          "config/vm.args"},
+    debug_info,
+    {sys_config, "config/sys.config"},
+    {mode,       dev}
+  ]}.
+
+{erl_opts, [debug_info]}.
+`
+	if got := config.FormatWith(FormatOptions{Indent: 2, Align: true}); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+
+	if got, want := config.FormatWith(FormatOptions{Indent: 2}), config.Format(2); got != want {
+		t.Errorf("Expected no alignment by default, got:\n%s", got)
+	}
+}
+
+// TestFormatWithPolicies tests per-key layout policies
+func TestFormatWithPolicies(t *testing.T) {
+	config, err := Parse(`{erl_opts, [debug_info, warnings_as_errors, {i, "include"}, {d, 'TEST'}]}.
+{deps, [{cowboy, {git, "https://github.com/ninenines/cowboy.git", {tag, "2.9.0"}}}, {jsx, "3.1.0"}, {meck, {git, "https://github.com/eproxus/meck.git", {branch, "master"}, [raw]}}]}.
+{profiles, [{test, [{deps, [meck]}]}]}.
+{minimum_otp_vsn, "24"}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	opts := FormatOptions{
+		Indent: 2,
+		Policies: map[string]Layout{
+			"erl_opts":        LayoutInline,
+			"deps":            LayoutElementPerLine,
+			"profiles":        LayoutExpanded,
+			"minimum_otp_vsn": LayoutExpanded,
+		},
+	}
+	expected := `{erl_opts, [debug_info, warnings_as_errors, {i, "include"}, {d, 'TEST'}]}.
+
+{deps, [
+    {cowboy, {git, "https://github.com/ninenines/cowboy.git", {tag, "2.9.0"}}},
+    {jsx, "3.1.0"},
+    {meck, {git, "https://github.com/eproxus/meck.git", {branch, "master"}, [raw]}}
+  ]}.
+
+{profiles, [
+    {test, [
+        {deps, [
+            meck
+          ]}
+      ]}
+  ]}.
+
+{minimum_otp_vsn, "24"}.
+`
+	if got := config.FormatWith(opts); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+
+	opts.Align = true
+	opts.Policies = map[string]Layout{"deps": LayoutElementPerLine}
+	config, err = Parse(`{deps, [{cowboy, {git, "url", {tag, "2.9.0"}}}, {jsx, "3.1.0"}, rebar3_hex]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	expected = `{deps, [
+    {cowboy, {git, "url", {tag, "2.9.0"}}},
+    {jsx,    "3.1.0"},
+    rebar3_hex
+  ]}.
+`
+	if got := config.FormatWith(opts); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+// TestFormatIdempotent tests that formatting is a fixed point on the corpus: Format(Parse(Format(x))) == Format(x)
+func TestFormatIdempotent(t *testing.T) {
+	paths, err := filepath.Glob("testdata/corpus/*.config")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("Failed to list corpus: %v", err)
+	}
+
+	options := []FormatOptions{
+		{},
+		{Indent: 2},
+		{Indent: 4, SortDeps: true, SortKeys: true},
+		{Indent: 4, Align: true},
+		{Indent: 2, Literals: true},
+		{Indent: 2, BlankLines: BlankLinesNone},
+		{Indent: 2, BlankLines: BlankLinesPreserve},
+		{Indent: 4, Align: true, Policies: map[string]Layout{"deps": LayoutElementPerLine, "profiles": LayoutExpanded, "erl_opts": LayoutInline}},
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read file: %v", err)
+		}
+		for _, opts := range options {
+			t.Run(fmt.Sprintf("%s/%+v", filepath.Base(path), opts), func(t *testing.T) {
+				config, err := Parse(string(data), KeepLiterals())
+				if err != nil {
+					t.Fatalf("Failed to parse config: %v", err)
+				}
+				first := config.FormatWith(opts)
+				reparsed, err := Parse(first, KeepLiterals())
+				if err != nil {
+					t.Fatalf("Failed to parse formatted output: %v\n%s", err, first)
+				}
+				if !opts.SortDeps && !opts.SortKeys && !compareConfigs(config, reparsed) {
+					t.Errorf("Formatting changed the terms:\n%s", first)
+				}
+				if second := reparsed.FormatWith(opts); second != first {
+					t.Errorf("Formatting is not idempotent.\nFirst:\n%s\nSecond:\n%s", first, second)
+				}
+			})
+		}
+	}
+}
+
+// TestFormatWithBlankLines tests the blank-line policies between top-level terms
+func TestFormatWithBlankLines(t *testing.T) {
+	config, err := Parse(`%% header
+{erl_opts, [debug_info]}.
+{minimum_otp_vsn, "24"}. {edoc_opts, []}.
+
+%% dependencies
+{deps, [cowboy]}.
+{plugins, [rebar3_hex]}.
+
+
+{shell, [{apps, [my_app]}]}.
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		policy   BlankLinePolicy
+		edit     func([]Term) []Term
+		expected string
+	}{
+		{
+			name:     "one",
+			policy:   BlankLinesOne,
+			expected: "{erl_opts, [debug_info]}.\n\n{minimum_otp_vsn, \"24\"}.\n\n{edoc_opts, []}.\n\n{deps, [cowboy]}.\n\n{plugins, [rebar3_hex]}.\n\n{shell, [{apps, [my_app]}]}.\n",
+		},
+		{
+			name:     "none",
+			policy:   BlankLinesNone,
+			expected: "{erl_opts, [debug_info]}.\n{minimum_otp_vsn, \"24\"}.\n{edoc_opts, []}.\n{deps, [cowboy]}.\n{plugins, [rebar3_hex]}.\n{shell, [{apps, [my_app]}]}.\n",
+		},
+		{
+			name:     "preserve",
+			policy:   BlankLinesPreserve,
+			expected: "{erl_opts, [debug_info]}.\n{minimum_otp_vsn, \"24\"}.\n{edoc_opts, []}.\n\n{deps, [cowboy]}.\n{plugins, [rebar3_hex]}.\n\n{shell, [{apps, [my_app]}]}.\n",
+		},
+		{
+			name:   "preserve with edits",
+			policy: BlankLinesPreserve,
+			edit: func(terms []Term) []Term {
+				edited := append([]Term(nil), terms[:3]...)
+				edited = append(edited, Tuple{Elements: []Term{Atom{Value: "deps"}, List{Elements: []Term{Atom{Value: "jsx"}}}}})
+				edited = append(edited, terms[5], terms[4])
+				return append(edited, Tuple{Elements: []Term{Atom{Value: "new"}, Atom{Value: "term"}}})
+			},
+			expected: "{erl_opts, [debug_info]}.\n{minimum_otp_vsn, \"24\"}.\n{edoc_opts, []}.\n\n{deps, [jsx]}.\n\n{shell, [{apps, [my_app]}]}.\n\n{plugins, [rebar3_hex]}.\n\n{new, term}.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edited := &RebarConfig{Raw: config.Raw, Terms: config.Terms}
+			if tt.edit != nil {
+				edited.Terms = tt.edit(config.Terms)
+			}
+			if got := edited.FormatWith(FormatOptions{BlankLines: tt.policy}); got != tt.expected {
+				t.Errorf("Expected:\n%s\nGot:\n%s", tt.expected, got)
+			}
+		})
+	}
+
+	config.Raw = ""
+	if got, want := config.FormatWith(FormatOptions{BlankLines: BlankLinesPreserve}), config.Format(0); got != want {
+		t.Errorf("Expected one blank line without Raw, got:\n%s", got)
+	}
+}
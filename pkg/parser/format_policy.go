@@ -0,0 +1,127 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Layout 是顶级配置项的排版方式，用于 FormatOptions.Policies
+type Layout int
+
+const (
+	// LayoutAuto 使用默认规则：短的简单列表写在一行，其他列表每个元素一行
+	LayoutAuto Layout = iota
+	// LayoutInline 把整个配置项写在一行，如 {erl_opts, [debug_info, warnings_as_errors, {i, "include"}]}
+	LayoutInline
+	// LayoutExpanded 让值中所有非空列表每个元素一行，即使列表很短，常用于 profiles
+	LayoutExpanded
+	// LayoutElementPerLine 让值列表每个元素一行，且每个元素完整写在一行，
+	// 常用于 deps，使 {cowboy, {git, Url, {tag, V}}} 这样的依赖总是各占一行
+	LayoutElementPerLine
+)
+
+// entry 格式化一个顶级项，{Key, Value} 形式的项按 Policies 中 Key 对应的排版方式输出
+func (f formatter) entry(term Term) string {
+	tuple, ok := term.(Tuple)
+	if !ok || len(tuple.Elements) != 2 {
+		return f.term(term, 0)
+	}
+	key, ok := tuple.Elements[0].(Atom)
+	if !ok {
+		return f.term(term, 0)
+	}
+
+	switch f.opts.Policies[key.Value] {
+	case LayoutInline:
+		return f.inline(term)
+	case LayoutExpanded:
+		expanded := f
+		expanded.expand = true
+		return expanded.term(term, 0)
+	case LayoutElementPerLine:
+		list, ok := tuple.Elements[1].(List)
+		if !ok || len(list.Elements) == 0 {
+			return f.inline(term)
+		}
+		return "{" + key.String() + ", " + f.inlineElements(list, 1) + "}"
+	}
+	return f.term(term, 0)
+}
+
+// inline 把 Term 完整地格式化在一行
+func (f formatter) inline(term Term) string {
+	switch t := term.(type) {
+	case Tuple:
+		return "{" + f.inlineSeq(t.Elements) + "}"
+	case List:
+		return "[" + f.inlineSeq(t.Elements) + "]"
+	case Map:
+		pairs := make([]string, len(t.Pairs))
+		for i, pair := range t.Pairs {
+			pairs[i] = f.inline(pair.Key) + " => " + f.inline(pair.Value)
+		}
+		return "#{" + strings.Join(pairs, ", ") + "}"
+	}
+	return f.term(term, 0)
+}
+
+// inlineSeq 把元素格式化在一行，用逗号和空格分隔
+func (f formatter) inlineSeq(elements []Term) string {
+	parts := make([]string, len(elements))
+	for i, elem := range elements {
+		parts[i] = f.inline(elem)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// inlineElements 分行输出列表，每个元素完整写在一行；启用 Align 时对齐 {key, value} 元素的值
+func (f formatter) inlineElements(list List, level int) string {
+	var widths []int
+	if f.opts.Align {
+		widths = keyWidths(list.Elements)
+	}
+
+	var b strings.Builder
+	b.WriteString("[\n")
+	innerIndent := strings.Repeat(" ", (level+1)*f.opts.Indent)
+	for i, elem := range list.Elements {
+		b.WriteString(innerIndent)
+		if widths != nil && widths[i] > 0 {
+			pair := elem.(Tuple)
+			key := pair.Elements[0].(Atom).String()
+			b.WriteString("{" + key + ", " + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(key)) + f.inline(pair.Elements[1]) + "}")
+		} else {
+			b.WriteString(f.inline(elem))
+		}
+		if i < len(list.Elements)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(strings.Repeat(" ", level*f.opts.Indent))
+	b.WriteString("]")
+	return b.String()
+}
+
+// containsList 检查 Term 中是否包含非空列表
+func containsList(term Term) bool {
+	switch t := term.(type) {
+	case List:
+		return len(t.Elements) > 0
+	case Tuple:
+		for _, elem := range t.Elements {
+			if containsList(elem) {
+				return true
+			}
+		}
+	case Map:
+		for _, pair := range t.Pairs {
+			if containsList(pair.Key) || containsList(pair.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -21,6 +21,10 @@ func (m MockTerm) Compare(other Term) bool {
 	return false
 }
 
+func (m MockTerm) Kind() TermKind {
+	return InvalidKind
+}
+
 // TestFormat tests the formatting of Erlang terms
 func TestFormat(t *testing.T) {
 	// Test simple formatting
@@ -269,6 +273,9 @@ func TestFormatEquivalence(t *testing.T) {
 
 		// Case with quoted atoms and strings
 		`{'complex-name', "value with \"quotes\""}. {empty_tuple, {}}.`,
+
+		// Case with escapes and non-ASCII text in strings
+		`{msg, "line1\nline2\ttab \\ back \"q\" caf\x{e9} 中文"}.`,
 	}
 
 	for _, tc := range testCases {
@@ -425,3 +432,40 @@ func TestIsSimpleTermEdgeCases(t *testing.T) {
 		})
 	}
 }
+
+// TestFormatMap tests formatting of map and binary terms
+func TestFormatMap(t *testing.T) {
+	input := `[{elvis, [{config, [#{dirs => ["src"], filter => "*.erl", rules => [{elvis_style, line_length, #{limit => 100}}]}, #{}]}]}].`
+	expected := `[
+  {elvis, [
+      {config, [
+          #{
+            dirs => ["src"],
+            filter => "*.erl",
+            rules => [
+              {elvis_style, line_length, #{limit => 100}}
+            ]
+          },
+          #{}
+        ]}
+    ]}
+].
+`
+	config, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Failed to parse input: %v", err)
+	}
+
+	formatted := config.Format(2)
+	if formatted != expected {
+		t.Errorf("Format mismatch:\nExpected:\n%s\nGot:\n%s", expected, formatted)
+	}
+
+	reParsedConfig, err := Parse(formatted)
+	if err != nil {
+		t.Fatalf("Failed to re-parse formatted output: %v", err)
+	}
+	if !compareConfigs(config, reParsedConfig) {
+		t.Errorf("Re-parsed config structure differs from original")
+	}
+}
@@ -4,7 +4,9 @@ package parser
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // Format 返回配置的格式化字符串表示
@@ -33,17 +35,28 @@ import (
 //	  {jsx, "3.1.0"}
 //	]}.
 func (c *RebarConfig) Format(indent int) string {
-	var result strings.Builder
+	return formatter{opts: FormatOptions{Indent: indent}}.terms(c.Terms)
+}
+
+// formatter 按格式化选项生成配置文本
+type formatter struct {
+	opts FormatOptions
+	// expand 为 true 时所有非空列表都分行书写，用于 LayoutExpanded
+	expand bool
+	// grouped[i] 为 true 时第 i 个顶级项与前一项之间不空行，用于 BlankLinesPreserve
+	grouped []bool
+}
 
-	for i, term := range c.Terms {
-		result.WriteString(formatTerm(term, 0, indent))
-		result.WriteString(".")
+// terms 格式化顶级项列表，项之间按 BlankLines 选项分隔
+func (f formatter) terms(terms []Term) string {
+	var result strings.Builder
 
-		if i < len(c.Terms)-1 {
-			result.WriteString("\n\n")
-		} else {
+	for i, term := range terms {
+		if i > 0 && f.opts.BlankLines != BlankLinesNone && !(i < len(f.grouped) && f.grouped[i]) {
 			result.WriteString("\n")
 		}
+		result.WriteString(f.entry(term))
+		result.WriteString(".\n")
 	}
 
 	return result.String()
@@ -61,23 +74,38 @@ func (c *RebarConfig) Format(indent int) string {
 //
 // 递归处理复杂的嵌套结构，对不同类型的 Term 应用不同的格式化规则
 func formatTerm(term Term, level, spaces int) string {
+	return formatter{opts: FormatOptions{Indent: spaces}}.term(term, level)
+}
+
+// term 按选项格式化单个 Term，level 是当前缩进级别
+func (f formatter) term(term Term, level int) string {
+	spaces := f.opts.Indent
 	indent := strings.Repeat(" ", level*spaces)
 
 	switch t := term.(type) {
 	case Atom:
-		if t.IsQuoted {
-			return "'" + t.Value + "'"
-		}
-		return t.Value
+		return t.String()
 
 	case String:
-		return fmt.Sprintf("%q", t.Value)
+		if text, ok := f.literal(t); ok {
+			return text
+		}
+		return t.String()
 
 	case Integer:
+		if text, ok := f.literal(t); ok {
+			return text
+		}
 		return fmt.Sprintf("%d", t.Value)
 
 	case Float:
-		return fmt.Sprintf("%g", t.Value)
+		if text, ok := f.literal(t); ok {
+			return text
+		}
+		return t.String()
+
+	case Binary:
+		return t.String()
 
 	case Tuple:
 		if len(t.Elements) == 0 {
@@ -88,10 +116,10 @@ func formatTerm(term Term, level, spaces int) string {
 		if len(t.Elements) >= 2 {
 			if atom, ok := t.Elements[0].(Atom); ok {
 				// 对于 {key, value} 形式的简单元组
-				if isSimpleTerm(t.Elements[1]) {
+				if f.simple(t.Elements[1]) && !(f.expand && containsList(t)) {
 					elems := make([]string, len(t.Elements))
 					for i, e := range t.Elements {
-						elems[i] = formatTerm(e, 0, spaces)
+						elems[i] = f.term(e, 0)
 					}
 					return "{" + strings.Join(elems, ", ") + "}"
 				}
@@ -107,7 +135,7 @@ func formatTerm(term Term, level, spaces int) string {
 						result.WriteString(", ")
 					}
 					// 对其余元素使用增加的缩进级别
-					result.WriteString(formatTerm(t.Elements[i], level+1, spaces))
+					result.WriteString(f.term(t.Elements[i], level+1))
 				}
 
 				result.WriteString("}")
@@ -122,7 +150,7 @@ func formatTerm(term Term, level, spaces int) string {
 		innerIndent := strings.Repeat(" ", (level+1)*spaces)
 		for i, elem := range t.Elements {
 			result.WriteString(innerIndent)
-			result.WriteString(formatTerm(elem, level+1, spaces))
+			result.WriteString(f.term(elem, level+1))
 
 			if i < len(t.Elements)-1 {
 				result.WriteString(",\n")
@@ -141,10 +169,10 @@ func formatTerm(term Term, level, spaces int) string {
 		}
 
 		// 对于只包含简单项的短列表，保持在一行
-		if len(t.Elements) <= 3 && allSimpleTerms(t.Elements) {
+		if !f.expand && len(t.Elements) <= 3 && allSimpleTerms(t.Elements) {
 			elems := make([]string, len(t.Elements))
 			for i, e := range t.Elements {
-				elems[i] = formatTerm(e, 0, spaces)
+				elems[i] = f.term(e, 0)
 			}
 			return "[" + strings.Join(elems, ", ") + "]"
 		}
@@ -153,10 +181,18 @@ func formatTerm(term Term, level, spaces int) string {
 		var result strings.Builder
 		result.WriteString("[\n")
 
+		var widths []int
+		if f.opts.Align {
+			widths = keyWidths(t.Elements)
+		}
 		innerIndent := strings.Repeat(" ", (level+1)*spaces)
 		for i, elem := range t.Elements {
 			result.WriteString(innerIndent)
-			result.WriteString(formatTerm(elem, level+1, spaces))
+			if widths != nil && widths[i] > 0 {
+				result.WriteString(f.alignedPair(elem.(Tuple), level+1, widths[i]))
+			} else {
+				result.WriteString(f.term(elem, level+1))
+			}
 
 			if i < len(t.Elements)-1 {
 				result.WriteString(",\n")
@@ -169,17 +205,122 @@ func formatTerm(term Term, level, spaces int) string {
 		result.WriteString("]")
 		return result.String()
 
+	case Map:
+		if len(t.Pairs) == 0 {
+			return "#{}"
+		}
+
+		// 对于只包含简单项的小映射，保持在一行
+		if f.simple(t) {
+			pairs := make([]string, len(t.Pairs))
+			for i, pair := range t.Pairs {
+				pairs[i] = f.term(pair.Key, 0) + " => " + f.term(pair.Value, 0)
+			}
+			return "#{" + strings.Join(pairs, ", ") + "}"
+		}
+
+		var result strings.Builder
+		result.WriteString("#{\n")
+
+		innerIndent := strings.Repeat(" ", (level+1)*spaces)
+		for i, pair := range t.Pairs {
+			result.WriteString(innerIndent)
+			result.WriteString(f.term(pair.Key, level+1))
+			result.WriteString(" => ")
+			result.WriteString(f.term(pair.Value, level+1))
+
+			if i < len(t.Pairs)-1 {
+				result.WriteString(",\n")
+			} else {
+				result.WriteString("\n")
+			}
+		}
+
+		result.WriteString(indent)
+		result.WriteString("}")
+		return result.String()
+
 	default:
 		return "UNKNOWN_TERM"
 	}
 }
 
+// keyWidths 返回分行列表中每个 {key, value} 元素对齐时键的宽度
+// @pkg 连续的 {key, value} 元素（键为原子）构成一组，组内的宽度为最长的键的长度；
+// 其他元素的宽度为 0，并打断分组
+func keyWidths(elements []Term) []int {
+	widths := make([]int, len(elements))
+	start := 0
+	for i := 0; i <= len(elements); i++ {
+		if i < len(elements) && pairKey(elements[i]) != "" {
+			continue
+		}
+		width := 0
+		for _, elem := range elements[start:i] {
+			if n := utf8.RuneCountInString(pairKey(elem)); n > width {
+				width = n
+			}
+		}
+		for j := start; j < i; j++ {
+			widths[j] = width
+		}
+		start = i + 1
+	}
+	return widths
+}
+
+// pairKey 返回 {key, value} 形式元组的键的文本，不是这种形式时返回空字符串
+func pairKey(term Term) string {
+	tuple, ok := term.(Tuple)
+	if !ok || len(tuple.Elements) != 2 {
+		return ""
+	}
+	if key, ok := tuple.Elements[0].(Atom); ok {
+		return key.String()
+	}
+	return ""
+}
+
+// alignedPair 格式化 {key, value} 元组，在逗号后补充空格使值从 width 之后的同一列开始
+func (f formatter) alignedPair(t Tuple, level, width int) string {
+	key := t.Elements[0].(Atom).String()
+	padding := strings.Repeat(" ", width-utf8.RuneCountInString(key))
+	return "{" + key + ", " + padding + f.term(t.Elements[1], level+1) + "}"
+}
+
+// literal 返回启用 Literals 选项时数字或字符串记录的源码写法
+// @pkg 没有记录写法，或写法与当前的值不一致（如解析后修改了 Value）时返回 false，改用规范写法
+func (f formatter) literal(term Term) (string, bool) {
+	if !f.opts.Literals {
+		return "", false
+	}
+	switch t := term.(type) {
+	case String:
+		n := len(t.Text)
+		ok := n >= 2 && t.Text[0] == '"' && t.Text[n-1] == '"' && processEscapes(t.Text[1:n-1]) == t.Value
+		return t.Text, ok
+	case Integer:
+		n, err := strconv.ParseInt(t.Text, 10, 64)
+		return t.Text, err == nil && n == t.Value
+	case Float:
+		v, err := strconv.ParseFloat(t.Text, 64)
+		return t.Text, err == nil && v == t.Value
+	}
+	return "", false
+}
+
+// simple 检查 Term 是否可以格式化在单行上；expand 模式下包含非空列表的项不是简单的
+func (f formatter) simple(term Term) bool {
+	return isSimpleTerm(term) && !(f.expand && containsList(term))
+}
+
 // isSimpleTerm 检查一个 Term 是否是"简单的"（可以格式化在单行上）
 // @pkg 判断一个 Term 是否足够简单可以在一行内显示
 // 简单 Term 包括：
-// - 原子、字符串、整数、浮点数
+// - 原子、字符串、二进制、整数、浮点数
 // - 元素数量少且所有元素都是简单 Term 的列表
 // - 元素数量少且所有元素都是简单 Term 的元组
+// - 空映射，或只有一个键值对且键和值都是简单 Term 的映射
 // 输入:
 //   - term: 要检查的 Term
 //
@@ -187,12 +328,14 @@ func formatTerm(term Term, level, spaces int) string {
 //   - bool: 如果是简单 Term 返回 true，否则返回 false
 func isSimpleTerm(term Term) bool {
 	switch t := term.(type) {
-	case Atom, String, Integer, Float:
+	case Atom, String, Binary, Integer, Float:
 		return true
 	case List:
 		return len(t.Elements) <= 3 && allSimpleTerms(t.Elements)
 	case Tuple:
 		return len(t.Elements) <= 2 && allSimpleTerms(t.Elements)
+	case Map:
+		return len(t.Pairs) == 0 || len(t.Pairs) == 1 && allSimpleTerms([]Term{t.Pairs[0].Key, t.Pairs[0].Value})
 	default:
 		return false
 	}
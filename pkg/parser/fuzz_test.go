@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// addCorpus seeds a fuzz target with the snippets in testdata/corpus
+func addCorpus(f *testing.F) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "corpus", "*.config"))
+	if err != nil {
+		f.Fatalf("Failed to list corpus: %v", err)
+	}
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatalf("Failed to read corpus file: %v", err)
+		}
+		f.Add(string(content))
+	}
+
+	for _, seed := range []string{
+		"",
+		"{a, b}.",
+		`{s, "esc\"aped\\\n"}.`,
+		"{'quoted atom', 'it\\'s'}.",
+		"{nums, [0, -1, 1.5, -2.5e-3, 1e10]}.",
+		"[[[[[[[[[[]]]]]]]]]].",
+		"{unterminated, \"",
+		"\x00\xff{.",
+	} {
+		f.Add(seed)
+	}
+}
+
+// FuzzParse checks that the parser never panics or hangs on arbitrary input
+func FuzzParse(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, input string) {
+		config, err := Parse(input)
+		if err != nil {
+			if !strings.Contains(err.Error(), "syntax error") {
+				t.Fatalf("Unexpected error kind: %v", err)
+			}
+			return
+		}
+
+		streamed, err := ParseReader(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("ParseReader failed on input accepted by Parse: %v", err)
+		}
+		if !compareConfigs(config, streamed) {
+			t.Fatalf("ParseReader disagrees with Parse")
+		}
+	})
+}
+
+// FuzzFormatRoundTrip checks that formatted output parses back to the same terms
+func FuzzFormatRoundTrip(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, input string) {
+		config, err := Parse(input)
+		if err != nil {
+			return
+		}
+
+		formatted := config.Format(2)
+		reparsed, err := Parse(formatted)
+		if err != nil {
+			t.Fatalf("Formatted output does not parse: %v\n%s", err, formatted)
+		}
+		if !compareConfigs(config, reparsed) {
+			t.Fatalf("Round trip changed terms:\ninput:  %q\noutput: %q", input, formatted)
+		}
+	})
+}
+
+// FuzzFormatIdempotent checks that formatting its own output changes nothing
+func FuzzFormatIdempotent(f *testing.F) {
+	addCorpus(f)
+	f.Fuzz(func(t *testing.T, input string) {
+		config, err := Parse(input)
+		if err != nil {
+			return
+		}
+
+		for _, opts := range []FormatOptions{{Indent: 2}, {Indent: 4, Align: true, SortKeys: true}} {
+			first := config.FormatWith(opts)
+			reparsed, err := Parse(first)
+			if err != nil {
+				t.Fatalf("Formatted output does not parse: %v\n%s", err, first)
+			}
+			if second := reparsed.FormatWith(opts); second != first {
+				t.Fatalf("Formatting is not idempotent for %q:\nfirst:  %q\nsecond: %q", input, first, second)
+			}
+		}
+	})
+}
@@ -0,0 +1,167 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PathError 表示按路径取值失败
+type PathError struct {
+	// Path 是查找的路径，如 ["relx", "dev_mode"]
+	Path []string
+	// Term 是路径上的值；路径不存在时为 nil
+	Term Term
+	// Expected 是期望的类型，如 "boolean"；路径不存在时为空
+	Expected string
+}
+
+// Error 返回错误描述，如 "relx/dev_mode: not found"、"relx/dev_mode: expected boolean, got 42"
+func (e *PathError) Error() string {
+	path := strings.Join(e.Path, "/")
+	if e.Term == nil {
+		return path + ": not found"
+	}
+	return fmt.Sprintf("%s: expected %s, got %s", path, e.Expected, e.Term)
+}
+
+// GetAt 按路径查找配置中的值
+// @pkg 第一段是顶级配置项的键，之后每一段在上一个值中按键查找：值是属性列表时按 Proplist.Get 的规则查找
+// （{Key, Value} 取 Value，裸原子 Key 取 true，重复的键取第一个），值是映射时查找同名的原子键
+// 输入:
+//   - path: 路径，如 "relx", "dev_mode"
+//
+// 输出:
+//   - Term: 路径上的值
+//   - error: 路径不存在时为 *PathError
+//
+// 示例:
+//
+//	value, err := config.GetAt("profiles", "test", "erl_opts")
+func (c *RebarConfig) GetAt(path ...string) (Term, error) {
+	if len(path) == 0 {
+		return nil, &PathError{Path: path}
+	}
+	value, ok := c.Proplist().Get(path[0])
+	for _, key := range path[1:] {
+		if !ok {
+			break
+		}
+		switch v := value.(type) {
+		case List:
+			value, ok = Proplist{Elements: v.Elements}.Get(key)
+		case Map:
+			value, ok = v.Get(NewAtom(key))
+		default:
+			ok = false
+		}
+	}
+	if !ok {
+		return nil, &PathError{Path: path}
+	}
+	return value, nil
+}
+
+// GetStringAt 按路径获取字符串或二进制的值
+// @pkg 路径规则见 GetAt
+// 输入:
+//   - path: 路径，如 "minimum_otp_vsn"
+//
+// 输出:
+//   - string: 字符串内容
+//   - error: 路径不存在或值不是字符串或二进制时为 *PathError
+//
+// 示例:
+//
+//	vsn, err := config.GetStringAt("minimum_otp_vsn")
+func (c *RebarConfig) GetStringAt(path ...string) (string, error) {
+	value, err := c.GetAt(path...)
+	if err != nil {
+		return "", err
+	}
+	if text, ok := versionText(value); ok {
+		return text, nil
+	}
+	return "", &PathError{Path: path, Term: value, Expected: "string"}
+}
+
+// GetAtomAt 按路径获取原子的值
+// @pkg 路径规则见 GetAt
+// 输入:
+//   - path: 路径，如 "relx", "mode"
+//
+// 输出:
+//   - string: 原子名称
+//   - error: 路径不存在或值不是原子时为 *PathError
+func (c *RebarConfig) GetAtomAt(path ...string) (string, error) {
+	value, err := c.GetAt(path...)
+	if err != nil {
+		return "", err
+	}
+	if atom, ok := value.(Atom); ok {
+		return atom.Value, nil
+	}
+	return "", &PathError{Path: path, Term: value, Expected: "atom"}
+}
+
+// GetBoolAt 按路径获取布尔值
+// @pkg 路径规则见 GetAt；值必须是原子 true 或 false，列表中的裸原子标志视为 true
+// 输入:
+//   - path: 路径，如 "relx", "dev_mode"
+//
+// 输出:
+//   - bool: 布尔值
+//   - error: 路径不存在或值不是 true、false 时为 *PathError
+//
+// 示例:
+//
+//	devMode, err := config.GetBoolAt("relx", "dev_mode")
+func (c *RebarConfig) GetBoolAt(path ...string) (bool, error) {
+	value, err := c.GetAt(path...)
+	if err != nil {
+		return false, err
+	}
+	if atom, ok := value.(Atom); ok && (atom.Value == "true" || atom.Value == "false") {
+		return atom.Value == "true", nil
+	}
+	return false, &PathError{Path: path, Term: value, Expected: "boolean"}
+}
+
+// GetIntAt 按路径获取整数的值
+// @pkg 路径规则见 GetAt
+// 输入:
+//   - path: 路径，如 "cover_opts", "min_coverage"
+//
+// 输出:
+//   - int64: 整数值
+//   - error: 路径不存在或值不是整数时为 *PathError
+func (c *RebarConfig) GetIntAt(path ...string) (int64, error) {
+	value, err := c.GetAt(path...)
+	if err != nil {
+		return 0, err
+	}
+	if n, ok := value.(Integer); ok {
+		return n.Value, nil
+	}
+	return 0, &PathError{Path: path, Term: value, Expected: "integer"}
+}
+
+// GetListAt 按路径获取列表的元素
+// @pkg 路径规则见 GetAt
+// 输入:
+//   - path: 路径，如 "profiles", "test", "deps"
+//
+// 输出:
+//   - []Term: 列表元素
+//   - error: 路径不存在或值不是列表时为 *PathError
+func (c *RebarConfig) GetListAt(path ...string) ([]Term, error) {
+	value, err := c.GetAt(path...)
+	if err != nil {
+		return nil, err
+	}
+	if list, ok := value.(List); ok {
+		return list.Elements, nil
+	}
+	return nil, &PathError{Path: path, Term: value, Expected: "list"}
+}
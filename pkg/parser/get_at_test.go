@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+)
+
+const getAtConfig = `{minimum_otp_vsn, "25"}.
+{relx, [{release, {app, "0.1.0"}, [app]}, {dev_mode, true}, {mode, dev}, include_erts]}.
+{cover_opts, [{min_coverage, 80}]}.
+{profiles, [{test, [{deps, [meck]}, {erl_opts, [nowarn_export_all]}]}]}.
+{elvis, #{config => [], output_format => plain}}.
+`
+
+// TestGetAt tests typed lookups along a path
+func TestGetAt(t *testing.T) {
+	config, err := Parse(getAtConfig)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	if vsn, err := config.GetStringAt("minimum_otp_vsn"); err != nil || vsn != "25" {
+		t.Errorf("GetStringAt: got %q, %v", vsn, err)
+	}
+	if mode, err := config.GetAtomAt("relx", "mode"); err != nil || mode != "dev" {
+		t.Errorf("GetAtomAt: got %q, %v", mode, err)
+	}
+	if devMode, err := config.GetBoolAt("relx", "dev_mode"); err != nil || !devMode {
+		t.Errorf("GetBoolAt: got %v, %v", devMode, err)
+	}
+	if erts, err := config.GetBoolAt("relx", "include_erts"); err != nil || !erts {
+		t.Errorf("GetBoolAt flag: got %v, %v", erts, err)
+	}
+	if coverage, err := config.GetIntAt("cover_opts", "min_coverage"); err != nil || coverage != 80 {
+		t.Errorf("GetIntAt: got %d, %v", coverage, err)
+	}
+	if deps, err := config.GetListAt("profiles", "test", "deps"); err != nil || len(deps) != 1 {
+		t.Errorf("GetListAt: got %v, %v", deps, err)
+	}
+	if format, err := config.GetAtomAt("elvis", "output_format"); err != nil || format != "plain" {
+		t.Errorf("GetAtomAt in map: got %q, %v", format, err)
+	}
+}
+
+// TestGetAtErrors tests the descriptive errors for missing paths and wrong types
+func TestGetAtErrors(t *testing.T) {
+	config, err := Parse(getAtConfig)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		get      func() error
+		expected string
+	}{
+		{"missing key", func() error { _, err := config.GetBoolAt("relx", "missing"); return err }, "relx/missing: not found"},
+		{"missing top-level", func() error { _, err := config.GetAt("nothing"); return err }, "nothing: not found"},
+		{"through scalar", func() error { _, err := config.GetAt("minimum_otp_vsn", "x"); return err }, "minimum_otp_vsn/x: not found"},
+		{"wrong bool", func() error { _, err := config.GetBoolAt("relx", "mode"); return err }, "relx/mode: expected boolean, got dev"},
+		{"wrong int", func() error { _, err := config.GetIntAt("minimum_otp_vsn"); return err }, `minimum_otp_vsn: expected integer, got "25"`},
+		{"wrong string", func() error { _, err := config.GetStringAt("cover_opts", "min_coverage"); return err }, "cover_opts/min_coverage: expected string, got 80"},
+		{"wrong list", func() error { _, err := config.GetListAt("relx", "dev_mode"); return err }, "relx/dev_mode: expected list, got true"},
+		{"wrong atom", func() error { _, err := config.GetAtomAt("relx", "release"); return err }, "relx/release: not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.get()
+			var pathErr *PathError
+			if !errors.As(err, &pathErr) {
+				t.Fatalf("Expected *PathError, got %v", err)
+			}
+			if err.Error() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, err.Error())
+			}
+		})
+	}
+}
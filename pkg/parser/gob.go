@@ -0,0 +1,346 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"math"
+)
+
+// 二进制编码
+// @pkg 各 Term 类型实现了 gob.GobEncoder 和 gob.GobDecoder，使用紧凑的无损二进制格式（保留原子的 IsQuoted 和 KeepLiterals 记录的 Text），
+// 并在包初始化时通过 gob.Register 注册，因此 RebarConfig 和 []Term 可以直接用 encoding/gob 编码，
+// 用于把解析结果缓存到磁盘或在进程间传递而无需重新解析:
+//
+//	var buf bytes.Buffer
+//	err := gob.NewEncoder(&buf).Encode(config)
+//	...
+//	var cached parser.RebarConfig
+//	err = gob.NewDecoder(&buf).Decode(&cached)
+//
+// 格式: 每个项以一个类型字节开头，长度和整数使用 varint，浮点数使用 8 字节大端序 IEEE 754；
+// 记录了 Text 的字符串和数字使用大写的类型字节，并在值之后追加带长度前缀的 Text
+
+// 二进制格式的类型字节
+const (
+	binAtom       = 'a'
+	binQuotedAtom = 'q'
+	binString     = 's'
+	binBinary     = 'b'
+	binInteger    = 'i'
+	binFloat      = 'f'
+	binTuple      = 't'
+	binList       = 'l'
+	binMap        = 'm'
+
+	// 带 Text 的字面量
+	binStringText  = 'S'
+	binIntegerText = 'I'
+	binFloatText   = 'F'
+)
+
+func init() {
+	gob.Register(Atom{})
+	gob.Register(String{})
+	gob.Register(Integer{})
+	gob.Register(Float{})
+	gob.Register(Binary{})
+	gob.Register(Tuple{})
+	gob.Register(List{})
+	gob.Register(Map{})
+}
+
+// GobEncode 实现 gob.GobEncoder
+func (a Atom) GobEncode() ([]byte, error) { return appendBinaryTerm(nil, a), nil }
+
+// GobEncode 实现 gob.GobEncoder
+func (s String) GobEncode() ([]byte, error) { return appendBinaryTerm(nil, s), nil }
+
+// GobEncode 实现 gob.GobEncoder
+func (i Integer) GobEncode() ([]byte, error) { return appendBinaryTerm(nil, i), nil }
+
+// GobEncode 实现 gob.GobEncoder
+func (f Float) GobEncode() ([]byte, error) { return appendBinaryTerm(nil, f), nil }
+
+// GobEncode 实现 gob.GobEncoder
+func (b Binary) GobEncode() ([]byte, error) { return appendBinaryTerm(nil, b), nil }
+
+// GobEncode 实现 gob.GobEncoder
+func (t Tuple) GobEncode() ([]byte, error) { return appendBinaryTerm(nil, t), nil }
+
+// GobEncode 实现 gob.GobEncoder
+func (l List) GobEncode() ([]byte, error) { return appendBinaryTerm(nil, l), nil }
+
+// GobEncode 实现 gob.GobEncoder
+func (m Map) GobEncode() ([]byte, error) { return appendBinaryTerm(nil, m), nil }
+
+// GobDecode 实现 gob.GobDecoder
+func (a *Atom) GobDecode(data []byte) error { return decodeBinaryInto(data, a) }
+
+// GobDecode 实现 gob.GobDecoder
+func (s *String) GobDecode(data []byte) error { return decodeBinaryInto(data, s) }
+
+// GobDecode 实现 gob.GobDecoder
+func (i *Integer) GobDecode(data []byte) error { return decodeBinaryInto(data, i) }
+
+// GobDecode 实现 gob.GobDecoder
+func (f *Float) GobDecode(data []byte) error { return decodeBinaryInto(data, f) }
+
+// GobDecode 实现 gob.GobDecoder
+func (b *Binary) GobDecode(data []byte) error { return decodeBinaryInto(data, b) }
+
+// GobDecode 实现 gob.GobDecoder
+func (t *Tuple) GobDecode(data []byte) error { return decodeBinaryInto(data, t) }
+
+// GobDecode 实现 gob.GobDecoder
+func (l *List) GobDecode(data []byte) error { return decodeBinaryInto(data, l) }
+
+// GobDecode 实现 gob.GobDecoder
+func (m *Map) GobDecode(data []byte) error { return decodeBinaryInto(data, m) }
+
+// appendBinaryTerm 将项的二进制编码追加到 buf
+func appendBinaryTerm(buf []byte, term Term) []byte {
+	switch t := term.(type) {
+	case Atom:
+		if t.IsQuoted {
+			buf = append(buf, binQuotedAtom)
+		} else {
+			buf = append(buf, binAtom)
+		}
+		return appendBinaryText(buf, t.Value)
+	case String:
+		if t.Text != "" {
+			return appendBinaryText(appendBinaryText(append(buf, binStringText), t.Value), t.Text)
+		}
+		return appendBinaryText(append(buf, binString), t.Value)
+	case Binary:
+		return appendBinaryText(append(buf, binBinary), t.Value)
+	case Integer:
+		if t.Text != "" {
+			return appendBinaryText(appendVarint(append(buf, binIntegerText), t.Value), t.Text)
+		}
+		return appendVarint(append(buf, binInteger), t.Value)
+	case Float:
+		if t.Text != "" {
+			return appendBinaryText(appendUint64(append(buf, binFloatText), math.Float64bits(t.Value)), t.Text)
+		}
+		return appendUint64(append(buf, binFloat), math.Float64bits(t.Value))
+	case Tuple:
+		return appendBinaryTerms(append(buf, binTuple), t.Elements)
+	case List:
+		return appendBinaryTerms(append(buf, binList), t.Elements)
+	case Map:
+		buf = appendUvarint(append(buf, binMap), uint64(len(t.Pairs)))
+		for _, pair := range t.Pairs {
+			buf = appendBinaryTerm(buf, pair.Key)
+			buf = appendBinaryTerm(buf, pair.Value)
+		}
+		return buf
+	}
+	// 其他 Term 实现按其 Erlang 文本编码为二进制
+	return appendBinaryText(append(buf, binBinary), term.String())
+}
+
+// appendBinaryText 追加带长度前缀的文本
+func appendBinaryText(buf []byte, s string) []byte {
+	return append(appendUvarint(buf, uint64(len(s))), s...)
+}
+
+// appendUvarint 追加无符号 varint
+func appendUvarint(buf []byte, v uint64) []byte {
+	var b [binary.MaxVarintLen64]byte
+	return append(buf, b[:binary.PutUvarint(b[:], v)]...)
+}
+
+// appendVarint 追加有符号 varint
+func appendVarint(buf []byte, v int64) []byte {
+	var b [binary.MaxVarintLen64]byte
+	return append(buf, b[:binary.PutVarint(b[:], v)]...)
+}
+
+// appendUint64 追加 8 字节大端序整数
+func appendUint64(buf []byte, v uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	return append(buf, b[:]...)
+}
+
+// appendBinaryTerms 追加带数量前缀的项列表
+func appendBinaryTerms(buf []byte, terms []Term) []byte {
+	buf = appendUvarint(buf, uint64(len(terms)))
+	for _, term := range terms {
+		buf = appendBinaryTerm(buf, term)
+	}
+	return buf
+}
+
+// decodeBinaryInto 解码二进制编码并写入 target 指向的同类型变量
+func decodeBinaryInto(data []byte, target interface{}) error {
+	d := &binaryDecoder{data: data}
+	term, err := d.decode()
+	if err != nil {
+		return err
+	}
+	if d.pos != len(data) {
+		return fmt.Errorf("invalid binary term: %d trailing bytes", len(data)-d.pos)
+	}
+	return assignTerm(term, target)
+}
+
+// binaryDecoder 保存解码位置
+type binaryDecoder struct {
+	data []byte
+	pos  int
+	// depth 是当前元组、列表和映射的嵌套深度，上限与文本解析相同（maxNestingDepth）
+	depth int
+}
+
+// errTruncated 返回数据不完整的错误
+func (d *binaryDecoder) errTruncated() error {
+	return fmt.Errorf("invalid binary term: unexpected end of data at offset %d", d.pos)
+}
+
+// uvarint 读取无符号 varint
+func (d *binaryDecoder) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(d.data[d.pos:])
+	if n <= 0 {
+		return 0, d.errTruncated()
+	}
+	d.pos += n
+	return v, nil
+}
+
+// count 读取元素数量，数量不能超过剩余字节数（每个元素至少占一个字节）
+func (d *binaryDecoder) count(per int) (int, error) {
+	n, err := d.uvarint()
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64((len(d.data)-d.pos)/per) {
+		return 0, d.errTruncated()
+	}
+	return int(n), nil
+}
+
+// text 读取带长度前缀的文本
+func (d *binaryDecoder) text() (string, error) {
+	n, err := d.count(1)
+	if err != nil {
+		return "", err
+	}
+	s := string(d.data[d.pos : d.pos+n])
+	d.pos += n
+	return s, nil
+}
+
+// literalText 读取带 Text 的类型字节之后的 Text，其他类型字节返回空字符串
+func (d *binaryDecoder) literalText(tag byte) (string, error) {
+	if tag != binStringText && tag != binIntegerText && tag != binFloatText {
+		return "", nil
+	}
+	return d.text()
+}
+
+// terms 读取带数量前缀的项列表
+func (d *binaryDecoder) terms() ([]Term, error) {
+	n, err := d.count(1)
+	if err != nil {
+		return nil, err
+	}
+	terms := make([]Term, n)
+	for i := range terms {
+		if terms[i], err = d.decode(); err != nil {
+			return nil, err
+		}
+	}
+	return terms, nil
+}
+
+// decode 解码一个项
+func (d *binaryDecoder) decode() (Term, error) {
+	if d.pos >= len(d.data) {
+		return nil, d.errTruncated()
+	}
+	tag := d.data[d.pos]
+	d.pos++
+
+	if tag == binTuple || tag == binList || tag == binMap {
+		if d.depth >= maxNestingDepth {
+			return nil, fmt.Errorf("invalid binary term: nesting too deep at offset %d", d.pos-1)
+		}
+		d.depth++
+		defer func() { d.depth-- }()
+	}
+
+	switch tag {
+	case binAtom, binQuotedAtom, binString, binStringText, binBinary:
+		s, err := d.text()
+		if err != nil {
+			return nil, err
+		}
+		switch tag {
+		case binAtom, binQuotedAtom:
+			return Atom{Value: s, IsQuoted: tag == binQuotedAtom}, nil
+		case binString, binStringText:
+			text, err := d.literalText(tag)
+			if err != nil {
+				return nil, err
+			}
+			return String{Value: s, Text: text}, nil
+		}
+		return Binary{Value: s}, nil
+
+	case binInteger, binIntegerText:
+		v, n := binary.Varint(d.data[d.pos:])
+		if n <= 0 {
+			return nil, d.errTruncated()
+		}
+		d.pos += n
+		text, err := d.literalText(tag)
+		if err != nil {
+			return nil, err
+		}
+		return Integer{Value: v, Text: text}, nil
+
+	case binFloat, binFloatText:
+		if d.pos+8 > len(d.data) {
+			return nil, d.errTruncated()
+		}
+		bits := binary.BigEndian.Uint64(d.data[d.pos:])
+		d.pos += 8
+		text, err := d.literalText(tag)
+		if err != nil {
+			return nil, err
+		}
+		return Float{Value: math.Float64frombits(bits), Text: text}, nil
+
+	case binTuple, binList:
+		elements, err := d.terms()
+		if err != nil {
+			return nil, err
+		}
+		if tag == binTuple {
+			return Tuple{Elements: elements}, nil
+		}
+		return List{Elements: elements}, nil
+
+	case binMap:
+		n, err := d.count(2)
+		if err != nil {
+			return nil, err
+		}
+		pairs := make([]MapPair, n)
+		for i := range pairs {
+			if pairs[i].Key, err = d.decode(); err != nil {
+				return nil, err
+			}
+			if pairs[i].Value, err = d.decode(); err != nil {
+				return nil, err
+			}
+		}
+		return Map{Pairs: pairs}, nil
+	}
+	return nil, fmt.Errorf("invalid binary term: unknown type byte %q at offset %d", tag, d.pos-1)
+}
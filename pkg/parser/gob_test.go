@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestGobRoundTrip tests that parsed configs survive a gob round trip
+func TestGobRoundTrip(t *testing.T) {
+	paths, err := filepath.Glob("testdata/corpus/*.config")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("Failed to list corpus: %v", err)
+	}
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read file: %v", err)
+			}
+			config, err := Parse(string(data))
+			if err != nil {
+				t.Fatalf("Failed to parse config: %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(config); err != nil {
+				t.Fatalf("Failed to encode config: %v", err)
+			}
+			var decoded RebarConfig
+			if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+				t.Fatalf("Failed to decode config: %v", err)
+			}
+
+			if decoded.Raw != config.Raw || len(decoded.Terms) != len(config.Terms) {
+				t.Fatalf("Decoded config differs from original")
+			}
+			for i := range config.Terms {
+				if decoded.Terms[i].String() != config.Terms[i].String() {
+					t.Errorf("Term %d: expected %s, got %s", i, config.Terms[i], decoded.Terms[i])
+				}
+			}
+		})
+	}
+}
+
+// TestGobTerms tests lossless encoding of every term type
+func TestGobTerms(t *testing.T) {
+	terms := []Term{
+		Atom{Value: "plain"},
+		Atom{Value: "plain", IsQuoted: true},
+		String{Value: "tab\there"},
+		Integer{Value: -1 << 62},
+		Float{Value: 2.0},
+		String{Value: "A", Text: `"\x41"`},
+		Integer{Value: 7, Text: "007"},
+		Float{Value: 2.1, Text: "2.10"},
+		Binary{Value: "\x00\xff"},
+		Tuple{Elements: []Term{}},
+		List{Elements: []Term{Atom{Value: "a"}, List{Elements: []Term{}}}},
+		Map{Pairs: []MapPair{{Key: Binary{Value: "k"}, Value: Float{Value: -0.5}}}},
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(terms); err != nil {
+		t.Fatalf("Failed to encode terms: %v", err)
+	}
+	var decoded []Term
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("Failed to decode terms: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, terms) {
+		t.Errorf("Expected %v, got %v", terms, decoded)
+	}
+}
+
+// TestGobDecodeErrors tests malformed binary terms
+func TestGobDecodeErrors(t *testing.T) {
+	tests := []struct {
+		data     []byte
+		target   interface{ GobDecode([]byte) error }
+		expected string
+	}{
+		{[]byte{}, &Atom{}, "unexpected end of data"},
+		{[]byte{'t', 5, 'i'}, &Tuple{}, "unexpected end of data"},
+		{[]byte{'a', 1, 'x', 'y'}, &Atom{}, "1 trailing bytes"},
+		{[]byte{'z'}, &Atom{}, "unknown type byte"},
+		{[]byte{'s', 1, 'x'}, &Atom{}, "cannot unmarshal String into *parser.Atom"},
+		{[]byte{'I', 14, 3, '0'}, &Integer{}, "unexpected end of data"},
+	}
+
+	for _, tt := range tests {
+		err := tt.target.GobDecode(tt.data)
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("GobDecode(%v): expected error containing %q, got %v", tt.data, tt.expected, err)
+		}
+	}
+}
+
+// TestGobKeepLiterals tests that source text recorded by KeepLiterals survives a gob round trip
+func TestGobKeepLiterals(t *testing.T) {
+	config, err := Parse(`{app, [{vsn, 2.10}, {retries, 007}, {name, "\x41bc"}]}.`, KeepLiterals())
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(config); err != nil {
+		t.Fatalf("Failed to encode config: %v", err)
+	}
+	var decoded RebarConfig
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("Failed to decode config: %v", err)
+	}
+
+	opts := FormatOptions{Indent: 2, Literals: true}
+	expected := config.FormatWith(opts)
+	if !strings.Contains(expected, "2.10") || !strings.Contains(expected, `"\x41bc"`) {
+		t.Fatalf("Expected literals in original output, got:\n%s", expected)
+	}
+	if got := decoded.FormatWith(opts); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+// TestGobDecodeNesting tests that deeply nested input is rejected instead of overflowing the stack
+func TestGobDecodeNesting(t *testing.T) {
+	var list List
+	err := list.GobDecode(bytes.Repeat([]byte("l\x01"), 1000000))
+	if err == nil || !strings.Contains(err.Error(), "nesting too deep") {
+		t.Errorf("Expected a nesting error, got %v", err)
+	}
+
+	// Nesting at the limit still decodes
+	ok := append(bytes.Repeat([]byte("l\x01"), maxNestingDepth), 'a', 1, 'x')
+	if err := list.GobDecode(ok); err != nil {
+		t.Errorf("Expected nesting at the limit to decode, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+)
+
+// 哈希中各类型的标记
+const (
+	hashAtom byte = iota + 1
+	hashString
+	hashInteger
+	hashFloat
+	hashBinary
+	hashTuple
+	hashList
+	hashMap
+)
+
+// Hash 返回项的 64 位哈希值
+// @pkg 与 Compare 一致：Compare 为 true 的两个项哈希值相同，因此可以用作映射的键（配合 Compare 处理碰撞）、去重或检测变化:
+// - 原子的引号标记和 KeepLiterals 记录的原始写法不参与计算
+// - 0.0 与 -0.0 的哈希值相同
+// - 映射与键值对的顺序无关
+//
+// 哈希值使用 FNV-1a 计算，在不同的运行和机器之间保持不变，可以持久化保存
+// 输入:
+//   - term: 要计算哈希的项
+//
+// 输出:
+//   - uint64: 哈希值
+//
+// 示例:
+//
+//	seen := make(map[uint64][]parser.Term)
+//	h := parser.Hash(dep)
+//	seen[h] = append(seen[h], dep)
+func Hash(term Term) uint64 {
+	h := fnv.New64a()
+	writeHash(h, term)
+	return h.Sum64()
+}
+
+// Hash 返回配置中全部顶级项按顺序计算的哈希值
+// @pkg 只与 Terms 有关，与注释、空白和 Raw 无关，可以快速判断两个配置是否有实质变化
+func (c *RebarConfig) Hash() uint64 {
+	return Hash(List{Elements: c.Terms})
+}
+
+// writeHash 将项的类型标记和内容写入哈希
+func writeHash(h hash.Hash64, term Term) {
+	var buf [9]byte
+	writeUint := func(tag byte, value uint64) {
+		buf[0] = tag
+		binary.BigEndian.PutUint64(buf[1:], value)
+		h.Write(buf[:])
+	}
+	writeText := func(tag byte, value string) {
+		writeUint(tag, uint64(len(value)))
+		h.Write([]byte(value))
+	}
+
+	switch t := term.(type) {
+	case Atom:
+		writeText(hashAtom, t.Value)
+	case String:
+		writeText(hashString, t.Value)
+	case Integer:
+		writeUint(hashInteger, uint64(t.Value))
+	case Float:
+		value := t.Value
+		if value == 0 {
+			// -0.0 与 0.0 相等，统一为 0.0
+			value = 0
+		}
+		writeUint(hashFloat, math.Float64bits(value))
+	case Binary:
+		writeText(hashBinary, t.Value)
+	case Tuple:
+		writeUint(hashTuple, uint64(len(t.Elements)))
+		for _, elem := range t.Elements {
+			writeHash(h, elem)
+		}
+	case List:
+		writeUint(hashList, uint64(len(t.Elements)))
+		for _, elem := range t.Elements {
+			writeHash(h, elem)
+		}
+	case Map:
+		// 各键值对的哈希值相加，结果与顺序无关；与 Compare 一致，重复的键只计最后一个
+		pairs := t.uniquePairs()
+		var sum uint64
+		for _, pair := range pairs {
+			sum += Hash(Tuple{Elements: []Term{pair.Key, pair.Value}})
+		}
+		writeUint(hashMap, uint64(len(pairs)))
+		writeUint(hashMap, sum)
+	default:
+		if term != nil {
+			writeText(0, term.String())
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"math"
+	"testing"
+)
+
+// TestHash tests that Hash is consistent with Compare
+func TestHash(t *testing.T) {
+	equal := []struct {
+		name string
+		a, b Term
+	}{
+		{"quoted atom", Atom{Value: "debug_info"}, Atom{Value: "debug_info", IsQuoted: true}},
+		{"literal text", Integer{Value: 7, Text: "007"}, Integer{Value: 7}},
+		{"negative zero", Float{Value: math.Copysign(0, -1)}, Float{Value: 0}},
+		{"map order", MustParseTerm(`#{a => 1, b => [x]}`), MustParseTerm(`#{b => [x], a => 1}`)},
+		{"duplicate map keys", MustParseTerm(`#{a => 1, a => 2}`), MustParseTerm(`#{a => 2, a => 2}`)},
+		{"nested", MustParseTerm(`{deps, [{cowboy, "2.9.0"}]}`), KV("deps", NewList(KV("cowboy", NewString("2.9.0"))))},
+	}
+	for _, tt := range equal {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.a.Compare(tt.b) {
+				t.Fatalf("Expected %s to equal %s", tt.a, tt.b)
+			}
+			if Hash(tt.a) != Hash(tt.b) {
+				t.Errorf("Expected equal hashes for %s and %s", tt.a, tt.b)
+			}
+		})
+	}
+
+	distinct := []Term{
+		NewAtom("a"), NewString("a"), NewBinary("a"), NewInteger(1), NewFloat(1),
+		NewTuple(), NewList(), NewMap(), NewList(NewAtom("a")), NewTuple(NewAtom("a")),
+		NewList(NewAtom("ab")), NewList(NewAtom("a"), NewAtom("b")), NewList(NewList(), NewList()),
+		NewList(NewList(NewList())), MustParseTerm(`#{a => b}`), MustParseTerm(`#{b => a}`),
+	}
+	seen := make(map[uint64]Term)
+	for _, term := range distinct {
+		h := Hash(term)
+		if other, ok := seen[h]; ok {
+			t.Errorf("Expected distinct hashes for %s and %s", term, other)
+		}
+		seen[h] = term
+	}
+
+	// The value is persisted by callers, so it must not change between releases
+	if got := Hash(MustParseTerm(`{deps, [{cowboy, "2.9.0"}, 1, 2.5, #{a => <<"b">>}]}`)); got != 7906169704482827847 {
+		t.Errorf("Expected hash 7906169704482827847, got %d", got)
+	}
+
+	a, _ := Parse("%% comment\n{deps, []}.")
+	b, _ := Parse("{deps,[]}.")
+	c, _ := Parse("{deps, [jsx]}.")
+	if a.Hash() != b.Hash() || a.Hash() == c.Hash() {
+		t.Errorf("Expected config hashes to depend only on terms")
+	}
+}
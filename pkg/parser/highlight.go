@@ -0,0 +1,57 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"html"
+	"strings"
+)
+
+// HighlightCSS 是 HighlightHTML 输出的默认样式表，可以直接放入 <style> 元素
+const HighlightCSS = `.rebar-config { background: #fafafa; color: #383a42; padding: 1em; overflow-x: auto; }
+.rebar-config .atom { color: #a626a4; }
+.rebar-config .string { color: #50a14f; }
+.rebar-config .number { color: #986801; }
+.rebar-config .comment { color: #a0a1a7; font-style: italic; }
+.rebar-config .variable { color: #e45649; }
+.rebar-config .punct { color: #383a42; }
+.rebar-config .invalid { color: #ffffff; background: #e45649; }
+`
+
+// HighlightHTML 将源码渲染为语法高亮的 HTML 片段
+// @pkg 基于 Tokenize 的结果，每个词法单元输出为带类别 CSS 类（atom、string、number、comment、variable、punct、invalid）的 span，
+// 空白原样输出；整体包裹在 class 为 rebar-config 的 pre 元素中，所有文本都经过转义。
+// 输入不需要是合法的配置，语法错误不影响渲染。默认样式见 HighlightCSS
+// 输入:
+//   - input: 源码
+//
+// 输出:
+//   - string: HTML 片段
+//
+// 示例:
+//
+//	data, _ := os.ReadFile("rebar.config")
+//	page := "<style>" + parser.HighlightCSS + "</style>" + parser.HighlightHTML(string(data))
+//
+// 数据样例:
+//
+//	{deps, []}. 渲染为
+//	<pre class="rebar-config"><code><span class="punct">{</span><span class="atom">deps</span>...</code></pre>
+func HighlightHTML(input string) string {
+	var b strings.Builder
+	b.Grow(len(input) * 3)
+	b.WriteString(`<pre class="rebar-config"><code>`)
+	for _, tok := range Tokenize(input) {
+		if tok.Kind == TokenWhitespace {
+			b.WriteString(tok.Text)
+			continue
+		}
+		b.WriteString(`<span class="`)
+		b.WriteString(tok.Kind.String())
+		b.WriteString(`">`)
+		b.WriteString(html.EscapeString(tok.Text))
+		b.WriteString(`</span>`)
+	}
+	b.WriteString("</code></pre>\n")
+	return b.String()
+}
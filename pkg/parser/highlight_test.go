@@ -0,0 +1,25 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestHighlightHTML tests span classes and escaping
+func TestHighlightHTML(t *testing.T) {
+	input := "%% <deps>\n{deps, [{cowboy, \"2.9 & up\"}, 1]}.\n"
+	expected := `<pre class="rebar-config"><code><span class="comment">%% &lt;deps&gt;</span>
+<span class="punct">{</span><span class="atom">deps</span><span class="punct">,</span> ` +
+		`<span class="punct">[</span><span class="punct">{</span><span class="atom">cowboy</span><span class="punct">,</span> ` +
+		`<span class="string">&#34;2.9 &amp; up&#34;</span><span class="punct">}</span><span class="punct">,</span> ` +
+		`<span class="number">1</span><span class="punct">]</span><span class="punct">}</span><span class="punct">.</span>
+</code></pre>
+`
+	if got := HighlightHTML(input); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+
+	if got := HighlightHTML(`<script>`); strings.Contains(got, "<script>") {
+		t.Errorf("Expected input to be escaped, got %s", got)
+	}
+}
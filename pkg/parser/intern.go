@@ -0,0 +1,81 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"strings"
+	"sync"
+)
+
+// AtomTable 是原子名称的驻留表
+// @pkg 同一个原子在多次解析中只保存一份字符串，解析大量配置时可以显著减少内存占用
+// AtomTable 可以安全地被多个 goroutine 并发使用，表中的字符串不会引用任何解析输入
+//
+// 示例:
+//
+//	table := parser.NewAtomTable()
+//	for _, path := range paths {
+//	  config, err := parser.ParseFile(path, parser.WithAtomTable(table))
+//	  ...
+//	}
+type AtomTable struct {
+	mu    sync.RWMutex
+	atoms map[string]string
+}
+
+// NewAtomTable 创建一个空的原子驻留表
+func NewAtomTable() *AtomTable {
+	return &AtomTable{atoms: make(map[string]string)}
+}
+
+// sharedAtoms 是 InternAtoms 选项使用的全局驻留表
+var sharedAtoms = NewAtomTable()
+
+// Intern 返回与 s 相等的驻留字符串
+// @pkg 首次出现的名称会被复制后加入表中，之后相同的名称都返回这份副本
+// 输入:
+//   - s: 原子名称
+//
+// 输出:
+//   - string: 驻留后的字符串
+func (t *AtomTable) Intern(s string) string {
+	t.mu.RLock()
+	interned, ok := t.atoms[s]
+	t.mu.RUnlock()
+	if ok {
+		return interned
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if interned, ok := t.atoms[s]; ok {
+		return interned
+	}
+	interned = strings.Clone(s)
+	t.atoms[interned] = interned
+	return interned
+}
+
+// Len 返回表中不同原子的数量
+func (t *AtomTable) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.atoms)
+}
+
+// InternAtoms 让解析器把原子名称驻留在包级别的共享表中
+// @pkg 所有使用此选项的解析共享同一张表，重复出现的原子（如 deps、git、tag）只保存一份。
+// 共享表只增不减，解析不受信任的输入时建议改用 WithAtomTable 并控制表的生命周期。
+func InternAtoms() ParseOption {
+	return WithAtomTable(sharedAtoms)
+}
+
+// WithAtomTable 让解析器把原子名称驻留在指定的表中
+// @pkg 适合按批次或按解析器管理驻留表，表不再使用时即可整体回收
+// 输入:
+//   - table: 原子驻留表，为 nil 时不驻留
+func WithAtomTable(table *AtomTable) ParseOption {
+	return func(o *parseOptions) {
+		o.atoms = table
+	}
+}
@@ -0,0 +1,70 @@
+package parser
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestAtomTable tests interning and sharing of atom names
+func TestAtomTable(t *testing.T) {
+	table := NewAtomTable()
+	input := "my_app my_app"
+	a := table.Intern(input[:6])
+	b := table.Intern(input[7:])
+	if a != "my_app" || stringData(a) != stringData(b) {
+		t.Error("Expected both lookups to return the same backing string")
+	}
+	if stringData(a) == stringData(input) {
+		t.Error("Expected interned string not to reference the input")
+	}
+	if table.Len() != 1 {
+		t.Errorf("Expected 1 atom, got %d", table.Len())
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			table.Intern("concurrent")
+		}()
+	}
+	wg.Wait()
+	if table.Len() != 2 {
+		t.Errorf("Expected 2 atoms, got %d", table.Len())
+	}
+}
+
+// TestParseWithAtomTable tests that repeated parses share atom strings
+func TestParseWithAtomTable(t *testing.T) {
+	table := NewAtomTable()
+	input := `{my_key, [custom_atom, 'quoted atom']}.`
+
+	c1, err := Parse(input, WithAtomTable(table))
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	c2, err := Parse(string([]byte(input)), WithAtomTable(table))
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	if !compareConfigs(c1, c2) {
+		t.Fatal("Expected equal configs")
+	}
+
+	atom1 := c1.Terms[0].(Tuple).Elements[1].(List).Elements[0].(Atom).Value
+	atom2 := c2.Terms[0].(Tuple).Elements[1].(List).Elements[0].(Atom).Value
+	if stringData(atom1) != stringData(atom2) {
+		t.Error("Expected atoms from both parses to share storage")
+	}
+	if table.Len() != 3 {
+		t.Errorf("Expected 3 interned atoms, got %d", table.Len())
+	}
+
+	if _, err := Parse(input, InternAtoms()); err != nil {
+		t.Fatalf("Failed to parse with shared table: %v", err)
+	}
+	if sharedAtoms.Len() < 3 {
+		t.Errorf("Expected shared table to hold the atoms, got %d", sharedAtoms.Len())
+	}
+}
@@ -0,0 +1,302 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// JSON 表示
+// @pkg 每个 Term 编码为带 type 字段的 JSON 对象，可以无损地还原:
+//
+//	{"type": "atom", "value": "debug_info"}
+//	{"type": "atom", "value": "my-dep", "quoted": true}
+//	{"type": "string", "value": "2.9.0"}
+//	{"type": "integer", "value": 42}
+//	{"type": "float", "value": 3.14}
+//	{"type": "float", "value": 2.1, "text": "2.10"}  // KeepLiterals 记录的源码写法
+//	{"type": "binary", "value": "text"}          // 合法的 UTF-8 内容
+//	{"type": "binary", "base64": "AAEC"}         // 其他字节
+//	{"type": "tuple", "elements": [...]}
+//	{"type": "list", "elements": [...]}
+//	{"type": "map", "pairs": [{"key": ..., "value": ...}]}
+//
+// RebarConfig 编码为 {"raw": "...", "terms": [...]}，Raw 为空时省略 raw 字段
+
+// jsonScalar 是标量项的 JSON 表示
+type jsonScalar struct {
+	Type   string      `json:"type"`
+	Value  interface{} `json:"value"`
+	Quoted bool        `json:"quoted,omitempty"`
+	Text   string      `json:"text,omitempty"`
+}
+
+// jsonContainer 是元组和列表的 JSON 表示
+type jsonContainer struct {
+	Type     string `json:"type"`
+	Elements []Term `json:"elements"`
+}
+
+// jsonPair 是映射键值对的 JSON 表示
+type jsonPair struct {
+	Key   Term `json:"key"`
+	Value Term `json:"value"`
+}
+
+// jsonMap 是映射的 JSON 表示
+type jsonMap struct {
+	Type  string     `json:"type"`
+	Pairs []jsonPair `json:"pairs"`
+}
+
+// jsonTerm 用于解码任意项
+type jsonTerm struct {
+	Type     string            `json:"type"`
+	Value    json.RawMessage   `json:"value"`
+	Quoted   bool              `json:"quoted"`
+	Text     string            `json:"text"`
+	Base64   *string           `json:"base64"`
+	Elements []json.RawMessage `json:"elements"`
+	Pairs    []struct {
+		Key   json.RawMessage `json:"key"`
+		Value json.RawMessage `json:"value"`
+	} `json:"pairs"`
+}
+
+// jsonConfig 是 RebarConfig 的 JSON 表示
+type jsonConfig struct {
+	Raw   string            `json:"raw,omitempty"`
+	Terms []json.RawMessage `json:"terms"`
+}
+
+// MarshalJSON 将原子编码为 {"type": "atom", "value": ...}
+func (a Atom) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonScalar{Type: "atom", Value: a.Value, Quoted: a.IsQuoted})
+}
+
+// MarshalJSON 将字符串编码为 {"type": "string", "value": ...}
+func (s String) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonScalar{Type: "string", Value: s.Value, Text: s.Text})
+}
+
+// MarshalJSON 将整数编码为 {"type": "integer", "value": ...}
+func (i Integer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonScalar{Type: "integer", Value: i.Value, Text: i.Text})
+}
+
+// MarshalJSON 将浮点数编码为 {"type": "float", "value": ...}
+func (f Float) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonScalar{Type: "float", Value: f.Value, Text: f.Text})
+}
+
+// MarshalJSON 将二进制编码为 {"type": "binary", "value": ...}
+// @pkg 内容不是合法的 UTF-8 时改用 base64 字段
+func (b Binary) MarshalJSON() ([]byte, error) {
+	if utf8.ValidString(b.Value) {
+		return json.Marshal(jsonScalar{Type: "binary", Value: b.Value})
+	}
+	return json.Marshal(struct {
+		Type   string `json:"type"`
+		Base64 string `json:"base64"`
+	}{"binary", base64.StdEncoding.EncodeToString([]byte(b.Value))})
+}
+
+// MarshalJSON 将元组编码为 {"type": "tuple", "elements": [...]}
+func (t Tuple) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonContainer{Type: "tuple", Elements: nonNil(t.Elements)})
+}
+
+// MarshalJSON 将列表编码为 {"type": "list", "elements": [...]}
+func (l List) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonContainer{Type: "list", Elements: nonNil(l.Elements)})
+}
+
+// MarshalJSON 将映射编码为 {"type": "map", "pairs": [{"key": ..., "value": ...}]}
+func (m Map) MarshalJSON() ([]byte, error) {
+	pairs := make([]jsonPair, len(m.Pairs))
+	for i, pair := range m.Pairs {
+		pairs[i] = jsonPair{Key: pair.Key, Value: pair.Value}
+	}
+	return json.Marshal(jsonMap{Type: "map", Pairs: pairs})
+}
+
+// MarshalJSON 将配置编码为 {"raw": "...", "terms": [...]}
+func (c *RebarConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Raw   string `json:"raw,omitempty"`
+		Terms []Term `json:"terms"`
+	}{c.Raw, nonNil(c.Terms)})
+}
+
+// UnmarshalJSON 从 {"raw": "...", "terms": [...]} 还原配置
+func (c *RebarConfig) UnmarshalJSON(data []byte) error {
+	var config jsonConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return err
+	}
+	terms, err := unmarshalTerms(config.Terms)
+	if err != nil {
+		return err
+	}
+	c.Raw, c.Terms = config.Raw, terms
+	return nil
+}
+
+// UnmarshalTerm 从带 type 字段的 JSON 对象还原任意项
+// @pkg 与各 Term 类型的 MarshalJSON 互逆，可用于还原通过网络传输或缓存的语法树
+// 输入:
+//   - data: Term 的 JSON 表示
+//
+// 输出:
+//   - Term: 还原的项
+//   - error: JSON 格式错误或未知的 type
+//
+// 示例:
+//
+//	term, err := parser.UnmarshalTerm([]byte(`{"type":"atom","value":"debug_info"}`))
+func UnmarshalTerm(data []byte) (Term, error) {
+	var t jsonTerm
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+
+	switch t.Type {
+	case "atom", "string":
+		var value string
+		if err := json.Unmarshal(t.Value, &value); err != nil {
+			return nil, fmt.Errorf("invalid %s value: %w", t.Type, err)
+		}
+		if t.Type == "string" {
+			return String{Value: value, Text: t.Text}, nil
+		}
+		return Atom{Value: value, IsQuoted: t.Quoted}, nil
+
+	case "integer":
+		value, err := strconv.ParseInt(string(t.Value), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer value: %s", t.Value)
+		}
+		return Integer{Value: value, Text: t.Text}, nil
+
+	case "float":
+		value, err := strconv.ParseFloat(string(t.Value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid float value: %s", t.Value)
+		}
+		return Float{Value: value, Text: t.Text}, nil
+
+	case "binary":
+		if t.Base64 != nil {
+			value, err := base64.StdEncoding.DecodeString(*t.Base64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid binary value: %w", err)
+			}
+			return Binary{Value: string(value)}, nil
+		}
+		var value string
+		if err := json.Unmarshal(t.Value, &value); err != nil {
+			return nil, fmt.Errorf("invalid binary value: %w", err)
+		}
+		return Binary{Value: value}, nil
+
+	case "tuple", "list":
+		elements, err := unmarshalTerms(t.Elements)
+		if err != nil {
+			return nil, err
+		}
+		if t.Type == "tuple" {
+			return Tuple{Elements: elements}, nil
+		}
+		return List{Elements: elements}, nil
+
+	case "map":
+		pairs := make([]MapPair, len(t.Pairs))
+		for i, pair := range t.Pairs {
+			key, err := UnmarshalTerm(pair.Key)
+			if err != nil {
+				return nil, err
+			}
+			value, err := UnmarshalTerm(pair.Value)
+			if err != nil {
+				return nil, err
+			}
+			pairs[i] = MapPair{Key: key, Value: value}
+		}
+		return Map{Pairs: pairs}, nil
+	}
+	return nil, fmt.Errorf("unknown term type: %q", t.Type)
+}
+
+// UnmarshalJSON 从 JSON 表示还原原子
+func (a *Atom) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(data, a)
+}
+
+// UnmarshalJSON 从 JSON 表示还原字符串
+func (s *String) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(data, s)
+}
+
+// UnmarshalJSON 从 JSON 表示还原整数
+func (i *Integer) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(data, i)
+}
+
+// UnmarshalJSON 从 JSON 表示还原浮点数
+func (f *Float) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(data, f)
+}
+
+// UnmarshalJSON 从 JSON 表示还原二进制
+func (b *Binary) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(data, b)
+}
+
+// UnmarshalJSON 从 JSON 表示还原元组
+func (t *Tuple) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(data, t)
+}
+
+// UnmarshalJSON 从 JSON 表示还原列表
+func (l *List) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(data, l)
+}
+
+// UnmarshalJSON 从 JSON 表示还原映射
+func (m *Map) UnmarshalJSON(data []byte) error {
+	return unmarshalInto(data, m)
+}
+
+// unmarshalInto 还原项并写入 target 指向的同类型变量
+func unmarshalInto(data []byte, target interface{}) error {
+	term, err := UnmarshalTerm(data)
+	if err != nil {
+		return err
+	}
+	return assignTerm(term, target)
+}
+
+// unmarshalTerms 还原项列表
+func unmarshalTerms(items []json.RawMessage) ([]Term, error) {
+	terms := make([]Term, len(items))
+	for i, item := range items {
+		term, err := UnmarshalTerm(item)
+		if err != nil {
+			return nil, err
+		}
+		terms[i] = term
+	}
+	return terms, nil
+}
+
+// nonNil 将 nil 切片替换为空切片，使其编码为 [] 而不是 null
+func nonNil(terms []Term) []Term {
+	if terms == nil {
+		return []Term{}
+	}
+	return terms
+}
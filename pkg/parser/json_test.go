@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestTermJSON tests the tagged JSON representation of each term type
+func TestTermJSON(t *testing.T) {
+	tests := []struct {
+		term     Term
+		expected string
+	}{
+		{Atom{Value: "debug_info"}, `{"type":"atom","value":"debug_info"}`},
+		{Atom{Value: "my-dep", IsQuoted: true}, `{"type":"atom","value":"my-dep","quoted":true}`},
+		{String{Value: "2.9.0"}, `{"type":"string","value":"2.9.0"}`},
+		{Integer{Value: -42}, `{"type":"integer","value":-42}`},
+		{Float{Value: 2.5}, `{"type":"float","value":2.5}`},
+		{String{Value: "A", Text: `"\x41"`}, `{"type":"string","value":"A","text":"\"\\x41\""}`},
+		{Integer{Value: 7, Text: "007"}, `{"type":"integer","value":7,"text":"007"}`},
+		{Float{Value: 2.1, Text: "2.10"}, `{"type":"float","value":2.1,"text":"2.10"}`},
+		{Binary{Value: "text"}, `{"type":"binary","value":"text"}`},
+		{Binary{Value: "\x00\xff"}, `{"type":"binary","base64":"AP8="}`},
+		{Tuple{}, `{"type":"tuple","elements":[]}`},
+		{List{Elements: []Term{Atom{Value: "a"}}}, `{"type":"list","elements":[{"type":"atom","value":"a"}]}`},
+		{Map{Pairs: []MapPair{{Key: Atom{Value: "k"}, Value: Integer{Value: 1}}}},
+			`{"type":"map","pairs":[{"key":{"type":"atom","value":"k"},"value":{"type":"integer","value":1}}]}`},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.term)
+		if err != nil {
+			t.Fatalf("Failed to marshal %s: %v", tt.term, err)
+		}
+		if string(data) != tt.expected {
+			t.Errorf("Expected %s, got %s", tt.expected, data)
+		}
+
+		term, err := UnmarshalTerm(data)
+		if err != nil {
+			t.Fatalf("Failed to unmarshal %s: %v", data, err)
+		}
+		if !term.Compare(tt.term) || term.String() != tt.term.String() {
+			t.Errorf("Round trip of %s produced %s", tt.term, term)
+		}
+	}
+}
+
+// TestConfigJSONRoundTrip tests that a parsed config survives a JSON round trip
+func TestConfigJSONRoundTrip(t *testing.T) {
+	config, err := Parse(`{erl_opts, [debug_info, {d, 'TEST'}]}.
+{deps, [{cowboy, "2.9.0"}, {'my-dep', {git, "https://example.com/x.git", {tag, "1.0"}}}]}.
+{relx, [{release, {app, "0.1.0"}, [app]}, {overlay_vars, #{port => 8080, ratio => 0.75}}]}.
+{plugins, [<<"rebar3_hex">>]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("Failed to marshal config: %v", err)
+	}
+
+	var decoded RebarConfig
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal config: %v", err)
+	}
+	if decoded.Raw != config.Raw {
+		t.Errorf("Raw content not preserved")
+	}
+	if len(decoded.Terms) != len(config.Terms) {
+		t.Fatalf("Expected %d terms, got %d", len(config.Terms), len(decoded.Terms))
+	}
+	for i := range config.Terms {
+		if decoded.Terms[i].String() != config.Terms[i].String() {
+			t.Errorf("Term %d: expected %s, got %s", i, config.Terms[i], decoded.Terms[i])
+		}
+	}
+
+	// Concrete term types can be decoded directly
+	var tuple Tuple
+	if err := json.Unmarshal([]byte(`{"type":"tuple","elements":[{"type":"atom","value":"a"}]}`), &tuple); err != nil {
+		t.Fatalf("Failed to unmarshal tuple: %v", err)
+	}
+	if tuple.String() != "{a}" {
+		t.Errorf("Expected {a}, got %s", tuple)
+	}
+}
+
+// TestConfigJSONKeepLiterals tests that source text recorded by KeepLiterals survives a JSON round trip
+func TestConfigJSONKeepLiterals(t *testing.T) {
+	config, err := Parse(`{app, [{vsn, 2.10}, {retries, 007}, {name, "\x41bc"}]}.`, KeepLiterals())
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("Failed to marshal config: %v", err)
+	}
+	var decoded RebarConfig
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal config: %v", err)
+	}
+
+	opts := FormatOptions{Indent: 2, Literals: true}
+	expected := config.FormatWith(opts)
+	if !strings.Contains(expected, "2.10") || !strings.Contains(expected, "007") || !strings.Contains(expected, `"\x41bc"`) {
+		t.Fatalf("Expected literals in original output, got:\n%s", expected)
+	}
+	if got := decoded.FormatWith(opts); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+// TestUnmarshalTermErrors tests invalid term JSON
+func TestUnmarshalTermErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"type":"pid"}`, `unknown term type: "pid"`},
+		{`{"type":"integer","value":1.5}`, "invalid integer value"},
+		{`{"type":"atom","value":1}`, "invalid atom value"},
+		{`{"type":"list","elements":[{"type":"ref"}]}`, `unknown term type: "ref"`},
+		{`[1]`, "cannot unmarshal"},
+	}
+
+	for _, tt := range tests {
+		_, err := UnmarshalTerm([]byte(tt.input))
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("For %s expected error containing %q, got %v", tt.input, tt.expected, err)
+		}
+	}
+
+	var atom Atom
+	if err := json.Unmarshal([]byte(`{"type":"string","value":"x"}`), &atom); err == nil || !strings.Contains(err.Error(), "cannot unmarshal String into *parser.Atom") {
+		t.Errorf("Expected type mismatch error, got %v", err)
+	}
+}
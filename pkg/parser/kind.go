@@ -0,0 +1,85 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+// TermKind 表示项的类别
+// @pkg 本包中的每种项对应一个类别，外部包可以对 Kind() 做穷尽的 switch 而不必写类型断言；
+// 零值 InvalidKind 不对应任何项
+//
+// 示例:
+//
+//	switch term.Kind() {
+//	case parser.AtomKind, parser.StringKind, parser.BinaryKind:
+//	  fmt.Println("text:", term)
+//	case parser.IntegerKind, parser.FloatKind:
+//	  fmt.Println("number:", term)
+//	case parser.TupleKind, parser.ListKind, parser.MapKind:
+//	  fmt.Println("container:", term)
+//	}
+type TermKind int
+
+const (
+	// InvalidKind 是零值，不对应本包定义的任何项
+	InvalidKind TermKind = iota
+	// AtomKind 是原子 Atom
+	AtomKind
+	// StringKind 是字符串 String
+	StringKind
+	// IntegerKind 是整数 Integer
+	IntegerKind
+	// FloatKind 是浮点数 Float
+	FloatKind
+	// BinaryKind 是二进制 Binary
+	BinaryKind
+	// TupleKind 是元组 Tuple
+	TupleKind
+	// ListKind 是列表 List
+	ListKind
+	// MapKind 是映射 Map
+	MapKind
+)
+
+// termKindNames 是各类别的名称
+var termKindNames = [...]string{
+	InvalidKind: "invalid",
+	AtomKind:    "atom",
+	StringKind:  "string",
+	IntegerKind: "integer",
+	FloatKind:   "float",
+	BinaryKind:  "binary",
+	TupleKind:   "tuple",
+	ListKind:    "list",
+	MapKind:     "map",
+}
+
+// String 返回类别的名称，如 "atom"
+func (k TermKind) String() string {
+	if k >= 0 && int(k) < len(termKindNames) {
+		return termKindNames[k]
+	}
+	return "unknown"
+}
+
+// Kind 返回 AtomKind
+func (a Atom) Kind() TermKind { return AtomKind }
+
+// Kind 返回 StringKind
+func (s String) Kind() TermKind { return StringKind }
+
+// Kind 返回 IntegerKind
+func (i Integer) Kind() TermKind { return IntegerKind }
+
+// Kind 返回 FloatKind
+func (f Float) Kind() TermKind { return FloatKind }
+
+// Kind 返回 BinaryKind
+func (b Binary) Kind() TermKind { return BinaryKind }
+
+// Kind 返回 TupleKind
+func (t Tuple) Kind() TermKind { return TupleKind }
+
+// Kind 返回 ListKind
+func (l List) Kind() TermKind { return ListKind }
+
+// Kind 返回 MapKind
+func (m Map) Kind() TermKind { return MapKind }
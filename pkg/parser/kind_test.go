@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"testing"
+)
+
+// TestTermKind tests the kind reported by each term type
+func TestTermKind(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected TermKind
+		name     string
+	}{
+		{"app", AtomKind, "atom"},
+		{`"2.9.0"`, StringKind, "string"},
+		{"42", IntegerKind, "integer"},
+		{"1.5", FloatKind, "float"},
+		{`<<"bin">>`, BinaryKind, "binary"},
+		{"{a, b}", TupleKind, "tuple"},
+		{"[a]", ListKind, "list"},
+		{"#{a => 1}", MapKind, "map"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := MustParseTerm(tt.input)
+			if term.Kind() != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, term.Kind())
+			}
+			if term.Kind().String() != tt.name {
+				t.Errorf("Expected %q, got %q", tt.name, term.Kind().String())
+			}
+		})
+	}
+
+	if InvalidKind.String() != "invalid" || TermKind(99).String() != "unknown" {
+		t.Errorf("Unexpected names: %q, %q", InvalidKind.String(), TermKind(99).String())
+	}
+}
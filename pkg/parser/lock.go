@@ -0,0 +1,268 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+)
+
+// LockFile 表示解析后的 rebar.lock 文件
+// @pkg rebar.lock 同样由 Erlang 项组成，但结构固定:
+//
+//	{"1.2.0",
+//	 [{<<"cowboy">>,{pkg,<<"cowboy">>,<<"2.9.0">>},0},
+//	  {<<"lager">>,{git,"https://github.com/erlang-lager/lager.git",{ref,"459a3b2"}},0}]}.
+//	[{pkg_hash,[{<<"cowboy">>,<<"2A4C...">>}]},
+//	 {pkg_hash_ext,[{<<"cowboy">>,<<"B3DC...">>}]}].
+//
+// 旧版（rebar3 3.0 之前）的锁文件只有一个依赖列表，没有版本号和哈希
+type LockFile struct {
+	// Version 是锁文件格式版本，如 "1.2.0"；旧版格式为空
+	Version string
+	// Deps 是锁定的依赖，按文件中的顺序排列
+	Deps []LockedDep
+}
+
+// LockedDep 表示锁文件中的一个依赖
+type LockedDep struct {
+	// Name 是依赖的应用名称
+	Name string
+	// Source 是依赖的来源
+	Source LockSource
+	// Level 是依赖的层级，0 表示顶层依赖，1 表示依赖的依赖，以此类推
+	Level int
+	// Hash 是 pkg_hash 中记录的包内部校验和，非 hex 包为空
+	Hash string
+	// HashExt 是 pkg_hash_ext 中记录的包外部校验和，非 hex 包为空
+	HashExt string
+}
+
+// LockSource 表示锁定依赖的来源
+// @pkg 常见来源:
+// - {pkg, <<"Package">>, <<"Vsn">>}: Kind 为 "pkg"，填充 Package 和 Version
+// - {git, "Url", {ref, "Ref"}}、{git_subdir, ...} 与 {hg, ...}: Kind 为源元组的首个原子，填充 URL 和 Ref
+// 其他来源只填充 Kind 和 Term
+type LockSource struct {
+	// Kind 是来源类别，即源元组的首个原子
+	Kind string
+	// Package 是 hex 包名，使用别名时可能与依赖名称不同
+	Package string
+	// Version 是 hex 包的版本
+	Version string
+	// URL 是版本库地址
+	URL string
+	// Ref 是锁定的版本库提交
+	Ref string
+	// Term 是原始的源项
+	Term Term
+}
+
+// ParseLockFile 解析指定路径的 rebar.lock 文件
+// @pkg 读取文件并按 rebar.lock 的结构构建 LockFile
+// 输入:
+//   - path: 文件路径，如 "./rebar.lock"
+//
+// 输出:
+//   - *LockFile: 解析后的锁文件
+//   - error: 读取、语法或结构错误
+//
+// 示例:
+//
+//	lock, err := parser.ParseLockFile("./rebar.lock")
+//	if err != nil {
+//	  log.Fatal(err)
+//	}
+//	for _, dep := range lock.Deps {
+//	  fmt.Println(dep.Name, dep.Source.Version, dep.Hash)
+//	}
+func ParseLockFile(path string) (*LockFile, error) {
+	terms, err := Consult(path)
+	if err != nil {
+		return nil, err
+	}
+	return lockFromTerms(terms)
+}
+
+// ParseLock 解析 rebar.lock 格式的字符串
+// @pkg 与 ParseLockFile 相同，但直接解析字符串内容
+// 输入:
+//   - input: rebar.lock 的内容
+//
+// 输出:
+//   - *LockFile: 解析后的锁文件
+//   - error: 语法或结构错误
+func ParseLock(input string) (*LockFile, error) {
+	terms, err := ParseConsult(input)
+	if err != nil {
+		return nil, err
+	}
+	return lockFromTerms(terms)
+}
+
+// Dep 按名称查找锁定的依赖
+// 输入:
+//   - name: 依赖名称
+//
+// 输出:
+//   - LockedDep: 找到的依赖
+//   - bool: 是否找到
+func (l *LockFile) Dep(name string) (LockedDep, bool) {
+	for _, dep := range l.Deps {
+		if dep.Name == name {
+			return dep, true
+		}
+	}
+	return LockedDep{}, false
+}
+
+// lockFromTerms 从锁文件的顶级项构建 LockFile
+func lockFromTerms(terms []Term) (*LockFile, error) {
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("invalid lock file: empty input")
+	}
+
+	lock := &LockFile{}
+	var deps List
+
+	switch t := terms[0].(type) {
+	case List:
+		deps = t
+	case Tuple:
+		if len(t.Elements) != 2 {
+			return nil, fmt.Errorf("invalid lock file: expected {Version, Deps}, got %s", t)
+		}
+		version, ok := t.Elements[0].(String)
+		if !ok {
+			return nil, fmt.Errorf("invalid lock file: version must be a string, got %s", t.Elements[0])
+		}
+		if deps, ok = t.Elements[1].(List); !ok {
+			return nil, fmt.Errorf("invalid lock file: deps must be a list, got %s", t.Elements[1])
+		}
+		lock.Version = version.Value
+	default:
+		return nil, fmt.Errorf("invalid lock file: unexpected term %s", t)
+	}
+
+	for _, elem := range deps.Elements {
+		dep, err := lockedDep(elem)
+		if err != nil {
+			return nil, err
+		}
+		lock.Deps = append(lock.Deps, dep)
+	}
+
+	if len(terms) > 1 {
+		if err := lock.applyAttrs(terms[1]); err != nil {
+			return nil, err
+		}
+	}
+	return lock, nil
+}
+
+// lockedDep 解析 {Name, Source, Level} 形式的依赖
+func lockedDep(term Term) (LockedDep, error) {
+	tuple, ok := term.(Tuple)
+	if !ok || len(tuple.Elements) != 3 {
+		return LockedDep{}, fmt.Errorf("invalid lock file: expected {Name, Source, Level}, got %s", term)
+	}
+
+	name, ok := lockText(tuple.Elements[0])
+	if !ok {
+		return LockedDep{}, fmt.Errorf("invalid lock file: invalid dependency name %s", tuple.Elements[0])
+	}
+	level, ok := tuple.Elements[2].(Integer)
+	if !ok {
+		return LockedDep{}, fmt.Errorf("invalid lock file: invalid level for %s: %s", name, tuple.Elements[2])
+	}
+
+	return LockedDep{Name: name, Source: lockSource(tuple.Elements[1]), Level: int(level.Value)}, nil
+}
+
+// lockSource 解析依赖的源项
+func lockSource(term Term) LockSource {
+	source := LockSource{Kind: termName(term), Term: term}
+	tuple, ok := term.(Tuple)
+	if !ok {
+		return source
+	}
+
+	switch source.Kind {
+	case "pkg":
+		if len(tuple.Elements) >= 3 {
+			source.Package, _ = lockText(tuple.Elements[1])
+			source.Version, _ = lockText(tuple.Elements[2])
+		}
+	case "git", "hg", "git_subdir":
+		if len(tuple.Elements) >= 3 {
+			source.URL, _ = lockText(tuple.Elements[1])
+			if ref, ok := tuple.Elements[2].(Tuple); ok && len(ref.Elements) == 2 {
+				source.Ref, _ = lockText(ref.Elements[1])
+			}
+		}
+	}
+	return source
+}
+
+// applyAttrs 将 [{pkg_hash, [...]}, {pkg_hash_ext, [...]}] 中的哈希填入依赖
+// @pkg 未知的属性会被忽略，以兼容未来的锁文件版本
+func (l *LockFile) applyAttrs(term Term) error {
+	attrs, ok := term.(List)
+	if !ok {
+		return fmt.Errorf("invalid lock file: attributes must be a list, got %s", term)
+	}
+
+	index := make(map[string]int, len(l.Deps))
+	for i, dep := range l.Deps {
+		index[dep.Name] = i
+	}
+
+	for _, attr := range attrs.Elements {
+		tuple, ok := attr.(Tuple)
+		if !ok || len(tuple.Elements) != 2 {
+			continue
+		}
+		kind := termName(tuple)
+		if kind != "pkg_hash" && kind != "pkg_hash_ext" {
+			continue
+		}
+		hashes, ok := tuple.Elements[1].(List)
+		if !ok {
+			return fmt.Errorf("invalid lock file: %s must be a list, got %s", kind, tuple.Elements[1])
+		}
+
+		for _, entry := range hashes.Elements {
+			pair, ok := entry.(Tuple)
+			if !ok || len(pair.Elements) != 2 {
+				return fmt.Errorf("invalid lock file: expected {Name, Hash} in %s, got %s", kind, entry)
+			}
+			name, _ := lockText(pair.Elements[0])
+			hash, ok := lockText(pair.Elements[1])
+			if !ok {
+				return fmt.Errorf("invalid lock file: invalid hash for %s: %s", name, pair.Elements[1])
+			}
+			i, ok := index[name]
+			if !ok {
+				continue
+			}
+			if kind == "pkg_hash" {
+				l.Deps[i].Hash = hash
+			} else {
+				l.Deps[i].HashExt = hash
+			}
+		}
+	}
+	return nil
+}
+
+// lockText 返回二进制、字符串或原子的文本值
+func lockText(term Term) (string, bool) {
+	switch t := term.(type) {
+	case Binary:
+		return t.Value, true
+	case String:
+		return t.Value, true
+	case Atom:
+		return t.Value, true
+	}
+	return "", false
+}
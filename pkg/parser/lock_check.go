@@ -0,0 +1,168 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+)
+
+// LockIssueKind 表示配置与锁文件不一致的类别
+type LockIssueKind string
+
+const (
+	// LockMissing 表示配置中声明的依赖不在锁文件中
+	LockMissing LockIssueKind = "missing"
+	// LockExtra 表示锁文件中的顶层依赖不再在配置中声明
+	LockExtra LockIssueKind = "extra"
+	// LockVersion 表示锁定的 hex 版本不满足配置中的版本要求
+	LockVersion LockIssueKind = "version"
+	// LockRef 表示锁定的 git/hg 提交与配置中固定的 ref 不同
+	LockRef LockIssueKind = "ref"
+	// LockSourceChanged 表示依赖的来源类别或地址发生了变化
+	LockSourceChanged LockIssueKind = "source"
+)
+
+// LockIssue 描述配置与锁文件之间的一处不一致
+type LockIssue struct {
+	Kind LockIssueKind
+	// Dep 是依赖名称
+	Dep string
+	// Expected 是配置中的要求，如版本要求、ref 或来源
+	Expected string
+	// Locked 是锁文件中记录的值
+	Locked string
+}
+
+// String 返回问题的可读描述
+// @pkg 例如 "version: cowboy requires ~> 2.10 but 2.9.0 is locked"
+func (i LockIssue) String() string {
+	switch i.Kind {
+	case LockMissing:
+		return fmt.Sprintf("missing: %s is not in the lock file", i.Dep)
+	case LockExtra:
+		return fmt.Sprintf("extra: %s is locked but no longer declared", i.Dep)
+	case LockVersion:
+		return fmt.Sprintf("version: %s requires %s but %s is locked", i.Dep, i.Expected, i.Locked)
+	case LockRef:
+		return fmt.Sprintf("ref: %s pins %s but %s is locked", i.Dep, i.Expected, i.Locked)
+	default:
+		return fmt.Sprintf("%s: %s is declared as %s but locked as %s", i.Kind, i.Dep, i.Expected, i.Locked)
+	}
+}
+
+// CheckLock 检查锁文件是否与配置一致
+// @pkg 回答“锁文件是否过期”的问题，报告:
+// - 配置中声明但未锁定的依赖
+// - 锁文件中的顶层依赖（Level 为 0）不再在配置中声明
+// - hex 依赖的版本要求不再允许锁定的版本
+// - git/hg 依赖固定的 {ref, ...} 与锁定的提交不同；{tag, ...} 和 {branch, ...} 无法离线验证，不会被报告
+// - 依赖的来源类别或版本库地址发生变化
+//
+// 只检查基础配置中的 deps，与 rebar3 只锁定 default profile 的依赖一致
+// 输入:
+//   - config: 解析后的 rebar.config
+//   - lock: 解析后的 rebar.lock
+//
+// 输出:
+//   - []LockIssue: 发现的问题，先按配置中的顺序列出，再列出多余的锁定依赖
+//
+// 示例:
+//
+//	config, _ := parser.ParseFile("./rebar.config")
+//	lock, _ := parser.ParseLockFile("./rebar.lock")
+//	for _, issue := range parser.CheckLock(config, lock) {
+//	  fmt.Println(issue)
+//	}
+func CheckLock(config *RebarConfig, lock *LockFile) []LockIssue {
+	var issues []LockIssue
+	declared := make(map[string]bool)
+
+	for _, term := range listElements(config, "deps") {
+		dep, ok := declaredDep(term)
+		if !ok {
+			continue
+		}
+		declared[dep.name] = true
+
+		locked, ok := lock.Dep(dep.name)
+		if !ok {
+			issues = append(issues, LockIssue{Kind: LockMissing, Dep: dep.name})
+			continue
+		}
+		if issue, ok := dep.check(locked); ok {
+			issues = append(issues, issue)
+		}
+	}
+
+	for _, locked := range lock.Deps {
+		if locked.Level == 0 && !declared[locked.Name] {
+			issues = append(issues, LockIssue{Kind: LockExtra, Dep: locked.Name})
+		}
+	}
+	return issues
+}
+
+// declaredSource 表示配置中声明的依赖的来源信息
+type declaredSource struct {
+	name string
+	kind string
+	// pkg 是 hex 依赖实际获取的包名，使用 {pkg, ...} 别名时与 name 不同
+	pkg string
+	// requirement 是 hex 依赖的版本要求，为空表示任意版本
+	requirement string
+	// url 和 ref 是版本库依赖的地址和固定的提交
+	url string
+	ref string
+}
+
+// declaredDep 从依赖项中提取来源信息
+func declaredDep(term Term) (declaredSource, bool) {
+	d, ok := AsDependency(term)
+	if !ok {
+		return declaredSource{}, false
+	}
+	dep := declaredSource{name: d.Name, kind: d.Family(), pkg: d.Package, requirement: d.Version, url: d.URL}
+	if d.RefType == "ref" {
+		dep.ref = d.RefValue
+	}
+	return dep, true
+}
+
+// check 比较声明的依赖与锁定的依赖
+func (d declaredSource) check(locked LockedDep) (LockIssue, bool) {
+	lockedKind := locked.Source.Kind
+	switch lockedKind {
+	case "pkg":
+		lockedKind = "hex"
+	case "git_subdir":
+		lockedKind = "git"
+	}
+	if d.kind != lockedKind {
+		return LockIssue{Kind: LockSourceChanged, Dep: d.name, Expected: d.kind, Locked: lockedKind}, true
+	}
+
+	switch d.kind {
+	case "hex":
+		if locked.Source.Package != "" && d.pkg != locked.Source.Package {
+			return LockIssue{Kind: LockSourceChanged, Dep: d.name, Expected: "hex " + d.pkg, Locked: "hex " + locked.Source.Package}, true
+		}
+		if d.requirement == "" {
+			return LockIssue{}, false
+		}
+		req, err := ParseRequirement(d.requirement)
+		if err != nil {
+			return LockIssue{}, false
+		}
+		if v, err := ParseVersion(locked.Source.Version); err != nil || !req.Match(v) {
+			return LockIssue{Kind: LockVersion, Dep: d.name, Expected: d.requirement, Locked: locked.Source.Version}, true
+		}
+	case "git", "hg":
+		if d.url != "" && d.url != locked.Source.URL {
+			return LockIssue{Kind: LockSourceChanged, Dep: d.name, Expected: d.url, Locked: locked.Source.URL}, true
+		}
+		if d.ref != "" && d.ref != locked.Source.Ref {
+			return LockIssue{Kind: LockRef, Dep: d.name, Expected: d.ref, Locked: locked.Source.Ref}, true
+		}
+	}
+	return LockIssue{}, false
+}
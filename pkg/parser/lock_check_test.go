@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"testing"
+)
+
+// TestCheckLock tests detection of stale lock files
+func TestCheckLock(t *testing.T) {
+	lock, err := ParseLock(sampleLock)
+	if err != nil {
+		t.Fatalf("Failed to parse lock: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		config   string
+		expected []string
+	}{
+		{
+			name: "in sync",
+			config: `{deps, [{cowboy, "~> 2.9"},
+        {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}},
+        {my_jsx, {pkg, jsx}}]}.`,
+		},
+		{
+			name: "stale",
+			config: `{deps, [{cowboy, "~> 2.10"},
+        {lager, {git, "https://github.com/erlang-lager/lager.git", {ref, "0000000"}}},
+        {my_jsx, {git, "https://github.com/talentdeficit/jsx.git", {tag, "v3.1.0"}}},
+        recon]}.`,
+			expected: []string{
+				"version: cowboy requires ~> 2.10 but 2.9.0 is locked",
+				"ref: lager pins 0000000 but 459a3b2cdd9eadd29e5a7ce5c43932f5ccd6eb88 is locked",
+				"source: my_jsx is declared as git but locked as hex",
+				"missing: recon is not in the lock file",
+			},
+		},
+		{
+			name:   "package alias changed",
+			config: `{deps, [{cowboy, "~> 2.9"}, {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}}, {my_jsx, "3.1.0", {pkg, jsx_fork}}]}.`,
+			expected: []string{
+				"source: my_jsx is declared as hex jsx_fork but locked as hex jsx",
+			},
+		},
+		{
+			name:   "removed deps",
+			config: `{deps, [{cowboy, "2.9.0"}, {lager, {git, "https://example.com/lager.git", {branch, "master"}}}]}.`,
+			expected: []string{
+				"source: lager is declared as https://example.com/lager.git but locked as https://github.com/erlang-lager/lager.git",
+				"extra: my_jsx is locked but no longer declared",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse(tt.config)
+			if err != nil {
+				t.Fatalf("Failed to parse config: %v", err)
+			}
+			issues := CheckLock(config, lock)
+			if len(issues) != len(tt.expected) {
+				t.Fatalf("Expected %d issues, got %d: %v", len(tt.expected), len(issues), issues)
+			}
+			for i, issue := range issues {
+				if issue.String() != tt.expected[i] {
+					t.Errorf("Issue %d: expected %q, got %q", i, tt.expected[i], issue.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const sampleLock = `{"1.2.0",
+[{<<"cowboy">>,{pkg,<<"cowboy">>,<<"2.9.0">>},0},
+ {<<"cowlib">>,{pkg,<<"cowlib">>,<<"2.11.0">>},1},
+ {<<"lager">>,
+  {git,"https://github.com/erlang-lager/lager.git",
+       {ref,"459a3b2cdd9eadd29e5a7ce5c43932f5ccd6eb88"}},
+  0},
+ {<<"my_jsx">>,{pkg,<<"jsx">>,<<"3.1.0">>},0}]}.
+[
+{pkg_hash,[
+ {<<"cowboy">>, <<"2C729F934B4E1AA149AFF882F57C6372C15399A20D54F65C8D67BEF583021BDE">>},
+ {<<"cowlib">>, <<"0B9FF9C346629256C42EBE1EEB769A83C6CB771A6EE5960BD110AB0B9B872063">>},
+ {<<"my_jsx">>, <<"D12516BAA0BB23A59BB35DCCAF02A1BD08243FCBB9EFE24F2D9D056CCFF71268">>}]},
+{pkg_hash_ext,[
+ {<<"cowboy">>, <<"2C729F934B4E1AA149AFF882F57C6372C15399A20D54F65C8D67BEF583021BDE">>},
+ {<<"cowlib">>, <<"2B3E9DA0B21C4565751A6D4901C20D1B4CC25CBB7FD50D91D2AB6DD287BC86A9">>},
+ {<<"my_jsx">>, <<"4C1AE5E3E4D8A0C4B7A0A98CDD7D1D3AE36F6AC6D0A5D4E08A5F11EC4E61C8C6">>}]}
+].
+`
+
+// TestParseLock tests parsing a current-format rebar.lock
+func TestParseLock(t *testing.T) {
+	lock, err := ParseLock(sampleLock)
+	if err != nil {
+		t.Fatalf("Failed to parse lock: %v", err)
+	}
+
+	if lock.Version != "1.2.0" {
+		t.Errorf("Expected version 1.2.0, got %q", lock.Version)
+	}
+	if len(lock.Deps) != 4 {
+		t.Fatalf("Expected 4 deps, got %d", len(lock.Deps))
+	}
+
+	cowlib, ok := lock.Dep("cowlib")
+	if !ok {
+		t.Fatal("Expected to find cowlib")
+	}
+	if cowlib.Level != 1 || cowlib.Source.Kind != "pkg" || cowlib.Source.Version != "2.11.0" {
+		t.Errorf("Unexpected cowlib entry: %+v", cowlib)
+	}
+	if cowlib.Hash != "0B9FF9C346629256C42EBE1EEB769A83C6CB771A6EE5960BD110AB0B9B872063" {
+		t.Errorf("Unexpected cowlib hash: %q", cowlib.Hash)
+	}
+	if cowlib.HashExt != "2B3E9DA0B21C4565751A6D4901C20D1B4CC25CBB7FD50D91D2AB6DD287BC86A9" {
+		t.Errorf("Unexpected cowlib outer hash: %q", cowlib.HashExt)
+	}
+
+	lager, _ := lock.Dep("lager")
+	if lager.Source.Kind != "git" ||
+		lager.Source.URL != "https://github.com/erlang-lager/lager.git" ||
+		lager.Source.Ref != "459a3b2cdd9eadd29e5a7ce5c43932f5ccd6eb88" {
+		t.Errorf("Unexpected lager source: %+v", lager.Source)
+	}
+	if lager.Hash != "" || lager.HashExt != "" {
+		t.Errorf("Expected no hashes for git dep, got %q/%q", lager.Hash, lager.HashExt)
+	}
+
+	alias, _ := lock.Dep("my_jsx")
+	if alias.Source.Package != "jsx" || alias.Source.Version != "3.1.0" {
+		t.Errorf("Expected aliased package jsx 3.1.0, got %+v", alias.Source)
+	}
+
+	if _, ok := lock.Dep("missing"); ok {
+		t.Error("Expected missing dep not to be found")
+	}
+}
+
+// TestParseLockLegacy tests parsing a pre-versioned rebar.lock
+func TestParseLockLegacy(t *testing.T) {
+	lock, err := ParseLock(`[{<<"goldrush">>,{pkg,<<"goldrush">>,<<"0.1.9">>},1}].`)
+	if err != nil {
+		t.Fatalf("Failed to parse legacy lock: %v", err)
+	}
+	if lock.Version != "" || len(lock.Deps) != 1 || lock.Deps[0].Level != 1 {
+		t.Errorf("Unexpected legacy lock: %+v", lock)
+	}
+}
+
+// TestParseLockErrors tests structural errors in lock files
+func TestParseLockErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ``},
+		{"not a lock", `{deps, []}.`},
+		{"bad version", `{'1.2.0', []}.`},
+		{"bad deps", `{"1.2.0", deps}.`},
+		{"bad entry", `{"1.2.0", [{<<"a">>, {pkg, <<"a">>, <<"1">>}}]}.`},
+		{"bad level", `{"1.2.0", [{<<"a">>, {pkg, <<"a">>, <<"1">>}, top}]}.`},
+		{"bad attrs", `{"1.2.0", []}. {pkg_hash, []}.`},
+		{"bad hash", `{"1.2.0", []}. [{pkg_hash, [{<<"a">>, 1}]}].`},
+		{"syntax", `{"1.2.0", [`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseLock(tt.input); err == nil {
+				t.Errorf("Expected error for %q", tt.input)
+			}
+		})
+	}
+}
+
+// TestParseLockFile tests reading a lock file from disk
+func TestParseLockFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rebar.lock")
+	if err := os.WriteFile(path, []byte(sampleLock), 0644); err != nil {
+		t.Fatalf("Failed to write lock file: %v", err)
+	}
+
+	lock, err := ParseLockFile(path)
+	if err != nil {
+		t.Fatalf("Failed to parse lock file: %v", err)
+	}
+	if len(lock.Deps) != 4 {
+		t.Errorf("Expected 4 deps, got %d", len(lock.Deps))
+	}
+
+	if _, err := ParseLockFile(filepath.Join(t.TempDir(), "missing.lock")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestLockFileFormat tests the exact rebar3 layout of a written lock file
+func TestLockFileFormat(t *testing.T) {
+	lock := &LockFile{Deps: []LockedDep{
+		{
+			Name:   "lager",
+			Source: LockSource{Kind: "git", URL: "https://github.com/erlang-lager/lager.git", Ref: "459a3b2"},
+		},
+		{
+			Name:    "cowboy",
+			Source:  LockSource{Kind: "pkg", Version: "2.9.0"},
+			Hash:    "AAA",
+			HashExt: "BBB",
+		},
+		{
+			Name:   "my_jsx",
+			Source: LockSource{Kind: "pkg", Package: "jsx", Version: "3.1.0"},
+			Level:  1,
+			Hash:   "CCC",
+		},
+	}}
+
+	got, err := lock.Format()
+	if err != nil {
+		t.Fatalf("Failed to format lock: %v", err)
+	}
+
+	expected := `{"1.2.0",
+[{<<"cowboy">>,{pkg,<<"cowboy">>,<<"2.9.0">>},0},
+ {<<"lager">>,{git,"https://github.com/erlang-lager/lager.git",{ref,"459a3b2"}},0},
+ {<<"my_jsx">>,{pkg,<<"jsx">>,<<"3.1.0">>},1}]}.
+[
+{pkg_hash,[
+ {<<"cowboy">>, <<"AAA">>},
+ {<<"my_jsx">>, <<"CCC">>}]},
+{pkg_hash_ext,[
+ {<<"cowboy">>, <<"BBB">>}]}
+].
+`
+	if got != expected {
+		t.Errorf("Unexpected lock output:\n%s\nexpected:\n%s", got, expected)
+	}
+
+	if lock.Deps[0].Name != "lager" {
+		t.Error("Format should not reorder the caller's deps")
+	}
+}
+
+// TestLockFileFormatRoundTrip tests that a parsed lock survives Format and ParseLock
+func TestLockFileFormatRoundTrip(t *testing.T) {
+	lock, err := ParseLock(sampleLock)
+	if err != nil {
+		t.Fatalf("Failed to parse lock: %v", err)
+	}
+	formatted, err := lock.Format()
+	if err != nil {
+		t.Fatalf("Failed to format lock: %v", err)
+	}
+	reparsed, err := ParseLock(formatted)
+	if err != nil {
+		t.Fatalf("Failed to reparse formatted lock: %v\n%s", err, formatted)
+	}
+
+	if len(reparsed.Deps) != len(lock.Deps) {
+		t.Fatalf("Expected %d deps, got %d", len(lock.Deps), len(reparsed.Deps))
+	}
+	for _, dep := range lock.Deps {
+		got, ok := reparsed.Dep(dep.Name)
+		if !ok {
+			t.Fatalf("Missing dep %s after round trip", dep.Name)
+		}
+		if got.Level != dep.Level || got.Hash != dep.Hash || got.HashExt != dep.HashExt ||
+			!got.Source.Term.Compare(dep.Source.Term) {
+			t.Errorf("Dep %s changed after round trip: %+v vs %+v", dep.Name, got, dep)
+		}
+	}
+}
+
+// TestLockFileFormatNoHashes tests that the attrs section is omitted without hashes
+func TestLockFileFormatNoHashes(t *testing.T) {
+	lock := &LockFile{Version: "1.1.0", Deps: []LockedDep{{
+		Name:   "custom",
+		Source: LockSource{Kind: "path", Term: Tuple{Elements: []Term{Atom{Value: "path"}, String{Value: "../custom"}}}},
+	}}}
+
+	got, err := lock.Format()
+	if err != nil {
+		t.Fatalf("Failed to format lock: %v", err)
+	}
+	expected := "{\"1.1.0\",\n[{<<\"custom\">>,{path,\"../custom\"},0}]}.\n"
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+// TestLockFileFormatErrors tests entries that cannot be written
+func TestLockFileFormatErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		dep  LockedDep
+	}{
+		{"empty name", LockedDep{Source: LockSource{Kind: "pkg", Version: "1.0.0"}}},
+		{"pkg without version", LockedDep{Name: "a", Source: LockSource{Kind: "pkg"}}},
+		{"git without ref", LockedDep{Name: "a", Source: LockSource{Kind: "git", URL: "u"}}},
+		{"unknown without term", LockedDep{Name: "a", Source: LockSource{Kind: "svn"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lock := &LockFile{Deps: []LockedDep{tt.dep}}
+			if _, err := lock.Format(); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+}
+
+// TestLockFileWriteFile tests writing a lock file to disk
+func TestLockFileWriteFile(t *testing.T) {
+	lock, err := ParseLock(sampleLock)
+	if err != nil {
+		t.Fatalf("Failed to parse lock: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "rebar.lock")
+	if err := lock.WriteFile(path); err != nil {
+		t.Fatalf("Failed to write lock file: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("Expected lock file to exist: %v", err)
+	}
+	if _, err := ParseLockFile(path); err != nil {
+		t.Errorf("Failed to parse written lock file: %v", err)
+	}
+
+	if err := lock.WriteFile(filepath.Join(t.TempDir(), "missing", "rebar.lock")); err == nil {
+		t.Error("Expected error writing to a missing directory")
+	}
+}
@@ -0,0 +1,211 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Pattern 是编译后的项模式
+// @pkg 模式是带有变量的 Erlang 项，语法与 ParseTerm 相同，另外支持:
+// - $Name: 变量，匹配任意项并绑定到 Name；同一个变量出现多次时各处必须相等（按 Compare 比较）
+// - _ 或 $_: 通配符，匹配任意项但不绑定
+//
+// 元组和列表要求长度相同并逐个匹配元素；映射模式只要求列出的键存在且值匹配（与 Erlang 的映射模式一致），键不能是变量；
+// 其他项按 Compare 比较。名称以 $ 开头的带引号原子（如 '$x'）同样被视为变量
+type Pattern struct {
+	text string
+	term Term
+}
+
+// CompilePattern 编译项模式
+// 输入:
+//   - pattern: 模式，如 "{deps, $Deps}"、"{$Name, {git, $Url, {tag, $Tag}}}"
+//
+// 输出:
+//   - *Pattern: 编译后的模式
+//   - error: 模式的语法错误
+//
+// 示例:
+//
+//	p, err := parser.CompilePattern(`{$Name, {git, $Url, _}}`)
+//	if err != nil {
+//	  log.Fatal(err)
+//	}
+func CompilePattern(pattern string) (*Pattern, error) {
+	term, err := ParseTerm(quoteVariables(pattern))
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return &Pattern{text: pattern, term: term}, nil
+}
+
+// MustCompilePattern 与 CompilePattern 相同，但在模式非法时 panic
+// @pkg 用于源码中固定的模式
+func MustCompilePattern(pattern string) *Pattern {
+	p, err := CompilePattern(pattern)
+	if err != nil {
+		panic("parser: MustCompilePattern(" + pattern + "): " + err.Error())
+	}
+	return p
+}
+
+// String 返回模式的原始文本
+func (p *Pattern) String() string {
+	return p.text
+}
+
+// Match 用模式匹配项
+// 输入:
+//   - term: 要匹配的项
+//
+// 输出:
+//   - map[string]Term: 变量名（不含 $）到所绑定项的映射，模式没有变量时为空映射
+//   - bool: 是否匹配
+//
+// 示例:
+//
+//	p := parser.MustCompilePattern(`{$Name, $Version}`)
+//	if bindings, ok := p.Match(dep); ok {
+//	  fmt.Println(bindings["Name"], bindings["Version"])
+//	}
+func (p *Pattern) Match(term Term) (map[string]Term, bool) {
+	bindings := make(map[string]Term)
+	if !matchPattern(p.term, term, bindings) {
+		return nil, false
+	}
+	return bindings, true
+}
+
+// Match 用模式匹配项
+// @pkg 等同于 MustCompilePattern(pattern).Match(term)，模式非法时 panic；模式来自外部输入时应使用 CompilePattern
+//
+// 示例:
+//
+//	if bindings, ok := parser.Match(term, "{deps, $Deps}"); ok {
+//	  fmt.Println(bindings["Deps"])
+//	}
+func Match(term Term, pattern string) (map[string]Term, bool) {
+	return MustCompilePattern(pattern).Match(term)
+}
+
+// Match 用模式依次匹配顶级配置项，返回第一个匹配项的绑定
+// 输入:
+//   - pattern: 模式，如 "{deps, $Deps}"
+//
+// 输出:
+//   - map[string]Term: 变量绑定
+//   - bool: 是否有顶级项匹配
+//
+// 示例:
+//
+//	if bindings, ok := config.Match(parser.MustCompilePattern(`{minimum_otp_vsn, $Vsn}`)); ok {
+//	  fmt.Println("minimum OTP:", bindings["Vsn"])
+//	}
+func (c *RebarConfig) Match(pattern *Pattern) (map[string]Term, bool) {
+	for _, term := range c.Terms {
+		if bindings, ok := pattern.Match(term); ok {
+			return bindings, true
+		}
+	}
+	return nil, false
+}
+
+// matchPattern 递归匹配模式，成功时把变量写入 bindings
+func matchPattern(pattern, term Term, bindings map[string]Term) bool {
+	switch p := pattern.(type) {
+	case Atom:
+		if name, ok := patternVariable(p); ok {
+			if name == "_" {
+				return true
+			}
+			if bound, ok := bindings[name]; ok {
+				return bound.Compare(term)
+			}
+			bindings[name] = term
+			return true
+		}
+	case Tuple:
+		t, ok := term.(Tuple)
+		return ok && matchElements(p.Elements, t.Elements, bindings)
+	case List:
+		t, ok := term.(List)
+		return ok && matchElements(p.Elements, t.Elements, bindings)
+	case Map:
+		t, ok := term.(Map)
+		if !ok {
+			return false
+		}
+		for _, pair := range p.Pairs {
+			value, ok := t.Get(pair.Key)
+			if !ok || !matchPattern(pair.Value, value, bindings) {
+				return false
+			}
+		}
+		return true
+	}
+	return pattern.Compare(term)
+}
+
+// matchElements 逐个匹配元组或列表的元素
+func matchElements(patterns, terms []Term, bindings map[string]Term) bool {
+	if len(patterns) != len(terms) {
+		return false
+	}
+	for i := range patterns {
+		if !matchPattern(patterns[i], terms[i], bindings) {
+			return false
+		}
+	}
+	return true
+}
+
+// patternVariable 返回模式原子表示的变量名，通配符为 "_"
+func patternVariable(atom Atom) (string, bool) {
+	if !atom.IsQuoted && atom.Value == "_" {
+		return "_", true
+	}
+	if atom.IsQuoted && len(atom.Value) > 1 && atom.Value[0] == '$' {
+		return atom.Value[1:], true
+	}
+	return "", false
+}
+
+// quoteVariables 把模式中字面量以外的 $Name 改写为带引号的原子 '$Name'
+func quoteVariables(pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		ch := pattern[i]
+		switch {
+		case ch == '"' || ch == '\'':
+			end := i + 1
+			for end < len(pattern) && pattern[end] != ch {
+				if pattern[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(pattern) {
+				end = len(pattern) - 1
+			}
+			b.WriteString(pattern[i : end+1])
+			i = end
+		case ch == '$' && i+1 < len(pattern) && isVariableStart(pattern[i+1]):
+			end := i + 1
+			for end < len(pattern) && isAtomChar(pattern[end]) && pattern[end] != '@' {
+				end++
+			}
+			b.WriteString("'" + pattern[i:end] + "'")
+			i = end - 1
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
+
+// isVariableStart 检查字符是否可以作为变量名的首字符
+func isVariableStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
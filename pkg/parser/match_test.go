@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"testing"
+)
+
+// TestMatch tests pattern matching and variable bindings
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		term     string
+		matched  bool
+		bindings map[string]string
+	}{
+		{"capture value", `{deps, $Deps}`, `{deps, [jsx]}`, true, map[string]string{"Deps": "[jsx]"}},
+		{"nested", `{$Name, {git, $Url, {tag, $Tag}}}`, `{lager, {git, "https://example.com/lager.git", {tag, "3.9.2"}}}`, true,
+			map[string]string{"Name": "lager", "Url": `"https://example.com/lager.git"`, "Tag": `"3.9.2"`}},
+		{"wildcards", `{$Name, _, $_}`, `{my_jsx, "3.1.0", {pkg, jsx}}`, true, map[string]string{"Name": "my_jsx"}},
+		{"literal mismatch", `{deps, $Deps}`, `{plugins, []}`, false, nil},
+		{"arity mismatch", `{$Name, $Vsn}`, `{a, b, c}`, false, nil},
+		{"repeated variable", `{$X, $X}`, `{a, 'a'}`, true, map[string]string{"X": "a"}},
+		{"repeated variable mismatch", `{$X, $X}`, `{a, b}`, false, nil},
+		{"list", `[$First, $Second]`, `[1, 2]`, true, map[string]string{"First": "1", "Second": "2"}},
+		{"map subset", `#{port => $Port}`, `#{host => "localhost", port => 8080}`, true, map[string]string{"Port": "8080"}},
+		{"map missing key", `#{port => $Port}`, `#{host => "localhost"}`, false, nil},
+		{"dollar in string", `{$Key, "$Value"}`, `{a, "$Value"}`, true, map[string]string{"Key": "a"}},
+		{"no variables", `{erl_opts, [debug_info]}`, `{erl_opts, [debug_info]}`, true, map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bindings, ok := Match(MustParseTerm(tt.term), tt.pattern)
+			if ok != tt.matched {
+				t.Fatalf("Expected matched %v, got %v", tt.matched, ok)
+			}
+			if len(bindings) != len(tt.bindings) {
+				t.Fatalf("Expected bindings %v, got %v", tt.bindings, bindings)
+			}
+			for name, expected := range tt.bindings {
+				if got, ok := bindings[name]; !ok || got.String() != expected {
+					t.Errorf("Expected %s = %s, got %v", name, expected, got)
+				}
+			}
+		})
+	}
+}
+
+// TestCompilePattern tests invalid patterns and matching whole configs
+func TestCompilePattern(t *testing.T) {
+	if _, err := CompilePattern(`{deps, $Deps`); err == nil {
+		t.Errorf("Expected an error for an unterminated pattern")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected MustCompilePattern to panic")
+		}
+	}()
+
+	config, err := Parse(`{erl_opts, [debug_info]}. {minimum_otp_vsn, "25"}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	bindings, ok := config.Match(MustCompilePattern(`{minimum_otp_vsn, $Vsn}`))
+	if !ok || bindings["Vsn"].String() != `"25"` {
+		t.Errorf("Unexpected config match: %v, %v", bindings, ok)
+	}
+	if _, ok := config.Match(MustCompilePattern(`{deps, _}`)); ok {
+		t.Errorf("Expected no match")
+	}
+
+	MustCompilePattern(`{`)
+}
@@ -0,0 +1,186 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"strconv"
+)
+
+// MergeConflict 表示三方合并中双方对同一项做了不同修改
+type MergeConflict struct {
+	// Key 是顶级项的键，如 "deps"、"minimum_otp_vsn"
+	Key string
+	// Name 是列表中元素的名称（如依赖名）；冲突发生在顶级项本身时为空
+	Name string
+	// Base、Ours、Theirs 分别是三方中的值，不存在（被删除或未添加）时为 nil
+	Base, Ours, Theirs Term
+}
+
+// String 返回冲突的描述，如 "deps: cowboy changed on both sides"
+func (c MergeConflict) String() string {
+	where := c.Key
+	if c.Name != "" {
+		where += ": " + c.Name
+	}
+	switch {
+	case c.Ours == nil:
+		return where + " deleted in ours but changed in theirs"
+	case c.Theirs == nil:
+		return where + " changed in ours but deleted in theirs"
+	case c.Base == nil:
+		return where + " added differently on both sides"
+	}
+	return where + " changed on both sides"
+}
+
+// Merge3 对 rebar.config 做结构化的三方合并
+// @pkg 按顶级项的键匹配三方的项，只有一方修改的项采用该方的值（包括添加和删除），双方修改相同时直接采用。
+// deps、plugins 和 project_plugins 双方都有修改时按元素名称（依赖名、插件名）继续逐项合并，
+// 因此双方分别添加、升级或删除不同的依赖不会冲突。
+// 双方对同一项做了不同修改时记录冲突，并在结果中保留 ours 的值。
+// 结果中项的顺序沿用 ours，只在 theirs 中新增的项追加到末尾；结果的 Raw 为 ours 的原始输入，
+// 可以用 FormatPreserving 写回以保留 ours 中未变化部分的注释和格式
+// 输入:
+//   - base: 共同祖先
+//   - ours: 当前分支的版本
+//   - theirs: 要合并进来的版本
+//
+// 输出:
+//   - *RebarConfig: 合并结果
+//   - []MergeConflict: 冲突，没有冲突时为空
+//
+// 示例:
+//
+//	// git merge driver: rebarmerge %O %A %B
+//	merged, conflicts := parser.Merge3(base, ours, theirs)
+//	for _, c := range conflicts {
+//	  fmt.Fprintln(os.Stderr, c)
+//	}
+//	os.WriteFile(oursPath, []byte(merged.FormatPreserving(4)), 0644)
+func Merge3(base, ours, theirs *RebarConfig) (*RebarConfig, []MergeConflict) {
+	var conflicts []MergeConflict
+	terms := merge3Terms(base.Terms, ours.Terms, theirs.Terms, "", &conflicts)
+	return &RebarConfig{Raw: ours.Raw, Terms: terms}, conflicts
+}
+
+// merge3Lists 是按元素名称逐项合并的顶级列表
+var merge3Lists = map[string]bool{"deps": true, "plugins": true, "project_plugins": true}
+
+// merge3Terms 按标识合并三方的项序列
+// @pkg listKey 为空时合并顶级项，否则合并该键的列表中的元素
+func merge3Terms(base, ours, theirs []Term, listKey string, conflicts *[]MergeConflict) []Term {
+	_, baseTerms := merge3Index(base)
+	oursIDs, oursTerms := merge3Index(ours)
+	theirsIDs, theirsTerms := merge3Index(theirs)
+
+	// theirs 中新增的项，以及在 ours 中被删除、在 theirs 中被修改的项追加到末尾
+	order := oursIDs
+	for _, id := range theirsIDs {
+		if _, ok := oursTerms[id]; ok {
+			continue
+		}
+		if b, ok := baseTerms[id]; !ok || !b.Compare(theirsTerms[id]) {
+			order = append(order, id)
+		}
+	}
+
+	var result []Term
+	for _, id := range order {
+		b, o, t := baseTerms[id], oursTerms[id], theirsTerms[id]
+		var merged Term
+		switch {
+		case sameOrAbsent(o, t):
+			merged = o
+		case sameOrAbsent(o, b):
+			merged = t
+		case sameOrAbsent(t, b):
+			merged = o
+		default:
+			if m, ok := merge3List(b, o, t, listKey, conflicts); ok {
+				merged = m
+				break
+			}
+			conflict := MergeConflict{Key: listKey, Base: b, Ours: o, Theirs: t}
+			name := merge3Name(o, t)
+			if listKey == "" {
+				conflict.Key = name
+			} else {
+				conflict.Name = name
+			}
+			*conflicts = append(*conflicts, conflict)
+			merged = o
+		}
+		if merged != nil {
+			result = append(result, merged)
+		}
+	}
+	return result
+}
+
+// merge3List 在三方都是 {Key, [...]} 形式的可逐项合并的顶级列表时按元素合并
+func merge3List(base, ours, theirs Term, listKey string, conflicts *[]MergeConflict) (Term, bool) {
+	if listKey != "" || ours == nil || theirs == nil {
+		return nil, false
+	}
+	key := termName(ours)
+	if !merge3Lists[key] {
+		return nil, false
+	}
+	// listValue 对空列表返回空切片，对非列表的值返回 nil
+	oursList, _ := listValue([]Term{ours}, key)
+	theirsList, _ := listValue([]Term{theirs}, key)
+	if oursList == nil || theirsList == nil {
+		return nil, false
+	}
+	var baseList []Term
+	if base != nil {
+		if baseList, _ = listValue([]Term{base}, key); baseList == nil {
+			return nil, false
+		}
+	}
+	elements := merge3Terms(baseList, oursList, theirsList, key, conflicts)
+	return Tuple{Elements: []Term{ours.(Tuple).Elements[0], NewList(elements...)}}, true
+}
+
+// merge3Index 返回各项的标识（按出现顺序）和标识到项的映射
+// @pkg 有名称的项以名称为标识，其他项以紧凑表示为标识；重复出现的标识按出现次数区分
+func merge3Index(terms []Term) ([]string, map[string]Term) {
+	ids := make([]string, 0, len(terms))
+	byID := make(map[string]Term, len(terms))
+	seen := make(map[string]int)
+	for _, term := range terms {
+		id := termName(term)
+		if id == "" {
+			id = "\x00" + compactString(term)
+		}
+		if n := seen[id]; n > 0 {
+			seen[id]++
+			id += "\x00" + strconv.Itoa(n)
+		} else {
+			seen[id] = 1
+		}
+		ids = append(ids, id)
+		byID[id] = term
+	}
+	return ids, byID
+}
+
+// merge3Name 返回冲突项的名称
+func merge3Name(ours, theirs Term) string {
+	term := ours
+	if term == nil {
+		term = theirs
+	}
+	if name := termName(term); name != "" {
+		return name
+	}
+	return compactString(term)
+}
+
+// sameOrAbsent 检查两个项是否相等，两者都不存在也视为相等
+func sameOrAbsent(a, b Term) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Compare(b)
+}
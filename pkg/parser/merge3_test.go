@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestMerge3 tests structural three-way merges by key and by dep name
+func TestMerge3(t *testing.T) {
+	base, _ := Parse(`{erl_opts, [debug_info]}.
+{deps, [{cowboy, "2.9.0"}, {jsx, "3.1.0"}, recon]}.
+{minimum_otp_vsn, "24"}.
+{shell, [{apps, [app]}]}.
+`)
+	ours, _ := Parse(`{erl_opts, [debug_info, warnings_as_errors]}.
+{deps, [{cowboy, "2.10.0"}, {jsx, "3.1.0"}, recon, {meck, "0.9.2"}]}.
+{minimum_otp_vsn, "25"}.
+{shell, [{apps, [app]}]}.
+`)
+	theirs, _ := Parse(`{erl_opts, [debug_info]}.
+{deps, [{cowboy, "2.9.0"}, {jsx, "3.2.0"}, {lager, "3.9.2"}]}.
+{minimum_otp_vsn, "26"}.
+{plugins, [rebar3_hex]}.
+`)
+
+	merged, conflicts := Merge3(base, ours, theirs)
+	expected, _ := Parse(`{erl_opts, [debug_info, warnings_as_errors]}.
+{deps, [{cowboy, "2.10.0"}, {jsx, "3.2.0"}, {meck, "0.9.2"}, {lager, "3.9.2"}]}.
+{minimum_otp_vsn, "25"}.
+{plugins, [rebar3_hex]}.
+`)
+	if !compareConfigs(merged, expected) {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected.Format(4), merged.Format(4))
+	}
+	if len(conflicts) != 1 || conflicts[0].Key != "minimum_otp_vsn" || conflicts[0].String() != "minimum_otp_vsn changed on both sides" {
+		t.Errorf("Expected one minimum_otp_vsn conflict, got %v", conflicts)
+	}
+	if merged.Raw != ours.Raw {
+		t.Error("Expected merged config to keep the ours source")
+	}
+}
+
+// TestMerge3Conflicts tests conflicts inside dep lists and delete/modify conflicts
+func TestMerge3Conflicts(t *testing.T) {
+	base, _ := Parse(`{deps, [{cowboy, "2.9.0"}, {jsx, "3.1.0"}]}. {relx, [{dev_mode, true}]}.`)
+	ours, _ := Parse(`{deps, [{cowboy, "2.10.0"}, {gun, "2.0.0"}]}.`)
+	theirs, _ := Parse(`{deps, [{cowboy, "2.11.0"}, {jsx, "3.2.0"}, {gun, "2.0.1"}]}. {relx, [{dev_mode, false}]}.`)
+
+	merged, conflicts := Merge3(base, ours, theirs)
+	var got []string
+	for _, c := range conflicts {
+		got = append(got, c.String())
+	}
+	expected := []string{
+		"deps: cowboy changed on both sides",
+		"deps: gun added differently on both sides",
+		"deps: jsx deleted in ours but changed in theirs",
+		"relx deleted in ours but changed in theirs",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected conflicts:\n%s\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+	if !compareConfigs(merged, ours) {
+		t.Errorf("Expected conflicting entries to keep ours, got:\n%s", merged.Format(4))
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+// mmapFile 在不支持内存映射的平台上总是返回 errMmapUnsupported
+func mmapFile(path string) ([]byte, func() error, error) {
+	return nil, nil, errMmapUnsupported
+}
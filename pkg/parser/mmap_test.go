@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// TestParseFileWithMmap tests that memory-mapped parsing matches regular parsing
+func TestParseFileWithMmap(t *testing.T) {
+	content := benchLargeConfig(200) + `{escaped, "tab\there", 'quoted atom'}.` + "\n"
+	path := createTempConfigFile(t, content)
+
+	expected, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("Failed to parse file: %v", err)
+	}
+	mapped, err := ParseFile(path, WithMmap())
+	if err != nil {
+		t.Fatalf("Failed to parse file with mmap: %v", err)
+	}
+
+	if !compareConfigs(expected, mapped) {
+		t.Error("Expected mmap parse to match regular parse")
+	}
+	if mapped.Raw != content {
+		t.Error("Expected Raw to hold the file content")
+	}
+
+	// The mapping is gone; values must still be readable after other work.
+	runtime.GC()
+	if got := mapped.Terms[len(mapped.Terms)-1].String(); !strings.Contains(got, "quoted atom") {
+		t.Errorf("Unexpected last term after unmapping: %s", got)
+	}
+}
+
+// TestParseFileWithMmapEdgeCases tests empty, invalid and missing files
+func TestParseFileWithMmapEdgeCases(t *testing.T) {
+	empty := createTempConfigFile(t, "")
+	config, err := ParseFile(empty, WithMmap())
+	if err != nil || len(config.Terms) != 0 {
+		t.Errorf("Expected empty config, got %v, %v", config, err)
+	}
+
+	invalid := createTempConfigFile(t, "{deps, [")
+	if _, err := ParseFile(invalid, WithMmap()); err == nil || !strings.Contains(err.Error(), "syntax error") {
+		t.Errorf("Expected syntax error, got %v", err)
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing.config")
+	if _, err := ParseFile(missing, WithMmap()); err == nil || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected not-exist error from fallback read, got %v", err)
+	}
+}
@@ -0,0 +1,257 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// ToMap 将配置转换为普通的 Go 值
+// @pkg 便于不使用 Term 类型判断直接读取配置，转换是有损的。约定:
+// - 顶层的 {Key, Value} 项成为映射的键值对，{Key, V1, V2, ...} 的值为 []interface{}；
+// 重复的键只保留第一个（与 GetTerm 一致），其他形式的顶层项被忽略
+// - 原子转换为 string，true 和 false 原子转换为 bool
+// - 字符串和二进制转换为 string，整数转换为 int64，浮点数转换为 float64
+// - 由 {Key, Value} 元组和原子组成、键不重复的属性列表（如 deps）转换为 map[string]interface{}，
+// 单独的原子 Key 对应值 true；其他列表和元组转换为 []interface{}
+// - Erlang 映射转换为 map[string]interface{}，原子、字符串和二进制以外的键使用其 Erlang 文本
+//
+// 输出:
+//   - map[string]interface{}: 转换后的配置
+//
+// 示例:
+//
+//	config, _ := parser.Parse(`{deps, [{cowboy, "2.9.0"}]}.`)
+//	deps := config.ToMap()["deps"].(map[string]interface{})
+//	fmt.Println(deps["cowboy"]) // 输出: 2.9.0
+func (c *RebarConfig) ToMap() map[string]interface{} {
+	m := make(map[string]interface{})
+	for _, term := range c.Terms {
+		tuple, ok := term.(Tuple)
+		if !ok || len(tuple.Elements) < 2 {
+			continue
+		}
+		key, ok := tuple.Elements[0].(Atom)
+		if !ok {
+			continue
+		}
+		if _, exists := m[key.Value]; exists {
+			continue
+		}
+		if len(tuple.Elements) == 2 {
+			m[key.Value] = ToNative(tuple.Elements[1])
+		} else {
+			m[key.Value] = nativeSlice(tuple.Elements[1:])
+		}
+	}
+	return m
+}
+
+// ToNative 将单个项转换为普通的 Go 值
+// @pkg 转换规则与 ToMap 相同
+// 输入:
+//   - term: 要转换的项
+//
+// 输出:
+//   - interface{}: string、bool、int64、float64、[]interface{} 或 map[string]interface{}
+func ToNative(term Term) interface{} {
+	switch t := term.(type) {
+	case Atom:
+		switch t.Value {
+		case "true":
+			return true
+		case "false":
+			return false
+		}
+		return t.Value
+	case String:
+		return t.Value
+	case Binary:
+		return t.Value
+	case Integer:
+		return t.Value
+	case Float:
+		return t.Value
+	case Tuple:
+		return nativeSlice(t.Elements)
+	case List:
+		if keys, values, ok := proplistPairs(t.Elements); ok {
+			m := make(map[string]interface{}, len(keys))
+			for i, key := range keys {
+				m[key] = ToNative(values[i])
+			}
+			return m
+		}
+		return nativeSlice(t.Elements)
+	case Map:
+		m := make(map[string]interface{}, len(t.Pairs))
+		for _, pair := range t.Pairs {
+			key := termText(pair.Key)
+			if b, ok := pair.Key.(Binary); ok {
+				key = b.Value
+			}
+			m[key] = ToNative(pair.Value)
+		}
+		return m
+	}
+	return term.String()
+}
+
+// nativeSlice 将项列表转换为 []interface{}
+func nativeSlice(terms []Term) []interface{} {
+	values := make([]interface{}, len(terms))
+	for i, term := range terms {
+		values[i] = ToNative(term)
+	}
+	return values
+}
+
+// FromMap 根据普通的 Go 值构建配置
+// @pkg 每个键成为一个顶层的 {Key, Value} 项，按键排序以保证输出稳定。值的转换规则:
+// - string 转换为 Erlang 字符串；需要原子时直接使用 Atom 值（任何 Term 值原样保留）
+// - bool 转换为 true 或 false 原子，nil 转换为 undefined 原子
+// - 各种整数类型转换为 Integer，float32 和 float64 转换为 Float
+// - 切片和数组转换为列表
+// - 键为字符串的映射转换为按键排序的属性列表 [{Key, Value}, ...]
+//
+// 输入:
+//   - m: 要转换的映射
+//
+// 输出:
+//   - *RebarConfig: 构建的配置，Raw 为空
+//   - error: 存在无法转换的值时返回错误
+//
+// 示例:
+//
+//	config, err := parser.FromMap(map[string]interface{}{
+//	  "erl_opts": []interface{}{parser.Atom{Value: "debug_info"}},
+//	  "deps":     map[string]interface{}{"cowboy": "2.9.0"},
+//	})
+//	fmt.Print(config.Format(4))
+func FromMap(m map[string]interface{}) (*RebarConfig, error) {
+	keys := sortedKeys(m)
+	config := &RebarConfig{Terms: make([]Term, 0, len(keys))}
+	for _, key := range keys {
+		value, err := FromNative(m[key])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		config.Terms = append(config.Terms, Tuple{Elements: []Term{atomTerm(key), value}})
+	}
+	return config, nil
+}
+
+// FromNative 将普通的 Go 值转换为项
+// @pkg 转换规则与 FromMap 相同
+// 输入:
+//   - value: 要转换的值
+//
+// 输出:
+//   - Term: 转换后的项
+//   - error: 值的类型无法转换，或无符号整数超出 int64 范围时返回错误
+func FromNative(value interface{}) (Term, error) {
+	switch v := value.(type) {
+	case nil:
+		return Atom{Value: "undefined"}, nil
+	case Term:
+		return v, nil
+	case string:
+		return String{Value: v}, nil
+	case bool:
+		if v {
+			return Atom{Value: "true"}, nil
+		}
+		return Atom{Value: "false"}, nil
+	case float32:
+		return Float{Value: float64(v)}, nil
+	case float64:
+		return Float{Value: v}, nil
+	case map[string]interface{}:
+		keys := sortedKeys(v)
+		elements := make([]Term, 0, len(keys))
+		for _, key := range keys {
+			elem, err := FromNative(v[key])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			elements = append(elements, Tuple{Elements: []Term{atomTerm(key), elem}})
+		}
+		return List{Elements: elements}, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Integer{Value: rv.Int()}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("unsigned value %d overflows int64", rv.Uint())
+		}
+		return Integer{Value: int64(rv.Uint())}, nil
+	case reflect.Slice, reflect.Array:
+		elements := make([]Term, rv.Len())
+		for i := range elements {
+			elem, err := FromNative(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = elem
+		}
+		return List{Elements: elements}, nil
+	}
+	return nil, fmt.Errorf("unsupported value type %T", value)
+}
+
+// proplistPairs 将属性列表拆分为键和值，列表不是键唯一的属性列表时返回 false
+// @pkg 至少需要一个 {Key, Value} 元组，单独的原子 Key 对应值 true
+func proplistPairs(elements []Term) ([]string, []Term, bool) {
+	keys := make([]string, 0, len(elements))
+	values := make([]Term, 0, len(elements))
+	seen := make(map[string]bool)
+	pairs := 0
+	for _, elem := range elements {
+		var key string
+		var value Term
+		switch e := elem.(type) {
+		case Atom:
+			key, value = e.Value, Atom{Value: "true"}
+		case Tuple:
+			if len(e.Elements) != 2 {
+				return nil, nil, false
+			}
+			atom, ok := e.Elements[0].(Atom)
+			if !ok {
+				return nil, nil, false
+			}
+			key, value = atom.Value, e.Elements[1]
+			pairs++
+		default:
+			return nil, nil, false
+		}
+		if seen[key] {
+			return nil, nil, false
+		}
+		seen[key] = true
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return keys, values, pairs > 0
+}
+
+// atomTerm 返回值为 value 的原子，需要时设置 IsQuoted
+func atomTerm(value string) Atom {
+	return Atom{Value: value, IsQuoted: !isPlainAtom(value)}
+}
+
+// sortedKeys 返回映射的键，按字典序排列
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
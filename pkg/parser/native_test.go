@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestToMap tests conversion of configs to native Go values
+func TestToMap(t *testing.T) {
+	config, err := Parse(`{erl_opts, [debug_info, {d, 'TEST'}]}.
+{deps, [recon, {cowboy, "2.9.0"}, {jsx, {pkg, jsx_fork}}]}.
+{relx, [{release, {app, "0.1.0"}, [app]}, {dev_mode, true}]}.
+{dist_node, [{setcookie, abc}], extra}.
+{limits, #{size => 3, <<"ratio">> => 0.5}}.
+{deps, []}.
+plain_atom.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"erl_opts": map[string]interface{}{"debug_info": true, "d": "TEST"},
+		"deps": map[string]interface{}{
+			"recon":  true,
+			"cowboy": "2.9.0",
+			"jsx":    []interface{}{"pkg", "jsx_fork"},
+		},
+		"relx": []interface{}{
+			[]interface{}{"release", []interface{}{"app", "0.1.0"}, []interface{}{"app"}},
+			[]interface{}{"dev_mode", true},
+		},
+		"dist_node": []interface{}{map[string]interface{}{"setcookie": "abc"}, "extra"},
+		"limits":    map[string]interface{}{"size": int64(3), "ratio": 0.5},
+	}
+	if got := config.ToMap(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %#v, got %#v", expected, got)
+	}
+}
+
+// TestFromMap tests building configs from native Go values
+func TestFromMap(t *testing.T) {
+	config, err := FromMap(map[string]interface{}{
+		"erl_opts":       []interface{}{Atom{Value: "debug_info"}, true},
+		"deps":           map[string]interface{}{"cowboy": "2.9.0", "my-dep": nil},
+		"minimum_otp":    uint8(24),
+		"cover_enabled":  false,
+		"sample_ratio":   float32(0.5),
+		"src_dirs":       []string{"src", "lib"},
+		"xref_extra":     [2]int{1, 2},
+		"empty_settings": map[string]interface{}{},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build config: %v", err)
+	}
+
+	expected := `{cover_enabled, false}.
+{deps, [{cowboy, "2.9.0"}, {'my-dep', undefined}]}.
+{empty_settings, []}.
+{erl_opts, [debug_info, true]}.
+{minimum_otp, 24}.
+{sample_ratio, 0.5}.
+{src_dirs, ["src", "lib"]}.
+{xref_extra, [1, 2]}.
+`
+	var got strings.Builder
+	for _, term := range config.Terms {
+		got.WriteString(term.String() + ".\n")
+	}
+	if got.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got.String())
+	}
+
+	if _, err := FromMap(map[string]interface{}{"bad": map[string]interface{}{"ch": make(chan int)}}); err == nil || err.Error() != "bad: ch: unsupported value type chan int" {
+		t.Errorf("Expected unsupported type error, got %v", err)
+	}
+
+	if term, err := FromNative(uint64(math.MaxInt64)); err != nil || term.String() != "9223372036854775807" {
+		t.Errorf("Expected MaxInt64 to convert, got %v, %v", term, err)
+	}
+	if _, err := FromNative([]uint64{math.MaxInt64 + 1}); err == nil || err.Error() != "unsigned value 9223372036854775808 overflows int64" {
+		t.Errorf("Expected overflow error, got %v", err)
+	}
+}
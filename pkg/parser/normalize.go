@@ -0,0 +1,117 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+// Normalize 返回语义上等价的规范化配置
+// @pkg 用于比较来自不同仓库、写法和顺序各异的配置，两个配置规范化后 Compare 相等即视为等价:
+// - 字面量统一写法：原子去掉不必要的引号，数字和字符串丢弃 KeepLiterals 记录的原始写法，映射按键排序并去掉重复的键，
+// 可打印的字符列表（如 [104,105]，可打印的判断与 Erlang ~p 的默认规则相同）写为字符串 "hi"，空字符串 "" 写为 []（在 Erlang 中它们是同一个项）
+// - deps、plugins、project_plugins 按名称去重（保留第一次出现的声明，与 rebar3 一致）并排序
+// - erl_opts、eunit_compile_opts、ct_compile_opts 按 NormalizeErlOpts 去重，顺序保持不变（编译选项的顺序有意义）
+// - 顶级配置项、各 profile 以及 profile 中的配置项按键名排序，profile 中的列表按同样的规则处理
+//
+// 原配置不会被修改，返回的配置不包含原始文本
+// 输出:
+//   - *RebarConfig: 规范化后的配置
+//
+// 示例:
+//
+//	a, _ := parser.ParseFile("repo-a/rebar.config")
+//	b, _ := parser.ParseFile("repo-b/rebar.config")
+//	same := bytes.Equal(a.Normalize().Canonical(), b.Normalize().Canonical())
+func (c *RebarConfig) Normalize() *RebarConfig {
+	terms := make([]Term, len(c.Terms))
+	for i, term := range c.Terms {
+		terms[i] = normalizeLiteral(canonicalTerm(term))
+	}
+	terms = normalizeSection(terms, true)
+	return &RebarConfig{Terms: sortSection(terms, FormatOptions{SortDeps: true, SortKeys: true}, true)}
+}
+
+// normalizeSection 对配置项列表中的依赖、插件和编译选项去重，top 表示是否为顶层（决定是否处理 profiles）
+func normalizeSection(terms []Term, top bool) []Term {
+	result := make([]Term, len(terms))
+	for i, term := range terms {
+		result[i] = term
+		tuple, ok := term.(Tuple)
+		if !ok || len(tuple.Elements) != 2 {
+			continue
+		}
+		list, ok := tuple.Elements[1].(List)
+		if !ok {
+			continue
+		}
+
+		var elements []Term
+		switch key := termName(tuple); {
+		case sortedLists[key]:
+			elements = uniqueByName(list.Elements)
+		case key == "erl_opts" || key == "eunit_compile_opts" || key == "ct_compile_opts":
+			elements = NormalizeErlOpts(list.Elements)
+		case top && key == "profiles":
+			elements = make([]Term, len(list.Elements))
+			for j, profile := range list.Elements {
+				elements[j] = profile
+				if entries, ok := profile.(Tuple); ok && len(entries.Elements) == 2 {
+					if opts, ok := entries.Elements[1].(List); ok {
+						elements[j] = Tuple{Elements: []Term{entries.Elements[0], List{Elements: normalizeSection(opts.Elements, false)}}}
+					}
+				}
+			}
+		default:
+			continue
+		}
+		result[i] = Tuple{Elements: []Term{tuple.Elements[0], List{Elements: elements}}}
+	}
+	return result
+}
+
+// uniqueByName 按名称去重，保留第一次出现的项；没有名称的项按 Compare 去重
+func uniqueByName(terms []Term) []Term {
+	seen := make(map[string]bool)
+	unique := make([]Term, 0, len(terms))
+	for _, term := range terms {
+		if name := termName(term); name != "" {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+		} else if containsTerm(unique, term) {
+			continue
+		}
+		unique = append(unique, term)
+	}
+	return unique
+}
+
+// normalizeLiteral 将可打印的字符列表写为字符串，将空字符串写为空列表
+func normalizeLiteral(term Term) Term {
+	switch t := term.(type) {
+	case String:
+		if t.Value == "" {
+			return List{Elements: []Term{}}
+		}
+	case List:
+		if chars, ok := charList(t.Elements); ok && len(chars) > 0 && printableLatin1(chars) {
+			return String{Value: string(chars)}
+		}
+		elements := make([]Term, len(t.Elements))
+		for i, elem := range t.Elements {
+			elements[i] = normalizeLiteral(elem)
+		}
+		return List{Elements: elements}
+	case Tuple:
+		elements := make([]Term, len(t.Elements))
+		for i, elem := range t.Elements {
+			elements[i] = normalizeLiteral(elem)
+		}
+		return Tuple{Elements: elements}
+	case Map:
+		pairs := make([]MapPair, len(t.Pairs))
+		for i, pair := range t.Pairs {
+			pairs[i] = MapPair{Key: normalizeLiteral(pair.Key), Value: normalizeLiteral(pair.Value)}
+		}
+		return Map{Pairs: pairs}
+	}
+	return term
+}
@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestNormalize tests that differently written equivalent configs normalize to the same config
+func TestNormalize(t *testing.T) {
+	a, err := Parse(`{'deps', [{jsx, "3.1.0"}, {cowboy, "2.9.0"}, {jsx, "3.0.0"}]}.
+{erl_opts, [debug_info, warnings_as_errors, debug_info]}.
+{app_vsn, [49, 46, 48]}.
+{profiles, [
+    {test, [{erl_opts, [nowarn_export_all, nowarn_export_all]}, {deps, [meck, proper, meck]}]},
+    {prod, [{relx, [{dev_mode, false}]}]}
+]}.
+{dist, #{port => 0.5e1, host => ""}}.
+{ports, [80, 443]}.
+`, KeepLiterals())
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	b, err := Parse(`{dist, #{host => [], port => 5.0}}.
+{profiles, [
+    {prod, [{relx, [{dev_mode, false}]}]},
+    {test, [{deps, [proper, meck]}, {erl_opts, [nowarn_export_all]}]}
+]}.
+{app_vsn, "1.0"}.
+{deps, [{cowboy, "2.9.0"}, {jsx, "3.1.0"}]}.
+{erl_opts, [debug_info, warnings_as_errors]}.
+{ports, [80, 443]}.
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	na, nb := a.Normalize(), b.Normalize()
+	if !bytes.Equal(na.Canonical(), nb.Canonical()) {
+		t.Errorf("Expected equal normalized configs:\n%s\n%s", na.Canonical(), nb.Canonical())
+	}
+
+	expected := `{app_vsn,"1.0"}.
+{deps,[{cowboy,"2.9.0"},{jsx,"3.1.0"}]}.
+{dist,#{host=>[],port=>5.0}}.
+{erl_opts,[debug_info,warnings_as_errors]}.
+{ports,[80,443]}.
+{profiles,[{prod,[{relx,[{dev_mode,false}]}]},{test,[{deps,[meck,proper]},{erl_opts,[nowarn_export_all]}]}]}.
+`
+	if got := string(na.Canonical()); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+	if na.Raw != "" {
+		t.Error("Expected normalized config to have no source")
+	}
+	if deps, _ := a.GetDeps(); len(deps[0].(List).Elements) != 3 {
+		t.Errorf("Expected original config to be unchanged, got %s", deps[0])
+	}
+}
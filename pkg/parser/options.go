@@ -0,0 +1,90 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"time"
+)
+
+// ParseOption 是解析选项
+// @pkg 通过函数式选项调整解析行为，可传给 Parse、ParseFile 和 ParseReader
+//
+// 示例:
+//
+//	config, err := parser.ParseFile("./huge.config", parser.WithMmap())
+type ParseOption func(*parseOptions)
+
+// parseOptions 保存所有解析选项的值
+type parseOptions struct {
+	mmap       bool
+	discardRaw bool
+	atoms      *AtomTable
+	foldConcat bool
+	literals   bool
+	profiles   []string
+	envProfile bool
+	script     scriptMode
+	getenv     func(string) (string, bool)
+
+	// watchInterval 只被 Watch 使用
+	watchInterval time.Duration
+}
+
+// newParseOptions 根据选项列表构造选项值
+func newParseOptions(opts []ParseOption) parseOptions {
+	var o parseOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithMmap 让 ParseFile 通过内存映射读取文件
+// @pkg 适用于数 MB 级别的生成配置文件：直接在映射的内存上解析，
+// 避免先读入缓冲区再复制为字符串。解析结果中的原子、字符串和 Raw 会复制到 Go 内存中，
+// 解析完成后立即解除映射，因此返回的配置可以安全地长期持有。
+// 当前平台不支持内存映射或映射失败时，自动回退为普通读取。
+func WithMmap() ParseOption {
+	return func(o *parseOptions) {
+		o.mmap = true
+	}
+}
+
+// DiscardRaw 让解析结果不保存原始输入
+// @pkg 解析后的 RebarConfig.Raw 为空字符串。原子和字符串的值会被复制，
+// 不再引用输入，因此输入在解析完成后即可被回收，适合批量分析大量配置时降低内存占用。
+// 需要原始文本时可以用 Format 重新生成等价的配置内容。
+func DiscardRaw() ParseOption {
+	return func(o *parseOptions) {
+		o.discardRaw = true
+	}
+}
+
+// FoldConcat 让解析器折叠列表字面量之间的 ++ 表达式
+// @pkg 生成的配置中有时会出现 {erl_opts, [debug_info] ++ [warnings_as_errors]}，
+// 启用该选项后这类表达式被解析为一个合并后的列表。只支持列表字面量之间的拼接，
+// 其他表达式仍会返回 *ScriptError
+//
+// 示例:
+//
+//	config, err := parser.Parse(`{erl_opts, [debug_info] ++ [warnings_as_errors]}.`, parser.FoldConcat())
+func FoldConcat() ParseOption {
+	return func(o *parseOptions) {
+		o.foldConcat = true
+	}
+}
+
+// KeepLiterals 让解析器记录数字和字符串在源码中的写法
+// @pkg 写法保存在 Integer、Float 和 String 的 Text 字段中，如版本号形式的 2.10 记录为 "2.10"。
+// 配合 FormatOptions.Literals 格式化时原样输出这些写法，避免 2.10 被改写为 2.1、"\x41" 被改写为 "A"。
+// Text 不参与 Compare，但使用 == 或 reflect.DeepEqual 比较项时会有影响
+//
+// 示例:
+//
+//	config, _ := parser.ParseFile("./rebar.config", parser.KeepLiterals())
+//	formatted := config.FormatWith(parser.FormatOptions{Indent: 4, Literals: true})
+func KeepLiterals() ParseOption {
+	return func(o *parseOptions) {
+		o.literals = true
+	}
+}
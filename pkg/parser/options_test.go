@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+// TestDiscardRaw tests that DiscardRaw drops the raw input without changing terms
+func TestDiscardRaw(t *testing.T) {
+	input := `{deps, [{cowboy, "2.9.0"}, {'my-dep', "1.0.0"}]}.`
+
+	expected, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	t.Run("Parse", func(t *testing.T) {
+		config, err := Parse(input, DiscardRaw())
+		if err != nil {
+			t.Fatalf("Failed to parse config: %v", err)
+		}
+		if config.Raw != "" {
+			t.Errorf("Expected empty Raw, got %q", config.Raw)
+		}
+		if !compareConfigs(expected, config) {
+			t.Error("Expected terms to match regular parse")
+		}
+	})
+
+	t.Run("ParseReader", func(t *testing.T) {
+		config, err := ParseReader(strings.NewReader(input), DiscardRaw())
+		if err != nil || config.Raw != "" || !compareConfigs(expected, config) {
+			t.Errorf("Unexpected ParseReader result: %v, %v", config, err)
+		}
+	})
+
+	t.Run("ParseFile", func(t *testing.T) {
+		path := createTempConfigFile(t, input)
+		for _, opts := range [][]ParseOption{{DiscardRaw()}, {DiscardRaw(), WithMmap()}} {
+			config, err := ParseFile(path, opts...)
+			if err != nil || config.Raw != "" || !compareConfigs(expected, config) {
+				t.Errorf("Unexpected ParseFile result: %v, %v", config, err)
+			}
+		}
+	})
+}
+
+// TestDiscardRawDetachesValues tests that parsed values do not alias the input
+func TestDiscardRawDetachesValues(t *testing.T) {
+	input := benchLargeConfig(10)
+	config, err := Parse(input, DiscardRaw())
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	start := stringData(input)
+	end := start + uintptr(len(input))
+	deps, _ := config.GetDeps()
+	dep := deps[0].(List).Elements[0].(Tuple)
+	for _, value := range []string{dep.Elements[0].(Atom).Value, dep.Elements[1].(Tuple).Elements[1].(String).Value} {
+		if p := stringData(value); p >= start && p < end {
+			t.Errorf("Value %q still references the input", value)
+		}
+	}
+}
+
+// stringData returns the address of a string's backing bytes
+func stringData(s string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+}
+
+// TestFoldConcat tests folding of ++ between list literals
+func TestFoldConcat(t *testing.T) {
+	input := `{erl_opts, [debug_info] ++ [warnings_as_errors] ++ []}.
+{deps, [{cowboy, "2.9.0"}] ++
+       %% test-only deps
+       [{meck, "0.9.2"}]}.`
+	expected, err := Parse(`{erl_opts, [debug_info, warnings_as_errors]}.
+{deps, [{cowboy, "2.9.0"}, {meck, "0.9.2"}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse expected config: %v", err)
+	}
+
+	config, err := Parse(input, FoldConcat())
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	if !compareConfigs(expected, config) {
+		t.Errorf("Expected %v, got %v", expected.Terms, config.Terms)
+	}
+
+	config, err = ParseReader(strings.NewReader(input), FoldConcat())
+	if err != nil || !compareConfigs(expected, config) {
+		t.Errorf("Unexpected ParseReader result: %v, %v", config, err)
+	}
+
+	if _, err := Parse(input); err == nil {
+		t.Error("Expected an error without FoldConcat")
+	}
+
+	_, err = Parse(`{erl_opts, [debug_info] ++ debug}.`, FoldConcat())
+	if err == nil || !strings.Contains(err.Error(), "right operand of '++' must be a list") {
+		t.Errorf("Expected operand error, got %v", err)
+	}
+}
+
+// TestKeepLiterals tests that KeepLiterals records the source text of numbers and strings
+func TestKeepLiterals(t *testing.T) {
+	input := `{vsn, 2.10}. {retries, 007}. {ratio, 1.0e3}. {name, "\x41bc"}.`
+
+	config, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	if f := config.Terms[0].(Tuple).Elements[1].(Float); f.Text != "" {
+		t.Errorf("Expected no literal text without KeepLiterals, got %q", f.Text)
+	}
+
+	expected := []Term{
+		Float{Value: 2.1, Text: "2.10"},
+		Integer{Value: 7, Text: "007"},
+		Float{Value: 1000, Text: "1.0e3"},
+		String{Value: "Abc", Text: `"\x41bc"`},
+	}
+	for _, opts := range [][]ParseOption{{KeepLiterals()}, {KeepLiterals(), DiscardRaw()}} {
+		config, err := Parse(input, opts...)
+		if err != nil {
+			t.Fatalf("Failed to parse config: %v", err)
+		}
+		for i, want := range expected {
+			if got := config.Terms[i].(Tuple).Elements[1]; !reflect.DeepEqual(got, want) {
+				t.Errorf("Expected %#v, got %#v", want, got)
+			}
+		}
+	}
+
+	config, err = ParseReader(strings.NewReader(input), KeepLiterals())
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	if got := config.Terms[1].(Tuple).Elements[1]; !reflect.DeepEqual(got, expected[1]) {
+		t.Errorf("Expected %#v from ParseReader, got %#v", expected[1], got)
+	}
+}
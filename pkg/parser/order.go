@@ -0,0 +1,43 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"sort"
+)
+
+// Cmp 按 Erlang 的项顺序比较两个项
+// @pkg 顺序为 数字 < 原子 < 元组 < 映射 < 列表 < 二进制，与 Erlang 的 <、== 和 > 一致:
+// - 整数与浮点数按数值比较，1 与 1.0 相等
+// - 原子和二进制按字节比较，字符串视为字符整数的列表，"" 与 [] 相等
+// - 元组先比较大小再逐个比较元素，列表逐个比较元素，前缀相同时较短的在前
+// - 映射先比较大小，再按顺序比较排序后的键，最后比较对应的值
+//
+// 输入:
+//   - a, b: 要比较的项
+//
+// 输出:
+//   - int: a 在前时为 -1，相等时为 0，a 在后时为 1
+//
+// 示例:
+//
+//	parser.Cmp(parser.NewInteger(1), parser.NewAtom("a")) // -1
+//	parser.Cmp(parser.NewInteger(2), parser.NewFloat(2.0)) // 0
+func Cmp(a, b Term) int {
+	return sign(compareTerms(a, b, false))
+}
+
+// SortTerms 按 Erlang 的项顺序对项排序，与 lists:sort/1 的结果一致
+// @pkg 原地稳定排序，Cmp 相等的项（如 1 与 1.0）保持原有顺序
+// 输入:
+//   - terms: 要排序的项
+//
+// 示例:
+//
+//	list := parser.MustParseTerm(`[b, 1, {x}, "s", a]`).(parser.List)
+//	parser.SortTerms(list.Elements) // [1, a, b, {x}, "s"]
+func SortTerms(terms []Term) {
+	sort.SliceStable(terms, func(i, j int) bool {
+		return compareTerms(terms[i], terms[j], false) < 0
+	})
+}
@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"testing"
+)
+
+// TestCmp tests Erlang term order across and within types
+func TestCmp(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{`1`, `a`, -1},
+		{`a`, `{}`, -1},
+		{`{a, b}`, `#{}`, -1},
+		{`#{}`, `[]`, -1},
+		{`[]`, `[1]`, -1},
+		{`[1]`, `<<>>`, -1},
+		{`1`, `1.0`, 0},
+		{`2`, `1.5`, 1},
+		{`9007199254740993`, `9007199254740992`, 1},
+		{`abc`, `abd`, -1},
+		{`{z}`, `{a, a}`, -1},
+		{`{a, 2}`, `{a, 1.0}`, 1},
+		{`[a, b]`, `[a]`, 1},
+		{`"ab"`, `[97, 98]`, 0},
+		{`""`, `[]`, 0},
+		{`"a"`, `[97.0]`, 0},
+		{`#{b => 1, a => 2}`, `#{a => 2, b => 1}`, 0},
+		{`#{a => 1}`, `#{b => 0}`, -1},
+		{`#{a => 1, b => 1}`, `#{c => 0}`, 1},
+		{`<<"a">>`, `<<"ab">>`, -1},
+	}
+
+	for _, tt := range tests {
+		a, b := MustParseTerm(tt.a), MustParseTerm(tt.b)
+		if got := Cmp(a, b); got != tt.expected {
+			t.Errorf("Cmp(%s, %s): expected %d, got %d", tt.a, tt.b, tt.expected, got)
+		}
+		if got := Cmp(b, a); got != -tt.expected {
+			t.Errorf("Cmp(%s, %s): expected %d, got %d", tt.b, tt.a, -tt.expected, got)
+		}
+	}
+}
+
+// TestSortTerms tests sorting a mixed list the way lists:sort/1 does
+func TestSortTerms(t *testing.T) {
+	list := MustParseTerm(`[<<"bin">>, "str", [], {b}, {a, 1}, #{k => v}, zeta, alpha, 2.5, 1.0, 1, -3]`).(List)
+	SortTerms(list.Elements)
+
+	expected := `[-3, 1.0, 1, 2.5, alpha, zeta, {b}, {a, 1}, #{k => v}, [], "str", <<"bin">>]`
+	if got := list.String(); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
@@ -0,0 +1,195 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OverlayVars 表示 relx overlay 变量
+// @pkg overlay 变量文件由 {Name, Value}. 形式的顶级项组成，
+// relx 用这些变量渲染 overlay 模板中的 {{Name}} 占位符
+// 值为 {Key, Value} 列表时，可以用 {{Name.Key}} 访问嵌套的值
+type OverlayVars map[string]Term
+
+// ParseOverlayVars 解析 overlay 变量文件的内容
+// 输入:
+//   - input: overlay 变量文件的内容，如 "{port, 8080}. {node_name, \"app@host\"}."
+//
+// 输出:
+//   - OverlayVars: 变量名到变量值的映射，重复定义时后出现的值生效
+//   - error: 语法或结构错误
+func ParseOverlayVars(input string) (OverlayVars, error) {
+	terms, err := ParseConsult(input)
+	if err != nil {
+		return nil, err
+	}
+	vars := make(OverlayVars)
+	if err := vars.add(terms); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+// LoadOverlayVars 读取并合并若干 overlay 变量文件
+// @pkg 与 relx 的 overlay_vars 配置一致，后面文件中的变量覆盖前面文件中的同名变量
+// 输入:
+//   - paths: 按优先级从低到高排列的文件路径
+//
+// 输出:
+//   - OverlayVars: 合并后的变量
+//   - error: 读取、语法或结构错误
+//
+// 示例:
+//
+//	vars, err := parser.LoadOverlayVars("config/vars.config", "config/vars_prod.config")
+//	if err != nil {
+//	  log.Fatal(err)
+//	}
+//	vmArgs, err := vars.Render(template)
+func LoadOverlayVars(paths ...string) (OverlayVars, error) {
+	vars := make(OverlayVars)
+	for _, path := range paths {
+		terms, err := Consult(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if err := vars.add(terms); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return vars, nil
+}
+
+// add 将 {Name, Value} 形式的项加入变量集合
+func (v OverlayVars) add(terms []Term) error {
+	for _, term := range terms {
+		tuple, ok := term.(Tuple)
+		if !ok || len(tuple.Elements) != 2 {
+			return fmt.Errorf("invalid overlay vars: expected {Name, Value}, got %s", term)
+		}
+		name, ok := tuple.Elements[0].(Atom)
+		if !ok {
+			return fmt.Errorf("invalid overlay vars: name must be an atom, got %s", tuple.Elements[0])
+		}
+		v[name.Value] = tuple.Elements[1]
+	}
+	return nil
+}
+
+// Lookup 按名称查找变量
+// @pkg 名称中的点号表示进入嵌套的 {Key, Value} 列表，如 "db.host"
+// 输入:
+//   - name: 变量名
+//
+// 输出:
+//   - Term: 变量值
+//   - bool: 是否找到
+func (v OverlayVars) Lookup(name string) (Term, bool) {
+	parts := strings.Split(name, ".")
+	value, ok := v[parts[0]]
+	for _, part := range parts[1:] {
+		if !ok {
+			break
+		}
+		value, ok = proplistValue(value, part)
+	}
+	return value, ok
+}
+
+// Render 用变量替换模板中的 {{Name}} 与 {{{Name}}} 占位符
+// @pkg 与 relx 一致，替换时不做 HTML 转义，两种写法效果相同；未定义的变量替换为空字符串（relx 使用的 bbmustache 同样如此），
+// 需要把拼写错误当作错误时使用 RenderStrict。
+// 字符串、二进制和原子替换为其文本，其他项替换为 Erlang 语法表示
+// 不支持 mustache 的段落、注释等其他标签
+// 输入:
+//   - template: 模板内容，如 "-name {{node_name}}"
+//
+// 输出:
+//   - string: 渲染结果
+//   - error: 使用了不支持的标签或标签未结束时返回错误
+//
+// 数据样例:
+// 变量: {node_name, "app@host"}.
+// 模板: -name {{node_name}}
+// 结果: -name app@host
+func (v OverlayVars) Render(template string) (string, error) {
+	return v.render(template, false)
+}
+
+// RenderStrict 与 Render 相同，但引用未定义的变量时返回错误，而不是像 relx 那样替换为空字符串
+// 输入:
+//   - template: 模板内容
+//
+// 输出:
+//   - string: 渲染结果
+//   - error: 引用了未定义的变量、使用了不支持的标签或标签未结束时返回错误
+func (v OverlayVars) RenderStrict(template string) (string, error) {
+	return v.render(template, true)
+}
+
+// render 渲染模板，strict 为 true 时未定义的变量是错误
+func (v OverlayVars) render(template string, strict bool) (string, error) {
+	var b strings.Builder
+	rest := template
+
+	for {
+		start := strings.Index(rest, "{{")
+		if start < 0 {
+			b.WriteString(rest)
+			return b.String(), nil
+		}
+		b.WriteString(rest[:start])
+		rest = rest[start:]
+
+		open, close := "{{", "}}"
+		if strings.HasPrefix(rest, "{{{") {
+			open, close = "{{{", "}}}"
+		}
+		end := strings.Index(rest, close)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated overlay tag: %.20s", rest)
+		}
+
+		name := strings.TrimSpace(rest[len(open):end])
+		if name == "" || strings.ContainsAny(name[:1], "#^/!>&=") {
+			return "", fmt.Errorf("unsupported overlay tag: %s", rest[:end+len(close)])
+		}
+		value, ok := v.Lookup(name)
+		if ok {
+			b.WriteString(overlayText(value))
+		} else if strict {
+			return "", fmt.Errorf("undefined overlay variable: %s", name)
+		}
+		rest = rest[end+len(close):]
+	}
+}
+
+// overlayText 返回变量值在模板中的文本
+func overlayText(value Term) string {
+	switch t := value.(type) {
+	case String:
+		return t.Value
+	case Binary:
+		return t.Value
+	case Atom:
+		return t.Value
+	default:
+		return value.String()
+	}
+}
+
+// proplistValue 在 {Key, Value} 列表中按键查找值
+func proplistValue(term Term, key string) (Term, bool) {
+	list, ok := term.(List)
+	if !ok {
+		return nil, false
+	}
+	for _, elem := range list.Elements {
+		if tuple, ok := elem.(Tuple); ok && len(tuple.Elements) == 2 && termName(tuple) == key {
+			return tuple.Elements[1], true
+		}
+	}
+	return nil, false
+}
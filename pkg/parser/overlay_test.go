@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestParseOverlayVars tests parsing and looking up overlay variables
+func TestParseOverlayVars(t *testing.T) {
+	vars, err := ParseOverlayVars(`
+{node_name, "app@127.0.0.1"}.
+{port, 8080}.
+{db, [{host, "localhost"}, {pool, [{size, 10}]}]}.
+{port, 9090}.
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse overlay vars: %v", err)
+	}
+
+	if len(vars) != 3 {
+		t.Errorf("Expected 3 vars, got %d", len(vars))
+	}
+	if port, _ := vars.Lookup("port"); !port.Compare(Integer{Value: 9090}) {
+		t.Errorf("Expected later port to win, got %v", port)
+	}
+	if size, ok := vars.Lookup("db.pool.size"); !ok || !size.Compare(Integer{Value: 10}) {
+		t.Errorf("Unexpected nested lookup: %v", size)
+	}
+	for _, name := range []string{"missing", "db.missing", "port.inner", "db.host.inner"} {
+		if _, ok := vars.Lookup(name); ok {
+			t.Errorf("Expected %s not to be found", name)
+		}
+	}
+
+	for _, input := range []string{`port.`, `{port}.`, `{"port", 1}.`, `{port, `} {
+		if _, err := ParseOverlayVars(input); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
+
+// TestOverlayVarsRender tests template substitution
+func TestOverlayVarsRender(t *testing.T) {
+	vars := OverlayVars{
+		"node_name": String{Value: "app@host"},
+		"cookie":    Atom{Value: "secret"},
+		"port":      Integer{Value: 8080},
+		"bin":       Binary{Value: "raw<&>"},
+		"opts":      List{Elements: []Term{Atom{Value: "a"}, Integer{Value: 1}}},
+		"db":        List{Elements: []Term{Tuple{Elements: []Term{Atom{Value: "host"}, String{Value: "localhost"}}}}},
+	}
+
+	tests := []struct {
+		template string
+		expected string
+	}{
+		{"plain text", "plain text"},
+		{"-name {{node_name}}\n-setcookie {{ cookie }}", "-name app@host\n-setcookie secret"},
+		{"port={{port}} bin={{{bin}}}", "port=8080 bin=raw<&>"},
+		{"{{opts}}", "[a, 1]"},
+		{"{{db.host}}:{{port}}", "localhost:8080"},
+		{"{ single }", "{ single }"},
+		{"-name {{missing}}@host", "-name @host"},
+	}
+
+	for _, tt := range tests {
+		got, err := vars.Render(tt.template)
+		if err != nil {
+			t.Fatalf("Failed to render %q: %v", tt.template, err)
+		}
+		if got != tt.expected {
+			t.Errorf("For %q expected %q, got %q", tt.template, tt.expected, got)
+		}
+	}
+
+	errorCases := map[string]string{
+		"{{#section}}{{/section}}": "unsupported overlay tag",
+		"{{}}":                     "unsupported overlay tag",
+		"{{port":                   "unterminated overlay tag",
+	}
+	for template, message := range errorCases {
+		if _, err := vars.Render(template); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("For %q expected error containing %q, got %v", template, message, err)
+		}
+	}
+
+	if got, err := vars.RenderStrict("-name {{node_name}}"); err != nil || got != "-name app@host" {
+		t.Errorf("RenderStrict: got %q, %v", got, err)
+	}
+	if _, err := vars.RenderStrict("{{missing}}"); err == nil || err.Error() != "undefined overlay variable: missing" {
+		t.Errorf("Expected undefined variable error from RenderStrict, got %v", err)
+	}
+}
+
+// TestLoadOverlayVars tests merging overlay vars files in order
+func TestLoadOverlayVars(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "vars.config")
+	prod := filepath.Join(dir, "vars_prod.config")
+	os.WriteFile(base, []byte(`{port, 8080}. {log_level, debug}.`), 0644)
+	os.WriteFile(prod, []byte(`{log_level, warning}.`), 0644)
+
+	vars, err := LoadOverlayVars(base, prod)
+	if err != nil {
+		t.Fatalf("Failed to load overlay vars: %v", err)
+	}
+	got, err := vars.Render("{{port}} {{log_level}}")
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if got != "8080 warning" {
+		t.Errorf("Expected later file to override, got %q", got)
+	}
+
+	if _, err := LoadOverlayVars(filepath.Join(dir, "missing.config")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+	bad := filepath.Join(dir, "bad.config")
+	os.WriteFile(bad, []byte(`[port].`), 0644)
+	if _, err := LoadOverlayVars(bad); err == nil {
+		t.Error("Expected error for malformed file")
+	}
+}
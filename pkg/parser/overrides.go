@@ -0,0 +1,126 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+// ApplyOverrides 按 rebar3 的规则将顶层项目的 overrides 应用到依赖自身的配置上
+// @pkg overrides 的元素可以是以下形式，与 rebar3 一致按以下顺序依次应用，每一步内按列表顺序:
+//  1. {override, Opts}：所有依赖，Opts 中的每个 {Key, Value} 取代原值
+//  2. {override, App, Opts}：名为 App 的依赖，规则同上
+//  3. {add, App, Opts}，然后 {add, Opts}：将 Value 追加到原列表之后，原值不存在时视为空列表
+//  4. {del, App, Opts} 与 {del, Opts}：从原列表中删除 Value 中的每个元素（只删除第一个相等的元素，同 Erlang 的 --）
+//
+// 不是以上形式的元素会被忽略。原配置不会被修改，返回配置的 Raw 仍为 dep 的原始输入
+// 输入:
+//   - dep: 依赖自身的 rebar.config
+//   - app: 依赖的应用名，如 "lager"
+//   - overrides: 顶层项目 {overrides, [...]} 中的列表元素
+//
+// 输出:
+//   - *RebarConfig: 应用 overrides 后的依赖配置
+//
+// 示例:
+//
+//	elements, _ := umbrella.GetTupleElements("overrides")
+//	lager := parser.ApplyOverrides(depConfig, "lager", elements[0].(parser.List).Elements)
+//
+// 数据样例:
+//
+//	依赖配置: {erl_opts, [debug_info, warnings_as_errors]}.
+//	overrides: [{del, lager, [{erl_opts, [warnings_as_errors]}]}, {add, lager, [{erl_opts, [{d, 'NO_LOG'}]}]}]
+//	结果: {erl_opts, [debug_info, {d, 'NO_LOG'}]}.
+func ApplyOverrides(dep *RebarConfig, app string, overrides []Term) *RebarConfig {
+	terms := append([]Term(nil), dep.Terms...)
+
+	steps := []struct {
+		kind   string
+		global bool
+		apply  func(terms []Term, key string, value Term) []Term
+	}{
+		{"override", true, setValue},
+		{"override", false, setValue},
+		{"add", false, addOpt},
+		{"add", true, addOpt},
+	}
+	for _, step := range steps {
+		for _, override := range overrides {
+			if opts, ok := overrideOpts(override, step.kind, step.global, app); ok {
+				terms = applyOverrideOpts(terms, opts, step.apply)
+			}
+		}
+	}
+	for _, override := range overrides {
+		opts, ok := overrideOpts(override, "del", false, app)
+		if !ok {
+			opts, ok = overrideOpts(override, "del", true, app)
+		}
+		if ok {
+			terms = applyOverrideOpts(terms, opts, delOpt)
+		}
+	}
+
+	return &RebarConfig{Raw: dep.Raw, Terms: terms}
+}
+
+// overrideOpts 返回与 kind 和作用范围匹配的 override 项中的选项列表
+// @pkg global 为 true 时匹配 {Kind, Opts}，否则匹配 {Kind, App, Opts}
+func overrideOpts(override Term, kind string, global bool, app string) ([]Term, bool) {
+	tuple, ok := override.(Tuple)
+	if !ok || termName(tuple) != kind {
+		return nil, false
+	}
+	if global && len(tuple.Elements) == 2 {
+		opts, ok := tuple.Elements[1].(List)
+		return opts.Elements, ok
+	}
+	if !global && len(tuple.Elements) == 3 {
+		name, ok := tuple.Elements[1].(Atom)
+		if !ok || name.Value != app {
+			return nil, false
+		}
+		opts, ok := tuple.Elements[2].(List)
+		return opts.Elements, ok
+	}
+	return nil, false
+}
+
+// applyOverrideOpts 对 opts 中的每个 {Key, Value} 调用 apply
+func applyOverrideOpts(terms []Term, opts []Term, apply func(terms []Term, key string, value Term) []Term) []Term {
+	for _, opt := range opts {
+		tuple, ok := opt.(Tuple)
+		if !ok || len(tuple.Elements) != 2 {
+			continue
+		}
+		if key, ok := tuple.Elements[0].(Atom); ok {
+			terms = apply(terms, key.Value, tuple.Elements[1])
+		}
+	}
+	return terms
+}
+
+// addOpt 将 value 中的元素追加到 key 的列表之后
+func addOpt(terms []Term, key string, value Term) []Term {
+	added, ok := value.(List)
+	if !ok {
+		return terms
+	}
+	old, _ := listValue(terms, key)
+	return setListValue(terms, key, append(old, added.Elements...))
+}
+
+// delOpt 从 key 的列表中删除 value 中的每个元素，每个元素只删除第一个相等的项
+func delOpt(terms []Term, key string, value Term) []Term {
+	deleted, ok := value.(List)
+	old, i := listValue(terms, key)
+	if !ok || i < 0 {
+		return terms
+	}
+	for _, elem := range deleted.Elements {
+		for j, existing := range old {
+			if existing.Compare(elem) {
+				old = append(old[:j], old[j+1:]...)
+				break
+			}
+		}
+	}
+	return setListValue(terms, key, old)
+}
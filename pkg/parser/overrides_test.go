@@ -0,0 +1,56 @@
+package parser
+
+import "testing"
+
+// TestApplyOverrides tests override, add and del entries and their order
+func TestApplyOverrides(t *testing.T) {
+	dep, err := Parse(`{erl_opts, [debug_info, warnings_as_errors, {parse_transform, lager_transform}]}.
+{deps, [{goldrush, "0.1.9"}]}.
+{minimum_otp_vsn, "21"}.
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse dep config: %v", err)
+	}
+	umbrella, err := Parse(`{overrides, [
+    {del, lager, [{erl_opts, [warnings_as_errors, missing]}]},
+    {add, lager, [{erl_opts, [{d, 'NO_LOG'}]}, {xref_checks, [undefined_function_calls]}]},
+    {override, lager, [{deps, []}]},
+    {add, [{erl_opts, [nowarn_deprecated_function]}]},
+    {override, [{minimum_otp_vsn, "24"}, {deps, [{goldrush, "0.2.0"}]}]},
+    {override, jsx, [{erl_opts, []}]},
+    {del, [{erl_opts, [debug_info]}]},
+    unknown
+]}.
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse umbrella config: %v", err)
+	}
+	elements, _ := umbrella.GetTupleElements("overrides")
+	overrides := elements[0].(List).Elements
+
+	expected, _ := Parse(`{erl_opts, [{parse_transform, lager_transform}, {d, 'NO_LOG'}, nowarn_deprecated_function]}.
+{deps, []}.
+{minimum_otp_vsn, "24"}.
+{xref_checks, [undefined_function_calls]}.
+`)
+	got := ApplyOverrides(dep, "lager", overrides)
+	if !compareConfigs(got, expected) {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected.Format(4), got.Format(4))
+	}
+	if got.Raw != dep.Raw {
+		t.Error("Expected the dep source to be kept")
+	}
+
+	other := ApplyOverrides(dep, "cowboy", overrides)
+	expected, _ = Parse(`{erl_opts, [warnings_as_errors, {parse_transform, lager_transform}, nowarn_deprecated_function]}.
+{deps, [{goldrush, "0.2.0"}]}.
+{minimum_otp_vsn, "24"}.
+`)
+	if !compareConfigs(other, expected) {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected.Format(4), other.Format(4))
+	}
+
+	if opts, _ := dep.GetErlOpts(); len(opts[0].(List).Elements) != 3 {
+		t.Errorf("Expected the dep config to be unchanged, got %v", opts)
+	}
+}
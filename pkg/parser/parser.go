@@ -3,21 +3,31 @@
 package parser
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strconv"
 	"strings"
+	"unsafe"
 )
 
 // Parser 表示 Erlang 项解析器
-// @pkg Parser 是一个用于解析 Erlang 项的解析器，跟踪输入字符串的位置、行号和列号
+// @pkg Parser 是一个用于解析 Erlang 项的解析器，直接在输入上通过下标运算扫描
+// 行号和列号不在扫描过程中维护，只在生成错误信息时根据位置计算
 type Parser struct {
-	input    string // 输入字符串
-	position int    // 当前位置
-	line     int    // 当前行号
-	column   int    // 当前列号
+	input    string     // 输入字符串
+	position int        // 当前位置（字节偏移）
+	detached bool       // 为 true 时解析结果中的字符串不引用 input 的内存
+	atoms    *AtomTable // 原子驻留表，为 nil 时不驻留
+	depth    int        // 当前元组和列表的嵌套深度
+	concat   bool       // 为 true 时折叠列表之间的 ++ 表达式
+	literals bool       // 为 true 时记录数字和字符串的源码写法
+
+	// startLine 和 startColumn 是 input 起始处在完整输入中的行号和列号，
+	// 流式解析时每个片段单独解析，错误信息仍报告完整输入中的位置
+	startLine   int
+	startColumn int
 }
 
 // NewParser 创建一个新的 Parser 实例
@@ -33,21 +43,30 @@ type Parser struct {
 //	parser := NewParser("{deps, [{cowboy, \"2.9.0\"}]}.")
 func NewParser(input string) *Parser {
 	return &Parser{
-		input:    input,
-		position: 0,
-		line:     1,
-		column:   1,
+		input:       input,
+		position:    0,
+		startLine:   1,
+		startColumn: 1,
 	}
 }
 
+// maxNestingDepth 是元组和列表的最大嵌套深度
+// 真实配置的嵌套不会超过几十层，该限制防止恶意输入耗尽栈空间
+const maxNestingDepth = 10000
+
+// errMmapUnsupported 表示当前平台或文件不支持内存映射
+var errMmapUnsupported = errors.New("mmap not supported")
+
 // ParseFile 解析指定路径的 rebar.config 文件
 // @pkg 从文件系统读取并解析 rebar.config 文件
+// 同目录下存在同名的 .script 文件时返回 *ScriptError，可用 IgnoreScript 或 EvalScript 改变该行为
 // 输入:
 //   - path: 文件路径，如 "./rebar.config"
+//   - opts: 可选的解析选项，如 WithMmap()
 //
 // 输出:
 //   - *RebarConfig: 解析后的配置对象
-//   - error: 解析过程中的错误，如文件不存在或解析失败
+//   - error: 解析过程中的错误，如文件不存在、解析失败或配置是动态的
 //
 // 示例:
 //
@@ -56,57 +75,143 @@ func NewParser(input string) *Parser {
 //	  log.Fatalf("解析失败: %v", err)
 //	}
 //	fmt.Printf("配置项数量: %d\n", len(config.Terms))
-func ParseFile(path string) (*RebarConfig, error) {
+func ParseFile(path string, opts ...ParseOption) (*RebarConfig, error) {
+	o := newParseOptions(opts)
+	if config, ok, err := parseWithScript(path, o, opts); ok {
+		return config, err
+	}
+	if o.mmap {
+		if config, ok, err := parseMapped(path, o); ok {
+			return config, withScriptPath(err, path)
+		}
+	}
+
 	content, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
-	return Parse(string(content))
+	config, err := Parse(string(content), opts...)
+	return config, withScriptPath(err, path)
+}
+
+// withScriptPath 为 Parse 返回的 *ScriptError 补充文件路径
+func withScriptPath(err error, path string) error {
+	if scriptErr, ok := err.(*ScriptError); ok && scriptErr.Path == "" {
+		scriptErr.Path = path
+	}
+	return err
+}
+
+// parseMapped 通过内存映射解析文件
+// @pkg 解析器直接在映射的字节上工作，并把结果中的所有字符串复制出来，解析完成后解除映射
+// 输出:
+//   - *RebarConfig: 解析后的配置对象
+//   - bool: 为 false 表示无法映射，调用方应回退为普通读取
+//   - error: 解析错误
+func parseMapped(path string, o parseOptions) (*RebarConfig, bool, error) {
+	data, unmap, err := mmapFile(path)
+	if err != nil {
+		return nil, false, nil
+	}
+	defer unmap()
+
+	// 映射的内存在解析期间保持有效，解析器不会让结果引用它
+	input := *(*string)(unsafe.Pointer(&data))
+	parser := NewParser(input)
+	parser.detached = true
+	parser.atoms = o.atoms
+	parser.concat = o.foldConcat
+	parser.literals = o.literals
+
+	terms, err := parser.parseTerms()
+	if err != nil {
+		if containsExpressions(input) {
+			return nil, true, &ScriptError{Reason: "the config contains Erlang expressions", Err: err}
+		}
+		return nil, true, err
+	}
+
+	config := &RebarConfig{Terms: terms}
+	if !o.discardRaw {
+		config.Raw = strings.Clone(input)
+	}
+	return o.effective(config), true, nil
 }
 
 // ParseReader 从给定的 reader 解析 rebar.config
-// @pkg 从 io.Reader 接口（如文件、HTTP 响应等）读取并解析 rebar.config
+// @pkg 从 io.Reader 接口（如文件、HTTP 响应等）以流式方式读取并解析 rebar.config
+// 每次只读取一个完整的顶级项（以点号结尾）并立即解析，不会先把整个输入读入内存。
+// 默认仍会在 Raw 中累积原始内容；配合 DiscardRaw 使用时，内存占用只与最大的单个顶级项有关，
+// 适合处理很大的或来自网络的输入。
 // 输入:
 //   - r: io.Reader 接口，提供配置内容
+//   - opts: 可选的解析选项，如 DiscardRaw()
 //
 // 输出:
 //   - *RebarConfig: 解析后的配置对象
-//   - error: 解析过程中的错误
+//   - error: 解析过程中的错误；输入包含变量等 Erlang 表达式时为 *ScriptError
 //
 // 示例:
 //
 //	file, _ := os.Open("./rebar.config")
-//	config, err := parser.ParseReader(file)
+//	config, err := parser.ParseReader(file, parser.DiscardRaw())
 //	if err != nil {
 //	  log.Fatalf("解析失败: %v", err)
 //	}
-func ParseReader(r io.Reader) (*RebarConfig, error) {
-	var builder strings.Builder
-	reader := bufio.NewReader(r)
+func ParseReader(r io.Reader, opts ...ParseOption) (*RebarConfig, error) {
+	o := newParseOptions(opts)
+	reader := newTermReader(r)
+
+	var raw strings.Builder
+	terms := []Term{}
 
 	for {
-		line, err := reader.ReadString('\n')
-		if err != nil {
-			if err == io.EOF {
-				builder.WriteString(line)
-				break
-			}
+		chunk, line, column, err := reader.next()
+		if err != nil && err != io.EOF {
 			return nil, fmt.Errorf("error reading input: %w", err)
 		}
-		builder.WriteString(line)
+
+		if len(chunk) > 0 {
+			input := string(chunk)
+			if !o.discardRaw {
+				raw.WriteString(input)
+			}
+
+			parser := NewParser(input)
+			parser.startLine, parser.startColumn = line, column
+			parser.atoms = o.atoms
+			parser.concat = o.foldConcat
+			parser.literals = o.literals
+			chunkTerms, parseErr := parser.parseTerms()
+			if parseErr != nil {
+				if containsExpressions(input) {
+					return nil, &ScriptError{Reason: "the config contains Erlang expressions", Err: parseErr}
+				}
+				return nil, parseErr
+			}
+			terms = append(terms, chunkTerms...)
+		}
+
+		if err == io.EOF {
+			break
+		}
 	}
 
-	return Parse(builder.String())
+	return o.effective(&RebarConfig{
+		Raw:   raw.String(),
+		Terms: terms,
+	}), nil
 }
 
 // Parse 将输入字符串解析为 rebar.config 文件
 // @pkg 解析包含 Erlang 项的字符串为 RebarConfig 对象
 // 输入:
 //   - input: 包含 Erlang 配置的字符串
+//   - opts: 可选的解析选项，如 DiscardRaw()
 //
 // 输出:
 //   - *RebarConfig: 解析后的配置对象
-//   - error: 解析过程中的错误
+//   - error: 解析过程中的错误；输入包含变量等 Erlang 表达式时为 *ScriptError
 //
 // 示例:
 //
@@ -122,17 +227,27 @@ func ParseReader(r io.Reader) (*RebarConfig, error) {
 //	if ok {
 //	  fmt.Println("依赖项:", deps)
 //	}
-func Parse(input string) (*RebarConfig, error) {
+func Parse(input string, opts ...ParseOption) (*RebarConfig, error) {
+	o := newParseOptions(opts)
+
 	parser := NewParser(input)
+	parser.detached = o.discardRaw
+	parser.atoms = o.atoms
+	parser.concat = o.foldConcat
+	parser.literals = o.literals
 	terms, err := parser.parseTerms()
 	if err != nil {
+		if containsExpressions(input) {
+			return nil, &ScriptError{Reason: "the config contains Erlang expressions", Err: err}
+		}
 		return nil, err
 	}
 
-	return &RebarConfig{
-		Raw:   input,
-		Terms: terms,
-	}, nil
+	config := &RebarConfig{Terms: terms}
+	if !o.discardRaw {
+		config.Raw = input
+	}
+	return o.effective(config), nil
 }
 
 // parseTerms 解析输入中的所有项
@@ -144,19 +259,12 @@ func Parse(input string) (*RebarConfig, error) {
 func (p *Parser) parseTerms() ([]Term, error) {
 	terms := []Term{}
 
-	for p.position < len(p.input) {
+	for {
 		p.skipWhitespace()
-
 		if p.position >= len(p.input) {
 			break
 		}
 
-		// 跳过注释
-		if p.currentChar() == '%' {
-			p.skipToEndOfLine()
-			continue
-		}
-
 		term, err := p.parseTerm()
 		if err != nil {
 			return nil, err
@@ -166,8 +274,8 @@ func (p *Parser) parseTerms() ([]Term, error) {
 
 		// 跳过末尾的点号
 		p.skipWhitespace()
-		if p.position < len(p.input) && p.currentChar() == '.' {
-			p.advance()
+		if p.position < len(p.input) && p.input[p.position] == '.' {
+			p.position++
 		} else {
 			return nil, p.errorAt("expected '.' after term")
 		}
@@ -180,9 +288,11 @@ func (p *Parser) parseTerms() ([]Term, error) {
 // @pkg 根据当前字符解析不同类型的 Erlang 项
 // 根据起始字符决定解析方式:
 // - '{' 解析为元组
-// - '[' 解析为列表
+// - '[' 解析为列表，启用 FoldConcat 时折叠其后的 ++ 表达式
 // - '"' 解析为字符串
 // - '\” 解析为带引号的原子
+// - '<<' 解析为二进制
+// - '#{' 解析为映射
 // - '-' 或数字解析为数字
 // - 其他字母开头解析为原子
 // 输出:
@@ -195,25 +305,33 @@ func (p *Parser) parseTerm() (Term, error) {
 		return nil, p.errorAt("unexpected end of input")
 	}
 
-	switch p.currentChar() {
+	ch := p.input[p.position]
+	switch ch {
 	case '{':
 		return p.parseTuple()
 	case '[':
+		if p.concat {
+			return p.parseConcat()
+		}
 		return p.parseList()
 	case '"':
 		return p.parseString()
 	case '\'':
 		return p.parseQuotedAtom()
+	case '<':
+		return p.parseBinary()
+	case '#':
+		return p.parseMap()
 	case '-':
 		// 可能是负数
 		return p.parseNumber()
 	default:
-		if isDigit(p.currentChar()) {
+		if isDigit(ch) {
 			return p.parseNumber()
-		} else if isAtomStart(p.currentChar()) {
+		} else if isAtomStart(ch) {
 			return p.parseAtom()
 		}
-		return nil, p.errorAt(fmt.Sprintf("unexpected character: %c", p.currentChar()))
+		return nil, p.errorAt(fmt.Sprintf("unexpected character: %c", ch))
 	}
 }
 
@@ -228,17 +346,90 @@ func (p *Parser) parseTerm() (Term, error) {
 // "{deps, [{cowboy, \"2.9.0\"}]}" 被解析为
 // Tuple{Elements: [Atom{Value: "deps"}, List{...}]}
 func (p *Parser) parseTuple() (Term, error) {
-	// 跳过 '{'
-	p.advance()
+	elements, err := p.parseSequence('}', "expected ',' or '}' in tuple")
+	if err != nil {
+		return nil, err
+	}
+	return Tuple{Elements: elements}, nil
+}
+
+// parseList 解析 Erlang 列表: [elem1, elem2, ...]
+// @pkg 解析以 '[' 开始的 Erlang 列表
+// 列表格式为 [元素1, 元素2, ...]，元素间用逗号分隔
+// 输出:
+//   - Term: 解析出的列表
+//   - error: 解析过程中的错误
+//
+// 数据样例:
+// "[debug_info, {parse_transform, lager_transform}]" 被解析为
+// List{Elements: [Atom{Value: "debug_info"}, Tuple{...}]}
+func (p *Parser) parseList() (Term, error) {
+	elements, err := p.parseSequence(']', "expected ',' or ']' in list")
+	if err != nil {
+		return nil, err
+	}
+	return List{Elements: elements}, nil
+}
+
+// parseConcat 解析列表及其后的 ++ 表达式，并折叠为单个列表
+// @pkg 与 Erlang 一致，++ 是右结合的，两侧都必须是列表字面量
+//
+// 数据样例:
+// "[debug_info] ++ [warnings_as_errors]" 被解析为
+// List{Elements: [Atom{Value: "debug_info"}, Atom{Value: "warnings_as_errors"}]}
+func (p *Parser) parseConcat() (Term, error) {
+	left, err := p.parseList()
+	if err != nil {
+		return nil, err
+	}
+	p.skipWhitespace()
+	if !strings.HasPrefix(p.input[p.position:], "++") {
+		return left, nil
+	}
+	p.position += 2
+	p.skipWhitespace()
 
-	elements := []Term{}
+	start := p.position
+	right, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	list, ok := right.(List)
+	if !ok {
+		p.position = start
+		return nil, p.errorAt("right operand of '++' must be a list")
+	}
+
+	elements := left.(List).Elements
+	return List{Elements: append(elements[:len(elements):len(elements)], list.Elements...)}, nil
+}
+
+// parseSequence 解析以逗号分隔、以 closer 结束的元素序列
+// @pkg 元组和列表共用的解析逻辑，当前位置应位于开括号上
+// 输入:
+//   - closer: 结束字符，'}' 或 ']'
+//   - message: 遇到非法分隔符时的错误消息
+//
+// 输出:
+//   - []Term: 解析出的元素
+//   - error: 解析过程中的错误
+func (p *Parser) parseSequence(closer byte, message string) ([]Term, error) {
+	if p.depth >= maxNestingDepth {
+		return nil, p.errorAt("nesting too deep")
+	}
+	p.depth++
+	defer func() { p.depth-- }()
+
+	// 跳过开括号
+	p.position++
 
 	p.skipWhitespace()
-	if p.currentChar() == '}' {
-		p.advance()
-		return Tuple{Elements: elements}, nil
+	if p.position < len(p.input) && p.input[p.position] == closer {
+		p.position++
+		return []Term{}, nil
 	}
 
+	var elements []Term
 	for {
 		element, err := p.parseTerm()
 		if err != nil {
@@ -248,64 +439,80 @@ func (p *Parser) parseTuple() (Term, error) {
 		elements = append(elements, element)
 
 		p.skipWhitespace()
-		if p.currentChar() == '}' {
-			p.advance()
-			return Tuple{Elements: elements}, nil
+		if p.position >= len(p.input) {
+			return nil, p.errorAt(message)
 		}
 
-		if p.currentChar() != ',' {
-			return nil, p.errorAt("expected ',' or '}' in tuple")
+		switch p.input[p.position] {
+		case closer:
+			p.position++
+			return elements, nil
+		case ',':
+			p.position++
+		default:
+			return nil, p.errorAt(message)
 		}
-
-		// 跳过 ','
-		p.advance()
-		p.skipWhitespace()
 	}
 }
 
-// parseList 解析 Erlang 列表: [elem1, elem2, ...]
-// @pkg 解析以 '[' 开始的 Erlang 列表
-// 列表格式为 [元素1, 元素2, ...]，元素间用逗号分隔
+// parseMap 解析 Erlang 映射: #{Key => Value, ...}
+// @pkg 解析以 '#{' 开始的 Erlang 映射，键值对之间用逗号分隔
 // 输出:
-//   - Term: 解析出的列表
+//   - Term: 解析出的映射
 //   - error: 解析过程中的错误
 //
 // 数据样例:
-// "[debug_info, {parse_transform, lager_transform}]" 被解析为
-// List{Elements: [Atom{Value: "debug_info"}, Tuple{...}]}
-func (p *Parser) parseList() (Term, error) {
-	// 跳过 '['
-	p.advance()
+// "#{limit => 100}" 被解析为 Map{Pairs: [{Key: Atom{Value: "limit"}, Value: Integer{Value: 100}}]}
+func (p *Parser) parseMap() (Term, error) {
+	if !strings.HasPrefix(p.input[p.position:], "#{") {
+		return nil, p.errorAt("unexpected character: #")
+	}
+	if p.depth >= maxNestingDepth {
+		return nil, p.errorAt("nesting too deep")
+	}
+	p.depth++
+	defer func() { p.depth-- }()
 
-	elements := []Term{}
+	p.position += 2
 
 	p.skipWhitespace()
-	if p.currentChar() == ']' {
-		p.advance()
-		return List{Elements: elements}, nil
+	if p.position < len(p.input) && p.input[p.position] == '}' {
+		p.position++
+		return Map{Pairs: []MapPair{}}, nil
 	}
 
+	var pairs []MapPair
 	for {
-		element, err := p.parseTerm()
+		key, err := p.parseTerm()
 		if err != nil {
 			return nil, err
 		}
 
-		elements = append(elements, element)
-
 		p.skipWhitespace()
-		if p.currentChar() == ']' {
-			p.advance()
-			return List{Elements: elements}, nil
+		if !strings.HasPrefix(p.input[p.position:], "=>") {
+			return nil, p.errorAt("expected '=>' in map")
 		}
+		p.position += 2
 
-		if p.currentChar() != ',' {
-			return nil, p.errorAt("expected ',' or ']' in list")
+		value, err := p.parseTerm()
+		if err != nil {
+			return nil, err
 		}
+		pairs = append(pairs, MapPair{Key: key, Value: value})
 
-		// 跳过 ','
-		p.advance()
 		p.skipWhitespace()
+		if p.position >= len(p.input) {
+			return nil, p.errorAt("expected ',' or '}' in map")
+		}
+		switch p.input[p.position] {
+		case '}':
+			p.position++
+			return Map{Pairs: pairs}, nil
+		case ',':
+			p.position++
+		default:
+			return nil, p.errorAt("expected ',' or '}' in map")
+		}
 	}
 }
 
@@ -318,33 +525,12 @@ func (p *Parser) parseList() (Term, error) {
 // 数据样例:
 // "\"hello world\"" 被解析为 String{Value: "hello world"}
 func (p *Parser) parseString() (Term, error) {
-	// 跳过开头的引号
-	p.advance()
-
-	startPos := p.position
-	for p.position < len(p.input) && p.currentChar() != '"' {
-		// 处理转义序列
-		if p.currentChar() == '\\' {
-			p.advance()
-			if p.position >= len(p.input) {
-				return nil, p.errorAt("unterminated string literal")
-			}
-		}
-		p.advance()
-	}
-
-	if p.position >= len(p.input) {
-		return nil, p.errorAt("unterminated string literal")
+	start := p.position
+	value, err := p.scanQuoted('"', "unterminated string literal")
+	if err != nil {
+		return nil, err
 	}
-
-	value := p.input[startPos:p.position]
-	// 处理转义序列
-	value = processEscapes(value)
-
-	// 跳过结束引号
-	p.advance()
-
-	return String{Value: value}, nil
+	return String{Value: value, Text: p.literal(start)}, nil
 }
 
 // parseQuotedAtom 解析带引号的原子 ('atom')
@@ -356,33 +542,128 @@ func (p *Parser) parseString() (Term, error) {
 // 数据样例:
 // "'quoted-atom'" 被解析为 Atom{Value: "quoted-atom", IsQuoted: true}
 func (p *Parser) parseQuotedAtom() (Term, error) {
-	// 跳过开头的引号
-	p.advance()
-
-	startPos := p.position
-	for p.position < len(p.input) && p.currentChar() != '\'' {
-		// 处理转义序列
-		if p.currentChar() == '\\' {
-			p.advance()
-			if p.position >= len(p.input) {
-				return nil, p.errorAt("unterminated atom literal")
+	value, err := p.scanQuoted('\'', "unterminated atom literal")
+	if err != nil {
+		return nil, err
+	}
+	return Atom{Value: p.atomValue(value), IsQuoted: true}, nil
+}
+
+// parseBinary 解析 Erlang 二进制: <<seg1, seg2, ...>>
+// @pkg 解析以 '<<' 开始的 Erlang 二进制，段之间用逗号分隔
+// 支持的段:
+// - 字符串段 "text"，每个字符取低 8 位作为一个字节（与 Erlang 一致）
+// - 带 /utf8 的字符串段 "text"/utf8，按 UTF-8 编码
+// - 0 到 255 之间的整数段
+// 输出:
+//   - Term: 解析出的二进制
+//   - error: 解析过程中的错误
+//
+// 数据样例:
+// "<<\"cowboy\">>" 被解析为 Binary{Value: "cowboy"}
+func (p *Parser) parseBinary() (Term, error) {
+	if !strings.HasPrefix(p.input[p.position:], "<<") {
+		return nil, p.errorAt("unexpected character: <")
+	}
+	p.position += 2
+
+	p.skipWhitespace()
+	if strings.HasPrefix(p.input[p.position:], ">>") {
+		p.position += 2
+		return Binary{}, nil
+	}
+
+	var value strings.Builder
+	for {
+		p.skipWhitespace()
+		if p.position >= len(p.input) {
+			return nil, p.errorAt("unterminated binary")
+		}
+
+		switch ch := p.input[p.position]; {
+		case ch == '"':
+			text, err := p.scanQuoted('"', "unterminated string literal")
+			if err != nil {
+				return nil, err
+			}
+			if strings.HasPrefix(p.input[p.position:], "/utf8") {
+				p.position += len("/utf8")
+				value.WriteString(text)
+			} else {
+				for _, r := range text {
+					value.WriteByte(byte(r))
+				}
+			}
+		case ch == '-' || isDigit(ch):
+			term, err := p.parseNumber()
+			if err != nil {
+				return nil, err
 			}
+			n, ok := term.(Integer)
+			if !ok || n.Value < 0 || n.Value > 255 {
+				return nil, p.errorAt("binary segment must be an integer between 0 and 255")
+			}
+			value.WriteByte(byte(n.Value))
+		default:
+			return nil, p.errorAt("unsupported binary segment")
+		}
+
+		p.skipWhitespace()
+		switch {
+		case strings.HasPrefix(p.input[p.position:], ">>"):
+			p.position += 2
+			return Binary{Value: value.String()}, nil
+		case p.position < len(p.input) && p.input[p.position] == ',':
+			p.position++
+		default:
+			return nil, p.errorAt("expected ',' or '>>' in binary")
 		}
-		p.advance()
 	}
+}
 
-	if p.position >= len(p.input) {
-		return nil, p.errorAt("unterminated atom literal")
+// scanQuoted 扫描由 quote 包围的字面量并返回处理转义后的内容
+// @pkg 字符串和带引号原子共用的扫描逻辑，当前位置应位于开引号上
+// 没有反斜杠时直接返回输入的子串，不产生额外分配
+// 输入:
+//   - quote: 引号字符
+//   - message: 字面量未结束时的错误消息
+//
+// 输出:
+//   - string: 字面量内容
+//   - error: 解析过程中的错误
+func (p *Parser) scanQuoted(quote byte, message string) (string, error) {
+	input := p.input
+	start := p.position + 1
+	hasEscape := false
+
+	i := start
+	for i < len(input) && input[i] != quote {
+		if input[i] == '\\' {
+			hasEscape = true
+			i++
+			if i >= len(input) {
+				p.position = i
+				return "", p.errorAt(message)
+			}
+		}
+		i++
 	}
 
-	value := p.input[startPos:p.position]
-	// 处理转义序列
-	value = processEscapes(value)
+	if i >= len(input) {
+		p.position = i
+		return "", p.errorAt(message)
+	}
 
-	// 跳过结束引号
-	p.advance()
+	value := input[start:i]
+	if hasEscape {
+		value = processEscapes(value)
+	} else if p.detached {
+		value = strings.Clone(value)
+	}
 
-	return Atom{Value: value, IsQuoted: true}, nil
+	// 跳过结束引号
+	p.position = i + 1
+	return value, nil
 }
 
 // parseAtom 解析 Erlang 原子（未带引号的符号）
@@ -394,22 +675,17 @@ func (p *Parser) parseQuotedAtom() (Term, error) {
 // 数据样例:
 // "debug_info" 被解析为 Atom{Value: "debug_info", IsQuoted: false}
 func (p *Parser) parseAtom() (Term, error) {
-	startPos := p.position
+	input := p.input
+	start := p.position
 
 	// 首字符已经检查为有效的原子起始字符
-	p.advance()
-
-	// 读取剩余的原子字符
-	for p.position < len(p.input) && isAtomChar(p.currentChar()) {
-		p.advance()
+	i := start + 1
+	for i < len(input) && isAtomChar(input[i]) {
+		i++
 	}
 
-	if p.position > startPos {
-		value := p.input[startPos:p.position]
-		return Atom{Value: value, IsQuoted: false}, nil
-	}
-
-	return nil, p.errorAt("invalid atom")
+	p.position = i
+	return Atom{Value: p.atomValue(input[start:i]), IsQuoted: false}, nil
 }
 
 // parseNumber 解析 Erlang 数字（整数或浮点数）
@@ -424,124 +700,152 @@ func (p *Parser) parseAtom() (Term, error) {
 // - "3.14" 被解析为 Float{Value: 3.14}
 // - "-2.5e-3" 被解析为 Float{Value: -0.0025}
 func (p *Parser) parseNumber() (Term, error) {
-	startPos := p.position
+	input := p.input
+	start := p.position
+	i := start
 
 	// 处理负号
-	if p.currentChar() == '-' {
-		p.advance()
+	if i < len(input) && input[i] == '-' {
+		i++
 	}
 
 	// 读取小数点前的数字
-	hasDigits := false
-	for p.position < len(p.input) && isDigit(p.currentChar()) {
-		hasDigits = true
-		p.advance()
+	digitsStart := i
+	for i < len(input) && isDigit(input[i]) {
+		i++
 	}
+	hasDigits := i > digitsStart
 
 	// 检查是否是浮点数
+	// 只有后面紧跟数字的点号才是小数点，否则它是项的结束符（如顶级项 "1."）
 	isFloat := false
-	if p.position < len(p.input) && p.currentChar() == '.' {
+	if i+1 < len(input) && input[i] == '.' && isDigit(input[i+1]) {
 		isFloat = true
-		p.advance()
+		i++
 
 		// 读取小数点后的数字
-		hasDecimalDigits := false
-		for p.position < len(p.input) && isDigit(p.currentChar()) {
-			hasDecimalDigits = true
-			p.advance()
-		}
-
-		if !hasDecimalDigits {
-			return nil, p.errorAt("expected digits after decimal point")
+		for i < len(input) && isDigit(input[i]) {
+			i++
 		}
 	}
 
 	// 处理科学计数法
-	if p.position < len(p.input) && (p.currentChar() == 'e' || p.currentChar() == 'E') {
+	if i < len(input) && (input[i] == 'e' || input[i] == 'E') {
 		isFloat = true
-		p.advance()
+		i++
 
 		// 处理指数中的符号
-		if p.position < len(p.input) && (p.currentChar() == '+' || p.currentChar() == '-') {
-			p.advance()
+		if i < len(input) && (input[i] == '+' || input[i] == '-') {
+			i++
 		}
 
 		// 读取指数数字
-		hasExpDigits := false
-		for p.position < len(p.input) && isDigit(p.currentChar()) {
-			hasExpDigits = true
-			p.advance()
+		expStart := i
+		for i < len(input) && isDigit(input[i]) {
+			i++
 		}
 
-		if !hasExpDigits {
+		if i == expStart {
+			p.position = i
 			return nil, p.errorAt("expected digits in exponent")
 		}
 	}
 
+	p.position = i
 	if !hasDigits {
 		return nil, p.errorAt("expected digits in number")
 	}
 
-	value := p.input[startPos:p.position]
+	value := input[start:i]
 
 	if isFloat {
 		f, err := strconv.ParseFloat(value, 64)
 		if err != nil {
 			return nil, p.errorAt(fmt.Sprintf("invalid float: %s", value))
 		}
-		return Float{Value: f}, nil
-	} else {
-		i, err := strconv.ParseInt(value, 10, 64)
-		if err != nil {
-			return nil, p.errorAt(fmt.Sprintf("invalid integer: %s", value))
-		}
-		return Integer{Value: i}, nil
+		return Float{Value: f, Text: p.literal(start)}, nil
 	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, p.errorAt(fmt.Sprintf("invalid integer: %s", value))
+	}
+	return Integer{Value: n, Text: p.literal(start)}, nil
 }
 
 // Helper methods for the parser
 // 解析器的辅助方法
 
-// currentChar 返回当前字符
-// @pkg 返回当前位置的字符，如果已到达输入末尾则返回 0
-func (p *Parser) currentChar() byte {
-	if p.position >= len(p.input) {
-		return 0
+// atomValue 返回原子名称的规范化字符串
+// @pkg 常见原子复用全局表中的字符串；设置了驻留表时其他原子从驻留表获取；
+// detached 模式下其余原子会被复制，不再引用输入
+func (p *Parser) atomValue(s string) string {
+	if interned, ok := commonAtoms[s]; ok {
+		return interned
+	}
+	if p.atoms != nil {
+		return p.atoms.Intern(s)
+	}
+	if p.detached {
+		return strings.Clone(s)
 	}
-	return p.input[p.position]
+	return s
 }
 
-// advance 前进到下一个字符
-// @pkg 将位置前进一个字符，并更新行号和列号
-func (p *Parser) advance() {
-	if p.position < len(p.input) {
-		if p.input[p.position] == '\n' {
-			p.line++
-			p.column = 1
-		} else {
-			p.column++
-		}
-		p.position++
+// literal 返回从 start 到当前位置的源码写法，未启用 literals 时返回空字符串
+func (p *Parser) literal(start int) string {
+	if !p.literals {
+		return ""
 	}
+	if p.detached {
+		return strings.Clone(p.input[start:p.position])
+	}
+	return p.input[start:p.position]
 }
 
-// skipWhitespace 跳过空白字符
-// @pkg 跳过所有空格、制表符、换行符和回车符
+// skipWhitespace 跳过空白字符和注释
+// @pkg 跳过所有空格、制表符、换行符、回车符以及 % 开始的行注释
 func (p *Parser) skipWhitespace() {
-	for p.position < len(p.input) && (p.currentChar() == ' ' || p.currentChar() == '\t' || p.currentChar() == '\n' || p.currentChar() == '\r') {
-		p.advance()
+	input := p.input
+	i := p.position
+	for i < len(input) {
+		switch input[i] {
+		case ' ', '\t', '\n', '\r':
+			i++
+		case '%':
+			for i < len(input) && input[i] != '\n' {
+				i++
+			}
+		default:
+			p.position = i
+			return
+		}
 	}
+	p.position = i
 }
 
-// skipToEndOfLine 跳到行尾
-// @pkg 跳过当前行的剩余部分，用于处理注释
-func (p *Parser) skipToEndOfLine() {
-	for p.position < len(p.input) && p.currentChar() != '\n' {
-		p.advance()
+// lineColumn 计算指定字节偏移对应的行号和列号
+// @pkg 行号和列号均从 1 开始，仅在生成错误信息时调用
+// 输入:
+//   - offset: 字节偏移
+//
+// 输出:
+//   - int: 行号
+//   - int: 列号
+func (p *Parser) lineColumn(offset int) (int, int) {
+	if offset > len(p.input) {
+		offset = len(p.input)
 	}
-	if p.position < len(p.input) {
-		p.advance() // 跳过换行符
+	line, column := p.startLine, p.startColumn
+	for i := 0; i < offset; i++ {
+		if p.input[i] == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
 	}
+	return line, column
 }
 
 // errorAt 生成带位置信息的错误
@@ -552,5 +856,6 @@ func (p *Parser) skipToEndOfLine() {
 // 输出:
 //   - error: 带位置信息的格式化错误
 func (p *Parser) errorAt(message string) error {
-	return fmt.Errorf("syntax error at line %d, column %d: %s", p.line, p.column, message)
+	line, column := p.lineColumn(p.position)
+	return fmt.Errorf("syntax error at line %d, column %d: %s", line, column, message)
 }
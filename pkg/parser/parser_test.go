@@ -625,15 +625,14 @@ func TestParseStringsWithEscapes(t *testing.T) {
 		t.Fatalf("Expected 4 terms, got %d", len(config.Terms))
 	}
 
-	// The expected values below match what the parser actually produces
-	// This may not match intuitive Go string literal escaping since
-	// our parser does simple string replacement.
+	// Each escape sequence is interpreted exactly once, so an escaped
+	// backslash followed by 'n' stays a backslash and an 'n'.
 	tests := []struct {
 		name     string
 		expected string // Expected Go string value after parsing Erlang escapes
 	}{
 		{"simple", "hello world"},
-		{"with_escapes", "line1\nline2\t tabbed \\\"quoted\\\" backslash\\\\."}, // Match what the parser actually produces
+		{"with_escapes", "line1\\nline2\\t tabbed \\\"quoted\\\" backslash\\\\."},
 		{"empty", ""},
 		{"unicode", "你好世界"},
 	}
@@ -778,3 +777,136 @@ func TestParseReader(t *testing.T) {
 		}
 	})
 }
+
+// TestParseNestedCommentsAndPositions tests comments inside terms and lazily computed error positions
+func TestParseNestedCommentsAndPositions(t *testing.T) {
+	input := `{deps, [ % inline comment
+    {cowboy, "2.9.0"} % trailing
+]}.`
+	config, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Failed to parse config with nested comments: %v", err)
+	}
+	deps, ok := config.GetDeps()
+	if !ok || len(deps[0].(List).Elements) != 1 {
+		t.Fatalf("Expected one dependency, got %v", deps)
+	}
+
+	_, err = Parse("{a, b}.\n{c, d")
+	if err == nil {
+		t.Fatal("Expected error for unterminated tuple")
+	}
+	if !strings.Contains(err.Error(), "line 2, column 6") {
+		t.Errorf("Expected error at line 2, column 6, got: %v", err)
+	}
+}
+
+// TestParseHardening tests inputs uncovered by fuzzing
+func TestParseHardening(t *testing.T) {
+	t.Run("Integer before end dot", func(t *testing.T) {
+		config, err := Parse("1.\n{a, 2}.")
+		if err != nil {
+			t.Fatalf("Failed to parse top-level integer: %v", err)
+		}
+		if !config.Terms[0].Compare(Integer{Value: 1}) {
+			t.Errorf("Expected integer 1, got %v", config.Terms[0])
+		}
+	})
+
+	t.Run("Deep nesting", func(t *testing.T) {
+		depth := maxNestingDepth + 1
+		input := strings.Repeat("[", depth) + strings.Repeat("]", depth) + "."
+		_, err := Parse(input)
+		if err == nil || !strings.Contains(err.Error(), "nesting too deep") {
+			t.Errorf("Expected nesting error, got %v", err)
+		}
+
+		input = strings.Repeat("{", 100) + strings.Repeat("}", 100) + "."
+		if _, err := Parse(input); err != nil {
+			t.Errorf("Expected moderate nesting to parse, got %v", err)
+		}
+	})
+
+	t.Run("Stray bytes", func(t *testing.T) {
+		for _, input := range []string{"\x00", "{a, \xff}.", "}.", "].", "{a, b}}.", "'"} {
+			if _, err := Parse(input); err == nil {
+				t.Errorf("Expected error for %q", input)
+			}
+		}
+	})
+
+	t.Run("Quoted atom with quote round trip", func(t *testing.T) {
+		config, err := Parse(`{'it\'s', 'back\\slash'}.`)
+		if err != nil {
+			t.Fatalf("Failed to parse: %v", err)
+		}
+		reparsed, err := Parse(config.Format(2))
+		if err != nil || !compareConfigs(config, reparsed) {
+			t.Errorf("Round trip failed: %q, %v", config.Format(2), err)
+		}
+	})
+}
+
+// TestParseBinary tests parsing binary literals
+func TestParseBinary(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`<<>>.`, ""},
+		{`<<"cowboy">>.`, "cowboy"},
+		{`<< "a" , "b" >>.`, "ab"},
+		{`<<1,2,255>>.`, "\x01\x02\xff"},
+		{`<<"é">>.`, "\xe9"},
+		{`<<"中文"/utf8>>.`, "中文"},
+		{`<<"a\nb">>.`, "a\nb"},
+	}
+
+	for _, tt := range tests {
+		config, err := Parse(tt.input)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", tt.input, err)
+		}
+		bin, ok := config.Terms[0].(Binary)
+		if !ok {
+			t.Fatalf("Expected Binary for %q, got %T", tt.input, config.Terms[0])
+		}
+		if bin.Value != tt.expected {
+			t.Errorf("For %q expected %q, got %q", tt.input, tt.expected, bin.Value)
+		}
+	}
+
+	for _, input := range []string{`<"a">.`, `<<"a".`, `<<256>>.`, `<<-1>>.`, `<<1.5>>.`, `<<a>>.`, `<<"a" "b">>.`} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
+
+// TestParseMap tests parsing map literals
+func TestParseMap(t *testing.T) {
+	config, err := Parse(`#{dirs => ["src"], limit => 100, #{} => <<"nested">>}. #{}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse map: %v", err)
+	}
+
+	m, ok := config.Terms[0].(Map)
+	if !ok || len(m.Pairs) != 3 {
+		t.Fatalf("Expected map with 3 pairs, got %v", config.Terms[0])
+	}
+	if limit, ok := m.Get(Atom{Value: "limit"}); !ok || !limit.Compare(Integer{Value: 100}) {
+		t.Errorf("Unexpected limit: %v", limit)
+	}
+	if nested, ok := m.Get(Map{}); !ok || !nested.Compare(Binary{Value: "nested"}) {
+		t.Errorf("Unexpected value for map key: %v", nested)
+	}
+	if empty, ok := config.Terms[1].(Map); !ok || len(empty.Pairs) != 0 {
+		t.Errorf("Expected empty map, got %v", config.Terms[1])
+	}
+
+	for _, input := range []string{`#[a].`, `#{a}.`, `#{a => }.`, `#{a => 1 b => 2}.`, `#{a => 1`, `#{a := 1}.`} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
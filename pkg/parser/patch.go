@@ -0,0 +1,240 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// PatchOpKind 表示补丁操作的类别
+type PatchOpKind string
+
+const (
+	// PatchAdd 在容器末尾添加新项，路径的最后一段是新项的名称
+	PatchAdd PatchOpKind = "add"
+	// PatchRemove 删除路径指向的项
+	PatchRemove PatchOpKind = "remove"
+	// PatchReplace 将路径指向的项替换为新值
+	PatchReplace PatchOpKind = "replace"
+)
+
+// PatchOp 是补丁中的一个操作
+// @pkg Path 由名称组成：第一段在顶级项中按键查找（如 "deps"），之后每一段进入上一项 {Key, [...]} 的列表，
+// 按元素名称查找（依赖名、选项名、profile 名等，名称规则同 termName：原子本身或元组的首个原子）。
+// 例如 ["profiles", "test", "deps", "meck"] 指向 test profile 中的 meck 依赖。
+// 空路径只用于 replace，表示以 Value（列表）替换全部顶级项
+//
+// JSON 表示为 {"op": "replace", "path": ["deps", "cowboy"], "value": {...}}，value 使用 Term 的 JSON 表示
+type PatchOp struct {
+	Op   PatchOpKind `json:"op"`
+	Path []string    `json:"path"`
+	// Value 是 add 和 replace 的新项，如 {cowboy, "2.10.0"}；remove 时为 nil
+	Value Term `json:"value,omitempty"`
+}
+
+// Patch 是按顺序应用的补丁操作列表
+// @pkg 可以用 encoding/json 序列化，便于审阅、存储，并在多个仓库中重放
+type Patch []PatchOp
+
+// String 返回操作的可读表示，如 `replace deps/cowboy {cowboy, "2.10.0"}`
+func (op PatchOp) String() string {
+	text := string(op.Op) + " " + strings.Join(op.Path, "/")
+	if op.Value != nil {
+		text += " " + op.Value.String()
+	}
+	return text
+}
+
+// UnmarshalJSON 从 JSON 还原操作，value 按 UnmarshalTerm 解码
+func (op *PatchOp) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Op    PatchOpKind     `json:"op"`
+		Path  []string        `json:"path"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	op.Op, op.Path, op.Value = raw.Op, raw.Path, nil
+	if len(raw.Value) > 0 && string(raw.Value) != "null" {
+		value, err := UnmarshalTerm(raw.Value)
+		if err != nil {
+			return err
+		}
+		op.Value = value
+	}
+	return nil
+}
+
+// Diff 返回将 a 变为 b 的补丁
+// @pkg 按名称匹配项：只在一方存在的项生成 add 或 remove；两方都有但不同的项，若都是 {Key, [...]} 且列表中的元素名称唯一，
+// 则进入列表逐项比较，否则整体 replace。顶级项的键不唯一时生成替换全部顶级项的 replace。
+// 补丁不记录顺序的变化，add 总是添加到容器末尾
+// 输入:
+//   - a: 原配置
+//   - b: 目标配置
+//
+// 输出:
+//   - Patch: 补丁，两个配置相同时为空
+//
+// 示例:
+//
+//	patch := parser.Diff(before, after)
+//	data, _ := json.MarshalIndent(patch, "", "  ")
+//	os.WriteFile("bump-cowboy.patch.json", data, 0644)
+func Diff(a, b *RebarConfig) Patch {
+	if !addressable(a.Terms) || !addressable(b.Terms) {
+		if sameTerms(a.Terms, b.Terms) {
+			return nil
+		}
+		return Patch{{Op: PatchReplace, Path: []string{}, Value: NewList(b.Terms...)}}
+	}
+	return diffEntries(nil, a.Terms, b.Terms)
+}
+
+// diffEntries 按名称比较两组元素名称唯一的项
+func diffEntries(path []string, a, b []Term) Patch {
+	var patch Patch
+	before := make(map[string]Term, len(a))
+	for _, term := range a {
+		before[termName(term)] = term
+	}
+	after := make(map[string]bool, len(b))
+	for _, term := range b {
+		after[termName(term)] = true
+	}
+
+	for _, term := range a {
+		if name := termName(term); !after[name] {
+			patch = append(patch, PatchOp{Op: PatchRemove, Path: childPath(path, name)})
+		}
+	}
+	for _, term := range b {
+		name := termName(term)
+		old, ok := before[name]
+		switch {
+		case !ok:
+			patch = append(patch, PatchOp{Op: PatchAdd, Path: childPath(path, name), Value: term})
+		case old.Compare(term):
+		default:
+			oldList, _ := listValue([]Term{old}, name)
+			newList, _ := listValue([]Term{term}, name)
+			if oldList != nil && newList != nil && addressable(oldList) && addressable(newList) {
+				patch = append(patch, diffEntries(childPath(path, name), oldList, newList)...)
+			} else {
+				patch = append(patch, PatchOp{Op: PatchReplace, Path: childPath(path, name), Value: term})
+			}
+		}
+	}
+	return patch
+}
+
+// addressable 检查每个项都有名称且名称不重复
+func addressable(terms []Term) bool {
+	seen := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		name := termName(term)
+		if name == "" || seen[name] {
+			return false
+		}
+		seen[name] = true
+	}
+	return true
+}
+
+// childPath 返回 path 后追加 name 的新路径
+func childPath(path []string, name string) []string {
+	return append(append([]string{}, path...), name)
+}
+
+// Apply 将补丁应用到配置上
+// @pkg 操作按顺序应用；任何一个操作失败时返回错误，配置保持不变。修改后可以用 FormatPreserving 写回
+// 输入:
+//   - config: 要修改的配置
+//   - patch: 补丁
+//
+// 输出:
+//   - error: 路径不存在、add 的项已存在、路径经过的项不是 {Key, [...]}，或操作无效
+//
+// 示例:
+//
+//	var patch parser.Patch
+//	json.Unmarshal(data, &patch)
+//	if err := parser.Apply(config, patch); err != nil {
+//	  log.Fatal(err)
+//	}
+func Apply(config *RebarConfig, patch Patch) error {
+	terms := config.Terms
+	for i, op := range patch {
+		var err error
+		if terms, err = applyOp(terms, op.Path, op); err != nil {
+			return fmt.Errorf("patch operation %d (%s): %w", i, op, err)
+		}
+	}
+	config.Terms = terms
+	return nil
+}
+
+// applyOp 将操作应用到 terms 中 path 指向的位置，返回修改后的副本
+func applyOp(terms []Term, path []string, op PatchOp) ([]Term, error) {
+	if len(path) == 0 {
+		list, ok := op.Value.(List)
+		if op.Op != PatchReplace || !ok {
+			return nil, fmt.Errorf("empty path requires replace with a list value")
+		}
+		return append([]Term{}, list.Elements...), nil
+	}
+
+	name := path[0]
+	index := -1
+	for i, term := range terms {
+		if termName(term) == name {
+			index = i
+			break
+		}
+	}
+
+	if len(path) > 1 {
+		if index < 0 {
+			return nil, fmt.Errorf("%s not found", name)
+		}
+		elements, _ := listValue(terms[index:index+1], name)
+		if elements == nil {
+			return nil, fmt.Errorf("%s is not a {Key, [...]} entry", name)
+		}
+		elements, err := applyOp(elements, path[1:], op)
+		if err != nil {
+			return nil, err
+		}
+		return setListValue(terms, name, elements), nil
+	}
+
+	result := append([]Term{}, terms...)
+	switch op.Op {
+	case PatchAdd:
+		if index >= 0 {
+			return nil, fmt.Errorf("%s already exists", name)
+		}
+		if op.Value == nil || termName(op.Value) != name {
+			return nil, fmt.Errorf("value name does not match %s", name)
+		}
+		return append(result, op.Value), nil
+	case PatchRemove:
+		if index < 0 {
+			return nil, fmt.Errorf("%s not found", name)
+		}
+		return append(result[:index], result[index+1:]...), nil
+	case PatchReplace:
+		if index < 0 {
+			return nil, fmt.Errorf("%s not found", name)
+		}
+		if op.Value == nil {
+			return nil, fmt.Errorf("replace requires a value")
+		}
+		result[index] = op.Value
+		return result, nil
+	}
+	return nil, fmt.Errorf("unknown operation %q", op.Op)
+}
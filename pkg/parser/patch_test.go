@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestDiffApply tests that Diff produces path-based operations that Apply replays
+func TestDiffApply(t *testing.T) {
+	before, err := Parse(`{erl_opts, [debug_info]}.
+{deps, [{cowboy, "2.9.0"}, jsx, recon]}.
+{erl_first_files, ["src/a.erl"]}.
+{profiles, [{test, [{deps, [{meck, "0.9.0"}]}]}]}.
+{post_hooks, []}.
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	after, err := Parse(`{erl_opts, [debug_info, warnings_as_errors]}.
+{deps, [{cowboy, "2.10.0"}, jsx, {lager, "3.9.2"}]}.
+{erl_first_files, ["src/a.erl", "src/b.erl"]}.
+{profiles, [{test, [{deps, [{meck, "0.9.2"}]}]}]}.
+{minimum_otp_vsn, "25"}.
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	patch := Diff(before, after)
+	expected := []string{
+		`remove post_hooks`,
+		`add erl_opts/warnings_as_errors warnings_as_errors`,
+		`remove deps/recon`,
+		`replace deps/cowboy {cowboy, "2.10.0"}`,
+		`add deps/lager {lager, "3.9.2"}`,
+		`replace erl_first_files {erl_first_files, ["src/a.erl", "src/b.erl"]}`,
+		`replace profiles/test/deps/meck {meck, "0.9.2"}`,
+		`add minimum_otp_vsn {minimum_otp_vsn, "25"}`,
+	}
+	var got []string
+	for _, op := range patch {
+		got = append(got, op.String())
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected:\n%s\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatalf("Failed to marshal patch: %v", err)
+	}
+	var decoded Patch
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal patch: %v", err)
+	}
+
+	if err := Apply(before, decoded); err != nil {
+		t.Fatalf("Failed to apply patch: %v", err)
+	}
+	if !compareConfigs(before, after) {
+		t.Errorf("Expected:\n%s\nGot:\n%s", after.Format(4), before.Format(4))
+	}
+	if patch := Diff(before, after); len(patch) != 0 {
+		t.Errorf("Expected empty patch, got %v", patch)
+	}
+}
+
+// TestApplyErrors tests that a failing operation leaves the config unchanged
+func TestApplyErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		op   PatchOp
+	}{
+		{"missing", PatchOp{Op: PatchRemove, Path: []string{"deps", "missing"}}},
+		{"exists", PatchOp{Op: PatchAdd, Path: []string{"deps", "jsx"}, Value: NewAtom("jsx")}},
+		{"name mismatch", PatchOp{Op: PatchAdd, Path: []string{"deps", "gun"}, Value: NewAtom("jsx")}},
+		{"not a list", PatchOp{Op: PatchRemove, Path: []string{"minimum_otp_vsn", "x"}}},
+		{"empty path", PatchOp{Op: PatchRemove, Path: []string{}}},
+		{"unknown op", PatchOp{Op: "move", Path: []string{"deps"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := Parse(`{deps, [jsx]}. {minimum_otp_vsn, "24"}.`)
+			patch := Patch{{Op: PatchAdd, Path: []string{"deps", "recon"}, Value: NewAtom("recon")}, tt.op}
+			if err := Apply(config, patch); err == nil {
+				t.Fatal("Expected error")
+			}
+			if deps, _ := config.GetDeps(); deps[0].String() != "[jsx]" {
+				t.Errorf("Expected config to be unchanged, got %s", deps[0])
+			}
+		})
+	}
+
+	config, _ := Parse(`{a, 1}. {a, 2}.`)
+	target, _ := Parse(`{b, 1}.`)
+	patch := Diff(config, target)
+	if len(patch) != 1 || len(patch[0].Path) != 0 {
+		t.Fatalf("Expected a whole-config replace, got %v", patch)
+	}
+	if err := Apply(config, patch); err != nil || !compareConfigs(config, target) {
+		t.Errorf("Expected whole-config replace to apply, got %v, %v", err, config.Terms)
+	}
+}
+
+// TestPatchJSONLiterals tests that patch values keep their recorded source text through JSON
+func TestPatchJSONLiterals(t *testing.T) {
+	patch := Patch{{Op: PatchReplace, Path: []string{"app", "vsn"}, Value: Tuple{Elements: []Term{NewAtom("vsn"), Float{Value: 2.1, Text: "2.10"}}}}}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatalf("Failed to marshal patch: %v", err)
+	}
+	var decoded Patch
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal patch: %v", err)
+	}
+	vsn := decoded[0].Value.(Tuple).Elements[1].(Float)
+	if vsn.Text != "2.10" {
+		t.Errorf("Expected text 2.10, got %q", vsn.Text)
+	}
+}
@@ -0,0 +1,130 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+)
+
+// ProjectPlugins 让插件编辑作用于 project_plugins 而不是 plugins
+// @pkg 只影响 AddPlugin 和 EnsurePlugin 写入的列表，对依赖编辑没有作用
+//
+// 示例:
+//
+//	config.EnsurePlugin(parser.NewAtom("rebar3_format"), parser.ProjectPlugins())
+func ProjectPlugins() DepOption {
+	return func(o *depOptions) {
+		o.project = true
+	}
+}
+
+// pluginKey 返回选项指定的插件列表的键
+func (o depOptions) pluginKey() string {
+	if o.project {
+		return "project_plugins"
+	}
+	return "plugins"
+}
+
+// otherPluginKey 返回另一个插件列表的键
+func (o depOptions) otherPluginKey() string {
+	if o.project {
+		return "plugins"
+	}
+	return "project_plugins"
+}
+
+// AddPlugin 添加插件
+// @pkg 同名插件已在目标列表中时在原位置替换（如修改版本），在另一个插件列表中时从那里移除，
+// 保证一个插件只出现一次；列表不存在时会被创建。
+// plugin 可以是 rebar3_hex、{rebar3_hex, "7.0.7"} 或 {rebar3_hex, {git, "...", {tag, "..."}}}
+// 输入:
+//   - plugin: 插件声明
+//   - opts: 编辑选项，如 ProjectPlugins()、InProfile("test")
+//
+// 输出:
+//   - error: plugin 不是原子或首元素为原子的元组
+//
+// 示例:
+//
+//	err := config.AddPlugin(parser.KV("rebar3_hex", parser.NewString("7.0.7")))
+func (c *RebarConfig) AddPlugin(plugin Term, opts ...DepOption) error {
+	name := termName(plugin)
+	if name == "" {
+		return fmt.Errorf("plugin %s has no name", plugin)
+	}
+	o := newDepOptions(opts)
+	if others, _ := c.listAt(o, o.otherPluginKey()); len(withoutName(others, name)) != len(others) {
+		c.editList(o, o.otherPluginKey(), false, func(plugins []Term) []Term {
+			return withoutName(plugins, name)
+		})
+	}
+	c.editList(o, o.pluginKey(), true, func(plugins []Term) []Term {
+		for i, existing := range plugins {
+			if termName(existing) == name {
+				plugins[i] = plugin
+				return plugins
+			}
+		}
+		return append(plugins, plugin)
+	})
+	return nil
+}
+
+// EnsurePlugin 确保插件存在
+// @pkg 插件已在 plugins 或 project_plugins 中（按名称比较，版本不同也视为存在）时不做修改，
+// 否则追加到目标列表末尾，列表不存在时会被创建
+// 输入:
+//   - plugin: 插件声明
+//   - opts: 编辑选项，如 ProjectPlugins()、InProfile("test")
+//
+// 输出:
+//   - bool: 是否添加了插件
+func (c *RebarConfig) EnsurePlugin(plugin Term, opts ...DepOption) bool {
+	o := newDepOptions(opts)
+	name := termName(plugin)
+	for _, key := range []string{"plugins", "project_plugins"} {
+		plugins, _ := c.listAt(o, key)
+		for _, existing := range plugins {
+			if (name != "" && termName(existing) == name) || existing.Compare(plugin) {
+				return false
+			}
+		}
+	}
+	c.editList(o, o.pluginKey(), true, func(plugins []Term) []Term {
+		return append(plugins, plugin)
+	})
+	return true
+}
+
+// RemovePlugin 从 plugins 和 project_plugins 中删除插件
+// 输入:
+//   - name: 插件名
+//   - opts: 编辑选项，如 InProfile("test")；ProjectPlugins() 不影响删除的范围
+//
+// 输出:
+//   - bool: 是否删除了插件；插件不存在时配置不变
+func (c *RebarConfig) RemovePlugin(name string, opts ...DepOption) bool {
+	o := newDepOptions(opts)
+	removed := false
+	for _, key := range []string{"plugins", "project_plugins"} {
+		if plugins, _ := c.listAt(o, key); len(withoutName(plugins, name)) != len(plugins) {
+			c.editList(o, key, false, func(plugins []Term) []Term {
+				return withoutName(plugins, name)
+			})
+			removed = true
+		}
+	}
+	return removed
+}
+
+// withoutName 返回去掉名称为 name 的项后的列表
+func withoutName(terms []Term, name string) []Term {
+	kept := make([]Term, 0, len(terms))
+	for _, term := range terms {
+		if termName(term) != name {
+			kept = append(kept, term)
+		}
+	}
+	return kept
+}
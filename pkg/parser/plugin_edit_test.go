@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestPluginEditing tests AddPlugin, EnsurePlugin and RemovePlugin
+func TestPluginEditing(t *testing.T) {
+	config, err := Parse(`{plugins, [rebar3_hex, {rebar3_proper, "0.12.0"}]}.
+{project_plugins, [rebar3_format]}.
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	if config.EnsurePlugin(NewAtom("rebar3_format")) {
+		t.Error("Expected EnsurePlugin to find rebar3_format in project_plugins")
+	}
+	if config.EnsurePlugin(KV("rebar3_hex", NewString("7.0.7"))) {
+		t.Error("Expected EnsurePlugin to match by name")
+	}
+	if !config.EnsurePlugin(NewAtom("rebar3_lint"), ProjectPlugins()) {
+		t.Error("Expected EnsurePlugin to add rebar3_lint")
+	}
+	if err := config.AddPlugin(KV("rebar3_proper", NewString("0.12.1"))); err != nil {
+		t.Fatalf("Failed to add plugin: %v", err)
+	}
+	if err := config.AddPlugin(NewAtom("rebar3_hex"), ProjectPlugins()); err != nil {
+		t.Fatalf("Failed to move plugin: %v", err)
+	}
+	if err := config.AddPlugin(NewAtom("covertool"), InProfile("test")); err != nil {
+		t.Fatalf("Failed to add profile plugin: %v", err)
+	}
+	if err := config.AddPlugin(NewInteger(1)); err == nil {
+		t.Error("Expected error for plugin without a name")
+	}
+	if !config.RemovePlugin("rebar3_format") {
+		t.Error("Expected RemovePlugin to remove rebar3_format")
+	}
+	if config.RemovePlugin("missing") || config.RemovePlugin("covertool") {
+		t.Error("Expected RemovePlugin to report missing plugins")
+	}
+
+	expected := `{plugins, [{rebar3_proper, "0.12.1"}]}.
+{project_plugins, [rebar3_lint, rebar3_hex]}.
+{profiles, [{test, [{plugins, [covertool]}]}]}.
+`
+	var b strings.Builder
+	for _, term := range config.Terms {
+		b.WriteString(term.String() + ".\n")
+	}
+	if got := b.String(); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+
+	empty := &RebarConfig{}
+	if !empty.EnsurePlugin(NewAtom("rebar3_hex")) || empty.EnsurePlugin(NewAtom("rebar3_hex")) {
+		t.Error("Expected EnsurePlugin to add once to an empty config")
+	}
+	if got := empty.Terms[0].String(); got != "{plugins, [rebar3_hex]}" {
+		t.Errorf("Expected plugins list to be created, got %s", got)
+	}
+}
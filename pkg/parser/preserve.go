@@ -0,0 +1,379 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FormatPreserving 返回保留原文格式的配置文本
+// @pkg 用于“解析、修改、写回”的工具（如只升级一个依赖的版本），使写回后的差异只包含实际修改的部分:
+// - 与原文相同的项（包括嵌套的元素）原样输出，保留其中的注释、空白和引号写法
+// - 修改过的元组和列表在元素数量对应时逐个元素比较，只重新生成改变的元素；新增的元素沿用相邻元素的分隔和缩进，
+// 删除的元素连同其前面的注释一起去掉
+// - 其余改变的项使用与 Format 相同的规则重新生成
+//
+// 原文来自 Raw，与 Terms 的对应关系通过重新解析 Raw 得到；Raw 为空（如使用 DiscardRaw 解析）、
+// 无法按默认选项重新解析（如 rebar.config.script）时退化为 Format(indent)
+// 输入:
+//   - indent: 重新生成的项使用的缩进空格数
+//
+// 输出:
+//   - string: 配置文本
+//
+// 示例:
+//
+//	config, _ := parser.ParseFile("./rebar.config")
+//	// ... 修改 config.Terms ...
+//	os.WriteFile("./rebar.config", []byte(config.FormatPreserving(4)), 0644)
+func (c *RebarConfig) FormatPreserving(indent int) string {
+	if c.Raw == "" {
+		return c.Format(indent)
+	}
+	original, err := Parse(c.Raw, DiscardRaw())
+	if err != nil {
+		return c.Format(indent)
+	}
+	spans, ok := sourceSpans(c.Raw)
+	if !ok || len(spans) != len(original.Terms) {
+		return c.Format(indent)
+	}
+
+	w := &preserver{src: c.Raw, indent: indent}
+	if len(spans) == 0 {
+		return w.appendTerms(c.Raw, c.Terms)
+	}
+	if len(c.Terms) == 0 {
+		return c.Raw[:spans[0].start]
+	}
+
+	l := w.layout(spans, 0, len(c.Raw), ".", true)
+	return w.merge(l, ".", original.Terms, spans, c.Terms, 0)
+}
+
+// srcSpan 是源码中一个项的字节范围
+type srcSpan struct {
+	start, end int
+	// seq 表示项是元组或列表，children 是其元素的范围；其他项的内容不再细分
+	seq      bool
+	children []srcSpan
+}
+
+// preserver 保存生成保留格式文本所需的状态
+type preserver struct {
+	src    string
+	indent int
+}
+
+// layout 是一个序列中元素周围的原文
+// @pkg 元素之间的文本按行切分：分隔符及其所在行的剩余部分（如行尾注释）属于前一个元素，
+// 之后的行（如元素上方的注释和缩进）属于后一个元素，这样删除元素时会一起去掉它的注释
+type layout struct {
+	open  string   // 第一个元素的前导文本之前的部分，如 "[\n"
+	pre   []string // 每个元素的前导文本，如 "    %% web server\n    "
+	post  []string // 每个元素之后到下一个元素前导文本之前的部分，如 ", % json\n"；最后一个元素的为序列结尾，如 "\n]"
+	multi bool     // 元素是否分行书写
+}
+
+// layout 根据元素的范围切分序列 [start, end) 的原文，spans 不能为空
+// 输入:
+//   - sepChar: 元素分隔符，顶层为 "."，元组和列表为 ","
+//   - top: 是否为顶层序列；顶层第一个项之前的文本以最后一个空行为界，之前的部分（如文件头注释）不属于第一个项
+func (w *preserver) layout(spans []srcSpan, start, end int, sepChar string, top bool) layout {
+	var l layout
+	lead := w.src[start:spans[0].start]
+	cut := 0
+	if top {
+		if i := strings.LastIndex(lead, "\n\n"); i >= 0 {
+			cut = i + 2
+		}
+	} else if i := strings.IndexByte(lead, '\n'); i >= 0 {
+		cut = i + 1
+		l.multi = true
+	} else {
+		cut = 1
+	}
+	l.open, l.pre = lead[:cut], []string{lead[cut:]}
+
+	for i := 1; i < len(spans); i++ {
+		gap := w.src[spans[i-1].end:spans[i].start]
+		cut := strings.Index(gap, sepChar) + 1
+		if nl := strings.IndexByte(gap[cut:], '\n'); nl >= 0 {
+			cut += nl + 1
+			l.multi = true
+		}
+		l.post = append(l.post, gap[:cut])
+		l.pre = append(l.pre, gap[cut:])
+	}
+	l.post = append(l.post, w.src[spans[len(spans)-1].end:end])
+	return l
+}
+
+// merge 按顺序输出新的元素序列，尽量复用原始元素的文本和分隔
+// @pkg 对每个新元素，在尚未使用的原始元素中查找完全相同的一个，找到时其前面跳过的原始元素视为已删除；
+// 找不到时，如果下一个新元素与当前原始元素相同则视为插入，否则视为对当前原始元素的修改
+// 输入:
+//   - l: 原始序列的布局
+//   - sepChar: 元素分隔符
+//   - origs, spans: 原始元素及其范围
+//   - news: 新元素
+//   - level: 元素的缩进级别
+func (w *preserver) merge(l layout, sepChar string, origs []Term, spans []srcSpan, news []Term, level int) string {
+	// 插入的元素沿用最后一个原始元素的缩进，分行书写时每个元素占一行
+	insertPre := bareGap(l.pre[len(l.pre)-1])
+	insertPost := sepChar
+	switch {
+	case sepChar == ".":
+		insertPost = ".\n"
+		if len(l.pre) == 1 {
+			insertPre = "\n"
+		}
+	case l.multi:
+		insertPost = sepChar + "\n"
+	case len(l.pre) == 1:
+		insertPre = " "
+	}
+
+	var b strings.Builder
+	b.WriteString(l.open)
+
+	next := 0
+	for i, term := range news {
+		matched := -1
+		for k := next; k < len(origs); k++ {
+			if sameTerm(origs[k], term) {
+				matched = k
+				break
+			}
+		}
+
+		reused := -1
+		var text string
+		switch {
+		case matched >= 0:
+			reused = matched
+			text = w.src[spans[matched].start:spans[matched].end]
+		case next < len(origs) && !(i+1 < len(news) && sameTerm(origs[next], news[i+1])):
+			reused = next
+			text = w.render(spans[next], origs[next], term, level)
+		default:
+			text = formatTerm(term, level, w.indent)
+		}
+
+		pre := insertPre
+		if reused >= 0 {
+			next = reused + 1
+			pre = l.pre[reused]
+		}
+		if sepChar == "." && i == 0 && reused != 0 {
+			// 顶层的第一个项之前不保留其他项前面的空行
+			pre = strings.TrimLeft(pre, "\r\n")
+		}
+		b.WriteString(pre)
+		b.WriteString(text)
+
+		switch {
+		case i == len(news)-1:
+			b.WriteString(l.post[len(l.post)-1])
+		case reused >= 0 && reused < len(origs)-1:
+			b.WriteString(l.post[reused])
+		default:
+			b.WriteString(insertPost)
+		}
+	}
+	return b.String()
+}
+
+// bareGap 去掉前导文本中的注释，只保留其中的空行和最后一行的缩进
+func bareGap(pre string) string {
+	lines := strings.Split(pre, "\n")
+	var kept []string
+	for _, line := range lines[:len(lines)-1] {
+		if strings.TrimSpace(line) != "" {
+			break
+		}
+		kept = append(kept, line)
+	}
+	last := lines[len(lines)-1]
+	if strings.TrimSpace(last) != "" {
+		last = ""
+	}
+	return strings.Join(append(kept, last), "\n")
+}
+
+// render 输出修改后的项，尽量保留原文
+func (w *preserver) render(span srcSpan, orig, term Term, level int) string {
+	if sameTerm(orig, term) {
+		return w.src[span.start:span.end]
+	}
+
+	var origElems, newElems []Term
+	switch o := orig.(type) {
+	case Tuple:
+		t, ok := term.(Tuple)
+		if !ok {
+			break
+		}
+		origElems, newElems = o.Elements, t.Elements
+	case List:
+		t, ok := term.(List)
+		if !ok {
+			break
+		}
+		origElems, newElems = o.Elements, t.Elements
+	}
+	if !span.seq || origElems == nil || len(span.children) != len(origElems) || len(span.children) == 0 || len(newElems) == 0 {
+		return formatTerm(term, level, w.indent)
+	}
+
+	l := w.layout(span.children, span.start, span.end, ",", false)
+	return w.merge(l, ",", origElems, span.children, newElems, level+1)
+}
+
+// sameTerm 判断两个项是否完全相同，包括原子是否带引号，但不考虑数字和字符串记录的源码写法（Text）
+func sameTerm(a, b Term) bool {
+	switch x := a.(type) {
+	case String:
+		y, ok := b.(String)
+		return ok && x.Value == y.Value
+	case Integer:
+		y, ok := b.(Integer)
+		return ok && x.Value == y.Value
+	case Float:
+		y, ok := b.(Float)
+		return ok && x.Value == y.Value
+	case Tuple:
+		y, ok := b.(Tuple)
+		return ok && sameTerms(x.Elements, y.Elements)
+	case List:
+		y, ok := b.(List)
+		return ok && sameTerms(x.Elements, y.Elements)
+	case Map:
+		y, ok := b.(Map)
+		if !ok || len(x.Pairs) != len(y.Pairs) {
+			return false
+		}
+		for i := range x.Pairs {
+			if !sameTerm(x.Pairs[i].Key, y.Pairs[i].Key) || !sameTerm(x.Pairs[i].Value, y.Pairs[i].Value) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// sameTerms 判断两个项列表是否逐个 sameTerm
+func sameTerms(a, b []Term) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !sameTerm(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// appendTerms 在没有任何项的原文（如只有注释的文件）之后追加新项
+func (w *preserver) appendTerms(src string, terms []Term) string {
+	var b strings.Builder
+	b.WriteString(src)
+	if len(terms) > 0 && src != "" && !strings.HasSuffix(src, "\n") {
+		b.WriteString("\n")
+	}
+	for i, term := range terms {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(formatTerm(term, 0, w.indent))
+		b.WriteString(".\n")
+	}
+	return b.String()
+}
+
+// sourceSpans 返回源码中各顶级项的范围（不包括结尾的点号）
+// @pkg 基于 Tokenize 的结果匹配括号，元组和列表会继续细分为元素，映射和二进制作为整体；
+// 遇到无法识别的结构时返回 false
+func sourceSpans(src string) ([]srcSpan, bool) {
+	var tokens []Token
+	for _, tok := range Tokenize(src) {
+		if tok.Kind != TokenWhitespace && tok.Kind != TokenComment {
+			tokens = append(tokens, tok)
+		}
+	}
+
+	var spans []srcSpan
+	i := 0
+	for i < len(tokens) {
+		span, next, ok := scanSpan(tokens, i)
+		if !ok || next >= len(tokens) || tokens[next].Text != "." {
+			return nil, false
+		}
+		spans = append(spans, span)
+		i = next + 1
+	}
+	return spans, true
+}
+
+// scanSpan 从 tokens[i] 开始扫描一个项，返回其范围和之后的下标
+func scanSpan(tokens []Token, i int) (srcSpan, int, bool) {
+	if i >= len(tokens) {
+		return srcSpan{}, i, false
+	}
+	tok := tokens[i]
+	switch tok.Kind {
+	case TokenAtom, TokenString, TokenNumber:
+		return srcSpan{start: tok.Offset, end: tok.Offset + len(tok.Text)}, i + 1, true
+	case TokenPunct:
+	default:
+		return srcSpan{}, i, false
+	}
+
+	switch tok.Text {
+	case "{", "[":
+		closer := "}"
+		if tok.Text == "[" {
+			closer = "]"
+		}
+		span := srcSpan{start: tok.Offset, seq: true}
+		i++
+		if i < len(tokens) && tokens[i].Text == closer {
+			span.end = tokens[i].Offset + 1
+			return span, i + 1, true
+		}
+		for {
+			child, next, ok := scanSpan(tokens, i)
+			if !ok || next >= len(tokens) {
+				return srcSpan{}, i, false
+			}
+			span.children = append(span.children, child)
+			switch tokens[next].Text {
+			case ",":
+				i = next + 1
+			case closer:
+				span.end = tokens[next].Offset + 1
+				return span, next + 1, true
+			default:
+				return srcSpan{}, i, false
+			}
+		}
+	case "#", "<<":
+		// 映射和二进制作为整体，匹配括号找到结尾
+		depth := 0
+		for j := i; j < len(tokens); j++ {
+			switch tokens[j].Text {
+			case "{", "[", "<<":
+				depth++
+			case "}", "]", ">>":
+				depth--
+				if depth == 0 {
+					return srcSpan{start: tok.Offset, end: tokens[j].Offset + len(tokens[j].Text)}, j + 1, true
+				}
+			}
+		}
+	}
+	return srcSpan{}, i, false
+}
@@ -0,0 +1,232 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const preserveInput = `%% Build settings
+{erl_opts, [debug_info,   warnings_as_errors]}.
+
+{deps, [
+    %% web server
+    {cowboy,"2.9.0"},
+    {jsx, "3.1.0"}, % json
+    'recon'
+]}.
+
+{relx, [{release, {my_app, "0.1.0"}, [my_app]}]}.
+`
+
+// depsList returns the deps list of a parsed config for editing
+func depsList(t *testing.T, config *RebarConfig) *List {
+	t.Helper()
+	for i, term := range config.Terms {
+		if tuple, ok := term.(Tuple); ok && tuple.Elements[0].Compare(Atom{Value: "deps"}) {
+			list := tuple.Elements[1].(List)
+			elements := append([]Term(nil), list.Elements...)
+			config.Terms[i] = Tuple{Elements: []Term{tuple.Elements[0], List{Elements: elements}}}
+			return &List{Elements: elements}
+		}
+	}
+	t.Fatal("deps not found")
+	return nil
+}
+
+// setDeps replaces the deps list of a config
+func setDeps(config *RebarConfig, deps []Term) {
+	for i, term := range config.Terms {
+		if tuple, ok := term.(Tuple); ok && tuple.Elements[0].Compare(Atom{Value: "deps"}) {
+			config.Terms[i] = Tuple{Elements: []Term{tuple.Elements[0], List{Elements: deps}}}
+		}
+	}
+}
+
+// TestFormatPreserving tests that only edited terms are re-rendered
+func TestFormatPreserving(t *testing.T) {
+	jsx := Tuple{Elements: []Term{Atom{Value: "jsx"}, String{Value: "3.1.0"}}}
+	cowboy := Tuple{Elements: []Term{Atom{Value: "cowboy"}, String{Value: "2.9.0"}}}
+	recon := Atom{Value: "recon", IsQuoted: true}
+
+	tests := []struct {
+		name     string
+		edit     func(config *RebarConfig)
+		expected string
+	}{
+		{
+			name:     "untouched",
+			edit:     func(config *RebarConfig) {},
+			expected: preserveInput,
+		},
+		{
+			name: "bump one dep",
+			edit: func(config *RebarConfig) {
+				setDeps(config, []Term{cowboy, Tuple{Elements: []Term{Atom{Value: "jsx"}, String{Value: "3.2.0"}}}, recon})
+			},
+			expected: `%% Build settings
+{erl_opts, [debug_info,   warnings_as_errors]}.
+
+{deps, [
+    %% web server
+    {cowboy,"2.9.0"},
+    {jsx, "3.2.0"}, % json
+    'recon'
+]}.
+
+{relx, [{release, {my_app, "0.1.0"}, [my_app]}]}.
+`,
+		},
+		{
+			name: "append and remove deps",
+			edit: func(config *RebarConfig) {
+				setDeps(config, []Term{jsx, recon, Atom{Value: "lager"}})
+			},
+			expected: `%% Build settings
+{erl_opts, [debug_info,   warnings_as_errors]}.
+
+{deps, [
+    {jsx, "3.1.0"}, % json
+    'recon',
+    lager
+]}.
+
+{relx, [{release, {my_app, "0.1.0"}, [my_app]}]}.
+`,
+		},
+		{
+			name: "insert before first dep",
+			edit: func(config *RebarConfig) {
+				setDeps(config, []Term{Atom{Value: "lager"}, cowboy, jsx, recon})
+			},
+			expected: `%% Build settings
+{erl_opts, [debug_info,   warnings_as_errors]}.
+
+{deps, [
+    lager,
+    %% web server
+    {cowboy,"2.9.0"},
+    {jsx, "3.1.0"}, % json
+    'recon'
+]}.
+
+{relx, [{release, {my_app, "0.1.0"}, [my_app]}]}.
+`,
+		},
+		{
+			name: "add and remove top-level terms",
+			edit: func(config *RebarConfig) {
+				config.Terms = append(config.Terms[1:], Tuple{Elements: []Term{Atom{Value: "minimum_otp_vsn"}, String{Value: "24"}}})
+			},
+			expected: `{deps, [
+    %% web server
+    {cowboy,"2.9.0"},
+    {jsx, "3.1.0"}, % json
+    'recon'
+]}.
+
+{relx, [{release, {my_app, "0.1.0"}, [my_app]}]}.
+
+{minimum_otp_vsn, "24"}.
+`,
+		},
+		{
+			name: "nested edit",
+			edit: func(config *RebarConfig) {
+				config.Terms[2] = Tuple{Elements: []Term{
+					Atom{Value: "relx"},
+					List{Elements: []Term{Tuple{Elements: []Term{
+						Atom{Value: "release"},
+						Tuple{Elements: []Term{Atom{Value: "my_app"}, String{Value: "0.2.0"}}},
+						List{Elements: []Term{Atom{Value: "my_app"}}},
+					}}}},
+				}}
+			},
+			expected: `%% Build settings
+{erl_opts, [debug_info,   warnings_as_errors]}.
+
+{deps, [
+    %% web server
+    {cowboy,"2.9.0"},
+    {jsx, "3.1.0"}, % json
+    'recon'
+]}.
+
+{relx, [{release, {my_app, "0.2.0"}, [my_app]}]}.
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse(preserveInput)
+			if err != nil {
+				t.Fatalf("Failed to parse config: %v", err)
+			}
+			tt.edit(config)
+			if got := config.FormatPreserving(4); got != tt.expected {
+				t.Errorf("Expected:\n%s\nGot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestFormatPreservingFallback tests configs without usable source text
+func TestFormatPreservingFallback(t *testing.T) {
+	config, err := Parse(`{deps,[cowboy]}.`, DiscardRaw())
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	if got, expected := config.FormatPreserving(4), config.Format(4); got != expected {
+		t.Errorf("Expected Format output %q, got %q", expected, got)
+	}
+
+	config, err = Parse("%% only comments\n")
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	config.Terms = append(config.Terms, Tuple{Elements: []Term{Atom{Value: "deps"}, List{}}})
+	if got, expected := config.FormatPreserving(4), "%% only comments\n{deps, []}.\n"; got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+// TestFormatPreservingCorpus tests untouched output and term removal on the corpus files
+func TestFormatPreservingCorpus(t *testing.T) {
+	paths, err := filepath.Glob("testdata/corpus/*.config")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("Failed to list corpus: %v", err)
+	}
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read file: %v", err)
+			}
+			config, err := Parse(string(data))
+			if err != nil {
+				t.Fatalf("Failed to parse config: %v", err)
+			}
+			if got := config.FormatPreserving(4); got != config.Raw {
+				t.Errorf("Expected untouched config to be unchanged, got:\n%s", got)
+			}
+
+			for i := range config.Terms {
+				edited := &RebarConfig{Raw: config.Raw, Terms: append(append([]Term(nil), config.Terms[:i]...), config.Terms[i+1:]...)}
+				reparsed, err := Parse(edited.FormatPreserving(4))
+				if err != nil {
+					t.Fatalf("Failed to parse output without term %d: %v", i, err)
+				}
+				if len(reparsed.Terms) != len(edited.Terms) {
+					t.Fatalf("Expected %d terms without term %d, got %d", len(edited.Terms), i, len(reparsed.Terms))
+				}
+				for j := range edited.Terms {
+					if !edited.Terms[j].Compare(reparsed.Terms[j]) {
+						t.Errorf("Term %d changed after removing term %d", j, i)
+					}
+				}
+			}
+		})
+	}
+}
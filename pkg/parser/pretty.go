@@ -0,0 +1,701 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// PrettyOptions 是 PrettyWith 的输出选项
+type PrettyOptions struct {
+	// Width 是行宽，对应 ~Wp 中的 W；为 0 时使用 Erlang 的默认值 80
+	Width int
+	// Unicode 对应 ~tp：二进制中的 UTF-8 文本输出为 <<"..."/utf8>>，原子中的非 Latin-1 字符原样输出
+	Unicode bool
+}
+
+// Pretty 返回与 Erlang 的 io_lib:format("~p", [Term]) 相同的文本
+// @pkg 按 io_lib_pretty 的规则输出，便于与 Erlang 工具生成的文件做逐字节比较:
+// - 能放进一行（80 列）的项不加空格地写在一行，如 {deps,[{cowboy,"2.9.0"}]}
+// - 放不下时列表和元组的元素分行对齐，原子、数字等简单元素尽量填满一行；以原子开头的元组把后续元素对齐到原子之后
+// - 原子按需加引号；字符串只在全部为可打印 Latin-1 字符时输出为 "..."，否则输出为整数列表，空字符串输出为 []
+// - 浮点数使用最短的精确表示，如 1000.0 输出为 1.0e3
+// - 映射的键按 Erlang 项的顺序排列（与 Erlang 中不超过 32 个键的映射一致），键值之间为 " => "
+//
+// 字符串和二进制即使超过行宽也不拆分
+// 输入:
+//   - term: 要输出的项
+//
+// 输出:
+//   - string: 不含结尾换行的文本
+//
+// 示例:
+//
+//	parser.Pretty(parser.Tuple{Elements: []parser.Term{parser.Atom{Value: "vsn"}, parser.Float{Value: 1000}}})
+//	// 返回 "{vsn,1.0e3}"
+func Pretty(term Term) string {
+	return PrettyWith(term, PrettyOptions{})
+}
+
+// PrettyWith 按选项返回与 io_lib:format("~Wp", [Term]) 或 "~Wtp" 相同的文本
+// 输入:
+//   - term: 要输出的项
+//   - opts: 行宽和编码选项
+//
+// 输出:
+//   - string: 不含结尾换行的文本
+func PrettyWith(term Term, opts PrettyOptions) string {
+	width := opts.Width
+	if width <= 0 {
+		width = 80
+	}
+	node := prettyIntermediate(term, opts.Unicode)
+	if node.kind == prettyAtomic || node.length < width-1 {
+		return node.write()
+	}
+
+	p := prettyPrinter{width: width, tagIndent: 1}
+	for _, tagIndent := range []int{-1, 4} {
+		if p.fits(node, tagIndent) {
+			p.tagIndent = tagIndent
+			break
+		}
+	}
+	return p.pp(node, 1, 0, 0)
+}
+
+// Pretty 返回每个顶级项按 io_lib:format("~p.~n", [Term]) 输出后拼接的文本
+// @pkg 与 Erlang 中 file:write_file(Path, [io_lib:format("~p.~n", [T]) || T <- Terms]) 写出的内容相同
+// 输出:
+//   - string: 配置文本，每项以 ".\n" 结尾
+func (c *RebarConfig) Pretty() string {
+	var b strings.Builder
+	for _, term := range c.Terms {
+		b.WriteString(Pretty(term))
+		b.WriteString(".\n")
+	}
+	return b.String()
+}
+
+// prettyKind 是中间表示中节点的种类
+type prettyKind int
+
+const (
+	prettyAtomic prettyKind = iota
+	prettyList
+	prettyTuple
+	prettyTagTuple
+	prettyMap
+	prettyPair
+)
+
+// prettyNode 是 io_lib_pretty 的中间表示：简单项保存其文本，复合项保存子节点；length 是写在一行时的字符数
+type prettyNode struct {
+	kind   prettyKind
+	text   string
+	elems  []*prettyNode
+	length int
+}
+
+// write 返回节点写在一行时的文本
+func (n *prettyNode) write() string {
+	switch n.kind {
+	case prettyList:
+		return "[" + writeNodes(n.elems) + "]"
+	case prettyTuple, prettyTagTuple:
+		return "{" + writeNodes(n.elems) + "}"
+	case prettyMap:
+		return "#{" + writeNodes(n.elems) + "}"
+	case prettyPair:
+		return n.elems[0].write() + " => " + n.elems[1].write()
+	}
+	return n.text
+}
+
+// atomic 检查节点是否为简单项；键值对的键和值都是简单项时也视为简单的
+func (n *prettyNode) atomic() bool {
+	if n.kind == prettyPair {
+		return n.elems[0].atomic() && n.elems[1].atomic()
+	}
+	return n.kind == prettyAtomic
+}
+
+// writeNodes 用逗号连接各节点的单行文本
+func writeNodes(nodes []*prettyNode) string {
+	parts := make([]string, len(nodes))
+	for i, node := range nodes {
+		parts[i] = node.write()
+	}
+	return strings.Join(parts, ",")
+}
+
+// prettyIntermediate 构建项的中间表示
+func prettyIntermediate(term Term, unicode bool) *prettyNode {
+	atomic := func(text string) *prettyNode {
+		return &prettyNode{text: text, length: utf8.RuneCountInString(text)}
+	}
+	compound := func(kind prettyKind, elems []*prettyNode, overhead int) *prettyNode {
+		n := &prettyNode{kind: kind, elems: elems, length: overhead}
+		for i, elem := range elems {
+			n.length += elem.length
+			if i > 0 {
+				n.length++
+			}
+		}
+		return n
+	}
+
+	switch t := term.(type) {
+	case Atom:
+		return atomic(erlangAtom(t.Value, unicode))
+	case Integer:
+		return atomic(strconv.FormatInt(t.Value, 10))
+	case Float:
+		return atomic(erlangFloat(t.Value))
+	case String:
+		chars := []rune(t.Value)
+		if len(chars) == 0 {
+			return atomic("[]")
+		}
+		if printableLatin1(chars) {
+			return atomic(erlangQuoted(chars, '"', false))
+		}
+		elems := make([]*prettyNode, len(chars))
+		for i, c := range chars {
+			elems[i] = atomic(strconv.Itoa(int(c)))
+		}
+		return compound(prettyList, elems, 2)
+	case Binary:
+		return atomic(erlangBinary(t.Value, unicode))
+	case List:
+		if len(t.Elements) == 0 {
+			return atomic("[]")
+		}
+		if chars, ok := charList(t.Elements); ok && printableLatin1(chars) {
+			return atomic(erlangQuoted(chars, '"', false))
+		}
+		elems := make([]*prettyNode, len(t.Elements))
+		for i, elem := range t.Elements {
+			elems[i] = prettyIntermediate(elem, unicode)
+		}
+		return compound(prettyList, elems, 2)
+	case Tuple:
+		elems := make([]*prettyNode, len(t.Elements))
+		for i, elem := range t.Elements {
+			elems[i] = prettyIntermediate(elem, unicode)
+		}
+		kind := prettyTuple
+		if len(t.Elements) > 1 {
+			if _, ok := t.Elements[0].(Atom); ok {
+				kind = prettyTagTuple
+			}
+		}
+		return compound(kind, elems, 2)
+	case Map:
+		pairs := append([]MapPair(nil), t.Pairs...)
+		sort.SliceStable(pairs, func(i, j int) bool {
+			return erlangCompare(pairs[i].Key, pairs[j].Key) < 0
+		})
+		elems := make([]*prettyNode, len(pairs))
+		for i, pair := range pairs {
+			elems[i] = compound(prettyPair, []*prettyNode{prettyIntermediate(pair.Key, unicode), prettyIntermediate(pair.Value, unicode)}, 3)
+		}
+		return compound(prettyMap, elems, 3)
+	}
+	return atomic(term.String())
+}
+
+// prettyPrinter 按 io_lib_pretty 的算法分行输出中间表示
+// @pkg tagIndent 对应 io_lib_pretty 中的 TInd：为 -1 时以原子开头的元组的后续元素总是对齐到原子之后；
+// 为正数时原子较长的元组的后续元素换行并缩进 tagIndent 列
+type prettyPrinter struct {
+	width     int
+	tagIndent int
+}
+
+// lastDepth 返回元素之后紧跟的右括号数：最后一个元素为外层的数量加一，其他元素为 0
+func lastDepth(rest []*prettyNode, depth int) int {
+	if len(rest) == 0 {
+		return depth + 1
+	}
+	return 0
+}
+
+// pp 输出节点，col 是起始列（从 1 开始），indent 是续行的缩进空格数，depth 是之后紧跟的右括号数
+func (p *prettyPrinter) pp(n *prettyNode, col, indent, depth int) string {
+	if n.length < p.width-col-depth {
+		return n.write()
+	}
+	switch n.kind {
+	case prettyList:
+		return "[" + p.ppList(n.elems, col+1, indent+1, depth) + "]"
+	case prettyTuple:
+		return "{" + p.ppList(n.elems, col+1, indent+1, depth) + "}"
+	case prettyTagTuple:
+		return "{" + p.ppTagTuple(n.elems, col, indent, depth) + "}"
+	case prettyMap:
+		return "#{" + p.ppList(n.elems, col+2, indent+2, depth) + "}"
+	}
+	return n.write()
+}
+
+// ppTagTuple 输出以原子开头的元组的内容
+func (p *prettyPrinter) ppTagTuple(elems []*prettyNode, col, indent, depth int) string {
+	tag := elems[0]
+	tagIndent := tag.length + 2
+	if p.tagIndent > 0 && tagIndent > p.tagIndent {
+		return tag.write() + p.ppTail(elems[1:], col+p.tagIndent, col+tagIndent, indent+p.tagIndent, depth)
+	}
+	return tag.write() + "," + p.ppList(elems[1:], col+tagIndent, indent+tagIndent, depth)
+}
+
+// ppList 输出逗号分隔的元素，col0 是元素的起始列
+func (p *prettyPrinter) ppList(elems []*prettyNode, col0, indent, depth int) string {
+	text, width := p.ppElement(elems[0], col0, indent, lastDepth(elems[1:], depth))
+	return text + p.ppTail(elems[1:], col0, col0+width, indent, depth)
+}
+
+// ppTail 输出其余元素：放得下的简单元素接在当前行，其他元素另起一行
+func (p *prettyPrinter) ppTail(elems []*prettyNode, col0, col, indent, depth int) string {
+	var b strings.Builder
+	for i, elem := range elems {
+		d := lastDepth(elems[i+1:], depth)
+		length := 1 + elem.length
+		if elem.atomic() && (d == 0 && length+1 < p.width-col || d > 0 && length < p.width-col-d) {
+			b.WriteString(",")
+			b.WriteString(elem.write())
+			col += length
+			continue
+		}
+		text, width := p.ppElement(elem, col0, indent, d)
+		b.WriteString(",\n")
+		b.WriteString(strings.Repeat(" ", indent))
+		b.WriteString(text)
+		col = col0 + width
+	}
+	return b.String()
+}
+
+// ppElement 输出一个元素，返回文本和占用的宽度；复合元素返回行宽，使下一个元素另起一行
+func (p *prettyPrinter) ppElement(n *prettyNode, col, indent, depth int) (string, int) {
+	if n.length < p.width-col-depth {
+		if n.atomic() {
+			return n.write(), n.length
+		}
+		return n.write(), p.width
+	}
+	if n.kind == prettyPair {
+		valueIndent := p.mapValueIndent()
+		return p.pp(n.elems[0], col, indent, depth) + " =>\n" + strings.Repeat(" ", indent+valueIndent) +
+			p.pp(n.elems[1], col+valueIndent, indent+valueIndent, depth), p.width
+	}
+	return p.pp(n, col, indent, depth), p.width
+}
+
+// mapValueIndent 返回映射中换行输出的值相对于键的缩进
+func (p *prettyPrinter) mapValueIndent() int {
+	if p.tagIndent > 0 {
+		return p.tagIndent
+	}
+	return 4
+}
+
+// fits 检查使用给定的 tagIndent 时，以原子开头的元组的对齐列是否都不超过行宽的一半（对应 io_lib_pretty 的 cind）
+func (p *prettyPrinter) fits(n *prettyNode, tagIndent int) bool {
+	q := prettyPrinter{width: p.width, tagIndent: tagIndent}
+	return q.cind(n, 1, 0)
+}
+
+func (p *prettyPrinter) cind(n *prettyNode, col, depth int) bool {
+	if n.length < p.width-col-depth {
+		return true
+	}
+	switch n.kind {
+	case prettyList, prettyTuple:
+		return p.cindList(n.elems, col+1, depth)
+	case prettyMap:
+		return p.cindList(n.elems, col+2, depth)
+	case prettyTagTuple:
+		tagIndent := n.elems[0].length + 2
+		if p.tagIndent > 0 && tagIndent > p.tagIndent {
+			if col+p.tagIndent > p.width/2 {
+				return false
+			}
+			return p.cindTail(n.elems[1:], col+p.tagIndent, col+tagIndent, depth)
+		}
+		if col+tagIndent >= p.width/2 {
+			return false
+		}
+		return p.cindList(n.elems[1:], col+tagIndent, depth)
+	case prettyPair:
+		valueIndent := p.mapValueIndent()
+		return p.cind(n.elems[0], col, depth) && p.cind(n.elems[1], col+valueIndent, depth)
+	}
+	return true
+}
+
+func (p *prettyPrinter) cindList(elems []*prettyNode, col0, depth int) bool {
+	width, ok := p.cindElement(elems[0], col0, lastDepth(elems[1:], depth))
+	return ok && p.cindTail(elems[1:], col0, col0+width, depth)
+}
+
+func (p *prettyPrinter) cindTail(elems []*prettyNode, col0, col, depth int) bool {
+	for i, elem := range elems {
+		d := lastDepth(elems[i+1:], depth)
+		length := 1 + elem.length
+		if elem.atomic() && (d == 0 && length+1 < p.width-col || d > 0 && length < p.width-col-d) {
+			col += length
+			continue
+		}
+		width, ok := p.cindElement(elem, col0, d)
+		if !ok {
+			return false
+		}
+		col = col0 + width
+	}
+	return true
+}
+
+func (p *prettyPrinter) cindElement(n *prettyNode, col, depth int) (int, bool) {
+	if n.atomic() && n.length < p.width-col-depth {
+		return n.length, true
+	}
+	return p.width, p.cind(n, col, depth)
+}
+
+// erlangFloat 返回与 Erlang 的 io_lib:write/1 相同的浮点数文本（io_lib_format:fwrite_g）
+// @pkg 使用最短的精确数字，在定点和指数形式中选择较短的一种，如 100.0、1.0e3、0.001、1.0e-7
+func erlangFloat(value float64) string {
+	if value == 0 {
+		if math.Signbit(value) {
+			return "-0.0"
+		}
+		return "0.0"
+	}
+	abs := math.Abs(value)
+	mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(abs, 'e', -1, 64), "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	exp, _ := strconv.Atoi(exponent)
+
+	text := insertDecimal(exp+1, digits, abs)
+	if value < 0 {
+		return "-" + text
+	}
+	return text
+}
+
+// insertDecimal 在数字串中插入小数点，place 是小数点的位置（值为 0.digits × 10^place）
+func insertDecimal(place int, digits string, abs float64) string {
+	n := len(digits)
+	if place == 0 {
+		return "0." + digits
+	}
+	if place > 0 && place < n {
+		return digits[:place] + "." + digits[place:]
+	}
+
+	exp := strconv.Itoa(place - 1)
+	dot := 1
+	if n == 1 {
+		dot = 2
+	}
+	cost := len(exp) + 1 + dot
+	if place < 0 {
+		if 2-place <= cost {
+			return "0." + strings.Repeat("0", -place) + digits
+		}
+	} else if place-n+2 <= cost && abs < float64(int64(1)<<52) {
+		return digits + strings.Repeat("0", place-n) + ".0"
+	}
+	if n == 1 {
+		return digits + ".0e" + exp
+	}
+	return digits[:1] + "." + digits[1:] + "e" + exp
+}
+
+// erlangAtom 返回与 io_lib:write_atom/1 相同的原子文本
+// @pkg 以小写字母开头、只包含字母、数字、下划线和 @ 且不是保留字的原子不加引号，这里的字母包括 Latin-1 字母
+func erlangAtom(value string, unicode bool) string {
+	chars := []rune(value)
+	quote := len(chars) == 0 || reservedWords[value]
+	for i, c := range chars {
+		lower := c >= 'a' && c <= 'z' || c >= 0xDF && c <= 0xFF && c != 0xF7
+		if i == 0 && !lower || i > 0 && !lower && !(c >= 'A' && c <= 'Z' || c >= 0xC0 && c <= 0xDE && c != 0xD7 || c >= '0' && c <= '9' || c == '_' || c == '@') {
+			quote = true
+			break
+		}
+	}
+	if !quote {
+		return value
+	}
+	return erlangQuoted(chars, '\'', unicode)
+}
+
+// erlangQuoted 按 io_lib:write_string/2 的规则给字符加引号和转义
+// @pkg 可打印的 ASCII 和 Latin-1 字符原样输出（unicode 为 true 时所有非控制字符原样输出），
+// 常见控制字符使用 \n、\t 等转义，其他控制字符使用三位八进制，Latin-1 以外的字符使用 \x{...}
+func erlangQuoted(chars []rune, quote rune, unicode bool) string {
+	var b strings.Builder
+	b.WriteRune(quote)
+	for _, c := range chars {
+		switch {
+		case c == quote || c == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(c)
+		case c >= ' ' && c <= '~', c >= 0xA0 && (c <= 0xFF || unicode):
+			b.WriteRune(c)
+		case c == '\n':
+			b.WriteString(`\n`)
+		case c == '\r':
+			b.WriteString(`\r`)
+		case c == '\t':
+			b.WriteString(`\t`)
+		case c == '\v':
+			b.WriteString(`\v`)
+		case c == '\b':
+			b.WriteString(`\b`)
+		case c == '\f':
+			b.WriteString(`\f`)
+		case c == 0x1B:
+			b.WriteString(`\e`)
+		case c == 0x7F:
+			b.WriteString(`\d`)
+		case c < 0xA0:
+			b.WriteString(`\` + string(rune('0'+c>>6)) + string(rune('0'+c>>3&7)) + string(rune('0'+c&7)))
+		default:
+			b.WriteString(`\x{` + strings.ToUpper(strconv.FormatInt(int64(c), 16)) + `}`)
+		}
+	}
+	b.WriteRune(quote)
+	return b.String()
+}
+
+// erlangBinary 返回与 ~p 或 ~tp 相同的二进制文本
+// @pkg 所有字节都是可打印 Latin-1 字符时输出为 <<"...">>；unicode 为 true 且内容是可打印的 UTF-8 文本时输出为 <<"..."/utf8>>；
+// 其他情况输出为逗号分隔的字节
+func erlangBinary(value string, unicode bool) string {
+	if value == "" {
+		return "<<>>"
+	}
+	if unicode && utf8.ValidString(value) {
+		chars := []rune(value)
+		if printableUnicode(chars) {
+			text := erlangQuoted(chars, '"', true)
+			for _, c := range chars {
+				if c > 0x7F {
+					return "<<" + text + "/utf8>>"
+				}
+			}
+			return "<<" + text + ">>"
+		}
+	}
+	bytes := make([]rune, len(value))
+	for i := 0; i < len(value); i++ {
+		bytes[i] = rune(value[i])
+	}
+	if printableLatin1(bytes) {
+		return "<<" + erlangQuoted(bytes, '"', false) + ">>"
+	}
+	parts := make([]string, len(value))
+	for i := 0; i < len(value); i++ {
+		parts[i] = strconv.Itoa(int(value[i]))
+	}
+	return "<<" + strings.Join(parts, ",") + ">>"
+}
+
+// charList 返回全部由整数组成的列表对应的字符
+func charList(elements []Term) ([]rune, bool) {
+	chars := make([]rune, len(elements))
+	for i, elem := range elements {
+		n, ok := elem.(Integer)
+		if !ok || n.Value < 0 || n.Value > utf8.MaxRune {
+			return nil, false
+		}
+		chars[i] = rune(n.Value)
+	}
+	return chars, true
+}
+
+// printableLatin1 对应 io_lib:printable_latin1_list/1
+func printableLatin1(chars []rune) bool {
+	for _, c := range chars {
+		if !(c >= ' ' && c <= '~' || c >= 0xA0 && c <= 0xFF || isPrintableControl(c)) {
+			return false
+		}
+	}
+	return true
+}
+
+// printableUnicode 对应 io_lib:printable_unicode_list/1
+func printableUnicode(chars []rune) bool {
+	for _, c := range chars {
+		if !(c >= ' ' && c <= '~' || c >= 0xA0 && c < 0xD800 || c > 0xDFFF && c < 0xFFFE || c > 0xFFFF && c <= 0x10FFFF || isPrintableControl(c)) {
+			return false
+		}
+	}
+	return true
+}
+
+// isPrintableControl 检查字符是否是 Erlang 视为可打印的控制字符（\n、\r、\t、\v、\b、\f、\e）
+func isPrintableControl(c rune) bool {
+	switch c {
+	case '\n', '\r', '\t', '\v', '\b', '\f', 0x1B:
+		return true
+	}
+	return false
+}
+
+// erlangCompare 按 Erlang 的项顺序比较两个项：数字 < 原子 < 元组 < 映射 < 空列表 < 列表 < 二进制
+// @pkg 字符串视为字符整数的列表；整数与浮点数按数值比较，数值相等时整数在前（与映射键的顺序一致）
+func erlangCompare(a, b Term) int {
+	return compareTerms(a, b, true)
+}
+
+// compareTerms 按 Erlang 的项顺序比较两个项
+// @pkg exact 为 true 时数值相等的整数排在浮点数之前，为 false 时两者相等（与 Erlang 的 == 一致）。
+// 映射先比较大小，再按键的顺序比较键，最后比较对应的值
+func compareTerms(a, b Term, exact bool) int {
+	ra, rb := erlangRank(a), erlangRank(b)
+	if ra != rb {
+		return ra - rb
+	}
+
+	switch x := a.(type) {
+	case Integer, Float:
+		_, ia := x.(Integer)
+		_, ib := b.(Integer)
+		if ia && ib {
+			return compareInt64(x.(Integer).Value, b.(Integer).Value)
+		}
+		fa, fb := numberValue(a), numberValue(b)
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		case !exact:
+			return 0
+		case ia && !ib:
+			return -1
+		case !ia && ib:
+			return 1
+		}
+		return 0
+	case Atom:
+		return strings.Compare(x.Value, b.(Atom).Value)
+	case Tuple:
+		y := b.(Tuple)
+		if len(x.Elements) != len(y.Elements) {
+			return len(x.Elements) - len(y.Elements)
+		}
+		return compareSeq(x.Elements, y.Elements, exact)
+	case Map:
+		y := b.(Map)
+		if len(x.Pairs) != len(y.Pairs) {
+			return len(x.Pairs) - len(y.Pairs)
+		}
+		xp, yp := sortedPairs(x.Pairs), sortedPairs(y.Pairs)
+		for i := range xp {
+			if c := erlangCompare(xp[i].Key, yp[i].Key); c != 0 {
+				return c
+			}
+		}
+		for i := range xp {
+			if c := compareTerms(xp[i].Value, yp[i].Value, exact); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case Binary:
+		return strings.Compare(x.Value, b.(Binary).Value)
+	}
+	return compareSeq(listItems(a), listItems(b), exact)
+}
+
+// sortedPairs 返回按键的项顺序排序的映射键值对，已有序时直接返回
+func sortedPairs(pairs []MapPair) []MapPair {
+	less := func(p []MapPair) func(i, j int) bool {
+		return func(i, j int) bool { return erlangCompare(p[i].Key, p[j].Key) < 0 }
+	}
+	if sort.SliceIsSorted(pairs, less(pairs)) {
+		return pairs
+	}
+	sorted := append([]MapPair(nil), pairs...)
+	sort.SliceStable(sorted, less(sorted))
+	return sorted
+}
+
+// erlangRank 返回项在 Erlang 项顺序中的类别序号
+func erlangRank(term Term) int {
+	switch t := term.(type) {
+	case Integer, Float:
+		return 0
+	case Atom:
+		return 1
+	case Tuple:
+		return 2
+	case Map:
+		return 3
+	case List:
+		if len(t.Elements) == 0 {
+			return 4
+		}
+		return 5
+	case String:
+		if t.Value == "" {
+			return 4
+		}
+		return 5
+	case Binary:
+		return 6
+	}
+	return 7
+}
+
+// numberValue 返回数字的浮点值
+func numberValue(term Term) float64 {
+	if n, ok := term.(Integer); ok {
+		return float64(n.Value)
+	}
+	return term.(Float).Value
+}
+
+// compareInt64 比较两个整数
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareSeq 逐个元素比较，前缀相同时较短的在前
+func compareSeq(a, b []Term, exact bool) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := compareTerms(a[i], b[i], exact); c != 0 {
+			return c
+		}
+	}
+	return len(a) - len(b)
+}
+
+// listItems 返回列表或字符串的元素，字符串的每个字符作为一个整数
+func listItems(term Term) []Term {
+	if s, ok := term.(String); ok {
+		items := make([]Term, 0, len(s.Value))
+		for _, c := range s.Value {
+			items = append(items, Integer{Value: int64(c)})
+		}
+		return items
+	}
+	if l, ok := term.(List); ok {
+		return l.Elements
+	}
+	return nil
+}
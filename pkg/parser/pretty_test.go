@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestPrettyScalars tests ~p output of atoms, numbers, strings and binaries
+func TestPrettyScalars(t *testing.T) {
+	tests := []struct {
+		term     Term
+		opts     PrettyOptions
+		expected string
+	}{
+		{Atom{Value: "debug_info"}, PrettyOptions{}, "debug_info"},
+		{Atom{Value: "TEST"}, PrettyOptions{}, "'TEST'"},
+		{Atom{Value: "my-app"}, PrettyOptions{}, "'my-app'"},
+		{Atom{Value: "receive"}, PrettyOptions{}, "'receive'"},
+		{Atom{Value: ""}, PrettyOptions{}, "''"},
+		{Atom{Value: "it's"}, PrettyOptions{}, `'it\'s'`},
+		{Atom{Value: "café"}, PrettyOptions{}, "café"},
+		{Atom{Value: "中"}, PrettyOptions{}, `'\x{4E2D}'`},
+		{Atom{Value: "中"}, PrettyOptions{Unicode: true}, "'中'"},
+		{Integer{Value: -42}, PrettyOptions{}, "-42"},
+		{Float{Value: 3.14}, PrettyOptions{}, "3.14"},
+		{Float{Value: 100}, PrettyOptions{}, "100.0"},
+		{Float{Value: 1000}, PrettyOptions{}, "1.0e3"},
+		{Float{Value: 1234}, PrettyOptions{}, "1234.0"},
+		{Float{Value: 0.001}, PrettyOptions{}, "0.001"},
+		{Float{Value: 1e-7}, PrettyOptions{}, "1.0e-7"},
+		{Float{Value: -2.5e-10}, PrettyOptions{}, "-2.5e-10"},
+		{Float{Value: 0}, PrettyOptions{}, "0.0"},
+		{String{Value: "2.9.0"}, PrettyOptions{}, `"2.9.0"`},
+		{String{Value: "say \"hi\"\n"}, PrettyOptions{}, `"say \"hi\"\n"`},
+		{String{Value: ""}, PrettyOptions{}, "[]"},
+		{String{Value: "a\x01"}, PrettyOptions{}, "[97,1]"},
+		{String{Value: "中"}, PrettyOptions{Unicode: true}, "[20013]"},
+		{List{Elements: []Term{Integer{Value: 104}, Integer{Value: 105}}}, PrettyOptions{}, `"hi"`},
+		{Binary{Value: "cowboy"}, PrettyOptions{}, `<<"cowboy">>`},
+		{Binary{Value: ""}, PrettyOptions{}, "<<>>"},
+		{Binary{Value: "\x01\x02"}, PrettyOptions{}, "<<1,2>>"},
+		{Binary{Value: "é"}, PrettyOptions{}, `<<"Ã©">>`},
+		{Binary{Value: "é"}, PrettyOptions{Unicode: true}, `<<"é"/utf8>>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := PrettyWith(tt.term, tt.opts); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestPrettyCompound tests ~p output of tuples, lists and maps on one line
+func TestPrettyCompound(t *testing.T) {
+	config, err := Parse(`{deps, [{cowboy, "2.9.0"}, jsx]}.
+{m, #{b => 1, 1 => x, a => [], {t} => 2.0, "s" => <<>>}}.
+{empty, {}, [], #{}}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	expected := `{deps,[{cowboy,"2.9.0"},jsx]}.
+{m,#{1 => x,a => [],b => 1,{t} => 2.0,"s" => <<>>}}.
+{empty,{},[],#{}}.
+`
+	if got := config.Pretty(); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+// TestPrettyWrapping tests line breaking of terms wider than the line length
+func TestPrettyWrapping(t *testing.T) {
+	var numbers []Term
+	for i := 1; i <= 30; i++ {
+		numbers = append(numbers, Integer{Value: int64(i)})
+	}
+	if got, want := Pretty(List{Elements: numbers}), "[1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20,21,22,23,24,25,26,27,28,\n 29,30]"; got != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, got)
+	}
+
+	config, err := Parse(`{deps, [{cowboy, "2.9.0"}, {cowlib, "2.11.0"}, {ranch, "1.8.0"}, {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}}]}.
+{profiles, [{test, [{deps, [{meck, "0.9.2"}, {proper, "1.4.0"}]}, {erl_opts, [nowarn_export_all, {d, 'TEST', true}]}]}, {bench, [{deps, [{eflame, {git, "https://github.com/proger/eflame.git", {branch, "master"}}}]}]}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	expected := `{deps,[{cowboy,"2.9.0"},
+       {cowlib,"2.11.0"},
+       {ranch,"1.8.0"},
+       {lager,{git,"https://github.com/erlang-lager/lager.git",
+                   {tag,"3.9.2"}}}]}.
+{profiles,
+    [{test,
+         [{deps,[{meck,"0.9.2"},{proper,"1.4.0"}]},
+          {erl_opts,[nowarn_export_all,{d,'TEST',true}]}]},
+     {bench,
+         [{deps,
+              [{eflame,
+                   {git,"https://github.com/proger/eflame.git",
+                       {branch,"master"}}}]}]}]}.
+`
+	if got := config.Pretty(); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+
+	narrow := PrettyWith(config.Terms[0], PrettyOptions{Width: 40})
+	for _, line := range strings.Split(narrow, "\n")[:3] {
+		if len(line) > 40 {
+			t.Errorf("Expected lines within 40 columns, got %q", line)
+		}
+	}
+}
+
+// TestPrettyRoundTrip tests that ~p output of the corpus parses back to the same terms
+func TestPrettyRoundTrip(t *testing.T) {
+	for _, path := range []string{"testdata/corpus/rebar_service.config", "testdata/corpus/rebar3_self.config"} {
+		config, err := ParseFile(path)
+		if err != nil {
+			t.Fatalf("Failed to parse config: %v", err)
+		}
+		reparsed, err := Parse(config.Pretty())
+		if err != nil {
+			t.Fatalf("Failed to parse pretty output: %v", err)
+		}
+		if !compareConfigs(config, reparsed) {
+			t.Errorf("Pretty output of %s changed the terms", path)
+		}
+	}
+}
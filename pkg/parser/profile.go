@@ -0,0 +1,262 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"os"
+	"strings"
+)
+
+// ProfileEnv 是 rebar3 用来指定 profile 的环境变量
+const ProfileEnv = "REBAR_PROFILE"
+
+// WithProfile 让解析结果为应用指定 profile 后的有效配置
+// @pkg 相当于 rebar3 as Profile1,Profile2 ...，profile 按给出的顺序依次合并到基础配置中
+// 与 WithEnvProfile 同时使用时，先应用环境变量中的 profile
+// 输入:
+//   - names: profile 名称，如 "test"、"prod"
+//
+// 示例:
+//
+//	config, err := parser.ParseFile("./rebar.config", parser.WithProfile("prod"))
+func WithProfile(names ...string) ParseOption {
+	return func(o *parseOptions) {
+		o.profiles = append(o.profiles, names...)
+	}
+}
+
+// WithEnvProfile 让解析结果为应用 REBAR_PROFILE 环境变量中 profile 后的有效配置
+// @pkg 环境变量的值可以是逗号分隔的多个 profile，未设置时不应用任何 profile
+//
+// 示例:
+//
+//	// REBAR_PROFILE=prod
+//	config, err := parser.ParseFile("./rebar.config", parser.WithEnvProfile())
+func WithEnvProfile() ParseOption {
+	return func(o *parseOptions) {
+		o.envProfile = true
+	}
+}
+
+// profileNames 返回需要应用的 profile 名称
+func (o parseOptions) profileNames() []string {
+	var names []string
+	if o.envProfile {
+		for _, name := range strings.Split(os.Getenv(ProfileEnv), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return append(names, o.profiles...)
+}
+
+// withoutProfiles 清除已设置的 profile 选项
+func withoutProfiles() ParseOption {
+	return func(o *parseOptions) {
+		o.profiles = nil
+		o.envProfile = false
+	}
+}
+
+// effective 按选项对解析结果应用 profile
+func (o parseOptions) effective(config *RebarConfig) *RebarConfig {
+	names := o.profileNames()
+	if len(names) == 0 {
+		return config
+	}
+	return config.ApplyProfiles(names...)
+}
+
+// ApplyProfiles 返回应用指定 profile 后的有效配置
+// @pkg 合并规则与 rebar3 的 rebar_opts:merge_opts 一致:
+// - erl_opts、eunit_compile_opts、ct_compile_opts 按 NormalizeErlOpts 合并，profile 中的选项取代冲突的基础选项
+// - erl_first_files、mib_first_files 追加到基础列表之后
+// - plugins 与字符串值直接被 profile 中的值取代
+// - 其他列表按键合并：元组以首元素为键，原子以自身为键，profile 中的同键项取代基础项
+// - 其他值直接被 profile 中的值取代
+//
+// default profile 和配置中不存在的 profile 会被跳过。原配置不会被修改，
+// 返回配置的 Raw 仍为原始输入
+// 输入:
+//   - names: 按应用顺序排列的 profile 名称
+//
+// 输出:
+//   - *RebarConfig: 有效配置
+//
+// 示例:
+//
+//	prod := config.ApplyProfiles("prod")
+//	opts, _ := prod.GetErlOpts()
+//
+// 数据样例:
+// 原始配置:
+//
+//	{erl_opts, [debug_info]}.
+//	{deps, [{cowboy, "2.9.0"}]}.
+//	{profiles, [{test, [{deps, [meck]}, {erl_opts, [nowarn_export_all]}]}]}.
+//
+// ApplyProfiles("test") 的结果中 erl_opts 为 [debug_info, nowarn_export_all]，
+// deps 为 [{cowboy, "2.9.0"}, meck]
+func (c *RebarConfig) ApplyProfiles(names ...string) *RebarConfig {
+	profiles := profileEntries(c.Terms)
+	terms := append([]Term(nil), c.Terms...)
+
+	for _, name := range names {
+		if name == "default" {
+			continue
+		}
+		for _, profile := range profiles {
+			if profile.name == name {
+				terms = mergeProfile(terms, profile.terms)
+			}
+		}
+	}
+	return &RebarConfig{Raw: c.Raw, Terms: terms}
+}
+
+// GetProfile 返回 profile 的选项组成的配置
+// @pkg profile {Name, [Option, ...]} 中的每个选项成为返回配置的一个顶级项，因此 GetDeps、GetErlOpts 等访问方法
+// 可以直接用于 profile 内部。返回的配置只包含 profile 自身的选项，不与基础配置合并（需要合并时使用 ApplyProfiles），
+// Raw 为空；它是一份副本，修改不会写回原配置，写回使用 SetProfileOption 等方法。
+// 同名 profile 出现多次时返回第一个
+// 输入:
+//   - name: profile 名称，如 "test"
+//
+// 输出:
+//   - *RebarConfig: profile 的选项
+//   - bool: profile 是否存在
+//
+// 示例:
+//
+//	if test, ok := config.GetProfile("test"); ok {
+//	  deps, _ := test.GetDeps()
+//	  opts, _ := test.GetErlOpts()
+//	  fmt.Println(deps, opts)
+//	}
+//
+// 数据样例:
+// 原始配置: {profiles, [{test, [{deps, [meck]}, {erl_opts, [nowarn_export_all]}]}]}.
+// GetProfile("test") 返回: RebarConfig{Terms: [{deps, [meck]}, {erl_opts, [nowarn_export_all]}]}, true
+func (c *RebarConfig) GetProfile(name string) (*RebarConfig, bool) {
+	for _, profile := range profileEntries(c.Terms) {
+		if profile.name == name {
+			return &RebarConfig{Terms: append([]Term{}, profile.terms...)}, true
+		}
+	}
+	return nil, false
+}
+
+// Merge 按 rebar3 合并 profile 的规则将 overlay 合并到 base 之上
+// @pkg 规则与 ApplyProfiles 相同，overlay 的顶级项相当于一个 profile 的选项:
+// - deps 等列表按键合并：同名项在原位置被取代，新项追加到末尾
+// - erl_opts 等编译选项拼接后按 NormalizeErlOpts 去重，冲突时 overlay 优先
+// - plugins、字符串和其他非列表值直接被 overlay 取代
+//
+// overlay 中不是 {Key, Value} 形式的顶级项在 base 中不存在时追加到末尾。
+// 两个配置都不会被修改；结果的 Raw 为 base 的原始输入，因此可以用 FormatPreserving 在 base 的原文上写回
+// 输入:
+//   - base: 基础配置
+//   - overlay: 覆盖配置
+//
+// 输出:
+//   - *RebarConfig: 合并后的配置
+//
+// 示例:
+//
+//	base, _ := parser.ParseFile("./rebar.config")
+//	local, _ := parser.ParseFile("./rebar.local.config")
+//	effective := parser.Merge(base, local)
+//
+// 数据样例:
+//
+//	base:    {deps, [{cowboy, "2.9.0"}, jsx]}. {erl_opts, [debug_info]}.
+//	overlay: {deps, [{cowboy, "2.10.0"}, meck]}. {erl_opts, [debug_info, warnings_as_errors]}.
+//	结果:    {deps, [{cowboy, "2.10.0"}, jsx, meck]}. {erl_opts, [debug_info, warnings_as_errors]}.
+func Merge(base, overlay *RebarConfig) *RebarConfig {
+	terms := mergeProfile(append([]Term(nil), base.Terms...), overlay.Terms)
+	for _, term := range overlay.Terms {
+		if tuple, ok := term.(Tuple); ok && len(tuple.Elements) == 2 && termName(tuple) != "" {
+			continue
+		}
+		if !containsTerm(terms, term) {
+			terms = append(terms, term)
+		}
+	}
+	return &RebarConfig{Raw: base.Raw, Terms: terms}
+}
+
+// mergeProfile 将 profile 中的选项合并到顶级项中
+func mergeProfile(terms []Term, opts []Term) []Term {
+	for _, opt := range opts {
+		key := termName(opt)
+		tuple, ok := opt.(Tuple)
+		if key == "" || !ok || len(tuple.Elements) != 2 {
+			continue
+		}
+
+		index := -1
+		for i, term := range terms {
+			if t, ok := term.(Tuple); ok && len(t.Elements) == 2 && termName(t) == key {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			terms = append(terms, opt)
+			continue
+		}
+
+		old := terms[index].(Tuple).Elements[1]
+		value := mergeOpt(key, tuple.Elements[1], old)
+		terms[index] = Tuple{Elements: []Term{tuple.Elements[0], value}}
+	}
+	return terms
+}
+
+// mergeOpt 按 rebar3 的规则合并同一选项的 profile 值和基础值
+func mergeOpt(key string, value, old Term) Term {
+	newList, ok1 := value.(List)
+	oldList, ok2 := old.(List)
+	if !ok1 || !ok2 || key == "plugins" {
+		return value
+	}
+
+	switch key {
+	case "erl_opts", "eunit_compile_opts", "ct_compile_opts":
+		return List{Elements: NormalizeErlOpts(oldList.Elements, newList.Elements)}
+	case "erl_first_files", "mib_first_files":
+		if newList.Compare(oldList) {
+			return value
+		}
+		return List{Elements: append(append([]Term{}, oldList.Elements...), newList.Elements...)}
+	}
+
+	elements := append([]Term{}, oldList.Elements...)
+	for _, elem := range newList.Elements {
+		replaced := false
+		if name := termName(elem); name != "" {
+			for i, existing := range elements {
+				if termName(existing) == name {
+					elements[i] = elem
+					replaced = true
+					break
+				}
+			}
+		}
+		if !replaced && !containsTerm(elements, elem) {
+			elements = append(elements, elem)
+		}
+	}
+	return List{Elements: elements}
+}
+
+// containsTerm 判断列表中是否存在与 term 相等的项
+func containsTerm(elements []Term, term Term) bool {
+	for _, elem := range elements {
+		if elem.Compare(term) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+// AddProfile 添加空的 profile，即 {profiles, [..., {name, []}]}
+// @pkg profiles 不存在时会被创建；profile 已存在时不做修改
+// 输入:
+//   - name: profile 名称，如 "test"
+//
+// 输出:
+//   - bool: 是否添加了 profile
+//
+// 示例:
+//
+//	config.AddProfile("test")
+func (c *RebarConfig) AddProfile(name string) bool {
+	profiles, _ := listValue(c.Terms, "profiles")
+	if _, i := listValue(profiles, name); i >= 0 {
+		return false
+	}
+	c.Terms = setListValue(c.Terms, "profiles", append(profiles, KV(name, NewList())))
+	return true
+}
+
+// SetProfileOption 设置 profile 中的选项 {key, value}
+// @pkg 选项已存在时在原位置替换，否则追加到 profile 末尾；profile 和 profiles 不存在时会被创建
+// 输入:
+//   - name: profile 名称，如 "test"
+//   - key: 选项名，如 "erl_opts"
+//   - value: 选项值
+//
+// 示例:
+//
+//	config.SetProfileOption("test", "erl_opts", parser.NewList(parser.NewAtom("nowarn_export_all")))
+//	// {profiles, [{test, [{erl_opts, [nowarn_export_all]}]}]}
+func (c *RebarConfig) SetProfileOption(name, key string, value Term) {
+	profiles, _ := listValue(c.Terms, "profiles")
+	profile, _ := listValue(profiles, name)
+	profiles = setListValue(profiles, name, setValue(profile, key, value))
+	c.Terms = setListValue(c.Terms, "profiles", profiles)
+}
+
+// SetProfileDeps 将 profile 的依赖设置为 deps
+// @pkg 相当于 SetProfileOption(name, "deps", [deps...])；逐个增删依赖使用 AddDep、RemoveDep 和 InProfile
+// 输入:
+//   - name: profile 名称，如 "test"
+//   - deps: 依赖声明
+//
+// 示例:
+//
+//	config.SetProfileDeps("test", []parser.Term{parser.KV("meck", parser.NewString("0.9.2")), parser.NewAtom("proper")})
+func (c *RebarConfig) SetProfileDeps(name string, deps []Term) {
+	c.SetProfileOption(name, "deps", NewList(deps...))
+}
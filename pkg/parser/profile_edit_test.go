@@ -0,0 +1,42 @@
+package parser
+
+import "testing"
+
+// TestProfileEditing tests AddProfile, SetProfileOption and SetProfileDeps
+func TestProfileEditing(t *testing.T) {
+	config, err := Parse(`{erl_opts, [debug_info]}.
+{profiles, [{prod, [{relx, [{dev_mode, false}]}]}]}.
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	if !config.AddProfile("test") || config.AddProfile("test") || config.AddProfile("prod") {
+		t.Error("Expected AddProfile to add test once and leave prod alone")
+	}
+	config.SetProfileDeps("test", []Term{KV("meck", NewString("0.9.2")), NewAtom("proper")})
+	config.SetProfileOption("test", "erl_opts", NewList(NewAtom("nowarn_export_all")))
+	config.SetProfileDeps("test", []Term{KV("meck", NewString("0.9.3"))})
+	config.SetProfileOption("prod", "relx", NewList(KV("dev_mode", NewAtom("false")), KV("include_erts", NewAtom("true"))))
+	config.SetProfileOption("ci", "cover_enabled", NewAtom("true"))
+
+	expected := MustParseTerm(`{profiles, [
+    {prod, [{relx, [{dev_mode, false}, {include_erts, true}]}]},
+    {test, [{deps, [{meck, "0.9.3"}]}, {erl_opts, [nowarn_export_all]}]},
+    {ci, [{cover_enabled, true}]}
+]}`)
+	if len(config.Terms) != 2 || !config.Terms[1].Compare(expected) {
+		t.Errorf("Expected %s, got %v", expected, config.Terms)
+	}
+
+	applied := config.ApplyProfiles("test")
+	if deps, ok := applied.GetDeps(); !ok || deps[0].String() != `[{meck, "0.9.3"}]` {
+		t.Errorf("Expected test profile deps to apply, got %v", deps)
+	}
+
+	empty := &RebarConfig{}
+	empty.SetProfileDeps("test", nil)
+	if got := empty.Terms[0].String(); got != "{profiles, [{test, [{deps, []}]}]}" {
+		t.Errorf("Expected nested profile to be created, got %s", got)
+	}
+}
@@ -0,0 +1,254 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const profileConfig = `{erl_opts, [debug_info, warnings_as_errors]}.
+{deps, [{cowboy, "2.9.0"}, {jsx, "3.1.0"}]}.
+{plugins, [rebar3_hex]}.
+{erl_first_files, ["src/a.erl"]}.
+{profiles, [
+    {test, [{deps, [meck, {jsx, "3.0.0"}]},
+            {erl_opts, [nowarn_export_all, {d, 'TEST'}]},
+            {cover_enabled, true}]},
+    {prod, [{erl_opts, [no_debug_info]},
+            {plugins, [rebar3_appup_plugin]},
+            {erl_first_files, ["src/b.erl"]},
+            {relx, [{dev_mode, false}]}]}
+]}.
+`
+
+// TestApplyProfiles tests merging of profiles into the base config
+func TestApplyProfiles(t *testing.T) {
+	config, err := Parse(profileConfig)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		profiles []string
+		expected map[string]string
+	}{
+		{
+			name:     "default",
+			profiles: []string{"default", "missing"},
+			expected: map[string]string{
+				"erl_opts": "[debug_info, warnings_as_errors]",
+				"deps":     `[{cowboy, "2.9.0"}, {jsx, "3.1.0"}]`,
+			},
+		},
+		{
+			name:     "test",
+			profiles: []string{"test"},
+			expected: map[string]string{
+				"erl_opts":      "[debug_info, warnings_as_errors, nowarn_export_all, {d, 'TEST'}]",
+				"deps":          `[{cowboy, "2.9.0"}, {jsx, "3.0.0"}, meck]`,
+				"cover_enabled": "true",
+			},
+		},
+		{
+			name:     "prod",
+			profiles: []string{"prod"},
+			expected: map[string]string{
+				"erl_opts":        "[no_debug_info, warnings_as_errors]",
+				"plugins":         "[rebar3_appup_plugin]",
+				"erl_first_files": `["src/a.erl", "src/b.erl"]`,
+				"relx":            "[{dev_mode, false}]",
+			},
+		},
+		{
+			name:     "test and prod",
+			profiles: []string{"test", "prod"},
+			expected: map[string]string{
+				"erl_opts": "[no_debug_info, warnings_as_errors, nowarn_export_all, {d, 'TEST'}]",
+				"deps":     `[{cowboy, "2.9.0"}, {jsx, "3.0.0"}, meck]`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			effective := config.ApplyProfiles(tt.profiles...)
+			for key, want := range tt.expected {
+				elements, ok := effective.GetTupleElements(key)
+				if !ok {
+					t.Errorf("Expected %s in effective config", key)
+					continue
+				}
+				if got := elements[0].String(); got != want {
+					t.Errorf("%s: expected %s, got %s", key, want, got)
+				}
+			}
+		})
+	}
+
+	if opts, _ := config.GetErlOpts(); opts[0].String() != "[debug_info, warnings_as_errors]" {
+		t.Errorf("Expected the original config to be unchanged, got %s", opts[0])
+	}
+}
+
+// TestProfileOptions tests the WithProfile and WithEnvProfile parse options
+func TestProfileOptions(t *testing.T) {
+	t.Setenv(ProfileEnv, "test")
+
+	path := filepath.Join(t.TempDir(), "rebar.config")
+	if err := os.WriteFile(path, []byte(profileConfig), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	erlOpts := func(config *RebarConfig, err error) string {
+		if err != nil {
+			t.Fatalf("Failed to parse config: %v", err)
+		}
+		opts, _ := config.GetErlOpts()
+		return opts[0].String()
+	}
+
+	if got := erlOpts(Parse(profileConfig)); got != "[debug_info, warnings_as_errors]" {
+		t.Errorf("Expected no profile without options, got %s", got)
+	}
+	if got := erlOpts(ParseFile(path, WithEnvProfile())); got != "[debug_info, warnings_as_errors, nowarn_export_all, {d, 'TEST'}]" {
+		t.Errorf("Unexpected erl_opts with REBAR_PROFILE: %s", got)
+	}
+	if got := erlOpts(ParseFile(path, WithEnvProfile(), WithProfile("prod"), WithMmap())); got != "[no_debug_info, warnings_as_errors, nowarn_export_all, {d, 'TEST'}]" {
+		t.Errorf("Unexpected erl_opts with REBAR_PROFILE and WithProfile: %s", got)
+	}
+	if got := erlOpts(ParseReader(openFile(t, path), WithProfile("prod"))); got != "[no_debug_info, warnings_as_errors]" {
+		t.Errorf("Unexpected erl_opts from ParseReader: %s", got)
+	}
+
+	if err := os.WriteFile(path+".script", []byte(`lists:keystore(erl_opts, 1, CONFIG, {erl_opts, []}).`), 0644); err != nil {
+		t.Fatalf("Failed to write script: %v", err)
+	}
+	if got := erlOpts(ParseFile(path, EvalScript(nil), WithProfile("prod"))); got != "[no_debug_info]" {
+		t.Errorf("Expected profiles to apply after the script, got %s", got)
+	}
+}
+
+func openFile(t *testing.T, path string) *os.File {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	t.Cleanup(func() { file.Close() })
+	return file
+}
+
+// TestMerge tests merging an overlay config with rebar3 profile semantics
+func TestMerge(t *testing.T) {
+	base, err := Parse(`{erl_opts, [debug_info, {d, 'LOG', 1}]}.
+{deps, [{cowboy, "2.9.0"}, jsx]}.
+{plugins, [rebar3_hex]}.
+{minimum_otp_vsn, "24"}.
+{relx, [{release, {app, "1.0.0"}, [app]}, {dev_mode, true}]}.
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse base: %v", err)
+	}
+	overlay, err := Parse(`{deps, [{cowboy, "2.10.0"}, meck]}.
+{erl_opts, [debug_info, warnings_as_errors, {d, 'LOG', 2}]}.
+{plugins, [rebar3_format]}.
+{minimum_otp_vsn, "25"}.
+{relx, [{dev_mode, false}]}.
+{shell, [{apps, [app]}]}.
+{ct_opts, [], extra}.
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse overlay: %v", err)
+	}
+	snapshot := base.Compact()
+
+	expected, _ := Parse(`{erl_opts, [debug_info, {d, 'LOG', 2}, warnings_as_errors]}.
+{deps, [{cowboy, "2.10.0"}, jsx, meck]}.
+{plugins, [rebar3_format]}.
+{minimum_otp_vsn, "25"}.
+{relx, [{release, {app, "1.0.0"}, [app]}, {dev_mode, false}]}.
+{shell, [{apps, [app]}]}.
+{ct_opts, [], extra}.
+`)
+	merged := Merge(base, overlay)
+	if !compareConfigs(merged, expected) {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected.Format(4), merged.Format(4))
+	}
+	if base.Compact() != snapshot {
+		t.Errorf("Expected base to be unchanged, got:\n%s", base.Compact())
+	}
+	if merged.Raw != base.Raw {
+		t.Error("Expected merged config to keep the base source")
+	}
+}
+
+// TestGetProfile tests using the existing accessors inside a profile
+func TestGetProfile(t *testing.T) {
+	config, err := Parse(profileConfig)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	test, ok := config.GetProfile("test")
+	if !ok {
+		t.Fatal("Expected to find the test profile")
+	}
+	if deps, ok := test.GetDeps(); !ok || deps[0].String() != `[meck, {jsx, "3.0.0"}]` {
+		t.Errorf("Unexpected profile deps: %v", deps)
+	}
+	if opts, ok := test.GetErlOpts(); !ok || opts[0].String() != "[nowarn_export_all, {d, 'TEST'}]" {
+		t.Errorf("Unexpected profile erl_opts: %v", opts)
+	}
+	if enabled, err := test.GetBoolAt("cover_enabled"); err != nil || !enabled {
+		t.Errorf("Expected cover_enabled, got %v, %v", enabled, err)
+	}
+
+	test.SetTerm("cover_enabled", NewAtom("false"))
+	if enabled, _ := config.GetBoolAt("profiles", "test", "cover_enabled"); !enabled {
+		t.Errorf("Expected changes to the profile view not to affect the config")
+	}
+
+	if prod, ok := config.GetProfile("prod"); !ok || len(prod.Terms) != 4 {
+		t.Errorf("Unexpected prod profile: %v", prod)
+	}
+	if _, ok := config.GetProfile("missing"); ok {
+		t.Errorf("Did not expect to find a missing profile")
+	}
+}
+
+// TestGetDepsWithProfiles tests the merged deps of a profile
+func TestGetDepsWithProfiles(t *testing.T) {
+	config, err := Parse(profileConfig)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	tests := []struct {
+		profiles []string
+		expected string
+	}{
+		{nil, `[{cowboy, "2.9.0"}, {jsx, "3.1.0"}]`},
+		{[]string{"test"}, `[{cowboy, "2.9.0"}, {jsx, "3.0.0"}, meck]`},
+		{[]string{"prod"}, `[{cowboy, "2.9.0"}, {jsx, "3.1.0"}]`},
+		{[]string{"missing"}, `[{cowboy, "2.9.0"}, {jsx, "3.1.0"}]`},
+	}
+
+	for _, tt := range tests {
+		deps, ok := config.GetDeps(tt.profiles...)
+		if !ok || deps[0].String() != tt.expected {
+			t.Errorf("GetDeps(%v): expected %s, got %v", tt.profiles, tt.expected, deps)
+		}
+	}
+
+	noBase, err := Parse(`{profiles, [{test, [{deps, [meck]}]}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	if _, ok := noBase.GetDeps(); ok {
+		t.Errorf("Did not expect base deps")
+	}
+	if deps, ok := noBase.GetDeps("test"); !ok || deps[0].String() != "[meck]" {
+		t.Errorf("Expected profile deps without base deps, got %v", deps)
+	}
+}
@@ -0,0 +1,192 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+// Proplist 是属性列表的视图，对应 Erlang proplists 模块的语义
+// @pkg 属性列表的条目是 {Key, Value} 元组或表示 {Key, true} 的裸原子（标志），如 erl_opts、relx 和 profiles 的内容。
+// 查询方法与 proplists 一致，第一个匹配的条目优先；Set 和 Delete 修改的是元素的副本，不会影响被包装的列表
+//
+// 数据样例:
+// [debug_info, {d, 'TEST'}, {i, "include"}] 中 Get("debug_info") 为 true，Get("i") 为 "include"
+type Proplist struct {
+	Elements []Term
+}
+
+// AsProplist 把列表包装为属性列表
+// @pkg 不检查元素的形式，不是条目的元素（如数字）在查询时被忽略
+// 输入:
+//   - term: 要包装的项
+//
+// 输出:
+//   - Proplist: 属性列表视图
+//   - bool: term 是否是列表
+//
+// 示例:
+//
+//	erlOpts, _ := config.GetErlOpts()
+//	opts, _ := parser.AsProplist(erlOpts[0])
+//	if value, ok := opts.Get("parse_transform"); ok {
+//	  fmt.Println("parse transform:", value)
+//	}
+func AsProplist(term Term) (Proplist, bool) {
+	list, ok := term.(List)
+	if !ok {
+		return Proplist{}, false
+	}
+	return Proplist{Elements: list.Elements}, true
+}
+
+// Proplist 返回配置顶级项组成的属性列表视图
+// @pkg 修改视图不会影响配置，需要时把修改后的 Elements 赋回 Terms
+//
+// 示例:
+//
+//	keys := config.Proplist().Keys() // ["erl_opts", "deps", ...]
+func (c *RebarConfig) Proplist() Proplist {
+	return Proplist{Elements: c.Terms}
+}
+
+// List 返回属性列表对应的列表
+func (p Proplist) List() List {
+	return NewList(p.Elements...)
+}
+
+// Lookup 返回第一个键为 key 的条目，与 proplists:lookup/2 一致
+// @pkg 条目是首元素为该原子的任意元组或该原子本身，按原样返回
+// 输入:
+//   - key: 键名
+//
+// 输出:
+//   - Term: 条目，如 {d, 'TEST'} 或 debug_info
+//   - bool: 是否找到
+func (p Proplist) Lookup(key string) (Term, bool) {
+	for _, elem := range p.Elements {
+		if isEntry(elem, key) {
+			return elem, true
+		}
+	}
+	return nil, false
+}
+
+// Get 返回键对应的值，与 proplists:get_value/2 一致
+// @pkg 第一个条目为 {key, Value} 时返回 Value，为裸原子 key 时返回原子 true；
+// 第一个条目是其他大小的元组（如 {key, A, B}）时视为没有值
+// 输入:
+//   - key: 键名
+//
+// 输出:
+//   - Term: 值
+//   - bool: 是否有值
+func (p Proplist) Get(key string) (Term, bool) {
+	entry, ok := p.Lookup(key)
+	if !ok {
+		return nil, false
+	}
+	return entryValue(entry)
+}
+
+// GetAll 返回键对应的所有值，与 proplists:get_all_values/2 一致
+// @pkg 适合可以重复出现的键，如 erl_opts 中的 {d, Macro} 和 {i, Dir}；没有值时返回空切片
+// 输入:
+//   - key: 键名
+//
+// 输出:
+//   - []Term: 按出现顺序排列的值
+func (p Proplist) GetAll(key string) []Term {
+	values := []Term{}
+	for _, elem := range p.Elements {
+		if !isEntry(elem, key) {
+			continue
+		}
+		if value, ok := entryValue(elem); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// Keys 返回所有条目的键，每个键只出现一次，按第一次出现的顺序排列
+func (p Proplist) Keys() []string {
+	keys := []string{}
+	seen := make(map[string]bool)
+	for _, elem := range p.Elements {
+		name := termName(elem)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		keys = append(keys, name)
+	}
+	return keys
+}
+
+// Set 把键设置为 value
+// @pkg 第一个键为 key 的条目被替换为 {key, value}，其余同名条目被删除；不存在时追加到末尾
+// 输入:
+//   - key: 键名
+//   - value: 值
+//
+// 示例:
+//
+//	opts.Set("warn_missing_spec", parser.NewAtom("true"))
+func (p *Proplist) Set(key string, value Term) {
+	entry := KV(key, value)
+	elements := make([]Term, 0, len(p.Elements)+1)
+	set := false
+	for _, elem := range p.Elements {
+		if !isEntry(elem, key) {
+			elements = append(elements, elem)
+			continue
+		}
+		if !set {
+			elements = append(elements, entry)
+			set = true
+		}
+	}
+	if !set {
+		elements = append(elements, entry)
+	}
+	p.Elements = elements
+}
+
+// Delete 删除键为 key 的所有条目，与 proplists:delete/2 一致
+// 输入:
+//   - key: 键名
+//
+// 输出:
+//   - bool: 是否删除了条目
+func (p *Proplist) Delete(key string) bool {
+	elements := make([]Term, 0, len(p.Elements))
+	for _, elem := range p.Elements {
+		if !isEntry(elem, key) {
+			elements = append(elements, elem)
+		}
+	}
+	deleted := len(elements) != len(p.Elements)
+	p.Elements = elements
+	return deleted
+}
+
+// isEntry 检查元素是否是键为 key 的条目
+func isEntry(elem Term, key string) bool {
+	switch e := elem.(type) {
+	case Atom:
+		return e.Value == key
+	case Tuple:
+		return hasKey(e, key)
+	}
+	return false
+}
+
+// entryValue 返回条目的值：{Key, Value} 为 Value，裸原子为 true
+func entryValue(entry Term) (Term, bool) {
+	switch e := entry.(type) {
+	case Atom:
+		return NewAtom("true"), true
+	case Tuple:
+		if len(e.Elements) == 2 {
+			return e.Elements[1], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestProplistQueries tests Lookup, Get, GetAll and Keys
+func TestProplistQueries(t *testing.T) {
+	opts, ok := AsProplist(MustParseTerm(`[debug_info, {d, 'TEST'}, {i, "include"}, {d, 'DEBUG', 1}, {d, 'LOG'}, 42, {platform_define, "^2", 'OTP'}]`))
+	if !ok {
+		t.Fatalf("Expected a list")
+	}
+
+	tests := []struct {
+		key      string
+		value    string
+		all      string
+		hasValue bool
+	}{
+		{"debug_info", "true", "[true]", true},
+		{"d", "'TEST'", "['TEST', 'LOG']", true},
+		{"i", `"include"`, `["include"]`, true},
+		{"platform_define", "", "[]", false},
+		{"missing", "", "[]", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			value, ok := opts.Get(tt.key)
+			if ok != tt.hasValue || (ok && value.String() != tt.value) {
+				t.Errorf("Get: expected %s (%v), got %v (%v)", tt.value, tt.hasValue, value, ok)
+			}
+			if all := NewList(opts.GetAll(tt.key)...).String(); all != tt.all {
+				t.Errorf("GetAll: expected %s, got %s", tt.all, all)
+			}
+		})
+	}
+
+	if entry, ok := opts.Lookup("platform_define"); !ok || entry.String() != `{platform_define, "^2", 'OTP'}` {
+		t.Errorf("Unexpected Lookup result: %v, %v", entry, ok)
+	}
+
+	expected := []string{"debug_info", "d", "i", "platform_define"}
+	if keys := opts.Keys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected keys %v, got %v", expected, keys)
+	}
+
+	if _, ok := AsProplist(NewAtom("x")); ok {
+		t.Errorf("Expected non-list to be rejected")
+	}
+}
+
+// TestProplistEdits tests Set and Delete without modifying the wrapped list
+func TestProplistEdits(t *testing.T) {
+	list := MustParseTerm(`[debug_info, {d, 'A'}, warnings_as_errors, {d, 'B'}]`).(List)
+	original := list.String()
+	opts, _ := AsProplist(list)
+
+	opts.Set("d", NewAtom("C"))
+	if got := opts.List().String(); got != `[debug_info, {d, 'C'}, warnings_as_errors]` {
+		t.Errorf("Unexpected result after Set: %s", got)
+	}
+
+	opts.Set("debug_info", NewAtom("false"))
+	opts.Set("i", NewString("include"))
+	if got := opts.List().String(); got != `[{debug_info, false}, {d, 'C'}, warnings_as_errors, {i, "include"}]` {
+		t.Errorf("Unexpected result after Set: %s", got)
+	}
+
+	if !opts.Delete("warnings_as_errors") || opts.Delete("missing") {
+		t.Errorf("Unexpected Delete results")
+	}
+	if got := opts.List().String(); got != `[{debug_info, false}, {d, 'C'}, {i, "include"}]` {
+		t.Errorf("Unexpected result after Delete: %s", got)
+	}
+
+	if list.String() != original {
+		t.Errorf("Expected wrapped list to be unchanged, got %s", list)
+	}
+}
+
+// TestConfigProplist tests the view over top-level terms
+func TestConfigProplist(t *testing.T) {
+	config, err := Parse(`{erl_opts, [debug_info]}. {deps, []}. {erl_opts, [warnings_as_errors]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	props := config.Proplist()
+	if keys := props.Keys(); !reflect.DeepEqual(keys, []string{"erl_opts", "deps"}) {
+		t.Errorf("Unexpected keys: %v", keys)
+	}
+	if value, _ := props.Get("erl_opts"); value.String() != "[debug_info]" {
+		t.Errorf("Expected first erl_opts, got %v", value)
+	}
+}
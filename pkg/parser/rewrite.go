@@ -0,0 +1,241 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+)
+
+// Rewriter 是基于规则的改写引擎
+// @pkg 每条规则由模式（语法见 Pattern）和替换模板组成，模板中的 $Name 被替换为模式绑定的项。
+// 改写自上而下进行：对每个节点按添加顺序尝试规则，第一条产生不同结果的规则替换该节点，替换结果不再被改写；
+// 没有规则生效时进入子节点（元组和列表的元素、映射的值）。每次 Apply 只改写一遍，不会反复应用到结果上。
+// 规则添加完成后 Rewriter 是只读的，可以在多个 goroutine 中同时对不同的配置使用，适合批量迁移大量仓库的配置
+type Rewriter struct {
+	rules []rewriteRule
+}
+
+// rewriteRule 是一条编译后的改写规则
+type rewriteRule struct {
+	name        string
+	pattern     *Pattern
+	replacement Term
+}
+
+// Rewrite 是改写产生的一处变更
+type Rewrite struct {
+	// Rule 是生效的规则名称
+	Rule string
+	// Path 是被替换节点的路径，语法与 Cursor.Path 相同，如 [1]{1}[0]
+	Path string
+	// Before 是替换前的节点
+	Before Term
+	// After 是替换后的节点
+	After Term
+}
+
+// String 返回变更的可读表示，如 `[0]{1}[0]: {jsx, "2.0"} -> {jsx, "3.1.0"} (bump-jsx)`
+func (rw Rewrite) String() string {
+	return fmt.Sprintf("%s: %s -> %s (%s)", rw.Path, rw.Before, rw.After, rw.Rule)
+}
+
+// NewRewriter 创建没有规则的改写引擎
+//
+// 示例:
+//
+//	r := parser.NewRewriter()
+//	if err := r.Add("no-warnings-as-errors", "warnings_as_errors", "warn_export_all"); err != nil {
+//	  log.Fatal(err)
+//	}
+func NewRewriter() *Rewriter {
+	return &Rewriter{}
+}
+
+// Add 添加一条改写规则
+// 输入:
+//   - name: 规则名称，出现在 Rewrite.Rule 中
+//   - pattern: 模式，如 `{$Name, {git, $Url, {branch, "master"}}}`
+//   - replacement: 替换模板，如 `{$Name, {git, $Url, {branch, "main"}}}`；只能使用模式中绑定的变量，不能使用通配符
+//
+// 输出:
+//   - error: 模式或模板的语法错误，或模板使用了未绑定的变量
+//
+// 示例:
+//
+//	err := r.Add("master-to-main",
+//	  `{$Name, {git, $Url, {branch, "master"}}}`,
+//	  `{$Name, {git, $Url, {branch, "main"}}}`)
+func (r *Rewriter) Add(name, pattern, replacement string) error {
+	p, err := CompilePattern(pattern)
+	if err != nil {
+		return err
+	}
+	template, err := ParseTerm(quoteVariables(replacement))
+	if err != nil {
+		return fmt.Errorf("invalid replacement %q: %w", replacement, err)
+	}
+
+	bound := make(map[string]bool)
+	for _, v := range FindAll(p.term, isPatternVariable) {
+		name, _ := patternVariable(v.(Atom))
+		bound[name] = true
+	}
+	for _, v := range FindAll(template, isPatternVariable) {
+		variable, _ := patternVariable(v.(Atom))
+		if variable == "_" {
+			return fmt.Errorf("replacement %q cannot use the wildcard _", replacement)
+		}
+		if !bound[variable] {
+			return fmt.Errorf("replacement %q uses $%s, which the pattern does not bind", replacement, variable)
+		}
+	}
+
+	r.rules = append(r.rules, rewriteRule{name: name, pattern: p, replacement: template})
+	return nil
+}
+
+// MustAdd 与 Add 相同，但在规则非法时 panic，并返回 r 以便链式调用
+// @pkg 用于源码中固定的规则
+//
+// 示例:
+//
+//	r := parser.NewRewriter().
+//	  MustAdd("bump-jsx", `{jsx, _}`, `{jsx, "3.1.0"}`).
+//	  MustAdd("debug-off", `{d, 'DEBUG'}`, `{d, 'DEBUG', false}`)
+func (r *Rewriter) MustAdd(name, pattern, replacement string) *Rewriter {
+	if err := r.Add(name, pattern, replacement); err != nil {
+		panic("parser: Rewriter.MustAdd(" + name + "): " + err.Error())
+	}
+	return r
+}
+
+// Rewrite 改写单个项
+// 输入:
+//   - term: 要改写的项，不会被修改
+//
+// 输出:
+//   - Term: 改写后的项
+//   - []Rewrite: 各处变更，路径相对于 term（term 本身被替换时路径为空），没有变更时为空切片
+func (r *Rewriter) Rewrite(term Term) (Term, []Rewrite) {
+	changes := []Rewrite{}
+	return r.rewrite(term, "", &changes), changes
+}
+
+// Apply 改写配置，结果写回 Terms；Raw 保持不变
+// 输出:
+//   - []Rewrite: 各处变更，按出现顺序排列，没有变更时为空切片
+//
+// 示例:
+//
+//	for _, change := range r.Apply(config) {
+//	  fmt.Println(change)
+//	}
+//	os.WriteFile("./rebar.config", []byte(config.FormatPreserving(4)), 0644)
+func (r *Rewriter) Apply(c *RebarConfig) []Rewrite {
+	terms, changes := r.rewriteTerms(c.Terms)
+	c.Terms = terms
+	return changes
+}
+
+// DryRun 返回 Apply 将产生的变更，但不修改配置
+// 输出:
+//   - []Rewrite: 各处变更，没有变更时为空切片
+//
+// 示例:
+//
+//	for path, config := range configs {
+//	  for _, change := range r.DryRun(config) {
+//	    fmt.Printf("%s %s\n", path, change)
+//	  }
+//	}
+func (r *Rewriter) DryRun(c *RebarConfig) []Rewrite {
+	_, changes := r.rewriteTerms(c.Terms)
+	return changes
+}
+
+// rewriteTerms 改写各顶级项，路径为 [i]
+func (r *Rewriter) rewriteTerms(terms []Term) ([]Term, []Rewrite) {
+	changes := []Rewrite{}
+	rewritten := make([]Term, len(terms))
+	for i, term := range terms {
+		rewritten[i] = r.rewrite(term, fmt.Sprintf("[%d]", i), &changes)
+	}
+	return rewritten, changes
+}
+
+// rewrite 自上而下改写项，把变更追加到 changes
+func (r *Rewriter) rewrite(term Term, path string, changes *[]Rewrite) Term {
+	for _, rule := range r.rules {
+		bindings, ok := rule.pattern.Match(term)
+		if !ok {
+			continue
+		}
+		after := substitute(rule.replacement, bindings)
+		if sameTerm(after, term) {
+			continue
+		}
+		*changes = append(*changes, Rewrite{Rule: rule.name, Path: path, Before: term, After: after})
+		return after
+	}
+
+	switch t := term.(type) {
+	case Tuple:
+		elements := make([]Term, len(t.Elements))
+		for i, elem := range t.Elements {
+			elements[i] = r.rewrite(elem, fmt.Sprintf("%s{%d}", path, i), changes)
+		}
+		return Tuple{Elements: elements}
+	case List:
+		elements := make([]Term, len(t.Elements))
+		for i, elem := range t.Elements {
+			elements[i] = r.rewrite(elem, fmt.Sprintf("%s[%d]", path, i), changes)
+		}
+		return List{Elements: elements}
+	case Map:
+		pairs := make([]MapPair, len(t.Pairs))
+		for i, pair := range t.Pairs {
+			pairs[i] = MapPair{Key: pair.Key, Value: r.rewrite(pair.Value, path+"#{"+pair.Key.String()+"}", changes)}
+		}
+		return Map{Pairs: pairs}
+	}
+	return term
+}
+
+// substitute 把模板中的变量替换为绑定的项
+func substitute(template Term, bindings map[string]Term) Term {
+	switch t := template.(type) {
+	case Atom:
+		if name, ok := patternVariable(t); ok {
+			return bindings[name]
+		}
+	case Tuple:
+		elements := make([]Term, len(t.Elements))
+		for i, elem := range t.Elements {
+			elements[i] = substitute(elem, bindings)
+		}
+		return Tuple{Elements: elements}
+	case List:
+		elements := make([]Term, len(t.Elements))
+		for i, elem := range t.Elements {
+			elements[i] = substitute(elem, bindings)
+		}
+		return List{Elements: elements}
+	case Map:
+		pairs := make([]MapPair, len(t.Pairs))
+		for i, pair := range t.Pairs {
+			pairs[i] = MapPair{Key: substitute(pair.Key, bindings), Value: substitute(pair.Value, bindings)}
+		}
+		return Map{Pairs: pairs}
+	}
+	return template
+}
+
+// isPatternVariable 检查项是否是模式变量或通配符
+func isPatternVariable(term Term) bool {
+	atom, ok := term.(Atom)
+	if !ok {
+		return false
+	}
+	_, ok = patternVariable(atom)
+	return ok
+}
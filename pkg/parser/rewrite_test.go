@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+const rewriteConfig = `{deps, [
+    {cowboy, {git, "https://github.com/ninenines/cowboy.git", {branch, "master"}}},
+    {jsx, "2.0.0"},
+    meck
+]}.
+{erl_opts, [debug_info, {d, 'DEBUG'}]}.
+{profiles, [{test, [{deps, [{jsx, "2.0.0"}]}]}]}.
+`
+
+func newTestRewriter() *Rewriter {
+	return NewRewriter().
+		MustAdd("master-to-main", `{$Name, {git, $Url, {branch, "master"}}}`, `{$Name, {git, $Url, {branch, "main"}}}`).
+		MustAdd("bump-jsx", `{jsx, _}`, `{jsx, "3.1.0"}`).
+		MustAdd("debug-off", `{d, 'DEBUG'}`, `{d, 'DEBUG', false}`)
+}
+
+// TestRewriterApply tests applying rules to every matching node
+func TestRewriterApply(t *testing.T) {
+	config, err := Parse(rewriteConfig)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	changes := newTestRewriter().Apply(config)
+	var got []string
+	for _, change := range changes {
+		got = append(got, change.Path+" "+change.Rule)
+	}
+	expected := "[0]{1}[0] master-to-main|[0]{1}[1] bump-jsx|[1]{1}[1] debug-off|[2]{1}[0]{1}[0]{1}[0] bump-jsx"
+	if strings.Join(got, "|") != expected {
+		t.Errorf("Expected %s, got %s", expected, strings.Join(got, "|"))
+	}
+
+	if changes[1].String() != `[0]{1}[1]: {jsx, "2.0.0"} -> {jsx, "3.1.0"} (bump-jsx)` {
+		t.Errorf("Unexpected change string: %s", changes[1])
+	}
+
+	deps, _ := config.GetDeps()
+	if deps[0].String() != `[{cowboy, {git, "https://github.com/ninenines/cowboy.git", {branch, "main"}}}, {jsx, "3.1.0"}, meck]` {
+		t.Errorf("Unexpected deps: %v", deps[0])
+	}
+
+	if again := newTestRewriter().Apply(config); len(again) != 0 {
+		t.Errorf("Expected no changes on a second run, got %v", again)
+	}
+}
+
+// TestRewriterDryRun tests that a dry run reports changes without applying them
+func TestRewriterDryRun(t *testing.T) {
+	config, err := Parse(rewriteConfig)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	before := config.Format(2)
+
+	changes := newTestRewriter().DryRun(config)
+	if len(changes) != 4 {
+		t.Errorf("Expected 4 changes, got %v", changes)
+	}
+	if config.Format(2) != before {
+		t.Errorf("Expected the config to be unchanged")
+	}
+}
+
+// TestRewriterTopDown tests that replacements are not rewritten again
+func TestRewriterTopDown(t *testing.T) {
+	r := NewRewriter().MustAdd("wrap", `{a, $X}`, `{a, {a, $X}}`)
+	result, changes := r.Rewrite(MustParseTerm(`[{a, 1}, {b, {a, 2}}]`))
+	if result.String() != "[{a, {a, 1}}, {b, {a, {a, 2}}}]" {
+		t.Errorf("Unexpected result: %s", result)
+	}
+	if len(changes) != 2 || changes[0].Path != "[0]" || changes[1].Path != "[1]{1}" {
+		t.Errorf("Unexpected changes: %v", changes)
+	}
+
+	root, changes := r.Rewrite(MustParseTerm(`{a, 1}`))
+	if root.String() != "{a, {a, 1}}" || len(changes) != 1 || changes[0].Path != "" {
+		t.Errorf("Unexpected root rewrite: %s %v", root, changes)
+	}
+}
+
+// TestRewriterAddErrors tests that invalid rules are rejected
+func TestRewriterAddErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		pattern     string
+		replacement string
+		expected    string
+	}{
+		{"invalid pattern", "{a,", "a", "invalid pattern"},
+		{"invalid replacement", "a", "{a,", "invalid replacement"},
+		{"unbound variable", "{a, $X}", "{a, $Y}", "does not bind"},
+		{"wildcard", "{a, _}", "{a, _}", "wildcard"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewRewriter().Add(tt.name, tt.pattern, tt.replacement)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,781 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ScriptError 表示配置是动态的，无法作为静态 Erlang 项读取
+// @pkg 以下情况会返回该错误:
+// - 配置文件旁边存在同名的 .script 文件（如 rebar.config.script），rebar3 会用它改写配置
+// - 文件本身包含变量、函数调用、case 等 Erlang 表达式
+// - 使用 EvalScript 时脚本用到了不支持的表达式
+//
+// 示例:
+//
+//	_, err := parser.ParseFile("./rebar.config")
+//	var scriptErr *parser.ScriptError
+//	if errors.As(err, &scriptErr) {
+//	  fmt.Println("动态配置:", scriptErr.Path)
+//	}
+type ScriptError struct {
+	// Path 是脚本文件或包含表达式的配置文件路径，解析字符串时为空
+	Path string
+	// Reason 是不支持的原因
+	Reason string
+	// Err 是底层的语法或求值错误，可能为 nil
+	Err error
+}
+
+// Error 实现 error 接口
+func (e *ScriptError) Error() string {
+	msg := "dynamic config not supported"
+	if e.Path != "" {
+		msg += ": " + e.Path
+	}
+	msg += ": " + e.Reason
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Unwrap 返回底层错误
+func (e *ScriptError) Unwrap() error {
+	return e.Err
+}
+
+// IgnoreScript 让 ParseFile 忽略同名的 .script 文件，只读取静态配置
+func IgnoreScript() ParseOption {
+	return func(o *parseOptions) {
+		o.script = scriptIgnore
+	}
+}
+
+// EvalScript 让 ParseFile 求值同名 .script 文件中受支持的安全子集
+// @pkg 脚本中的 CONFIG 绑定为静态配置的顶级项列表，SCRIPT 绑定为脚本路径，
+// 脚本的结果（必须是列表）作为最终配置的顶级项。支持的表达式:
+// - 字面量、变量、元组、列表、变量绑定（Var = Expr）和 begin ... end
+// - 列表拼接 ++ 以及比较运算 ==、/=、=:=、=/=
+// - case ... of 与 if（条件为比较表达式或 true）
+// - os:getenv/1,2、lists:keystore/4、lists:keyreplace/4、lists:keydelete/3、lists:keyfind/3、
+// lists:keymember/3、lists:member/2、lists:append/2、proplists:get_value/2,3
+//
+// 其他表达式会返回 *ScriptError，而不会执行任何文件系统或网络操作
+// 输入:
+//   - getenv: 查找环境变量的函数，为 nil 时使用 os.LookupEnv
+//
+// 示例:
+//
+//	config, err := parser.ParseFile("./rebar.config", parser.EvalScript(nil))
+func EvalScript(getenv func(string) (string, bool)) ParseOption {
+	return func(o *parseOptions) {
+		o.script = scriptEval
+		o.getenv = getenv
+		if o.getenv == nil {
+			o.getenv = os.LookupEnv
+		}
+	}
+}
+
+// scriptMode 表示 ParseFile 如何处理同名的 .script 文件
+type scriptMode int
+
+const (
+	scriptReject scriptMode = iota
+	scriptIgnore
+	scriptEval
+)
+
+// parseWithScript 处理 path 旁边的 .script 文件
+// 输出:
+//   - *RebarConfig: 求值后的配置
+//   - bool: 为 false 表示不存在脚本文件，调用方应按静态文件解析
+//   - error: 脚本错误
+func parseWithScript(path string, o parseOptions, opts []ParseOption) (*RebarConfig, bool, error) {
+	if o.script == scriptIgnore {
+		return nil, false, nil
+	}
+	scriptPath := path + ".script"
+	source, err := os.ReadFile(scriptPath)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	if o.script == scriptReject {
+		return nil, true, &ScriptError{
+			Path:   scriptPath,
+			Reason: "the config is generated by a script; use EvalScript to evaluate a safe subset or IgnoreScript to read the static file only",
+		}
+	}
+
+	// 与 rebar3 一致，静态文件不存在时 CONFIG 为空列表
+	base := &RebarConfig{Terms: []Term{}}
+	if _, statErr := os.Stat(path); statErr == nil {
+		// 与 rebar3 一致，先求值脚本，再应用 profile
+		static := append(append([]ParseOption(nil), opts...), IgnoreScript(), withoutProfiles())
+		if base, err = ParseFile(path, static...); err != nil {
+			return nil, true, err
+		}
+	}
+
+	eval := &scriptEvaluator{
+		p:      NewParser(string(source)),
+		getenv: o.getenv,
+		vars: map[string]Term{
+			"CONFIG": List{Elements: base.Terms},
+			"SCRIPT": String{Value: scriptPath},
+		},
+	}
+	result, err := eval.run()
+	if err != nil {
+		return nil, true, &ScriptError{Path: scriptPath, Reason: "unsupported script", Err: err}
+	}
+	list, ok := result.(List)
+	if !ok {
+		return nil, true, &ScriptError{Path: scriptPath, Reason: fmt.Sprintf("script must return a list, got %s", result)}
+	}
+
+	return o.effective(&RebarConfig{Raw: base.Raw, Terms: list.Elements}), true, nil
+}
+
+// containsExpressions 判断输入是否包含 Erlang 表达式
+// @pkg 跳过字符串、带引号原子和注释后，查找变量、关键字、-> 、++ 或模块调用，
+// 仅在解析失败后用于给出更明确的错误
+func containsExpressions(input string) bool {
+	for i := 0; i < len(input); i++ {
+		switch ch := input[i]; {
+		case ch == '"' || ch == '\'':
+			for i++; i < len(input) && input[i] != ch; i++ {
+				if input[i] == '\\' {
+					i++
+				}
+			}
+		case ch == '%':
+			for i < len(input) && input[i] != '\n' {
+				i++
+			}
+		case ch >= 'A' && ch <= 'Z':
+			if i == 0 || !isAtomChar(input[i-1]) {
+				return true
+			}
+		case isAtomStart(ch) && (i == 0 || !isAtomChar(input[i-1])):
+			j := i
+			for j < len(input) && isAtomChar(input[j]) {
+				j++
+			}
+			switch input[i:j] {
+			case "case", "fun", "begin", "receive", "try", "if", "end", "of", "when":
+				return true
+			}
+			if j < len(input) && input[j] == ':' && j+1 < len(input) && isAtomStart(input[j+1]) {
+				return true
+			}
+			i = j - 1
+		case strings.HasPrefix(input[i:], "->") || strings.HasPrefix(input[i:], "++"):
+			return true
+		}
+	}
+	return false
+}
+
+// scriptEvaluator 求值 rebar.config.script 中受支持的表达式
+// @pkg 复用 Parser 的词法辅助函数读取字面量，边解析边构建表达式树，最后求值
+type scriptEvaluator struct {
+	p      *Parser
+	vars   map[string]Term
+	getenv func(string) (string, bool)
+}
+
+// scriptExpr 是脚本中的表达式
+type scriptExpr interface{}
+
+type (
+	litExpr     struct{ term Term }
+	varExpr     struct{ name string }
+	tupleExpr   struct{ elems []scriptExpr }
+	listExpr    struct{ elems []scriptExpr }
+	concatExpr  struct{ left, right scriptExpr }
+	compareExpr struct {
+		op          string
+		left, right scriptExpr
+	}
+	matchExpr struct {
+		pattern scriptExpr
+		expr    scriptExpr
+	}
+	blockExpr struct{ body []scriptExpr }
+	caseExpr  struct {
+		subject scriptExpr
+		clauses []scriptClause
+	}
+	ifExpr   struct{ clauses []scriptClause }
+	callExpr struct {
+		fun  string
+		args []scriptExpr
+	}
+)
+
+// scriptClause 是 case 或 if 的一个分支
+// @pkg 对 case 来说 head 是模式，对 if 来说 head 是条件
+type scriptClause struct {
+	head scriptExpr
+	body []scriptExpr
+}
+
+// run 解析整个脚本并求值，返回最后一个表达式的值
+func (e *scriptEvaluator) run() (Term, error) {
+	body, err := e.parseBody()
+	if err != nil {
+		return nil, err
+	}
+	if !e.consume(".") {
+		return nil, e.p.errorAt("expected '.' at end of script")
+	}
+	e.p.skipWhitespace()
+	if e.p.position < len(e.p.input) {
+		return nil, e.p.errorAt("unexpected input after end of script")
+	}
+	return e.evalBody(body)
+}
+
+// parseBody 解析以逗号分隔的表达式序列
+func (e *scriptEvaluator) parseBody() ([]scriptExpr, error) {
+	var body []scriptExpr
+	for {
+		expr, err := e.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, expr)
+		if !e.consume(",") {
+			return body, nil
+		}
+	}
+}
+
+// parseExpr 解析匹配、比较和拼接表达式
+func (e *scriptEvaluator) parseExpr() (scriptExpr, error) {
+	left, err := e.parseConcat()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"=:=", "=/=", "==", "/="} {
+		if e.consume(op) {
+			right, err := e.parseConcat()
+			if err != nil {
+				return nil, err
+			}
+			return compareExpr{op: op, left: left, right: right}, nil
+		}
+	}
+	if e.consume("=") {
+		right, err := e.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		return matchExpr{pattern: left, expr: right}, nil
+	}
+	return left, nil
+}
+
+// parseConcat 解析右结合的 ++ 表达式
+func (e *scriptEvaluator) parseConcat() (scriptExpr, error) {
+	left, err := e.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if !e.consume("++") {
+		return left, nil
+	}
+	right, err := e.parseConcat()
+	if err != nil {
+		return nil, err
+	}
+	return concatExpr{left: left, right: right}, nil
+}
+
+// parsePrimary 解析字面量、变量、容器、case、if、begin 和函数调用
+func (e *scriptEvaluator) parsePrimary() (scriptExpr, error) {
+	p := e.p
+	p.skipWhitespace()
+	if p.position >= len(p.input) {
+		return nil, p.errorAt("unexpected end of script")
+	}
+
+	switch ch := p.input[p.position]; {
+	case ch == '{':
+		p.position++
+		elems, err := e.parseElems("}")
+		return tupleExpr{elems: elems}, err
+	case ch == '[':
+		p.position++
+		elems, err := e.parseElems("]")
+		return listExpr{elems: elems}, err
+	case ch == '(':
+		p.position++
+		expr, err := e.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if !e.consume(")") {
+			return nil, p.errorAt("expected ')'")
+		}
+		return expr, nil
+	case ch == '"', ch == '\'', ch == '<', ch == '-', isDigit(ch):
+		term, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		return e.maybeCall(term)
+	case ch >= 'A' && ch <= 'Z' || ch == '_':
+		name := e.peekVar()
+		p.position += len(name)
+		return varExpr{name: name}, nil
+	case isAtomStart(ch):
+		switch e.peekWord() {
+		case "case":
+			return e.parseCase()
+		case "if":
+			return e.parseIf()
+		case "begin":
+			e.consume("begin")
+			body, err := e.parseBody()
+			if err != nil {
+				return nil, err
+			}
+			if !e.consume("end") {
+				return nil, p.errorAt("expected 'end'")
+			}
+			return blockExpr{body: body}, nil
+		case "fun", "receive", "try", "of", "end", "when":
+			return nil, p.errorAt("unsupported expression: " + e.peekWord())
+		}
+		term, err := p.parseAtom()
+		if err != nil {
+			return nil, err
+		}
+		return e.maybeCall(term)
+	}
+	return nil, p.errorAt(fmt.Sprintf("unsupported expression: %c", p.input[p.position]))
+}
+
+// maybeCall 在原子后跟随 :Fun( 时解析为远程调用，否则返回字面量
+func (e *scriptEvaluator) maybeCall(term Term) (scriptExpr, error) {
+	mod, ok := term.(Atom)
+	if !ok || !e.consume(":") {
+		return litExpr{term: term}, nil
+	}
+	fun := e.peekWord()
+	if fun == "" {
+		return nil, e.p.errorAt("expected function name")
+	}
+	e.p.position += len(fun)
+	if !e.consume("(") {
+		return nil, e.p.errorAt("expected '('")
+	}
+	args, err := e.parseElems(")")
+	if err != nil {
+		return nil, err
+	}
+	return callExpr{fun: mod.Value + ":" + fun, args: args}, nil
+}
+
+// parseElems 解析以逗号分隔、以 closer 结束的表达式列表，开括号已被跳过
+func (e *scriptEvaluator) parseElems(closer string) ([]scriptExpr, error) {
+	if e.consume(closer) {
+		return nil, nil
+	}
+	elems, err := e.parseBody()
+	if err != nil {
+		return nil, err
+	}
+	if !e.consume(closer) {
+		return nil, e.p.errorAt("expected ',' or '" + closer + "'")
+	}
+	return elems, nil
+}
+
+// parseCase 解析 case Expr of Pattern -> Body; ... end
+func (e *scriptEvaluator) parseCase() (scriptExpr, error) {
+	e.consume("case")
+	subject, err := e.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if !e.consume("of") {
+		return nil, e.p.errorAt("expected 'of'")
+	}
+	clauses, err := e.parseClauses()
+	return caseExpr{subject: subject, clauses: clauses}, err
+}
+
+// parseIf 解析 if Cond -> Body; ... end
+func (e *scriptEvaluator) parseIf() (scriptExpr, error) {
+	e.consume("if")
+	clauses, err := e.parseClauses()
+	return ifExpr{clauses: clauses}, err
+}
+
+// parseClauses 解析以分号分隔、以 end 结束的分支
+func (e *scriptEvaluator) parseClauses() ([]scriptClause, error) {
+	var clauses []scriptClause
+	for {
+		head, err := e.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if e.peekWord() == "when" {
+			return nil, e.p.errorAt("unsupported expression: guards")
+		}
+		if !e.consume("->") {
+			return nil, e.p.errorAt("expected '->'")
+		}
+		body, err := e.parseBody()
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, scriptClause{head: head, body: body})
+
+		if e.consume("end") {
+			return clauses, nil
+		}
+		if !e.consume(";") {
+			return nil, e.p.errorAt("expected ';' or 'end'")
+		}
+	}
+}
+
+// consume 跳过空白后，如果输入以 token 开头则跳过它
+// @pkg 关键字只在完整匹配一个单词时才会被跳过
+func (e *scriptEvaluator) consume(token string) bool {
+	p := e.p
+	p.skipWhitespace()
+	if !strings.HasPrefix(p.input[p.position:], token) {
+		return false
+	}
+	end := p.position + len(token)
+	if isAtomStart(token[0]) && end < len(p.input) && isAtomChar(p.input[end]) {
+		return false
+	}
+	p.position = end
+	return true
+}
+
+// peekWord 返回当前位置的单词（原子或关键字），不移动位置
+func (e *scriptEvaluator) peekWord() string {
+	p := e.p
+	p.skipWhitespace()
+	i := p.position
+	if i >= len(p.input) || !isAtomStart(p.input[i]) {
+		return ""
+	}
+	for i < len(p.input) && isAtomChar(p.input[i]) {
+		i++
+	}
+	return p.input[p.position:i]
+}
+
+// peekVar 返回当前位置的变量名，不移动位置
+func (e *scriptEvaluator) peekVar() string {
+	p := e.p
+	p.skipWhitespace()
+	i := p.position
+	if i >= len(p.input) || !(p.input[i] >= 'A' && p.input[i] <= 'Z' || p.input[i] == '_') {
+		return ""
+	}
+	for i < len(p.input) && isAtomChar(p.input[i]) {
+		i++
+	}
+	return p.input[p.position:i]
+}
+
+// evalBody 依次求值表达式序列，返回最后一个值
+func (e *scriptEvaluator) evalBody(body []scriptExpr) (Term, error) {
+	var result Term
+	for _, expr := range body {
+		var err error
+		if result, err = e.eval(expr); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// evalAll 求值表达式列表
+func (e *scriptEvaluator) evalAll(exprs []scriptExpr) ([]Term, error) {
+	terms := make([]Term, len(exprs))
+	for i, expr := range exprs {
+		var err error
+		if terms[i], err = e.eval(expr); err != nil {
+			return nil, err
+		}
+	}
+	return terms, nil
+}
+
+// eval 求值单个表达式
+func (e *scriptEvaluator) eval(expr scriptExpr) (Term, error) {
+	switch x := expr.(type) {
+	case litExpr:
+		return x.term, nil
+	case varExpr:
+		value, ok := e.vars[x.name]
+		if !ok {
+			return nil, fmt.Errorf("variable %s is unbound", x.name)
+		}
+		return value, nil
+	case tupleExpr:
+		elems, err := e.evalAll(x.elems)
+		if err != nil {
+			return nil, err
+		}
+		return Tuple{Elements: elems}, nil
+	case listExpr:
+		elems, err := e.evalAll(x.elems)
+		if err != nil {
+			return nil, err
+		}
+		if elems == nil {
+			elems = []Term{}
+		}
+		return List{Elements: elems}, nil
+	case concatExpr:
+		left, err := e.eval(x.left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := e.eval(x.right)
+		if err != nil {
+			return nil, err
+		}
+		l, ok1 := left.(List)
+		r, ok2 := right.(List)
+		if !ok1 || !ok2 {
+			return nil, fmt.Errorf("++ requires lists, got %s and %s", left, right)
+		}
+		return List{Elements: append(append([]Term{}, l.Elements...), r.Elements...)}, nil
+	case compareExpr:
+		left, err := e.eval(x.left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := e.eval(x.right)
+		if err != nil {
+			return nil, err
+		}
+		equal := left.Compare(right)
+		return scriptBool(equal == (x.op == "==" || x.op == "=:=")), nil
+	case matchExpr:
+		value, err := e.eval(x.expr)
+		if err != nil {
+			return nil, err
+		}
+		bindings := make(map[string]Term)
+		ok, err := e.match(x.pattern, value, bindings)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("no match of right hand side value %s", value)
+		}
+		for name, bound := range bindings {
+			e.vars[name] = bound
+		}
+		return value, nil
+	case blockExpr:
+		return e.evalBody(x.body)
+	case caseExpr:
+		subject, err := e.eval(x.subject)
+		if err != nil {
+			return nil, err
+		}
+		for _, clause := range x.clauses {
+			bindings := make(map[string]Term)
+			ok, err := e.match(clause.head, subject, bindings)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				for name, value := range bindings {
+					e.vars[name] = value
+				}
+				return e.evalBody(clause.body)
+			}
+		}
+		return nil, fmt.Errorf("no case clause matching %s", subject)
+	case ifExpr:
+		for _, clause := range x.clauses {
+			cond, err := e.eval(clause.head)
+			if err != nil {
+				return nil, err
+			}
+			if cond.Compare(Atom{Value: "true"}) {
+				return e.evalBody(clause.body)
+			}
+		}
+		return nil, errors.New("no true branch found when evaluating an if expression")
+	case callExpr:
+		args, err := e.evalAll(x.args)
+		if err != nil {
+			return nil, err
+		}
+		return e.call(x.fun, args)
+	}
+	return nil, fmt.Errorf("unsupported expression")
+}
+
+// match 将模式与值匹配，新绑定的变量写入 bindings
+func (e *scriptEvaluator) match(pattern scriptExpr, value Term, bindings map[string]Term) (bool, error) {
+	switch x := pattern.(type) {
+	case varExpr:
+		if x.name == "_" {
+			return true, nil
+		}
+		if bound, ok := e.vars[x.name]; ok {
+			return bound.Compare(value), nil
+		}
+		if bound, ok := bindings[x.name]; ok {
+			return bound.Compare(value), nil
+		}
+		bindings[x.name] = value
+		return true, nil
+	case litExpr:
+		return x.term.Compare(value), nil
+	case tupleExpr:
+		tuple, ok := value.(Tuple)
+		if !ok {
+			return false, nil
+		}
+		return e.matchElems(x.elems, tuple.Elements, bindings)
+	case listExpr:
+		list, ok := value.(List)
+		if !ok {
+			return false, nil
+		}
+		return e.matchElems(x.elems, list.Elements, bindings)
+	}
+	return false, fmt.Errorf("unsupported pattern")
+}
+
+// matchElems 逐个匹配元组或列表的元素
+func (e *scriptEvaluator) matchElems(patterns []scriptExpr, values []Term, bindings map[string]Term) (bool, error) {
+	if len(patterns) != len(values) {
+		return false, nil
+	}
+	for i := range patterns {
+		if ok, err := e.match(patterns[i], values[i], bindings); !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// call 调用受支持的内置函数
+func (e *scriptEvaluator) call(fun string, args []Term) (Term, error) {
+	switch fmt.Sprintf("%s/%d", fun, len(args)) {
+	case "os:getenv/1", "os:getenv/2":
+		name, ok := args[0].(String)
+		if !ok {
+			return nil, fmt.Errorf("os:getenv expects a string, got %s", args[0])
+		}
+		if value, ok := e.getenv(name.Value); ok {
+			return String{Value: value}, nil
+		}
+		if len(args) == 2 {
+			return args[1], nil
+		}
+		return Atom{Value: "false"}, nil
+
+	case "lists:keyfind/3", "lists:keymember/3", "lists:keydelete/3", "lists:keystore/4", "lists:keyreplace/4":
+		pos, ok := args[1].(Integer)
+		list, ok2 := args[2].(List)
+		if !ok || !ok2 || pos.Value < 1 {
+			return nil, fmt.Errorf("%s: invalid arguments", fun)
+		}
+		index := keyIndex(list.Elements, args[0], int(pos.Value))
+		switch fun {
+		case "lists:keyfind":
+			if index < 0 {
+				return Atom{Value: "false"}, nil
+			}
+			return list.Elements[index], nil
+		case "lists:keymember":
+			return scriptBool(index >= 0), nil
+		case "lists:keydelete":
+			if index < 0 {
+				return list, nil
+			}
+			return List{Elements: append(append([]Term{}, list.Elements[:index]...), list.Elements[index+1:]...)}, nil
+		default:
+			elems := append([]Term{}, list.Elements...)
+			switch {
+			case index >= 0:
+				elems[index] = args[3]
+			case fun == "lists:keystore":
+				elems = append(elems, args[3])
+			}
+			return List{Elements: elems}, nil
+		}
+
+	case "lists:member/2":
+		list, ok := args[1].(List)
+		if !ok {
+			return nil, fmt.Errorf("lists:member expects a list, got %s", args[1])
+		}
+		for _, elem := range list.Elements {
+			if elem.Compare(args[0]) {
+				return scriptBool(true), nil
+			}
+		}
+		return scriptBool(false), nil
+
+	case "lists:append/2":
+		return e.eval(concatExpr{left: litExpr{args[0]}, right: litExpr{args[1]}})
+
+	case "proplists:get_value/2", "proplists:get_value/3":
+		list, ok := args[1].(List)
+		if !ok {
+			return nil, fmt.Errorf("proplists:get_value expects a list, got %s", args[1])
+		}
+		for _, elem := range list.Elements {
+			if tuple, ok := elem.(Tuple); ok && len(tuple.Elements) >= 1 && tuple.Elements[0].Compare(args[0]) {
+				if len(tuple.Elements) == 2 {
+					return tuple.Elements[1], nil
+				}
+				break
+			}
+			if elem.Compare(args[0]) {
+				return Atom{Value: "true"}, nil
+			}
+		}
+		if len(args) == 3 {
+			return args[2], nil
+		}
+		return Atom{Value: "undefined"}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported function %s/%d", fun, len(args))
+}
+
+// keyIndex 返回列表中第 pos 个元素等于 key 的第一个元组的下标，未找到时返回 -1
+func keyIndex(elems []Term, key Term, pos int) int {
+	for i, elem := range elems {
+		if tuple, ok := elem.(Tuple); ok && len(tuple.Elements) >= pos && tuple.Elements[pos-1].Compare(key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// scriptBool 将布尔值转换为 true 或 false 原子
+func scriptBool(b bool) Atom {
+	if b {
+		return Atom{Value: "true"}
+	}
+	return Atom{Value: "false"}
+}
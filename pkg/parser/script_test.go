@@ -0,0 +1,187 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeScriptProject writes a rebar.config and, when script is non-empty, a sibling rebar.config.script
+func writeScriptProject(t *testing.T, config, script string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rebar.config")
+	if config != "" {
+		if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+	}
+	if script != "" {
+		if err := os.WriteFile(path+".script", []byte(script), 0644); err != nil {
+			t.Fatalf("Failed to write script: %v", err)
+		}
+	}
+	return path
+}
+
+// TestScriptDetection tests that a sibling script makes ParseFile fail with a ScriptError
+func TestScriptDetection(t *testing.T) {
+	path := writeScriptProject(t, `{deps, []}.`, `CONFIG.`)
+
+	_, err := ParseFile(path)
+	var scriptErr *ScriptError
+	if !errors.As(err, &scriptErr) {
+		t.Fatalf("Expected *ScriptError, got %v", err)
+	}
+	if scriptErr.Path != path+".script" {
+		t.Errorf("Expected path %s, got %s", path+".script", scriptErr.Path)
+	}
+
+	config, err := ParseFile(path, IgnoreScript())
+	if err != nil {
+		t.Fatalf("Failed to parse with IgnoreScript: %v", err)
+	}
+	if len(config.Terms) != 1 {
+		t.Errorf("Expected 1 term, got %d", len(config.Terms))
+	}
+}
+
+// TestExpressionDetection tests that configs containing Erlang expressions produce a ScriptError
+func TestExpressionDetection(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		dynamic bool
+	}{
+		{"variable", `Deps = [cowboy], {deps, Deps}.`, true},
+		{"concat", `{erl_opts, [debug_info] ++ [warnings_as_errors]}.`, true},
+		{"remote call", `{deps, [{cowboy, os:getenv("V")}]}.`, true},
+		{"case", `case true of _ -> [] end.`, true},
+		{"plain syntax error", `{deps, [cowboy}.`, false},
+		{"quoted upper atom", `{'Deps', "X ++ Y" [}.`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			var scriptErr *ScriptError
+			if errors.As(err, &scriptErr) != tt.dynamic {
+				t.Errorf("Expected dynamic=%v, got %v", tt.dynamic, err)
+			}
+
+			_, err = ParseReader(strings.NewReader(tt.input))
+			if err == nil {
+				t.Fatal("Expected an error from ParseReader")
+			}
+			if errors.As(err, &scriptErr) != tt.dynamic {
+				t.Errorf("ParseReader: expected dynamic=%v, got %v", tt.dynamic, err)
+			}
+		})
+	}
+
+	path := writeScriptProject(t, `{deps, Deps}.`, "")
+	_, err := ParseFile(path)
+	var scriptErr *ScriptError
+	if !errors.As(err, &scriptErr) || scriptErr.Path != path {
+		t.Errorf("Expected *ScriptError for %s, got %v", path, err)
+	}
+}
+
+// TestEvalScript tests evaluation of the supported script subset
+func TestEvalScript(t *testing.T) {
+	config := `{erl_opts, [debug_info]}.
+{deps, [{cowboy, "2.9.0"}]}.
+`
+	script := `%% add a dependency when running in CI
+Deps = proplists:get_value(deps, CONFIG, []),
+CIDeps = case os:getenv("CI") of
+    false -> Deps;
+    "true" -> Deps ++ [{meck, "0.9.2"}]
+end,
+Profile = os:getenv("PROFILE", "dev"),
+Opts = if
+    Profile =:= "prod" -> [no_debug_info];
+    true -> [debug_info, warnings_as_errors]
+end,
+C1 = lists:keystore(deps, 1, CONFIG, {deps, CIDeps}),
+lists:keystore(erl_opts, 1, C1, {erl_opts, Opts}).
+`
+	path := writeScriptProject(t, config, script)
+	env := map[string]string{"CI": "true"}
+	getenv := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	result, err := ParseFile(path, EvalScript(getenv))
+	if err != nil {
+		t.Fatalf("Failed to evaluate script: %v", err)
+	}
+
+	expected, err := Parse(`{erl_opts, [debug_info, warnings_as_errors]}.
+{deps, [{cowboy, "2.9.0"}, {meck, "0.9.2"}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse expected config: %v", err)
+	}
+	if len(result.Terms) != len(expected.Terms) {
+		t.Fatalf("Expected %d terms, got %d", len(expected.Terms), len(result.Terms))
+	}
+	for i := range expected.Terms {
+		if !result.Terms[i].Compare(expected.Terms[i]) {
+			t.Errorf("Term %d: expected %s, got %s", i, expected.Terms[i], result.Terms[i])
+		}
+	}
+}
+
+// TestEvalScriptWithoutConfig tests that CONFIG is empty when only the script exists
+func TestEvalScriptWithoutConfig(t *testing.T) {
+	path := writeScriptProject(t, "", `[{deps, []} | []] ++ CONFIG.`)
+	_, err := ParseFile(path, EvalScript(nil))
+	if err == nil {
+		t.Fatal("Expected an error for the unsupported cons pattern")
+	}
+
+	path = writeScriptProject(t, "", `CONFIG ++ [{deps, []}].`)
+	config, err := ParseFile(path, EvalScript(nil))
+	if err != nil {
+		t.Fatalf("Failed to evaluate script: %v", err)
+	}
+	if len(config.Terms) != 1 || termName(config.Terms[0]) != "deps" {
+		t.Errorf("Unexpected terms: %v", config.Terms)
+	}
+}
+
+// TestEvalScriptErrors tests that unsupported scripts are rejected with a ScriptError
+func TestEvalScriptErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		message string
+	}{
+		{"file access", `{ok, T} = file:consult("x"), T.`, "unsupported function file:consult/1"},
+		{"fun", `F = fun() -> ok end, CONFIG.`, "unsupported expression: fun"},
+		{"unbound", `Missing.`, "variable Missing is unbound"},
+		{"not a list", `ok.`, "script must return a list"},
+		{"no case clause", `case 1 of 2 -> CONFIG end.`, "no case clause matching 1"},
+		{"missing dot", `CONFIG`, "expected '.' at end of script"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeScriptProject(t, `{deps, []}.`, tt.script)
+			_, err := ParseFile(path, EvalScript(nil))
+			var scriptErr *ScriptError
+			if !errors.As(err, &scriptErr) {
+				t.Fatalf("Expected *ScriptError, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected error containing %q, got %q", tt.message, err.Error())
+			}
+		})
+	}
+}
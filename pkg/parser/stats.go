@@ -0,0 +1,92 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+// Stats 是配置的统计信息
+// @pkg 由 RebarConfig.Stats 生成
+type Stats struct {
+	// TermCounts 按类型名称（Atom、String、Binary、Integer、Float、Tuple、List、Map）统计所有项的数量，包括嵌套的项
+	TermCounts map[string]int
+	// MaxDepth 是最大嵌套深度，顶级项的深度为 1
+	MaxDepth int
+	// TopLevelTerms 是顶级项的数量
+	TopLevelTerms int
+	// ByteSize 是原始输入的字节数；使用 DiscardRaw 解析时为 0
+	ByteSize int
+}
+
+// Stats 返回配置的统计信息
+// @pkg 递归遍历所有项，统计各类型数量和最大嵌套深度
+// 输出:
+//   - Stats: 统计信息
+//
+// 示例:
+//
+//	stats := config.Stats()
+//	fmt.Printf("共 %d 个顶级项，最大深度 %d\n", stats.TopLevelTerms, stats.MaxDepth)
+//	fmt.Printf("原子数量: %d\n", stats.TermCounts["Atom"])
+//
+// 数据样例:
+// 原始配置: {deps, [{cowboy, "2.9.0"}]}.
+// 返回: Stats{TermCounts: {Tuple: 2, Atom: 2, List: 1, String: 1}, MaxDepth: 4, TopLevelTerms: 1, ByteSize: 28}
+func (c *RebarConfig) Stats() Stats {
+	stats := Stats{
+		TermCounts:    make(map[string]int),
+		TopLevelTerms: len(c.Terms),
+		ByteSize:      len(c.Raw),
+	}
+
+	for _, term := range c.Terms {
+		countTerms(term, 1, &stats)
+	}
+
+	return stats
+}
+
+// countTerms 递归统计项的类型和深度
+func countTerms(term Term, depth int, stats *Stats) {
+	stats.TermCounts[termTypeName(term)]++
+	if depth > stats.MaxDepth {
+		stats.MaxDepth = depth
+	}
+
+	switch t := term.(type) {
+	case Tuple:
+		for _, elem := range t.Elements {
+			countTerms(elem, depth+1, stats)
+		}
+	case List:
+		for _, elem := range t.Elements {
+			countTerms(elem, depth+1, stats)
+		}
+	case Map:
+		for _, pair := range t.Pairs {
+			countTerms(pair.Key, depth+1, stats)
+			countTerms(pair.Value, depth+1, stats)
+		}
+	}
+}
+
+// termTypeName 返回项的类型名称
+func termTypeName(term Term) string {
+	switch term.(type) {
+	case Atom:
+		return "Atom"
+	case String:
+		return "String"
+	case Binary:
+		return "Binary"
+	case Integer:
+		return "Integer"
+	case Float:
+		return "Float"
+	case Tuple:
+		return "Tuple"
+	case List:
+		return "List"
+	case Map:
+		return "Map"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"testing"
+)
+
+// TestStats tests term counting and depth calculation
+func TestStats(t *testing.T) {
+	input := `{deps, [{cowboy, "2.9.0"}]}.
+{nums, 1, 2.5}.
+{empty, []}.`
+	config, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	stats := config.Stats()
+	if stats.TopLevelTerms != 3 {
+		t.Errorf("Expected 3 top-level terms, got %d", stats.TopLevelTerms)
+	}
+	if stats.MaxDepth != 4 {
+		t.Errorf("Expected max depth 4, got %d", stats.MaxDepth)
+	}
+	if stats.ByteSize != len(input) {
+		t.Errorf("Expected byte size %d, got %d", len(input), stats.ByteSize)
+	}
+
+	expected := map[string]int{"Tuple": 4, "Atom": 4, "List": 2, "String": 1, "Integer": 1, "Float": 1}
+	if len(stats.TermCounts) != len(expected) {
+		t.Errorf("Unexpected term counts: %v", stats.TermCounts)
+	}
+	for name, n := range expected {
+		if stats.TermCounts[name] != n {
+			t.Errorf("Expected %d %s terms, got %d", n, name, stats.TermCounts[name])
+		}
+	}
+}
+
+// TestStatsEmpty tests statistics of an empty config
+func TestStatsEmpty(t *testing.T) {
+	config, _ := Parse("% only a comment\n", DiscardRaw())
+	stats := config.Stats()
+	if stats.TopLevelTerms != 0 || stats.MaxDepth != 0 || stats.ByteSize != 0 || len(stats.TermCounts) != 0 {
+		t.Errorf("Unexpected stats for empty config: %+v", stats)
+	}
+}
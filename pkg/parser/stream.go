@@ -0,0 +1,122 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"bufio"
+	"io"
+)
+
+// termReader 从 reader 中逐个切分顶级项
+// @pkg 只做最少的词法判断（字符串、带引号原子、注释和括号嵌套），
+// 找到嵌套深度为 0 且后跟空白、注释或输入结尾的点号时返回一个片段，具体解析交给 Parser
+type termReader struct {
+	r      *bufio.Reader
+	line   int // 下一个字节的行号
+	column int // 下一个字节的列号
+	eof    bool
+	buf    []byte
+}
+
+// newTermReader 创建一个 termReader
+func newTermReader(r io.Reader) *termReader {
+	return &termReader{r: bufio.NewReader(r), line: 1, column: 1}
+}
+
+// next 返回下一个顶级项的原始字节（包括其前面的空白和注释）
+// @pkg 返回的切片在下一次调用前有效
+// 输出:
+//   - []byte: 片段内容
+//   - int: 片段起始处的行号
+//   - int: 片段起始处的列号
+//   - error: 输入结束时为 io.EOF（此时片段可能包含未结束的内容），或读取错误
+func (t *termReader) next() ([]byte, int, int, error) {
+	t.buf = t.buf[:0]
+	line, column := t.line, t.column
+	if t.eof {
+		return nil, line, column, io.EOF
+	}
+
+	const (
+		stateNormal = iota
+		stateString
+		stateQuoted
+		stateComment
+	)
+
+	state := stateNormal
+	escape := false
+	depth := 0
+
+	for {
+		ch, err := t.r.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				t.eof = true
+			}
+			return t.buf, line, column, err
+		}
+
+		t.buf = append(t.buf, ch)
+		if ch == '\n' {
+			t.line++
+			t.column = 1
+		} else {
+			t.column++
+		}
+
+		switch state {
+		case stateComment:
+			if ch == '\n' {
+				state = stateNormal
+			}
+		case stateString, stateQuoted:
+			quote := byte('"')
+			if state == stateQuoted {
+				quote = '\''
+			}
+			if escape {
+				escape = false
+			} else if ch == '\\' {
+				escape = true
+			} else if ch == quote {
+				state = stateNormal
+			}
+		default:
+			switch ch {
+			case '%':
+				state = stateComment
+			case '"':
+				state = stateString
+			case '\'':
+				state = stateQuoted
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			case '.':
+				if depth <= 0 && t.atTermEnd() {
+					return t.buf, line, column, nil
+				}
+			}
+		}
+	}
+}
+
+// atTermEnd 检查下一个字节是否表示顶级项结束
+// @pkg 与 Erlang 的规则一致，结束符是后跟空白、注释或输入结尾的点号，
+// 因此顶级的浮点数（如 1.5）不会被误切分
+func (t *termReader) atTermEnd() bool {
+	next, err := t.r.Peek(1)
+	if err != nil {
+		if err == io.EOF {
+			t.eof = true
+		}
+		return true
+	}
+	switch next[0] {
+	case ' ', '\t', '\n', '\r', '%':
+		return true
+	}
+	return false
+}
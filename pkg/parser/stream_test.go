@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// TestParseReaderStreaming tests that term splitting respects strings, comments and floats
+func TestParseReaderStreaming(t *testing.T) {
+	input := `% leading comment with a dot. inside
+{url, "https://example.com/a.b. c"}.
+{'atom. with dots', ok}. % trailing. comment
+{nested, [{a, 1.5}, {b, "x.y"}]}.
+2.5.
+{last, 'x'}.`
+
+	expected, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	for name, r := range map[string]io.Reader{
+		"plain":    strings.NewReader(input),
+		"one byte": iotest.OneByteReader(strings.NewReader(input)),
+		"half":     iotest.HalfReader(strings.NewReader(input)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			config, err := ParseReader(r)
+			if err != nil {
+				t.Fatalf("ParseReader failed: %v", err)
+			}
+			if !compareConfigs(expected, config) {
+				t.Errorf("Expected %v, got %v", expected.Terms, config.Terms)
+			}
+			if config.Raw != input {
+				t.Errorf("Expected Raw to equal the input")
+			}
+		})
+	}
+}
+
+// TestParseReaderErrorPositions tests that errors report positions in the whole input
+func TestParseReaderErrorPositions(t *testing.T) {
+	input := "{a, b}.\n{c, d}.\n  {e, }.\n"
+	_, err := ParseReader(strings.NewReader(input))
+	if err == nil {
+		t.Fatal("Expected syntax error")
+	}
+	_, parseErr := Parse(input)
+	if err.Error() != parseErr.Error() {
+		t.Errorf("Expected %q, got %q", parseErr, err)
+	}
+	if !strings.Contains(err.Error(), "line 3, column 7") {
+		t.Errorf("Expected error at line 3, column 7, got %v", err)
+	}
+
+	if _, err := ParseReader(strings.NewReader("{a, b}. {c, d}")); err == nil || !strings.Contains(err.Error(), "line 1, column 15") {
+		t.Errorf("Expected error for missing final dot, got %v", err)
+	}
+}
+
+// endlessReader yields the same term forever
+type endlessReader struct{}
+
+func (endlessReader) Read(p []byte) (int, error) {
+	return copy(p, "{more, terms}.\n"), nil
+}
+
+// TestParseReaderStopsEarly tests that a syntax error stops reading without consuming the whole stream
+func TestParseReaderStopsEarly(t *testing.T) {
+	r := io.MultiReader(strings.NewReader("{ok, 1}.\n{bad}}.\n"), endlessReader{})
+	if _, err := ParseReader(r, DiscardRaw()); err == nil {
+		t.Fatal("Expected syntax error")
+	}
+}
+
+// TestParseReaderReadErrorAfterTerms tests read errors reported mid-stream
+func TestParseReaderReadErrorAfterTerms(t *testing.T) {
+	r := io.MultiReader(strings.NewReader("{ok, 1}.\n"), iotest.ErrReader(io.ErrUnexpectedEOF))
+	_, err := ParseReader(r)
+	if err == nil || !strings.Contains(err.Error(), "error reading input") {
+		t.Errorf("Expected read error, got %v", err)
+	}
+}
@@ -0,0 +1,282 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// SysConfig 表示 sys.config 文件
+// @pkg sys.config 由单个列表组成，列表元素是应用配置 {App, [{Par, Val}, ...]}
+// 或者被包含的其他配置文件的路径:
+//
+//	[
+//	  {kernel, [{logger_level, info}]},
+//	  {my_app, [{port, 8080}]},
+//	  "config/extra.config"
+//	].
+type SysConfig struct {
+	// Apps 是应用配置，按出现顺序排列；同一个应用可能出现多次
+	Apps []AppEnv
+	// Includes 是被包含的配置文件路径，按出现顺序排列
+	Includes []string
+}
+
+// AppEnv 表示 sys.config 中一个应用的配置
+type AppEnv struct {
+	// App 是应用名称
+	App string
+	// Env 是 {Par, Val} 形式的参数列表
+	Env []Term
+}
+
+// ParseSysConfig 解析 sys.config 格式的字符串
+// @pkg 只解析内容本身，不读取被包含的文件；需要合并包含文件时使用 LoadSysConfig
+// 输入:
+//   - input: sys.config 的内容
+//
+// 输出:
+//   - *SysConfig: 解析后的配置
+//   - error: 语法或结构错误
+func ParseSysConfig(input string) (*SysConfig, error) {
+	terms, err := ParseConsult(input)
+	if err != nil {
+		return nil, err
+	}
+	return sysConfigFromTerms(terms)
+}
+
+// ParseSysConfigFile 解析指定路径的 sys.config 文件
+// @pkg 只解析该文件本身，不读取被包含的文件；需要合并包含文件时使用 LoadSysConfig
+// 输入:
+//   - path: 文件路径，如 "./config/sys.config"
+//
+// 输出:
+//   - *SysConfig: 解析后的配置
+//   - error: 读取、语法或结构错误
+func ParseSysConfigFile(path string) (*SysConfig, error) {
+	terms, err := Consult(path)
+	if err != nil {
+		return nil, err
+	}
+	return sysConfigFromTerms(terms)
+}
+
+// LoadSysConfig 解析 sys.config 并递归合并被包含的文件
+// @pkg 合并规则与 OTP 一致：按顺序处理列表，遇到文件名时读取并合并该文件，
+// 遇到应用配置时与已有结果合并，新参数被追加，已有参数的值被覆盖
+// 相对路径按包含它的文件所在目录解析，省略的 .config 扩展名会被补上
+// 输入:
+//   - path: sys.config 文件路径
+//
+// 输出:
+//   - *SysConfig: 合并后的配置，每个应用只出现一次，Includes 为空
+//   - error: 读取、语法、结构错误或循环包含
+//
+// 示例:
+//
+//	sys, err := parser.LoadSysConfig("./config/sys.config")
+//	if err != nil {
+//	  log.Fatal(err)
+//	}
+//	port, ok := sys.GetAppEnv("my_app", "port")
+func LoadSysConfig(path string) (*SysConfig, error) {
+	merged := &SysConfig{}
+	if err := merged.load(path, make(map[string]bool)); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// load 读取 path 并将其内容合并到 c 中
+func (c *SysConfig) load(path string, loading map[string]bool) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if loading[abs] {
+		return fmt.Errorf("include cycle detected at %s", path)
+	}
+	loading[abs] = true
+	defer delete(loading, abs)
+
+	terms, err := Consult(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	list, err := sysConfigList(terms)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	for _, elem := range list.Elements {
+		if include, ok := elem.(String); ok {
+			file := include.Value
+			if !strings.HasSuffix(file, ".config") {
+				file += ".config"
+			}
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(filepath.Dir(path), file)
+			}
+			if err := c.load(file, loading); err != nil {
+				return err
+			}
+			continue
+		}
+
+		app, err := sysConfigApp(elem)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		c.merge(app)
+	}
+	return nil
+}
+
+// merge 将应用配置合并到已有结果中
+func (c *SysConfig) merge(app AppEnv) {
+	for i := range c.Apps {
+		if c.Apps[i].App != app.App {
+			continue
+		}
+		for _, param := range app.Env {
+			key := termName(param)
+			replaced := false
+			for j, existing := range c.Apps[i].Env {
+				if termName(existing) == key {
+					c.Apps[i].Env[j] = param
+					replaced = true
+					break
+				}
+			}
+			if !replaced {
+				c.Apps[i].Env = append(c.Apps[i].Env, param)
+			}
+		}
+		return
+	}
+	c.Apps = append(c.Apps, AppEnv{App: app.App, Env: append([]Term(nil), app.Env...)})
+}
+
+// GetAppEnv 获取应用的配置参数
+// @pkg 与 application:get_env/2 类似；同一参数出现多次时返回最后一次出现的值
+// 输入:
+//   - app: 应用名称，如 "kernel"
+//   - key: 参数名称，如 "logger_level"
+//
+// 输出:
+//   - Term: 参数值
+//   - bool: 是否找到
+//
+// 示例:
+//
+//	level, ok := sys.GetAppEnv("kernel", "logger_level")
+func (c *SysConfig) GetAppEnv(app, key string) (Term, bool) {
+	for i := len(c.Apps) - 1; i >= 0; i-- {
+		if c.Apps[i].App != app {
+			continue
+		}
+		env := c.Apps[i].Env
+		for j := len(env) - 1; j >= 0; j-- {
+			if termName(env[j]) == key {
+				return env[j].(Tuple).Elements[1], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// GetAppEnvs 获取应用的全部配置参数
+// @pkg 与 application:get_all_env/1 类似，同一参数出现多次时后出现的值覆盖先前的值
+// 输入:
+//   - app: 应用名称
+//
+// 输出:
+//   - map[string]Term: 参数名到参数值的映射，应用未配置时为空映射
+func (c *SysConfig) GetAppEnvs(app string) map[string]Term {
+	env := make(map[string]Term)
+	for _, entry := range c.Apps {
+		if entry.App != app {
+			continue
+		}
+		for _, param := range entry.Env {
+			env[termName(param)] = param.(Tuple).Elements[1]
+		}
+	}
+	return env
+}
+
+// AppNames 返回配置了参数的应用名称，按首次出现的顺序排列且不重复
+func (c *SysConfig) AppNames() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, entry := range c.Apps {
+		if !seen[entry.App] {
+			seen[entry.App] = true
+			names = append(names, entry.App)
+		}
+	}
+	return names
+}
+
+// sysConfigFromTerms 从顶级项构建 SysConfig
+func sysConfigFromTerms(terms []Term) (*SysConfig, error) {
+	list, err := sysConfigList(terms)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &SysConfig{}
+	for _, elem := range list.Elements {
+		if include, ok := elem.(String); ok {
+			config.Includes = append(config.Includes, include.Value)
+			continue
+		}
+		app, err := sysConfigApp(elem)
+		if err != nil {
+			return nil, err
+		}
+		config.Apps = append(config.Apps, app)
+	}
+	return config, nil
+}
+
+// sysConfigList 返回 sys.config 中唯一的顶级列表
+func sysConfigList(terms []Term) (List, error) {
+	if len(terms) != 1 {
+		return List{}, fmt.Errorf("invalid sys.config: expected exactly one term, got %d", len(terms))
+	}
+	list, ok := terms[0].(List)
+	if !ok {
+		return List{}, fmt.Errorf("invalid sys.config: expected a list, got %s", terms[0])
+	}
+	return list, nil
+}
+
+// sysConfigApp 解析 {App, [{Par, Val}, ...]} 形式的应用配置
+func sysConfigApp(term Term) (AppEnv, error) {
+	tuple, ok := term.(Tuple)
+	if !ok || len(tuple.Elements) != 2 {
+		return AppEnv{}, fmt.Errorf("invalid sys.config: expected {App, Env} or a file name, got %s", term)
+	}
+	app, ok := tuple.Elements[0].(Atom)
+	if !ok {
+		return AppEnv{}, fmt.Errorf("invalid sys.config: application name must be an atom, got %s", tuple.Elements[0])
+	}
+	env, ok := tuple.Elements[1].(List)
+	if !ok {
+		return AppEnv{}, fmt.Errorf("invalid sys.config: env of %s must be a list, got %s", app.Value, tuple.Elements[1])
+	}
+	for _, param := range env.Elements {
+		pair, ok := param.(Tuple)
+		if !ok || len(pair.Elements) != 2 {
+			return AppEnv{}, fmt.Errorf("invalid sys.config: expected {Par, Val} in %s, got %s", app.Value, param)
+		}
+		if _, ok := pair.Elements[0].(Atom); !ok {
+			return AppEnv{}, fmt.Errorf("invalid sys.config: parameter name in %s must be an atom, got %s", app.Value, pair.Elements[0])
+		}
+	}
+	return AppEnv{App: app.Value, Env: env.Elements}, nil
+}
@@ -0,0 +1,170 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestParseSysConfig tests parsing sys.config content without includes
+func TestParseSysConfig(t *testing.T) {
+	sys, err := ParseSysConfig(`[
+    {kernel, [{logger_level, info}]},
+    {my_app, [{port, 8080}, {host, "localhost"}]},
+    "config/extra",
+    {my_app, [{port, 9090}]}
+].`)
+	if err != nil {
+		t.Fatalf("Failed to parse sys.config: %v", err)
+	}
+
+	if !reflect.DeepEqual(sys.Includes, []string{"config/extra"}) {
+		t.Errorf("Unexpected includes: %v", sys.Includes)
+	}
+	if !reflect.DeepEqual(sys.AppNames(), []string{"kernel", "my_app"}) {
+		t.Errorf("Unexpected app names: %v", sys.AppNames())
+	}
+
+	level, ok := sys.GetAppEnv("kernel", "logger_level")
+	if !ok || !level.Compare(Atom{Value: "info"}) {
+		t.Errorf("Unexpected logger_level: %v", level)
+	}
+	port, ok := sys.GetAppEnv("my_app", "port")
+	if !ok || !port.Compare(Integer{Value: 9090}) {
+		t.Errorf("Expected later port to win, got %v", port)
+	}
+	if _, ok := sys.GetAppEnv("my_app", "missing"); ok {
+		t.Error("Expected missing key not to be found")
+	}
+	if _, ok := sys.GetAppEnv("other", "port"); ok {
+		t.Error("Expected missing app not to be found")
+	}
+
+	env := sys.GetAppEnvs("my_app")
+	if len(env) != 2 || !env["port"].Compare(Integer{Value: 9090}) {
+		t.Errorf("Unexpected env: %v", env)
+	}
+	if len(sys.GetAppEnvs("other")) != 0 {
+		t.Error("Expected empty env for missing app")
+	}
+}
+
+// TestParseSysConfigErrors tests malformed sys.config content
+func TestParseSysConfigErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"empty", ``},
+		{"not a list", `{kernel, []}.`},
+		{"two terms", `[]. [].`},
+		{"bad entry", `[kernel].`},
+		{"app not atom", `[{"kernel", []}].`},
+		{"env not list", `[{kernel, env}].`},
+		{"bad param", `[{kernel, [level]}].`},
+		{"param not atom", `[{kernel, [{"level", info}]}].`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseSysConfig(tt.input); err == nil {
+				t.Errorf("Expected error for %q", tt.input)
+			}
+		})
+	}
+}
+
+// TestLoadSysConfig tests merging included files
+func TestLoadSysConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		return path
+	}
+
+	writeFile("shared/base.config", `[{my_app, [{port, 1}, {pool, 10}]}, {sasl, [{errlog_type, error}]}].`)
+	writeFile("shared/secrets.config", `[{my_app, [{password, "secret"}]}].`)
+	path := writeFile("sys.config", `[
+    "shared/base",
+    {my_app, [{port, 8080}]},
+    "shared/secrets.config"
+].`)
+
+	sys, err := LoadSysConfig(path)
+	if err != nil {
+		t.Fatalf("Failed to load sys.config: %v", err)
+	}
+	if len(sys.Includes) != 0 {
+		t.Errorf("Expected includes to be resolved, got %v", sys.Includes)
+	}
+	if len(sys.Apps) != 2 {
+		t.Fatalf("Expected one entry per app, got %d", len(sys.Apps))
+	}
+
+	expected := map[string]Term{
+		"port":     Integer{Value: 8080},
+		"pool":     Integer{Value: 10},
+		"password": String{Value: "secret"},
+	}
+	env := sys.GetAppEnvs("my_app")
+	if len(env) != len(expected) {
+		t.Fatalf("Expected %d params, got %v", len(expected), env)
+	}
+	for key, value := range expected {
+		if !env[key].Compare(value) {
+			t.Errorf("Expected %s = %s, got %v", key, value, env[key])
+		}
+	}
+}
+
+// TestLoadSysConfigErrors tests include failures
+func TestLoadSysConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.config")
+	b := filepath.Join(dir, "b.config")
+	os.WriteFile(a, []byte(`["b"].`), 0644)
+	os.WriteFile(b, []byte(`["a.config"].`), 0644)
+
+	if _, err := LoadSysConfig(a); err == nil || !strings.Contains(err.Error(), "include cycle") {
+		t.Errorf("Expected include cycle error, got %v", err)
+	}
+
+	missing := filepath.Join(dir, "missing.config")
+	os.WriteFile(missing, []byte(`["nowhere"].`), 0644)
+	if _, err := LoadSysConfig(missing); err == nil {
+		t.Error("Expected error for missing include")
+	}
+
+	bad := filepath.Join(dir, "bad.config")
+	os.WriteFile(bad, []byte(`[kernel].`), 0644)
+	if _, err := LoadSysConfig(bad); err == nil {
+		t.Error("Expected error for malformed entry")
+	}
+}
+
+// TestParseSysConfigFile tests reading a sys.config from disk without merging
+func TestParseSysConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sys.config")
+	if err := os.WriteFile(path, []byte(`[{kernel, []}, "other"].`), 0644); err != nil {
+		t.Fatalf("Failed to write sys.config: %v", err)
+	}
+
+	sys, err := ParseSysConfigFile(path)
+	if err != nil {
+		t.Fatalf("Failed to parse sys.config: %v", err)
+	}
+	if len(sys.Apps) != 1 || len(sys.Includes) != 1 {
+		t.Errorf("Unexpected sys.config: %+v", sys)
+	}
+	if _, err := ParseSysConfigFile(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
@@ -9,15 +9,7 @@ import (
 // compareConfigs compares two RebarConfig structs by comparing their terms
 // This is a common helper used across different test files
 func compareConfigs(c1, c2 *RebarConfig) bool {
-	if len(c1.Terms) != len(c2.Terms) {
-		return false
-	}
-	for i := range c1.Terms {
-		if !c1.Terms[i].Compare(c2.Terms[i]) {
-			return false
-		}
-	}
-	return true
+	return c1.Equal(c2)
 }
 
 // createTempConfigFile creates a temporary file with the given content and returns its path
@@ -0,0 +1,186 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+)
+
+// ParseTerm 解析单个 Erlang 项
+// @pkg 与 Parse 不同，输入只能包含一个项，末尾的点号可以省略
+// 输入:
+//   - input: Erlang 项的源码，如 "{cowboy, \"2.9.0\"}"
+//
+// 输出:
+//   - Term: 解析出的项
+//   - error: 语法错误，或项之后还有其他内容
+//
+// 示例:
+//
+//	term, err := parser.ParseTerm(`{d, 'TEST'}`)
+func ParseTerm(input string) (Term, error) {
+	p := NewParser(input)
+	p.detached = true
+	term, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	p.skipWhitespace()
+	if p.position < len(p.input) && p.input[p.position] == '.' {
+		p.position++
+		p.skipWhitespace()
+	}
+	if p.position < len(p.input) {
+		return nil, p.errorAt("unexpected content after term")
+	}
+	return term, nil
+}
+
+// 文本表示
+// @pkg 各 Term 类型实现了 encoding.TextMarshaler 和 encoding.TextUnmarshaler，文本为 String() 返回的 Erlang 源码形式，
+// 便于作为映射的键或嵌入使用文本编码的格式（如 YAML、TOML、命令行参数）。
+// encoding/json 优先使用 MarshalJSON，因此 JSON 中仍然是带 type 字段的对象
+
+// MarshalText 返回原子的 Erlang 源码形式
+func (a Atom) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// MarshalText 返回字符串的 Erlang 源码形式
+func (s String) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// MarshalText 返回整数的 Erlang 源码形式
+func (i Integer) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// MarshalText 返回浮点数的 Erlang 源码形式
+func (f Float) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// MarshalText 返回二进制的 Erlang 源码形式
+func (b Binary) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// MarshalText 返回元组的 Erlang 源码形式
+func (t Tuple) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// MarshalText 返回列表的 Erlang 源码形式
+func (l List) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// MarshalText 返回映射的 Erlang 源码形式
+func (m Map) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText 从 Erlang 源码解析原子
+func (a *Atom) UnmarshalText(text []byte) error {
+	return parseTextInto(text, a)
+}
+
+// UnmarshalText 从 Erlang 源码解析字符串
+func (s *String) UnmarshalText(text []byte) error {
+	return parseTextInto(text, s)
+}
+
+// UnmarshalText 从 Erlang 源码解析整数
+func (i *Integer) UnmarshalText(text []byte) error {
+	return parseTextInto(text, i)
+}
+
+// UnmarshalText 从 Erlang 源码解析浮点数，也接受整数形式如 2
+func (f *Float) UnmarshalText(text []byte) error {
+	term, err := ParseTerm(string(text))
+	if err != nil {
+		return err
+	}
+	if i, ok := term.(Integer); ok {
+		*f = Float{Value: float64(i.Value)}
+		return nil
+	}
+	return assignTerm(term, f)
+}
+
+// UnmarshalText 从 Erlang 源码解析二进制
+func (b *Binary) UnmarshalText(text []byte) error {
+	return parseTextInto(text, b)
+}
+
+// UnmarshalText 从 Erlang 源码解析元组
+func (t *Tuple) UnmarshalText(text []byte) error {
+	return parseTextInto(text, t)
+}
+
+// UnmarshalText 从 Erlang 源码解析列表
+func (l *List) UnmarshalText(text []byte) error {
+	return parseTextInto(text, l)
+}
+
+// UnmarshalText 从 Erlang 源码解析映射
+func (m *Map) UnmarshalText(text []byte) error {
+	return parseTextInto(text, m)
+}
+
+// parseTextInto 解析 Erlang 源码并写入 target 指向的同类型变量
+func parseTextInto(text []byte, target interface{}) error {
+	term, err := ParseTerm(string(text))
+	if err != nil {
+		return err
+	}
+	return assignTerm(term, target)
+}
+
+// assignTerm 将项写入 target 指向的同类型变量，类型不同时返回错误
+func assignTerm(term Term, target interface{}) error {
+	switch p := target.(type) {
+	case *Atom:
+		if v, ok := term.(Atom); ok {
+			*p = v
+			return nil
+		}
+	case *String:
+		if v, ok := term.(String); ok {
+			*p = v
+			return nil
+		}
+	case *Integer:
+		if v, ok := term.(Integer); ok {
+			*p = v
+			return nil
+		}
+	case *Float:
+		if v, ok := term.(Float); ok {
+			*p = v
+			return nil
+		}
+	case *Binary:
+		if v, ok := term.(Binary); ok {
+			*p = v
+			return nil
+		}
+	case *Tuple:
+		if v, ok := term.(Tuple); ok {
+			*p = v
+			return nil
+		}
+	case *List:
+		if v, ok := term.(List); ok {
+			*p = v
+			return nil
+		}
+	case *Map:
+		if v, ok := term.(Map); ok {
+			*p = v
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot unmarshal %s into %T", termTypeName(term), target)
+}
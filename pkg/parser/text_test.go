@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"encoding"
+	"strings"
+	"testing"
+)
+
+// TestParseTerm tests parsing of a single term
+func TestParseTerm(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		err      string
+	}{
+		{input: `{d, 'TEST'}`, expected: "{d, 'TEST'}"},
+		{input: ` [a, "b"]. `, expected: `[a, "b"]`},
+		{input: `#{k => <<"v">>}`, expected: `#{k => <<"v">>}`},
+		{input: `a. b.`, err: "unexpected content after term"},
+		{input: `{a`, err: "expected"},
+		{input: ``, err: "unexpected end of input"},
+	}
+
+	for _, tt := range tests {
+		term, err := ParseTerm(tt.input)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("ParseTerm(%q): expected error containing %q, got %v", tt.input, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", tt.input, err)
+		}
+		if term.String() != tt.expected {
+			t.Errorf("ParseTerm(%q): expected %s, got %s", tt.input, tt.expected, term)
+		}
+	}
+}
+
+// TestTextMarshaling tests MarshalText and UnmarshalText round trips
+func TestTextMarshaling(t *testing.T) {
+	terms := []struct {
+		value  encoding.TextMarshaler
+		target encoding.TextUnmarshaler
+	}{
+		{Atom{Value: "my-dep", IsQuoted: true}, &Atom{}},
+		{String{Value: "2.9.0"}, &String{}},
+		{Integer{Value: -7}, &Integer{}},
+		{Float{Value: 0.5}, &Float{}},
+		{Binary{Value: "bin"}, &Binary{}},
+		{Tuple{Elements: []Term{Atom{Value: "a"}, Integer{Value: 1}}}, &Tuple{}},
+		{List{Elements: []Term{String{Value: "x"}}}, &List{}},
+		{Map{Pairs: []MapPair{{Key: Atom{Value: "k"}, Value: Atom{Value: "v"}}}}, &Map{}},
+	}
+
+	for _, tt := range terms {
+		text, err := tt.value.MarshalText()
+		if err != nil {
+			t.Fatalf("Failed to marshal %v: %v", tt.value, err)
+		}
+		if err := tt.target.UnmarshalText(text); err != nil {
+			t.Fatalf("Failed to unmarshal %s: %v", text, err)
+		}
+		if got := tt.target.(Term).String(); got != string(text) {
+			t.Errorf("Round trip: expected %s, got %s", text, got)
+		}
+	}
+
+	var f Float
+	if err := f.UnmarshalText([]byte("2")); err != nil || f.Value != 2 {
+		t.Errorf("Expected integer text to unmarshal into Float, got %v, %v", f, err)
+	}
+	atom := Atom{Value: "keep", IsQuoted: true}
+	if err := atom.UnmarshalText([]byte(`"s"`)); err == nil || err.Error() != "cannot unmarshal String into *parser.Atom" {
+		t.Errorf("Expected type mismatch error, got %v", err)
+	}
+	if atom != (Atom{Value: "keep", IsQuoted: true}) {
+		t.Errorf("Expected target to be left untouched on error, got %+v", atom)
+	}
+	list := List{Elements: []Term{NewAtom("a")}}
+	if err := list.GobDecode([]byte{'a', 1, 'x'}); err == nil || len(list.Elements) != 1 {
+		t.Errorf("Expected gob decode error to leave target untouched, got %v, %v", list, err)
+	}
+}
@@ -0,0 +1,190 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenKind 表示词法单元的类别
+type TokenKind int
+
+const (
+	// TokenWhitespace 是空格、制表符和换行
+	TokenWhitespace TokenKind = iota
+	// TokenComment 是 % 开始的行注释，不包括换行符
+	TokenComment
+	// TokenAtom 是原子，包括带引号的原子
+	TokenAtom
+	// TokenString 是双引号字符串
+	TokenString
+	// TokenNumber 是整数或浮点数，包括紧跟数字的负号
+	TokenNumber
+	// TokenVariable 是以大写字母开头的变量名，只出现在 rebar.config.script 等脚本中
+	TokenVariable
+	// TokenPunct 是括号、逗号、点号和运算符，如 { } [ ] , . << >> => ++
+	TokenPunct
+	// TokenInvalid 是无法识别的字符
+	TokenInvalid
+)
+
+// tokenKindNames 是各类别的名称
+var tokenKindNames = [...]string{
+	TokenWhitespace: "whitespace",
+	TokenComment:    "comment",
+	TokenAtom:       "atom",
+	TokenString:     "string",
+	TokenNumber:     "number",
+	TokenVariable:   "variable",
+	TokenPunct:      "punct",
+	TokenInvalid:    "invalid",
+}
+
+// String 返回类别的名称，如 "atom"
+func (k TokenKind) String() string {
+	if k >= 0 && int(k) < len(tokenKindNames) {
+		return tokenKindNames[k]
+	}
+	return "unknown"
+}
+
+// Token 是源码中的一个词法单元
+type Token struct {
+	// Kind 是词法单元的类别
+	Kind TokenKind
+	// Text 是词法单元在源码中的原始文本
+	Text string
+	// Offset 是起始处的字节偏移
+	Offset int
+	// Line 是起始处的行号，从 1 开始
+	Line int
+	// Column 是起始处的列号（字节），从 1 开始
+	Column int
+}
+
+// multiCharPuncts 是由多个字符组成的运算符，按长度优先匹配
+var multiCharPuncts = []string{"<<", ">>", "=>", ":=", "++", "--", "->", "::", "||"}
+
+// Tokenize 将源码切分为词法单元
+// @pkg 切分是无损的：所有词法单元的 Text 依次拼接后与输入完全相同，空白和注释也作为词法单元保留。
+// Tokenize 不会失败，未结束的字符串或带引号原子延续到输入结尾，无法识别的字符作为 TokenInvalid 返回，
+// 因此可以用于语法高亮等需要处理不完整输入的场景
+// 输入:
+//   - input: 源码
+//
+// 输出:
+//   - []Token: 词法单元，按出现顺序排列
+//
+// 示例:
+//
+//	for _, tok := range parser.Tokenize(`{deps, []}.`) {
+//	  fmt.Println(tok.Kind, tok.Text)
+//	}
+func Tokenize(input string) []Token {
+	var tokens []Token
+	line, column := 1, 1
+	i := 0
+	for i < len(input) {
+		start := i
+		kind := TokenInvalid
+		ch := input[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			kind = TokenWhitespace
+			for i < len(input) && (input[i] == ' ' || input[i] == '\t' || input[i] == '\n' || input[i] == '\r') {
+				i++
+			}
+		case ch == '%':
+			kind = TokenComment
+			for i < len(input) && input[i] != '\n' {
+				i++
+			}
+		case ch == '"' || ch == '\'':
+			kind = TokenString
+			if ch == '\'' {
+				kind = TokenAtom
+			}
+			i = scanQuotedEnd(input, i)
+		case isDigit(ch) || (ch == '-' && i+1 < len(input) && isDigit(input[i+1])):
+			kind = TokenNumber
+			i = scanNumberEnd(input, i)
+		case isAtomStart(ch) || (ch >= 'A' && ch <= 'Z'):
+			// 与 Parser 一致，下划线开头的名称视为原子
+			kind = TokenAtom
+			if ch >= 'A' && ch <= 'Z' {
+				kind = TokenVariable
+			}
+			i++
+			for i < len(input) && isAtomChar(input[i]) {
+				i++
+			}
+		case strings.IndexByte("{}[](),.|#/:;=<>+-*!?", ch) >= 0:
+			kind = TokenPunct
+			i++
+			for _, punct := range multiCharPuncts {
+				if strings.HasPrefix(input[start:], punct) {
+					i = start + len(punct)
+					break
+				}
+			}
+		default:
+			_, size := utf8.DecodeRuneInString(input[i:])
+			i += size
+		}
+
+		text := input[start:i]
+		tokens = append(tokens, Token{Kind: kind, Text: text, Offset: start, Line: line, Column: column})
+		if n := strings.Count(text, "\n"); n > 0 {
+			line += n
+			column = len(text) - strings.LastIndexByte(text, '\n')
+		} else {
+			column += len(text)
+		}
+	}
+	return tokens
+}
+
+// scanQuotedEnd 返回从 start 处开引号开始的字面量的结束位置，未结束时返回输入长度
+func scanQuotedEnd(input string, start int) int {
+	quote := input[start]
+	for i := start + 1; i < len(input); i++ {
+		switch input[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(input)
+}
+
+// scanNumberEnd 返回从 start 处开始的数字的结束位置，规则与 parseNumber 相同
+func scanNumberEnd(input string, start int) int {
+	i := start
+	if input[i] == '-' {
+		i++
+	}
+	for i < len(input) && isDigit(input[i]) {
+		i++
+	}
+	if i+1 < len(input) && input[i] == '.' && isDigit(input[i+1]) {
+		i++
+		for i < len(input) && isDigit(input[i]) {
+			i++
+		}
+	}
+	if i < len(input) && (input[i] == 'e' || input[i] == 'E') {
+		j := i + 1
+		if j < len(input) && (input[j] == '+' || input[j] == '-') {
+			j++
+		}
+		if j < len(input) && isDigit(input[j]) {
+			for j < len(input) && isDigit(input[j]) {
+				j++
+			}
+			i = j
+		}
+	}
+	return i
+}
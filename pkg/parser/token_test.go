@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestTokenize tests token kinds and positions
+func TestTokenize(t *testing.T) {
+	input := "% deps\n{deps, [{'my app', \"1.0\"}, -2.5e3, X, <<\"a\">>, #{k => 1}]}.\n"
+	tokens := Tokenize(input)
+
+	var b strings.Builder
+	for _, tok := range tokens {
+		b.WriteString(tok.Text)
+	}
+	if b.String() != input {
+		t.Fatalf("Expected tokens to reproduce the input, got %q", b.String())
+	}
+
+	type expectation struct {
+		kind   TokenKind
+		text   string
+		line   int
+		column int
+	}
+	var significant []expectation
+	for _, tok := range tokens {
+		if tok.Kind != TokenWhitespace {
+			significant = append(significant, expectation{tok.Kind, tok.Text, tok.Line, tok.Column})
+		}
+	}
+	expected := []expectation{
+		{TokenComment, "% deps", 1, 1},
+		{TokenPunct, "{", 2, 1},
+		{TokenAtom, "deps", 2, 2},
+		{TokenPunct, ",", 2, 6},
+		{TokenPunct, "[", 2, 8},
+		{TokenPunct, "{", 2, 9},
+		{TokenAtom, "'my app'", 2, 10},
+		{TokenPunct, ",", 2, 18},
+		{TokenString, "\"1.0\"", 2, 20},
+		{TokenPunct, "}", 2, 25},
+		{TokenPunct, ",", 2, 26},
+		{TokenNumber, "-2.5e3", 2, 28},
+		{TokenPunct, ",", 2, 34},
+		{TokenVariable, "X", 2, 36},
+		{TokenPunct, ",", 2, 37},
+		{TokenPunct, "<<", 2, 39},
+		{TokenString, "\"a\"", 2, 41},
+		{TokenPunct, ">>", 2, 44},
+		{TokenPunct, ",", 2, 46},
+		{TokenPunct, "#", 2, 48},
+		{TokenPunct, "{", 2, 49},
+		{TokenAtom, "k", 2, 50},
+		{TokenPunct, "=>", 2, 52},
+		{TokenNumber, "1", 2, 55},
+		{TokenPunct, "}", 2, 56},
+		{TokenPunct, "]", 2, 57},
+		{TokenPunct, "}", 2, 58},
+		{TokenPunct, ".", 2, 59},
+	}
+	if len(significant) != len(expected) {
+		t.Fatalf("Expected %d tokens, got %d: %v", len(expected), len(significant), significant)
+	}
+	for i := range expected {
+		if significant[i] != expected[i] {
+			t.Errorf("Token %d: expected %+v, got %+v", i, expected[i], significant[i])
+		}
+	}
+}
+
+// TestTokenizeIncomplete tests that malformed input is still split losslessly
+func TestTokenizeIncomplete(t *testing.T) {
+	tests := []struct {
+		input string
+		last  TokenKind
+	}{
+		{`{deps, "unterminated`, TokenString},
+		{`{'open`, TokenAtom},
+		{"a ~", TokenInvalid},
+		{"1.", TokenPunct},
+		{"é", TokenInvalid},
+	}
+	for _, tt := range tests {
+		tokens := Tokenize(tt.input)
+		var b strings.Builder
+		for _, tok := range tokens {
+			b.WriteString(tok.Text)
+		}
+		if b.String() != tt.input {
+			t.Errorf("Tokenize(%q) did not reproduce the input: %q", tt.input, b.String())
+		}
+		if last := tokens[len(tokens)-1]; last.Kind != tt.last {
+			t.Errorf("Tokenize(%q): expected last token kind %s, got %s", tt.input, tt.last, last.Kind)
+		}
+	}
+}
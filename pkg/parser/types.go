@@ -23,7 +23,7 @@ import (
 //	  ]
 //	}
 type RebarConfig struct {
-	// Raw 存储原始内容，以备参考
+	// Raw 存储原始内容，以备参考；使用 DiscardRaw 选项解析时为空
 	Raw string
 	// Terms 是配置文件中的顶级配置项列表
 	Terms []Term
@@ -36,6 +36,8 @@ type Term interface {
 	String() string
 	// Compare 比较此 Term 与另一个 Term
 	Compare(other Term) bool
+	// Kind 返回项的类别，可以代替类型断言来区分各种项
+	Kind() TermKind
 }
 
 // Tuple 表示 Erlang 元组 {elem1, elem2, ...}
@@ -145,11 +147,13 @@ type Atom struct {
 
 // String 返回原子的字符串表示
 // @pkg 将 Atom 转换为字符串形式
-// 如果原子是引号包围的，返回如 'atom-name'
+// 如果原子是引号包围的，返回如 'atom-name'，其中的单引号和反斜杠会被转义
+// 未加引号但名称不能作为裸原子书写时（如以程序方式构造的 Atom{Value: "my-app"}，
+// 包含空格、大写字母开头、保留字或空名称）也会自动加上引号，保证输出能被重新解析
 // 否则直接返回原子名称，如 atom_name
 func (a Atom) String() string {
-	if a.IsQuoted {
-		return "'" + a.Value + "'"
+	if a.IsQuoted || !isPlainAtom(a.Value) {
+		return quoteAtom(a.Value)
 	}
 	return a.Value
 }
@@ -175,12 +179,15 @@ func (a Atom) Compare(other Term) bool {
 // 数据样例: "hello world" 被解析为 String{Value: "hello world"}
 type String struct {
 	Value string
+	// Text 是源码中的写法，如 "\x41bc"；只在使用 KeepLiterals 选项解析时记录
+	Text string
 }
 
 // String 返回字符串的字符串表示（带引号）
-// @pkg 将 String 转换为字符串形式（带双引号），如 "hello world"
+// @pkg 将 String 转换为带双引号的 Erlang 字符串字面量，如 "hello world"；
+// 双引号、反斜杠和控制字符按 Erlang 规则转义（如 \"、\\、\n），输出总能被重新解析为相同的值
 func (s String) String() string {
-	return "\"" + s.Value + "\""
+	return quoteString(s.Value)
 }
 
 // Compare 比较两个 String 是否相等
@@ -204,6 +211,8 @@ func (s String) Compare(other Term) bool {
 // 数据样例: 123 被解析为 Integer{Value: 123}
 type Integer struct {
 	Value int64
+	// Text 是源码中的写法，如 007；只在使用 KeepLiterals 选项解析时记录
+	Text string
 }
 
 // String 返回整数的字符串表示
@@ -233,12 +242,15 @@ func (i Integer) Compare(other Term) bool {
 // 数据样例: 3.14 被解析为 Float{Value: 3.14}
 type Float struct {
 	Value float64
+	// Text 是源码中的写法，如 0.50；只在使用 KeepLiterals 选项解析时记录
+	Text string
 }
 
 // String 返回浮点数的字符串表示
-// @pkg 将 Float 转换为字符串形式，如 "3.14"
+// @pkg 将 Float 转换为字符串形式，如 "3.14"、"1.0e10"
+// 输出总是合法的 Erlang 浮点数，且解析后与原值完全相等
 func (f Float) String() string {
-	return fmt.Sprintf("%g", f.Value)
+	return formatFloat(f.Value)
 }
 
 // Compare 比较两个 Float 是否相等
@@ -256,3 +268,125 @@ func (f Float) Compare(other Term) bool {
 	}
 	return f.Value == otherFloat.Value
 }
+
+// Binary 表示 Erlang 二进制 <<...>>
+// @pkg Binary 对应 Erlang 的二进制类型，Value 保存其字节内容
+// 支持字符串段和字节整数段，如 <<"cowboy">>、<<"中文"/utf8>>、<<1,2,3>>
+// 数据样例: <<"2.9.0">> 被解析为 Binary{Value: "2.9.0"}
+type Binary struct {
+	Value string
+}
+
+// String 返回二进制的字符串表示
+// @pkg 将 Binary 转换为 Erlang 语法:
+// - 纯 ASCII 内容输出为 <<"text">>
+// - 其他合法 UTF-8 内容输出为 <<"text"/utf8>>
+// - 其余内容按字节输出，如 <<1,2,3>>
+func (b Binary) String() string {
+	return formatBinary(b.Value)
+}
+
+// Compare 比较两个 Binary 是否相等
+// @pkg 比较当前 Binary 与另一个 Term 是否相等
+// 如果另一个 Term 不是 Binary，返回 false
+// 比较两个二进制的字节内容是否相等
+// 示例:
+// bin1 := Binary{Value: "cowboy"}
+// bin2 := Binary{Value: "cowboy"}
+// bin1.Compare(bin2) // 返回 true
+func (b Binary) Compare(other Term) bool {
+	otherBinary, ok := other.(Binary)
+	if !ok {
+		return false
+	}
+	return b.Value == otherBinary.Value
+}
+
+// Map 表示 Erlang 映射 #{Key => Value, ...}
+// @pkg Map 对应 Erlang 的映射类型，Pairs 按源文件中的顺序保存键值对
+// 数据样例: #{dirs => ["src"], filter => "*.erl"} 被解析为
+// Map{Pairs: [
+//
+//	{Key: Atom{Value: "dirs"}, Value: List{...}},
+//	{Key: Atom{Value: "filter"}, Value: String{Value: "*.erl"}}
+//
+// ]}
+type Map struct {
+	Pairs []MapPair
+}
+
+// MapPair 表示映射中的一个键值对
+type MapPair struct {
+	Key   Term
+	Value Term
+}
+
+// String 返回映射的字符串表示
+// @pkg 将 Map 转换为字符串形式，例如 "#{port => 8080}"
+func (m Map) String() string {
+	pairs := make([]string, len(m.Pairs))
+	for i, pair := range m.Pairs {
+		pairs[i] = pair.Key.String() + " => " + pair.Value.String()
+	}
+	return "#{" + strings.Join(pairs, ", ") + "}"
+}
+
+// Compare 比较两个 Map 是否相等
+// @pkg 比较当前 Map 与另一个 Term 是否相等
+// 与 Erlang 一致，映射是无序的：键值对相同即相等，与书写顺序无关；重复的键只有最后一个生效
+// 示例:
+// map1 := Map{Pairs: []MapPair{{Atom{Value: "a"}, Integer{Value: 1}}, {Atom{Value: "b"}, Integer{Value: 2}}}}
+// map2 := Map{Pairs: []MapPair{{Atom{Value: "b"}, Integer{Value: 2}}, {Atom{Value: "a"}, Integer{Value: 1}}}}
+// map1.Compare(map2) // 返回 true
+func (m Map) Compare(other Term) bool {
+	otherMap, ok := other.(Map)
+	if !ok {
+		return false
+	}
+	pairs := m.uniquePairs()
+	if len(pairs) != len(otherMap.uniquePairs()) {
+		return false
+	}
+	for _, pair := range pairs {
+		value, ok := otherMap.Get(pair.Key)
+		if !ok || !pair.Value.Compare(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Get 按键查找映射中的值
+// @pkg 键重复时返回最后一个，与 Erlang 构造映射时后者覆盖前者一致
+// 输入:
+//   - key: 要查找的键
+//
+// 输出:
+//   - Term: 对应的值
+//   - bool: 是否找到
+func (m Map) Get(key Term) (Term, bool) {
+	for i := len(m.Pairs) - 1; i >= 0; i-- {
+		if m.Pairs[i].Key.Compare(key) {
+			return m.Pairs[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// uniquePairs 返回去掉重复键后的键值对，重复的键只保留最后一个，与 Get 一致
+func (m Map) uniquePairs() []MapPair {
+	pairs := make([]MapPair, 0, len(m.Pairs))
+	for i, pair := range m.Pairs {
+		overridden := false
+		for _, later := range m.Pairs[i+1:] {
+			if later.Key.Compare(pair.Key) {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs
+}
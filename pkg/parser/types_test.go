@@ -85,11 +85,21 @@ func TestTermStringMethods(t *testing.T) {
 	}{
 		{Atom{Value: "simple", IsQuoted: false}, "simple"},
 		{Atom{Value: "quoted atom", IsQuoted: true}, "'quoted atom'"},
+		{Atom{Value: "my-app"}, "'my-app'"},
+		{Atom{Value: "TEST"}, "'TEST'"},
+		{Atom{Value: "has space"}, "'has space'"},
+		{Atom{Value: "receive"}, "'receive'"},
+		{Atom{Value: ""}, "''"},
+		{Atom{Value: "node@host"}, "node@host"},
 		{String{Value: "hello"}, `"hello"`},
+		{String{Value: "say \"hi\"\n\tC:\\dir\x01"}, `"say \"hi\"\n\tC:\\dir\001"`},
 		{Integer{Value: 123}, "123"},
 		{Integer{Value: -45}, "-45"},
 		{Float{Value: 1.23}, "1.23"},
 		{Float{Value: -0.5}, "-0.5"},
+		{Float{Value: 100}, "100.0"},
+		{Float{Value: 1e10}, "1.0e10"},
+		{Float{Value: 1.5e-7}, "1.5e-7"},
 		{List{Elements: []Term{Integer{Value: 1}, Atom{Value: "a"}}}, "[1, a]"},
 		{List{Elements: []Term{}}, "[]"},
 		{Tuple{Elements: []Term{Atom{Value: "key"}, String{Value: "val"}}}, "{key, \"val\"}"},
@@ -103,3 +113,84 @@ func TestTermStringMethods(t *testing.T) {
 		})
 	}
 }
+
+// TestBinary tests Binary String and Compare
+func TestBinary(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"", `<<>>`},
+		{"cowboy", `<<"cowboy">>`},
+		{"say \"hi\"\n", `<<"say \"hi\"\n">>`},
+		{"中文", `<<"中文"/utf8>>`},
+		{"\xff\x00", `<<255,0>>`},
+	}
+
+	for _, tt := range tests {
+		bin := Binary{Value: tt.value}
+		if got := bin.String(); got != tt.expected {
+			t.Errorf("Expected %s, got %s", tt.expected, got)
+		}
+
+		config, err := Parse(bin.String() + ".")
+		if err != nil {
+			t.Fatalf("Failed to reparse %s: %v", bin, err)
+		}
+		if !bin.Compare(config.Terms[0]) {
+			t.Errorf("Round trip of %s produced %s", bin, config.Terms[0])
+		}
+	}
+
+	if (Binary{Value: "a"}).Compare(String{Value: "a"}) {
+		t.Error("Binary should not equal String")
+	}
+}
+
+// TestMap tests Map String, Compare and Get
+func TestMap(t *testing.T) {
+	a := Map{Pairs: []MapPair{
+		{Atom{Value: "a"}, Integer{Value: 1}},
+		{Atom{Value: "b"}, List{Elements: []Term{String{Value: "x"}}}},
+	}}
+	b := Map{Pairs: []MapPair{
+		{Atom{Value: "b"}, List{Elements: []Term{String{Value: "x"}}}},
+		{Atom{Value: "a"}, Integer{Value: 1}},
+	}}
+
+	if got := a.String(); got != `#{a => 1, b => ["x"]}` {
+		t.Errorf("Unexpected string: %s", got)
+	}
+	if !a.Compare(b) {
+		t.Error("Maps with the same pairs in different order should be equal")
+	}
+	if a.Compare(Map{Pairs: a.Pairs[:1]}) {
+		t.Error("Maps with different sizes should not be equal")
+	}
+	if a.Compare(Map{Pairs: []MapPair{{Atom{Value: "a"}, Integer{Value: 2}}, a.Pairs[1]}}) {
+		t.Error("Maps with different values should not be equal")
+	}
+	if a.Compare(List{}) {
+		t.Error("Map should not equal List")
+	}
+	if _, ok := a.Get(Atom{Value: "c"}); ok {
+		t.Error("Expected missing key not to be found")
+	}
+
+	dup := Map{Pairs: []MapPair{{Atom{Value: "a"}, Integer{Value: 1}}, {Atom{Value: "a"}, Integer{Value: 2}}}}
+	if v, _ := dup.Get(Atom{Value: "a"}); !v.Compare(Integer{Value: 2}) {
+		t.Errorf("Expected last duplicate key to win, got %v", v)
+	}
+
+	// Duplicate keys compare as the map Erlang would build, in both directions
+	x := MustParseTerm(`#{a => 1, a => 2}`)
+	y := MustParseTerm(`#{a => 2, a => 2}`)
+	z := MustParseTerm(`#{a => 2}`)
+	if !x.Compare(y) || !y.Compare(x) || !x.Compare(z) || !z.Compare(x) {
+		t.Error("Expected maps with duplicate keys to equal #{a => 2} symmetrically")
+	}
+	w := MustParseTerm(`#{a => 2, a => 1}`)
+	if w.Compare(z) || z.Compare(w) {
+		t.Error("Expected #{a => 2, a => 1} not to equal #{a => 2}")
+	}
+}
@@ -0,0 +1,380 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// UnmarshalError 表示项无法写入目标 Go 值
+type UnmarshalError struct {
+	// Path 是项在配置中的位置，如 deps[1].version
+	Path string
+	// Term 是无法转换的项
+	Term Term
+	// Type 是目标 Go 类型
+	Type reflect.Type
+}
+
+// Error 返回错误描述
+func (e *UnmarshalError) Error() string {
+	msg := fmt.Sprintf("cannot unmarshal %s into Go value of type %s", e.Term, e.Type)
+	if e.Path != "" {
+		msg += " at " + e.Path
+	}
+	return msg
+}
+
+// termType 是 Term 接口的反射类型
+var termType = reflect.TypeOf((*Term)(nil)).Elem()
+
+// Unmarshal 将配置写入 v 指向的结构体
+// @pkg 类似 encoding/json，通过反射把配置映射到结构体字段。规则:
+// - 字段名取 erlang 标签，如 `erlang:"deps"`；没有标签时按忽略大小写和下划线的方式匹配字段名，
+// 如 ErlOpts 匹配 erl_opts；标签为 "-" 的字段被忽略。没有对应配置项的字段保持不变
+// - 顶层的 {Key, Value} 项对应字段 Key，{Key, V1, V2, ...} 的值为列表 [V1, V2, ...]，重复的键取第一个
+// - 结构体可以从属性列表（[{Key, Value}, Atom]，单独的原子 Key 对应 true，{Key, V1, V2, ...} 的值为列表）
+// 或映射按键解码；也可以从元组或其他列表按位置解码：第 N 个元素写入第 N 个可导出字段；其他项视为单元素元组 {X}
+// - 切片和数组从列表或元组解码，map[string]T 从属性列表或映射解码
+// - string 接受原子、字符串和二进制；bool 接受 true、false 原子；整数和浮点数检查溢出
+// - Term 类型的字段直接保存原始项，interface{} 字段保存 ToNative 的结果，指针字段会自动分配
+//
+// 输入:
+//   - config: 解析后的配置
+//   - v: 指向结构体的非 nil 指针
+//
+// 输出:
+//   - error: v 不是结构体指针，或存在类型不匹配（*UnmarshalError）
+//
+// 示例:
+//
+//	type Dep struct {
+//	  Name    string
+//	  Version string
+//	}
+//	var settings struct {
+//	  ErlOpts []parser.Term `erlang:"erl_opts"`
+//	  Deps    []Dep         `erlang:"deps"`
+//	}
+//	err := parser.Unmarshal(config, &settings)
+func Unmarshal(config *RebarConfig, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unmarshal target must be a non-nil pointer to a struct, got %T", v)
+	}
+
+	var keys []string
+	values := make(map[string]Term)
+	for _, term := range config.Terms {
+		tuple, ok := term.(Tuple)
+		if !ok || len(tuple.Elements) < 2 {
+			continue
+		}
+		key, ok := tuple.Elements[0].(Atom)
+		if !ok {
+			continue
+		}
+		if _, exists := values[key.Value]; exists {
+			continue
+		}
+		keys = append(keys, key.Value)
+		if len(tuple.Elements) == 2 {
+			values[key.Value] = tuple.Elements[1]
+		} else {
+			values[key.Value] = List{Elements: tuple.Elements[1:]}
+		}
+	}
+	return decodeFields(keys, values, rv.Elem(), "")
+}
+
+// decodeTerm 将项写入 rv
+func decodeTerm(term Term, rv reflect.Value, path string) error {
+	typ := rv.Type()
+	if typ.Kind() == reflect.Interface && typ.NumMethod() > 0 {
+		if !reflect.TypeOf(term).AssignableTo(typ) {
+			return &UnmarshalError{Path: path, Term: term, Type: typ}
+		}
+		rv.Set(reflect.ValueOf(term))
+		return nil
+	}
+	if typ.Implements(termType) {
+		if reflect.TypeOf(term) != typ {
+			return &UnmarshalError{Path: path, Term: term, Type: typ}
+		}
+		rv.Set(reflect.ValueOf(term))
+		return nil
+	}
+
+	mismatch := func() error {
+		return &UnmarshalError{Path: path, Term: term, Type: typ}
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			rv.Set(reflect.New(typ.Elem()))
+		}
+		return decodeTerm(term, rv.Elem(), path)
+
+	case reflect.Interface:
+		rv.Set(reflect.ValueOf(ToNative(term)))
+		return nil
+
+	case reflect.String:
+		switch t := term.(type) {
+		case Atom:
+			rv.SetString(t.Value)
+		case String:
+			rv.SetString(t.Value)
+		case Binary:
+			rv.SetString(t.Value)
+		default:
+			return mismatch()
+		}
+		return nil
+
+	case reflect.Bool:
+		atom, ok := term.(Atom)
+		if !ok || atom.Value != "true" && atom.Value != "false" {
+			return mismatch()
+		}
+		rv.SetBool(atom.Value == "true")
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := term.(Integer)
+		if !ok || rv.OverflowInt(i.Value) {
+			return mismatch()
+		}
+		rv.SetInt(i.Value)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, ok := term.(Integer)
+		if !ok || i.Value < 0 || rv.OverflowUint(uint64(i.Value)) {
+			return mismatch()
+		}
+		rv.SetUint(uint64(i.Value))
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch t := term.(type) {
+		case Float:
+			f = t.Value
+		case Integer:
+			f = float64(t.Value)
+		default:
+			return mismatch()
+		}
+		if typ.Kind() == reflect.Float32 && math.Abs(f) > math.MaxFloat32 {
+			return mismatch()
+		}
+		rv.SetFloat(f)
+		return nil
+
+	case reflect.Slice:
+		elements, ok := sequenceElements(term)
+		if !ok {
+			return mismatch()
+		}
+		slice := reflect.MakeSlice(typ, len(elements), len(elements))
+		for i, elem := range elements {
+			if err := decodeTerm(elem, slice.Index(i), indexPath(path, i)); err != nil {
+				return err
+			}
+		}
+		rv.Set(slice)
+		return nil
+
+	case reflect.Array:
+		elements, ok := sequenceElements(term)
+		if !ok || len(elements) != typ.Len() {
+			return mismatch()
+		}
+		for i, elem := range elements {
+			if err := decodeTerm(elem, rv.Index(i), indexPath(path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			return mismatch()
+		}
+		keys, values, ok := keyedPairs(term)
+		if !ok {
+			return mismatch()
+		}
+		m := reflect.MakeMapWithSize(typ, len(keys))
+		for _, key := range keys {
+			elem := reflect.New(typ.Elem()).Elem()
+			if err := decodeTerm(values[key], elem, fieldPath(path, key)); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(typ.Key()), elem)
+		}
+		rv.Set(m)
+		return nil
+
+	case reflect.Struct:
+		if tuple, ok := term.(Tuple); ok {
+			return decodePositional(tuple.Elements, rv, path)
+		}
+		if keys, values, ok := keyedPairs(term); ok {
+			return decodeFields(keys, values, rv, path)
+		}
+		if list, ok := term.(List); ok {
+			return decodePositional(list.Elements, rv, path)
+		}
+		return decodePositional([]Term{term}, rv, path)
+	}
+	return mismatch()
+}
+
+// decodeFields 按键将值写入结构体字段
+func decodeFields(keys []string, values map[string]Term, rv reflect.Value, path string) error {
+	typ := rv.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, ok := fieldName(field)
+		if !ok {
+			continue
+		}
+		for _, key := range keys {
+			if !fieldMatches(name, field, key) {
+				continue
+			}
+			if err := decodeTerm(values[key], rv.Field(i), fieldPath(path, key)); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// decodePositional 将元组元素按位置写入结构体的可导出字段
+func decodePositional(elements []Term, rv reflect.Value, path string) error {
+	typ := rv.Type()
+	n := 0
+	for i := 0; i < typ.NumField() && n < len(elements); i++ {
+		if _, ok := fieldName(typ.Field(i)); !ok {
+			continue
+		}
+		if err := decodeTerm(elements[n], rv.Field(i), indexPath(path, n)); err != nil {
+			return err
+		}
+		n++
+	}
+	if n < len(elements) {
+		return &UnmarshalError{Path: path, Term: Tuple{Elements: elements}, Type: typ}
+	}
+	return nil
+}
+
+// fieldName 返回字段的 erlang 标签名，不可导出或标签为 "-" 的字段返回 false
+func fieldName(field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" {
+		return "", false
+	}
+	tag := field.Tag.Get("erlang")
+	if tag == "-" {
+		return "", false
+	}
+	if i := strings.IndexByte(tag, ','); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag, true
+}
+
+// fieldMatches 判断配置键是否对应字段，没有标签时忽略大小写和下划线比较字段名
+func fieldMatches(tag string, field reflect.StructField, key string) bool {
+	if tag != "" {
+		return tag == key
+	}
+	return strings.EqualFold(field.Name, strings.ReplaceAll(key, "_", ""))
+}
+
+// sequenceElements 返回列表或元组的元素
+func sequenceElements(term Term) ([]Term, bool) {
+	switch t := term.(type) {
+	case List:
+		return t.Elements, true
+	case Tuple:
+		return t.Elements, true
+	}
+	return nil, false
+}
+
+// keyedPairs 返回属性列表或映射的键和值，重复的键取第一个
+// @pkg 属性列表中的 {Key, V1, V2, ...} 的值为列表 [V1, V2, ...]
+func keyedPairs(term Term) ([]string, map[string]Term, bool) {
+	var keys []string
+	values := make(map[string]Term)
+	add := func(key string, value Term) {
+		if _, exists := values[key]; !exists {
+			keys = append(keys, key)
+			values[key] = value
+		}
+	}
+
+	switch t := term.(type) {
+	case List:
+		for _, elem := range t.Elements {
+			switch e := elem.(type) {
+			case Atom:
+				add(e.Value, Atom{Value: "true"})
+			case Tuple:
+				if len(e.Elements) < 2 {
+					return nil, nil, false
+				}
+				atom, ok := e.Elements[0].(Atom)
+				if !ok {
+					return nil, nil, false
+				}
+				if len(e.Elements) == 2 {
+					add(atom.Value, e.Elements[1])
+				} else {
+					add(atom.Value, List{Elements: e.Elements[1:]})
+				}
+			default:
+				return nil, nil, false
+			}
+		}
+		return keys, values, true
+	case Map:
+		for _, pair := range t.Pairs {
+			switch k := pair.Key.(type) {
+			case Atom:
+				add(k.Value, pair.Value)
+			case String:
+				add(k.Value, pair.Value)
+			case Binary:
+				add(k.Value, pair.Value)
+			default:
+				return nil, nil, false
+			}
+		}
+		return keys, values, true
+	}
+	return nil, nil, false
+}
+
+// fieldPath 返回带键的路径
+func fieldPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// indexPath 返回带下标的路径
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
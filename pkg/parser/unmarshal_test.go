@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type unmarshalDep struct {
+	Name    string
+	Version string
+	Source  Term
+}
+
+type unmarshalRelease struct {
+	App struct {
+		Name    string
+		Version string
+	}
+	Apps []string
+}
+
+type unmarshalConfig struct {
+	ErlOpts      []Term         `erlang:"erl_opts"`
+	Deps         []unmarshalDep `erlang:"deps"`
+	MinimumOTP   string         `erlang:"minimum_otp_vsn"`
+	CoverEnabled bool
+	Shell        struct {
+		Apps   []string
+		Config string
+	}
+	Relx struct {
+		Release unmarshalRelease `erlang:"release"`
+		DevMode *bool            `erlang:"dev_mode"`
+	}
+	Vars     map[string]interface{} `erlang:"overlay_vars"`
+	Ratio    float64
+	Limit    uint16
+	Skipped  string `erlang:"-"`
+	internal string
+}
+
+// TestUnmarshal tests decoding configs into tagged Go structs
+func TestUnmarshal(t *testing.T) {
+	config, err := Parse(`{erl_opts, [debug_info, {d, 'TEST'}]}.
+{deps, [recon, {cowboy, "2.9.0"}, {lager, "3.9.2", {git, "https://github.com/erlang-lager/lager.git"}}]}.
+{minimum_otp_vsn, "24"}.
+{cover_enabled, true}.
+{shell, [{apps, [my_app, sasl]}, {config, "config/sys.config"}]}.
+{relx, [{release, {my_app, "0.1.0"}, [my_app]}, {dev_mode, false}]}.
+{overlay_vars, #{port => 8080, <<"name">> => <<"app">>}}.
+{ratio, 2}.
+{limit, 100}.
+{skipped, "x"}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	var got unmarshalConfig
+	if err := Unmarshal(config, &got); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	if len(got.ErlOpts) != 2 || got.ErlOpts[1].String() != "{d, 'TEST'}" {
+		t.Errorf("Unexpected erl_opts: %v", got.ErlOpts)
+	}
+	expectedDeps := []unmarshalDep{
+		{Name: "recon"},
+		{Name: "cowboy", Version: "2.9.0"},
+		{Name: "lager", Version: "3.9.2", Source: Tuple{Elements: []Term{Atom{Value: "git"}, String{Value: "https://github.com/erlang-lager/lager.git"}}}},
+	}
+	if !reflect.DeepEqual(got.Deps, expectedDeps) {
+		t.Errorf("Expected deps %+v, got %+v", expectedDeps, got.Deps)
+	}
+	if got.MinimumOTP != "24" || !got.CoverEnabled {
+		t.Errorf("Unexpected scalars: %q %v", got.MinimumOTP, got.CoverEnabled)
+	}
+	if !reflect.DeepEqual(got.Shell.Apps, []string{"my_app", "sasl"}) || got.Shell.Config != "config/sys.config" {
+		t.Errorf("Unexpected shell: %+v", got.Shell)
+	}
+	release := got.Relx.Release
+	if release.App.Name != "my_app" || release.App.Version != "0.1.0" || !reflect.DeepEqual(release.Apps, []string{"my_app"}) || got.Relx.DevMode == nil || *got.Relx.DevMode {
+		t.Errorf("Unexpected relx: %+v", got.Relx)
+	}
+	if !reflect.DeepEqual(got.Vars, map[string]interface{}{"port": int64(8080), "name": "app"}) {
+		t.Errorf("Unexpected overlay_vars: %v", got.Vars)
+	}
+	if got.Ratio != 2 || got.Limit != 100 || got.Skipped != "" {
+		t.Errorf("Unexpected values: %v %v %q", got.Ratio, got.Limit, got.Skipped)
+	}
+}
+
+// TestUnmarshalErrors tests type mismatches and invalid targets
+func TestUnmarshalErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		target   interface{}
+		expected string
+	}{
+		{
+			name:     "Non-pointer target",
+			input:    `{a, 1}.`,
+			target:   struct{}{},
+			expected: "unmarshal target must be a non-nil pointer to a struct, got struct {}",
+		},
+		{
+			name:  "Nested type mismatch",
+			input: `{deps, [{cowboy, "2.9.0"}, {lager, {git, "url"}}]}.`,
+			target: &struct {
+				Deps []struct{ Name, Version string }
+			}{},
+			expected: `cannot unmarshal {git, "url"} into Go value of type string at deps[1][1]`,
+		},
+		{
+			name:     "Integer overflow",
+			input:    `{limit, 300}.`,
+			target:   &struct{ Limit uint8 }{},
+			expected: "cannot unmarshal 300 into Go value of type uint8 at limit",
+		},
+		{
+			name:  "Too many tuple elements",
+			input: `{release, {app, "1.0", extra}}.`,
+			target: &struct {
+				Release struct{ Name, Version string }
+			}{},
+			expected: `cannot unmarshal {app, "1.0", extra} into Go value of type struct { Name string; Version string } at release`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to parse config: %v", err)
+			}
+			err = Unmarshal(config, tt.target)
+			if err == nil || err.Error() != tt.expected {
+				t.Errorf("Expected error %q, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	config, _ := Parse(`{limit, "x"}.`)
+	var target struct{ Limit int }
+	var unmarshalErr *UnmarshalError
+	if err := Unmarshal(config, &target); !errors.As(err, &unmarshalErr) || unmarshalErr.Path != "limit" {
+		t.Errorf("Expected *UnmarshalError at limit, got %v", err)
+	}
+}
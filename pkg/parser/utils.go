@@ -3,21 +3,27 @@
 package parser
 
 import (
+	"fmt"
+	"math"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // processEscapes 处理字符串字面量中的转义序列
 // @pkg 处理字符串和原子中的转义字符，将转义序列转换为实际字符
 //
-// 注意: 此实现处理以下转义序列:
-// - \\\" 变成 " (双引号)
-// - \\\\ 变成 \\ (反斜杠)
-// - \\n 变成 \n (换行符)
-// - \\r 变成 \r (回车符)
-// - \\t 变成 \t (制表符)
+// 采用单次线性扫描，每个转义序列只被解释一次，因此 \\n 会得到反斜杠加 n，
+// 而不会被误转为换行符。输入中没有反斜杠时直接返回原字符串，否则只分配一次。
 //
-// Erlang 样式的用双引号转义(")不支持。
-// 对于原子名称中的单引号，请使用反斜杠转义(\\')。
+// 支持 Erlang 的全部转义序列:
+// - \b \d \e \f \n \r \s \t \v (退格、删除、ESC、换页、换行、回车、空格、制表符、垂直制表符)
+// - \\ \" \' (反斜杠和引号)
+// - \NNN 一到三位八进制数
+// - \xHH 两位十六进制数，\x{H...} 任意位十六进制数
+// - \^C 控制字符（C 的低 5 位）
+//
+// 其他字符前的反斜杠会被去掉，保留该字符本身，与 Erlang 的行为一致。
 //
 // 输入:
 //   - s: 包含转义序列的字符串
@@ -30,14 +36,253 @@ import (
 //	processEscapes("hello\\nworld") // 返回 "hello\nworld"
 //	processEscapes("\\\"quoted\\\"") // 返回 "\"quoted\""
 func processEscapes(s string) string {
-	// 处理常见的转义序列
-	s = strings.ReplaceAll(s, "\\\"", "\"")
-	s = strings.ReplaceAll(s, "\\\\", "\\")
-	s = strings.ReplaceAll(s, "\\n", "\n")
-	s = strings.ReplaceAll(s, "\\r", "\r")
-	s = strings.ReplaceAll(s, "\\t", "\t")
-
-	return s
+	first := strings.IndexByte(s, '\\')
+	if first < 0 {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	b.WriteString(s[:first])
+
+	for i := first; i < len(s); i++ {
+		ch := s[i]
+		if ch != '\\' || i+1 >= len(s) {
+			b.WriteByte(ch)
+			continue
+		}
+
+		i++
+		switch c := s[i]; c {
+		case 'b':
+			b.WriteByte('\b')
+		case 'd':
+			b.WriteByte(0x7f)
+		case 'e':
+			b.WriteByte(0x1b)
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 's':
+			b.WriteByte(' ')
+		case 't':
+			b.WriteByte('\t')
+		case 'v':
+			b.WriteByte('\v')
+		case '^':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i] & 0x1f)
+			} else {
+				b.WriteByte(c)
+			}
+		case 'x':
+			value, next, ok := parseHexEscape(s, i+1)
+			if !ok {
+				b.WriteByte(c)
+				continue
+			}
+			b.WriteRune(value)
+			i = next - 1
+		default:
+			if isOctalDigit(c) {
+				value := rune(0)
+				j := i
+				for j < len(s) && j < i+3 && isOctalDigit(s[j]) {
+					value = value*8 + rune(s[j]-'0')
+					j++
+				}
+				b.WriteRune(value)
+				i = j - 1
+				continue
+			}
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+// parseHexEscape 解析 \x 之后的十六进制转义
+// @pkg 支持 \xHH 和 \x{H...} 两种形式
+// 输入:
+//   - s: 完整字符串
+//   - i: 紧跟在 x 之后的位置
+//
+// 输出:
+//   - rune: 解析出的字符
+//   - int: 转义序列之后的位置
+//   - bool: 是否为合法的十六进制转义
+func parseHexEscape(s string, i int) (rune, int, bool) {
+	if i < len(s) && s[i] == '{' {
+		end := strings.IndexByte(s[i:], '}')
+		if end <= 1 {
+			return 0, 0, false
+		}
+		value, ok := parseHexDigits(s[i+1 : i+end])
+		return value, i + end + 1, ok
+	}
+	if i+2 > len(s) {
+		return 0, 0, false
+	}
+	value, ok := parseHexDigits(s[i : i+2])
+	return value, i + 2, ok
+}
+
+// parseHexDigits 将十六进制数字串转换为字符
+func parseHexDigits(digits string) (rune, bool) {
+	value := rune(0)
+	for i := 0; i < len(digits); i++ {
+		ch := digits[i]
+		switch {
+		case ch >= '0' && ch <= '9':
+			value = value*16 + rune(ch-'0')
+		case ch >= 'a' && ch <= 'f':
+			value = value*16 + rune(ch-'a'+10)
+		case ch >= 'A' && ch <= 'F':
+			value = value*16 + rune(ch-'A'+10)
+		default:
+			return 0, false
+		}
+		if value > 0x10ffff {
+			return 0, false
+		}
+	}
+	return value, true
+}
+
+// quoteAtom 返回带单引号的原子文本
+// @pkg 转义原子名称中的反斜杠和单引号，使输出可以被重新解析
+// 输入:
+//   - value: 原子名称
+//
+// 输出:
+//   - string: 如 'it\'s'
+//
+// 示例:
+//
+//	quoteAtom("it's") // 返回 "'it\\'s'"
+func quoteAtom(value string) string {
+	if !strings.ContainsAny(value, "'\\") {
+		return "'" + value + "'"
+	}
+
+	var b strings.Builder
+	b.Grow(len(value) + 4)
+	b.WriteByte('\'')
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\'' || value[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(value[i])
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// formatBinary 返回二进制的 Erlang 语法表示
+// @pkg 纯 ASCII 内容输出为字符串段，其他合法 UTF-8 内容加上 /utf8 类型说明，
+// 其余内容（如 Latin-1 字节或哈希原始字节）按逗号分隔的字节整数输出
+// 输入:
+//   - value: 二进制的字节内容
+//
+// 输出:
+//   - string: 如 <<"cowboy">>、<<"中文"/utf8>>、<<255,0>>
+func formatBinary(value string) string {
+	if value == "" {
+		return "<<>>"
+	}
+
+	ascii := true
+	for i := 0; i < len(value); i++ {
+		if value[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+
+	switch {
+	case ascii:
+		return "<<" + quoteString(value) + ">>"
+	case utf8.ValidString(value):
+		return "<<" + quoteString(value) + "/utf8>>"
+	}
+
+	var b strings.Builder
+	b.WriteString("<<")
+	for i := 0; i < len(value); i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.Itoa(int(value[i])))
+	}
+	b.WriteString(">>")
+	return b.String()
+}
+
+// formatFloat 返回浮点数的 Erlang 字面量
+// @pkg 使用能精确还原原值的最短表示；Erlang 要求浮点数包含小数部分，
+// 因此整数值补上 ".0"，指数形式的尾数也补上 ".0"，如 1e10 输出为 "1.0e10"。
+// 无穷大和 NaN 在 Erlang 中没有字面量，按 strconv 的形式输出
+// 输入:
+//   - value: 浮点数
+//
+// 输出:
+//   - string: 如 "3.14"、"100.0"、"1.5e-7"
+func formatFloat(value float64) string {
+	text := strconv.FormatFloat(value, 'g', -1, 64)
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return text
+	}
+	mantissa, exponent := text, ""
+	if i := strings.IndexByte(text, 'e'); i >= 0 {
+		sign, digits := "", strings.TrimPrefix(text[i+1:], "+")
+		if strings.HasPrefix(digits, "-") {
+			sign, digits = "-", digits[1:]
+		}
+		mantissa, exponent = text[:i], "e"+sign+strings.TrimLeft(digits, "0")
+	}
+	if !strings.Contains(mantissa, ".") {
+		mantissa += ".0"
+	}
+	return mantissa + exponent
+}
+
+// quoteString 返回带双引号并按 Erlang 规则转义的字符串字面量
+// @pkg 转义双引号、反斜杠和控制字符，其他字符原样输出
+// 输入:
+//   - value: 字符串内容
+//
+// 输出:
+//   - string: 如 "say \"hi\"\n"
+func quoteString(value string) string {
+	var b strings.Builder
+	b.Grow(len(value) + 2)
+	b.WriteByte('"')
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\n':
+			b.WriteString("\\n")
+		case '\r':
+			b.WriteString("\\r")
+		case '\t':
+			b.WriteString("\\t")
+		default:
+			if c < 0x20 || c == 0x7f {
+				fmt.Fprintf(&b, "\\%03o", c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
 }
 
 // 字符分类的辅助函数
@@ -53,6 +298,17 @@ func isDigit(ch byte) bool {
 	return ch >= '0' && ch <= '9'
 }
 
+// isOctalDigit 检查字符是否是八进制数字
+// @pkg 判断一个字符是否是八进制数字字符 (0-7)
+// 输入:
+//   - ch: 要检查的字符
+//
+// 输出:
+//   - bool: 如果是八进制数字返回 true，否则返回 false
+func isOctalDigit(ch byte) bool {
+	return ch >= '0' && ch <= '7'
+}
+
 // isAtomStart 检查字符是否可以作为原子的起始字符
 // @pkg 判断一个字符是否可以作为 Erlang 原子的首字符
 // Erlang 原子必须以小写字母或下划线开头
@@ -76,3 +332,56 @@ func isAtomStart(ch byte) bool {
 func isAtomChar(ch byte) bool {
 	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9') || ch == '_' || ch == '@'
 }
+
+// reservedWords 是 Erlang 的保留字，作为原子使用时必须加引号
+var reservedWords = map[string]bool{
+	"after": true, "and": true, "andalso": true, "band": true, "begin": true, "bnot": true,
+	"bor": true, "bsl": true, "bsr": true, "bxor": true, "case": true, "catch": true,
+	"cond": true, "div": true, "else": true, "end": true, "fun": true, "if": true,
+	"let": true, "maybe": true, "not": true, "of": true, "or": true, "orelse": true,
+	"receive": true, "rem": true, "try": true, "when": true, "xor": true,
+}
+
+// isPlainAtom 检查原子是否可以不加引号书写
+// @pkg 以小写字母开头、只包含字母、数字、下划线和@符号且不是保留字的原子可以不加引号
+// 输入:
+//   - value: 原子的值
+//
+// 输出:
+//   - bool: 可以不加引号时返回 true
+//
+// 示例:
+//
+//	isPlainAtom("debug_info") // 返回 true
+//	isPlainAtom("my-dep")     // 返回 false
+func isPlainAtom(value string) bool {
+	if value == "" || value[0] < 'a' || value[0] > 'z' || reservedWords[value] {
+		return false
+	}
+	for i := 1; i < len(value); i++ {
+		if !isAtomChar(value[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// commonAtoms 是 rebar.config 中高频出现的原子
+// 解析时命中此表的原子会复用表中的字符串，不再引用原始输入（见 Parser.atomValue）
+var commonAtoms = func() map[string]string {
+	atoms := []string{
+		"deps", "erl_opts", "debug_info", "warnings_as_errors", "plugins",
+		"project_plugins", "profiles", "relx", "release", "test", "prod", "dev",
+		"git", "hg", "pkg", "tag", "branch", "ref", "true", "false",
+		"overrides", "override", "add", "del", "shell", "apps", "dialyzer",
+		"xref_checks", "cover_enabled", "minimum_otp_vsn", "provider_hooks",
+		"pre", "post", "compile", "clean", "sys_config", "vm_args",
+		"dev_mode", "include_erts", "extended_start_script", "d",
+		"parse_transform", "platform_define", "i", "src_dirs", "app_name",
+	}
+	m := make(map[string]string, len(atoms))
+	for _, a := range atoms {
+		m[a] = a
+	}
+	return m
+}()
@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"math"
+	"strings"
 	"testing"
 )
 
@@ -18,6 +20,16 @@ func TestProcessEscapes(t *testing.T) {
 		{"return\\rchar", "return\rchar", "Carriage return"},
 		{"back\\\\slash", "back\\slash", "Backslash"},
 		{"\\\"\\n\\r\\t\\\\", "\"\n\r\t\\", "Multiple escapes"},
+		{"\\\\n", "\\n", "Escaped backslash before n"},
+		{"\\\\\\n", "\\\n", "Escaped backslash then newline"},
+		{"it\\'s", "it's", "Single quote"},
+		{"\\b\\d\\e\\f\\s\\v", "\b\x7f\x1b\f \v", "Erlang control escapes"},
+		{"\\101\\0\\1234", "A\x00S4", "Octal escapes"},
+		{"\\x41\\x{4E2D}\\x{1F600}", "A中😀", "Hex escapes"},
+		{"\\^A\\^z", "\x01\x1a", "Control character escapes"},
+		{"\\xZZ", "xZZ", "Invalid hex escape"},
+		{"\\z", "z", "Unknown escape"},
+		{"end\\", "end\\", "Trailing backslash"},
 	}
 
 	for _, tt := range tests {
@@ -86,3 +98,46 @@ func TestCharClassifiers(t *testing.T) {
 		}
 	})
 }
+
+// TestAtomValue tests that common atoms are shared and others pass through
+func TestAtomValue(t *testing.T) {
+	p := NewParser("")
+	input := "xdepsx"
+	if got := p.atomValue(input[1:5]); got != "deps" {
+		t.Errorf("atomValue(deps) = %q, want %q", got, "deps")
+	}
+	if got := p.atomValue("my_custom_atom"); got != "my_custom_atom" {
+		t.Errorf("atomValue(my_custom_atom) = %q", got)
+	}
+}
+
+// TestProcessEscapesAllocations tests that escape processing allocates at most once
+func TestProcessEscapesAllocations(t *testing.T) {
+	plain := "no escapes here"
+	if allocs := testing.AllocsPerRun(100, func() { processEscapes(plain) }); allocs != 0 {
+		t.Errorf("Expected no allocations for plain input, got %v", allocs)
+	}
+	escaped := `line1\nline2\t\"quoted\" \x{4E2D}\101`
+	if allocs := testing.AllocsPerRun(100, func() { processEscapes(escaped) }); allocs > 1 {
+		t.Errorf("Expected at most one allocation, got %v", allocs)
+	}
+}
+
+// TestFormatFloatRoundTrip tests that formatted floats are valid Erlang and parse back to the same value
+func TestFormatFloatRoundTrip(t *testing.T) {
+	values := []float64{0, 1, -2, 0.1, 1.0 / 3, 123456789.125, 1e10, 1e21, 1.5e-7, -4.56e-2, math.MaxFloat64, math.SmallestNonzeroFloat64}
+	for _, value := range values {
+		text := formatFloat(value)
+		if !strings.Contains(strings.SplitN(text, "e", 2)[0], ".") {
+			t.Errorf("formatFloat(%v) = %q, mantissa has no decimal point", value, text)
+		}
+		config, err := Parse("{f, " + text + "}.")
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", text, err)
+		}
+		got, ok := config.Terms[0].(Tuple).Elements[1].(Float)
+		if !ok || got.Value != value {
+			t.Errorf("formatFloat(%v) = %q, parsed back as %v", value, text, config.Terms[0])
+		}
+	}
+}
@@ -0,0 +1,237 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version 表示 hex 包使用的语义化版本号
+// @pkg 格式为 Major.Minor.Patch[-Pre][+Build]，构建元数据不参与比较
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	// Pre 是预发布标识，如 "rc.1"；正式版本为空
+	Pre string
+}
+
+// ParseVersion 解析版本号字符串
+// @pkg 省略的次版本号和修订号视为 0，如 "2.9" 等同于 "2.9.0"
+// 输入:
+//   - s: 版本号，如 "2.9.0"、"1.0.0-rc.1"
+//
+// 输出:
+//   - Version: 解析后的版本号
+//   - error: 格式错误
+func ParseVersion(s string) (Version, error) {
+	text := strings.TrimSpace(s)
+	if i := strings.IndexByte(text, '+'); i >= 0 {
+		text = text[:i]
+	}
+
+	var v Version
+	if i := strings.IndexByte(text, '-'); i >= 0 {
+		v.Pre = text[i+1:]
+		text = text[:i]
+		if v.Pre == "" {
+			return Version{}, fmt.Errorf("invalid version: %q", s)
+		}
+	}
+
+	parts := strings.Split(text, ".")
+	if len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid version: %q", s)
+	}
+	fields := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version: %q", s)
+		}
+		*fields[i] = n
+	}
+	return v, nil
+}
+
+// String 返回版本号的文本形式
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	return s
+}
+
+// Compare 比较两个版本号
+// @pkg 预发布版本低于对应的正式版本，预发布标识按语义化版本规则逐段比较
+// 输出:
+//   - int: v 小于、等于、大于 other 时分别返回 -1、0、1
+func (v Version) Compare(other Version) int {
+	for _, d := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+
+	switch {
+	case v.Pre == other.Pre:
+		return 0
+	case v.Pre == "":
+		return 1
+	case other.Pre == "":
+		return -1
+	}
+
+	a, b := strings.Split(v.Pre, "."), strings.Split(other.Pre, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+		x, errX := strconv.Atoi(a[i])
+		y, errY := strconv.Atoi(b[i])
+		switch {
+		case errX == nil && errY == nil:
+			return sign(x - y)
+		case errX == nil:
+			return -1
+		case errY == nil:
+			return 1
+		case a[i] < b[i]:
+			return -1
+		default:
+			return 1
+		}
+	}
+	return sign(len(a) - len(b))
+}
+
+// sign 返回整数的符号
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// Requirement 表示 hex 依赖的版本要求
+// @pkg 语法与 hex 一致:
+// - "2.9.0" 或 "== 2.9.0": 精确版本
+// - ">= 1.0.0"、"> 1.0.0"、"<= 2.0.0"、"< 2.0.0"、"!= 1.5.0": 比较
+// - "~> 2.9": 大于等于 2.9.0 且小于 3.0.0；"~> 2.9.1": 大于等于 2.9.1 且小于 2.10.0
+// - 用 and、or 组合多个条件，and 的优先级高于 or
+type Requirement struct {
+	text string
+	// alternatives 是用 or 连接的条件组，每组内的条件用 and 连接
+	alternatives [][]versionCondition
+}
+
+// versionCondition 是单个比较条件
+type versionCondition struct {
+	op      string
+	version Version
+}
+
+// ParseRequirement 解析版本要求
+// 输入:
+//   - s: 版本要求，如 "~> 2.9"、">= 1.0.0 and < 2.0.0"
+//
+// 输出:
+//   - Requirement: 解析后的版本要求
+//   - error: 格式错误
+func ParseRequirement(s string) (Requirement, error) {
+	req := Requirement{text: strings.TrimSpace(s)}
+	for _, alternative := range strings.Split(req.text, " or ") {
+		var conditions []versionCondition
+		for _, part := range strings.Split(alternative, " and ") {
+			condition, err := parseCondition(part)
+			if err != nil {
+				return Requirement{}, fmt.Errorf("invalid version requirement %q: %w", s, err)
+			}
+			conditions = append(conditions, condition...)
+		}
+		req.alternatives = append(req.alternatives, conditions)
+	}
+	return req, nil
+}
+
+// parseCondition 解析单个条件，~> 被展开为上下界两个条件
+func parseCondition(s string) ([]versionCondition, error) {
+	text := strings.TrimSpace(s)
+	op := "=="
+	for _, candidate := range []string{"~>", ">=", "<=", "==", "!=", ">", "<"} {
+		if strings.HasPrefix(text, candidate) {
+			op = candidate
+			text = strings.TrimSpace(text[len(candidate):])
+			break
+		}
+	}
+
+	v, err := ParseVersion(text)
+	if err != nil {
+		return nil, err
+	}
+	if op != "~>" {
+		return []versionCondition{{op: op, version: v}}, nil
+	}
+
+	upper := Version{Major: v.Major + 1}
+	if strings.Count(strings.SplitN(text, "-", 2)[0], ".") >= 2 {
+		upper = Version{Major: v.Major, Minor: v.Minor + 1}
+	}
+	// 与 hex 一致，上界排除下一个版本的预发布版本
+	upper.Pre = "0"
+	return []versionCondition{{op: ">=", version: v}, {op: "<", version: upper}}, nil
+}
+
+// String 返回版本要求的原始文本
+func (r Requirement) String() string {
+	return r.text
+}
+
+// Match 判断版本是否满足要求
+// @pkg 与 hex 一致，除非要求本身包含预发布版本，否则预发布版本不满足要求
+func (r Requirement) Match(v Version) bool {
+	for _, conditions := range r.alternatives {
+		matched := true
+		allowPre := v.Pre == ""
+		for _, c := range conditions {
+			if c.version.Pre != "" && c.op != "<" {
+				allowPre = true
+			}
+			if !c.match(v) {
+				matched = false
+				break
+			}
+		}
+		if matched && allowPre {
+			return true
+		}
+	}
+	return false
+}
+
+// match 判断版本是否满足单个条件
+func (c versionCondition) match(v Version) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
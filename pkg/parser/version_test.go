@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"testing"
+)
+
+// TestParseVersion tests parsing and ordering of versions
+func TestParseVersion(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.2", "2.0.0+build.5"}
+	for i := 0; i+1 < len(ordered); i++ {
+		a, err := ParseVersion(ordered[i])
+		if err != nil {
+			t.Fatalf("Failed to parse version %s: %v", ordered[i], err)
+		}
+		b, err := ParseVersion(ordered[i+1])
+		if err != nil {
+			t.Fatalf("Failed to parse version %s: %v", ordered[i+1], err)
+		}
+		if a.Compare(b) != -1 || b.Compare(a) != 1 || a.Compare(a) != 0 {
+			t.Errorf("Expected %s < %s", a, b)
+		}
+	}
+
+	if v, _ := ParseVersion("2.9"); v.String() != "2.9.0" {
+		t.Errorf("Expected 2.9.0, got %s", v)
+	}
+	for _, invalid := range []string{"", "a.b.c", "1.2.3.4", "1.0.0-", "-1.0.0"} {
+		if _, err := ParseVersion(invalid); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+}
+
+// TestRequirementMatch tests hex version requirement matching
+func TestRequirementMatch(t *testing.T) {
+	tests := []struct {
+		requirement string
+		version     string
+		match       bool
+	}{
+		{"2.9.0", "2.9.0", true},
+		{"2.9.0", "2.9.1", false},
+		{"== 2.9.0", "2.9.0", true},
+		{"~> 2.9", "2.12.3", true},
+		{"~> 2.9", "3.0.0", false},
+		{"~> 2.9", "3.0.0-rc.1", false},
+		{"~> 2.9.1", "2.9.5", true},
+		{"~> 2.9.1", "2.10.0", false},
+		{"~> 2.9.1", "2.9.0", false},
+		{">= 1.0.0 and < 2.0.0", "1.5.0", true},
+		{">= 1.0.0 and < 2.0.0", "2.0.0", false},
+		{"< 1.0.0 or >= 2.0.0", "2.1.0", true},
+		{"< 1.0.0 or >= 2.0.0", "1.1.0", false},
+		{"!= 1.5.0", "1.5.0", false},
+		{"> 1.0.0", "1.1.0-rc.1", false},
+		{">= 1.1.0-rc.0", "1.1.0-rc.1", true},
+	}
+
+	for _, tt := range tests {
+		req, err := ParseRequirement(tt.requirement)
+		if err != nil {
+			t.Fatalf("Failed to parse requirement %q: %v", tt.requirement, err)
+		}
+		v, err := ParseVersion(tt.version)
+		if err != nil {
+			t.Fatalf("Failed to parse version %q: %v", tt.version, err)
+		}
+		if got := req.Match(v); got != tt.match {
+			t.Errorf("%q matching %s: expected %v, got %v", tt.requirement, tt.version, tt.match, got)
+		}
+	}
+
+	if _, err := ParseRequirement("~> two"); err == nil {
+		t.Error("Expected error for invalid requirement")
+	}
+}
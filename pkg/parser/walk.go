@@ -0,0 +1,68 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+// Walk 深度优先遍历项的所有节点，并用回调的返回值替换节点
+// @pkg 遍历是后序的：先处理元组、列表和映射（包括映射的键）中的子节点，再以处理后的子节点构造父节点并交给 fn，
+// 因此 fn 的返回值不会再被遍历。fn 返回参数本身或 nil 时节点保持不变；
+// 元组、列表和映射总是被重新构造，原有的项不会被修改
+// 输入:
+//   - term: 要遍历的项
+//   - fn: 对每个节点调用的回调，返回替换后的节点
+//
+// 输出:
+//   - Term: 替换后的项
+//
+// 示例:
+//
+//	// 把所有 scp 形式的 git 地址改为 https 形式
+//	updated := parser.Walk(term, func(t parser.Term) parser.Term {
+//	  if s, ok := t.(parser.String); ok && strings.HasPrefix(s.Value, "git@") {
+//	    return parser.NewString(parser.HTTPSGitURL(s.Value))
+//	  }
+//	  return t
+//	})
+func Walk(term Term, fn func(Term) Term) Term {
+	switch t := term.(type) {
+	case Tuple:
+		term = Tuple{Elements: walkElements(t.Elements, fn)}
+	case List:
+		term = List{Elements: walkElements(t.Elements, fn)}
+	case Map:
+		pairs := make([]MapPair, len(t.Pairs))
+		for i, pair := range t.Pairs {
+			pairs[i] = MapPair{Key: Walk(pair.Key, fn), Value: Walk(pair.Value, fn)}
+		}
+		term = Map{Pairs: pairs}
+	}
+	if replaced := fn(term); replaced != nil {
+		return replaced
+	}
+	return term
+}
+
+// Walk 遍历配置中的所有顶级项，并用回调的返回值替换节点，规则与 Walk 相同
+// @pkg 处理后的顶级项写回 Terms；Raw 保持不变
+// 输入:
+//   - fn: 对每个节点调用的回调，返回替换后的节点
+//
+// 示例:
+//
+//	config.Walk(func(t parser.Term) parser.Term {
+//	  if atom, ok := t.(parser.Atom); ok && atom.Value == "warnings_as_errors" {
+//	    return parser.NewAtom("warn_export_all")
+//	  }
+//	  return t
+//	})
+func (c *RebarConfig) Walk(fn func(Term) Term) {
+	c.Terms = walkElements(c.Terms, fn)
+}
+
+// walkElements 遍历一组元素，返回处理后的新切片
+func walkElements(elements []Term, fn func(Term) Term) []Term {
+	result := make([]Term, len(elements))
+	for i, elem := range elements {
+		result[i] = Walk(elem, fn)
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestWalk tests post-order traversal and replacement
+func TestWalk(t *testing.T) {
+	term := MustParseTerm(`{deps, [{a, {git, "git@github.com:u/a.git", {tag, "1.0"}}}, #{url => "git@github.com:u/b.git"}]}`)
+	original := term.String()
+
+	var visited []string
+	updated := Walk(term, func(t Term) Term {
+		visited = append(visited, t.String())
+		if s, ok := t.(String); ok && strings.HasPrefix(s.Value, "git@") {
+			return NewString(HTTPSGitURL(s.Value))
+		}
+		return t
+	})
+
+	expected := `{deps, [{a, {git, "https://github.com/u/a.git", {tag, "1.0"}}}, #{url => "https://github.com/u/b.git"}]}`
+	if updated.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, updated)
+	}
+	if term.String() != original {
+		t.Errorf("Expected original term to be unchanged, got %s", term)
+	}
+
+	if visited[0] != "deps" || visited[len(visited)-1] != expected {
+		t.Errorf("Expected post-order traversal, got %v", visited)
+	}
+	if len(visited) != 14 {
+		t.Errorf("Expected 14 visited nodes, got %d: %v", len(visited), visited)
+	}
+}
+
+// TestWalkReplacementNotRevisited tests that replacements are not walked again
+func TestWalkReplacementNotRevisited(t *testing.T) {
+	calls := 0
+	updated := Walk(MustParseTerm(`[a]`), func(t Term) Term {
+		calls++
+		if atom, ok := t.(Atom); ok {
+			return NewList(atom, atom)
+		}
+		if _, ok := t.(List); ok {
+			return nil
+		}
+		return t
+	})
+	if updated.String() != "[[a, a]]" || calls != 2 {
+		t.Errorf("Unexpected result %s after %d calls", updated, calls)
+	}
+}
+
+// TestConfigWalk tests rewriting every top-level term
+func TestConfigWalk(t *testing.T) {
+	config, err := Parse(`{erl_opts, [debug_info, warnings_as_errors]}.
+{profiles, [{test, [{erl_opts, [warnings_as_errors]}]}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	config.Walk(func(t Term) Term {
+		if atom, ok := t.(Atom); ok && atom.Value == "warnings_as_errors" {
+			return NewAtom("warn_export_all")
+		}
+		return t
+	})
+
+	expected, _ := Parse(`{erl_opts, [debug_info, warn_export_all]}.
+{profiles, [{test, [{erl_opts, [warn_export_all]}]}]}.`)
+	if !config.Equal(expected) {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected.Format(4), config.Format(4))
+	}
+}
@@ -0,0 +1,171 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"crypto/sha256"
+	"os"
+	"sync"
+	"time"
+)
+
+// defaultWatchInterval 是 Watch 默认的检查间隔
+const defaultWatchInterval = 500 * time.Millisecond
+
+// WithWatchInterval 设置 Watch 检查文件变化的间隔
+// @pkg 文件变化后需要保持一个间隔不再变化才会重新解析，因此该间隔同时也是防抖时间
+// 输入:
+//   - interval: 检查间隔，不大于 0 时使用默认的 500ms
+func WithWatchInterval(interval time.Duration) ParseOption {
+	return func(o *parseOptions) {
+		o.watchInterval = interval
+	}
+}
+
+// Watcher 监视配置文件，在文件变化后重新解析
+// @pkg 由 Watch 创建，调用 Close 停止监视
+type Watcher struct {
+	path     string
+	opts     []ParseOption
+	fn       func(*RebarConfig, error)
+	interval time.Duration
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// Watch 监视配置文件并在变化时重新解析
+// @pkg 适用于需要与磁盘上的配置保持同步的长期运行的工具，如仪表盘或语言服务器
+// 每个检查间隔比较文件的修改时间和大小，只有两者变化时才重新读取文件并计算内容的 SHA-256，内容不变（如只 touch）时不会重新解析。
+// 这里有意使用轮询而不是 fsnotify 等文件通知机制：本模块不引入任何第三方依赖，且轮询在网络文件系统和容器挂载目录中同样可靠；
+// 代价是变化最多延迟两个检查间隔才被发现。
+// 同名的 .script 文件也会被监视。连续的多次写入只触发一次重新解析。
+// 回调在独立的 goroutine 中依次调用，启动时会先以当前内容调用一次；
+// 文件被删除或解析失败时，回调收到 nil 配置和对应的错误
+// 输入:
+//   - path: 文件路径
+//   - fn: 每次解析后调用的回调
+//   - opts: 传给 ParseFile 的选项，以及 WithWatchInterval
+//
+// 输出:
+//   - *Watcher: 监视器，使用完毕后调用 Close
+//   - error: 目前总是返回 nil，保留以便将来报告初始化错误
+//
+// 示例:
+//
+//	w, err := parser.Watch("./rebar.config", func(config *parser.RebarConfig, err error) {
+//	  if err != nil {
+//	    log.Println("配置无效:", err)
+//	    return
+//	  }
+//	  deps, _ := config.GetDeps()
+//	  log.Println("依赖已更新:", deps)
+//	})
+//	if err != nil {
+//	  log.Fatal(err)
+//	}
+//	defer w.Close()
+func Watch(path string, fn func(*RebarConfig, error), opts ...ParseOption) (*Watcher, error) {
+	o := newParseOptions(opts)
+	w := &Watcher{
+		path:     path,
+		opts:     opts,
+		fn:       fn,
+		interval: o.watchInterval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if w.interval <= 0 {
+		w.interval = defaultWatchInterval
+	}
+
+	go w.run()
+	return w, nil
+}
+
+// Close 停止监视并等待正在执行的回调返回
+// @pkg 不能在回调中调用，否则会一直等待
+func (w *Watcher) Close() error {
+	w.once.Do(func() {
+		close(w.stop)
+	})
+	<-w.done
+	return nil
+}
+
+// run 是监视循环
+func (w *Watcher) run() {
+	defer close(w.done)
+
+	last := w.snapshot(watchState{})
+	w.fn(ParseFile(w.path, w.opts...))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	pending := false
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+
+		current := w.snapshot(last)
+		changed := !current.sameContent(last)
+		last = current
+		switch {
+		case changed:
+			// 文件仍在变化，等待其稳定
+			pending = true
+		case pending:
+			pending = false
+			w.fn(ParseFile(w.path, w.opts...))
+		}
+	}
+}
+
+// fileState 记录文件用于检测变化的属性
+// @pkg 修改时间和大小用于判断是否需要重新计算哈希，是否变化只看文件是否存在和内容的哈希
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime int64
+	hash    [sha256.Size]byte
+}
+
+// watchState 记录配置文件及其 .script 文件的状态
+type watchState struct {
+	config fileState
+	script fileState
+}
+
+// sameContent 检查两个状态中文件的存在性和内容是否相同
+func (s watchState) sameContent(other watchState) bool {
+	return s.config.exists == other.config.exists && s.config.hash == other.config.hash &&
+		s.script.exists == other.script.exists && s.script.hash == other.script.hash
+}
+
+// snapshot 返回被监视文件的当前状态，修改时间和大小与 prev 相同的文件沿用 prev 中的哈希
+func (w *Watcher) snapshot(prev watchState) watchState {
+	return watchState{config: statFile(w.path, prev.config), script: statFile(w.path+".script", prev.script)}
+}
+
+// statFile 返回文件的状态，文件不存在时 exists 为 false
+// @pkg 修改时间和大小与 prev 相同时不读取文件，沿用 prev 的哈希
+func statFile(path string, prev fileState) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+	state := fileState{exists: true, size: info.Size(), modTime: info.ModTime().UnixNano()}
+	if prev.exists && prev.size == state.size && prev.modTime == state.modTime {
+		state.hash = prev.hash
+		return state
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		state.hash = sha256.Sum256(data)
+	}
+	return state
+}
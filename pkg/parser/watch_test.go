@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestWatch tests that Watch reports the initial config and re-parses after changes
+func TestWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rebar.config")
+	if err := os.WriteFile(path, []byte(`{deps, []}.`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	type result struct {
+		config *RebarConfig
+		err    error
+	}
+	results := make(chan result, 10)
+	w, err := Watch(path, func(config *RebarConfig, err error) {
+		results <- result{config, err}
+	}, WithWatchInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Failed to watch config: %v", err)
+	}
+	defer w.Close()
+
+	next := func() result {
+		t.Helper()
+		select {
+		case r := <-results:
+			return r
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for watch callback")
+			return result{}
+		}
+	}
+
+	if r := next(); r.err != nil || len(r.config.Terms) != 1 {
+		t.Fatalf("Unexpected initial result: %+v", r)
+	}
+
+	if err := os.WriteFile(path, []byte(`{deps, [cowboy]}. {erl_opts, []}.`), 0644); err != nil {
+		t.Fatalf("Failed to update config: %v", err)
+	}
+	if r := next(); r.err != nil || len(r.config.Terms) != 2 {
+		t.Fatalf("Unexpected result after update: %+v", r)
+	}
+
+	// 只修改时间变化而内容不变时不重新解析
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("Failed to touch config: %v", err)
+	}
+	select {
+	case r := <-results:
+		t.Fatalf("Unexpected callback after touch: %+v", r)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// 大小不变的写入在修改时间变化后通过内容哈希发现
+	if err := os.WriteFile(path, []byte(`{deps, [cowlib]}. {erl_opts, []}.`), 0644); err != nil {
+		t.Fatalf("Failed to update config: %v", err)
+	}
+	if r := next(); r.err != nil || r.config.Terms[0].String() != "{deps, [cowlib]}" {
+		t.Fatalf("Unexpected result after same-size update: %+v", r)
+	}
+
+	if err := os.WriteFile(path, []byte(`{deps, [}.`), 0644); err != nil {
+		t.Fatalf("Failed to update config: %v", err)
+	}
+	if r := next(); r.err == nil {
+		t.Fatal("Expected a parse error after writing an invalid config")
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("Failed to remove config: %v", err)
+	}
+	if r := next(); r.err == nil {
+		t.Fatal("Expected an error after removing the config")
+	}
+
+	w.Close()
+	select {
+	case r := <-results:
+		t.Errorf("Unexpected callback after Close: %+v", r)
+	default:
+	}
+}
@@ -0,0 +1,66 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteFile 按选项格式化配置并原子地写入文件
+// @pkg 内容先写入同一目录下的临时文件并同步到磁盘，再重命名为目标文件，
+// 因此写入过程中崩溃或出错时原文件保持不变，不会留下只写了一半的 rebar.config。
+// 目标文件已存在时沿用其权限；目标是符号链接时替换链接指向的文件
+// 输入:
+//   - path: 文件路径，如 "./rebar.config"
+//   - opts: 格式化选项，与 FormatWith 相同
+//
+// 输出:
+//   - error: 写入失败时返回错误
+//
+// 示例:
+//
+//	config, _ := parser.ParseFile("./rebar.config")
+//	// ... 修改 config.Terms ...
+//	err := config.WriteFile("./rebar.config", parser.FormatOptions{Indent: 4})
+func (c *RebarConfig) WriteFile(path string, opts FormatOptions) error {
+	return writeFileAtomic(path, []byte(c.FormatWith(opts)))
+}
+
+// writeFileAtomic 通过临时文件和重命名原子地替换文件内容
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	// 成功重命名后临时文件已不存在，Remove 只在出错时生效
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// TestWriteFile tests atomic writes, mode preservation and error handling
+func TestWriteFile(t *testing.T) {
+	config, err := Parse(`{deps,[{cowboy,"2.9.0"}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rebar.config")
+	if err := config.WriteFile(path, FormatOptions{Indent: 4}); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if string(data) != config.Format(4) {
+		t.Errorf("Expected formatted content, got:\n%s", data)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0644 {
+		t.Errorf("Expected new file mode 0644, got %v, %v", info, err)
+	}
+
+	if runtime.GOOS != "windows" {
+		if err := os.Chmod(path, 0600); err != nil {
+			t.Fatalf("Failed to chmod file: %v", err)
+		}
+		if err := config.WriteFile(path, FormatOptions{}); err != nil {
+			t.Fatalf("Failed to rewrite config: %v", err)
+		}
+		if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+			t.Errorf("Expected mode 0600 to be preserved, got %v, %v", info, err)
+		}
+
+		link := filepath.Join(dir, "link.config")
+		if err := os.Symlink(path, link); err != nil {
+			t.Fatalf("Failed to create symlink: %v", err)
+		}
+		if err := config.WriteFile(link, FormatOptions{Indent: 2}); err != nil {
+			t.Fatalf("Failed to write through symlink: %v", err)
+		}
+		if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+			t.Errorf("Expected symlink to be kept, got %v, %v", info, err)
+		}
+		if data, _ := os.ReadFile(path); string(data) != config.Format(2) {
+			t.Errorf("Expected link target to be rewritten, got:\n%s", data)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read dir: %v", err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("Expected no temporary files left, found %s", entry.Name())
+		}
+	}
+
+	if err := config.WriteFile(filepath.Join(dir, "missing", "rebar.config"), FormatOptions{}); err == nil {
+		t.Error("Expected error writing to a missing directory")
+	}
+}
@@ -0,0 +1,204 @@
+// Package parser 提供解析 Erlang rebar 配置文件的功能。
+// @pkg 该包用于解析 Erlang 的 rebar.config 配置文件，将其转换为 Go 的数据结构，方便 Go 程序操作和使用这些配置。
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// yamlFlowWidth 是单行（flow 风格）序列的最大长度，更长的序列使用块风格
+const yamlFlowWidth = 60
+
+// yamlPlain 匹配可以不加引号输出的原子
+var yamlPlain = regexp.MustCompile(`^[a-z][a-zA-Z0-9_@]*$`)
+
+// yamlReserved 是 YAML 会解析为布尔值或空值的单词，作为原子输出时需要加引号
+var yamlReserved = map[string]bool{
+	"null": true, "yes": true, "no": true, "on": true, "off": true, "y": true, "n": true,
+}
+
+// ToYAML 将配置导出为 YAML 文档
+// @pkg 用于生成文档或便于 diff 的快照，转换是单向的，不保留元组与列表的区别。约定:
+// - 顶层的 {Key, Value} 项成为映射的键值对，{Key, V1, V2, ...} 的值为序列；
+// 顶层存在其他形式的项或重复的键时，整个文档为所有项组成的序列
+// - 原子输出为普通标量，不能安全地作为普通标量的原子（如 'my-dep'、yes）使用单引号；
+// true 和 false 原子即 YAML 布尔值
+// - 字符串和二进制总是使用双引号，因此 "2.9.0" 与原子 debug_info 可以区分
+// - 整数和浮点数输出为数字，浮点数总是带小数点或指数
+// - 由 {Key, Value} 元组和原子组成、键不重复的属性列表（如 deps）输出为映射，单独的原子 Key 对应值 true；
+// 其他列表和元组输出为序列，较短的序列使用 [a, b] 形式
+// - Erlang 映射输出为 YAML 映射
+//
+// 输出:
+//   - string: YAML 文档
+//
+// 示例:
+//
+//	config, _ := parser.Parse(`{deps, [recon, {cowboy, "2.9.0"}]}.`)
+//	fmt.Print(config.ToYAML())
+//
+// 数据样例:
+// 输入配置:
+//
+//	{erl_opts, [debug_info]}.
+//	{deps, [{cowboy, "2.9.0"}, {'my-dep', {git, "https://example.com/my-dep.git", {tag, "1.0"}}}]}.
+//
+// 输出:
+//
+//	erl_opts: [debug_info]
+//	deps:
+//	  cowboy: "2.9.0"
+//	  'my-dep': [git, "https://example.com/my-dep.git", [tag, "1.0"]]
+func (c *RebarConfig) ToYAML() string {
+	var lines []string
+	if keys, values, ok := configMapping(c.Terms); ok {
+		lines = yamlMapping(keys, values)
+	} else {
+		lines = yamlSequence(c.Terms)
+	}
+	if len(lines) == 0 {
+		return "{}\n"
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// configMapping 将顶层项拆分为键和值，无法表示为映射时返回 false
+func configMapping(terms []Term) ([]string, []Term, bool) {
+	keys := make([]string, 0, len(terms))
+	values := make([]Term, 0, len(terms))
+	seen := make(map[string]bool)
+	for _, term := range terms {
+		tuple, ok := term.(Tuple)
+		if !ok || len(tuple.Elements) < 2 {
+			return nil, nil, false
+		}
+		key, ok := tuple.Elements[0].(Atom)
+		if !ok || seen[key.Value] {
+			return nil, nil, false
+		}
+		seen[key.Value] = true
+
+		keys = append(keys, yamlAtom(key.Value))
+		if len(tuple.Elements) == 2 {
+			values = append(values, tuple.Elements[1])
+		} else {
+			values = append(values, List{Elements: tuple.Elements[1:]})
+		}
+	}
+	return keys, values, true
+}
+
+// yamlNode 将项转换为 YAML
+// @pkg 可以写在一行内的项返回单行文本，否则返回块风格的多行文本（不含外层缩进）
+func yamlNode(term Term) (string, []string) {
+	switch t := term.(type) {
+	case Atom:
+		return yamlAtom(t.Value), nil
+	case String:
+		return strconv.Quote(t.Value), nil
+	case Binary:
+		return strconv.Quote(t.Value), nil
+	case Integer:
+		return strconv.FormatInt(t.Value, 10), nil
+	case Float:
+		text := strconv.FormatFloat(t.Value, 'g', -1, 64)
+		if !strings.ContainsAny(text, ".e") {
+			text += ".0"
+		}
+		return text, nil
+	case Tuple:
+		return yamlElements(t.Elements)
+	case List:
+		if keys, values, ok := proplistPairs(t.Elements); ok {
+			for i, key := range keys {
+				keys[i] = yamlAtom(key)
+			}
+			return "", yamlMapping(keys, values)
+		}
+		return yamlElements(t.Elements)
+	case Map:
+		if len(t.Pairs) == 0 {
+			return "{}", nil
+		}
+		keys := make([]string, len(t.Pairs))
+		values := make([]Term, len(t.Pairs))
+		for i, pair := range t.Pairs {
+			if key, ok := pair.Key.(Atom); ok {
+				keys[i] = yamlAtom(key.Value)
+			} else if inline, lines := yamlNode(pair.Key); lines == nil {
+				keys[i] = inline
+			} else {
+				keys[i] = strconv.Quote(pair.Key.String())
+			}
+			values[i] = pair.Value
+		}
+		return "", yamlMapping(keys, values)
+	}
+	return strconv.Quote(term.String()), nil
+}
+
+// yamlElements 将元组或列表的元素转换为序列，较短时使用单行形式
+func yamlElements(elements []Term) (string, []string) {
+	if len(elements) == 0 {
+		return "[]", nil
+	}
+
+	items := make([]string, 0, len(elements))
+	width := 0
+	for _, elem := range elements {
+		inline, lines := yamlNode(elem)
+		if lines != nil {
+			return "", yamlSequence(elements)
+		}
+		items = append(items, inline)
+		width += len(inline) + 2
+	}
+	if width > yamlFlowWidth {
+		return "", yamlSequence(elements)
+	}
+	return "[" + strings.Join(items, ", ") + "]", nil
+}
+
+// yamlSequence 返回块风格的序列
+func yamlSequence(elements []Term) []string {
+	var out []string
+	for _, elem := range elements {
+		inline, lines := yamlNode(elem)
+		if lines == nil {
+			out = append(out, "- "+inline)
+			continue
+		}
+		out = append(out, "- "+lines[0])
+		for _, line := range lines[1:] {
+			out = append(out, "  "+line)
+		}
+	}
+	return out
+}
+
+// yamlMapping 返回块风格的映射
+func yamlMapping(keys []string, values []Term) []string {
+	var out []string
+	for i, key := range keys {
+		inline, lines := yamlNode(values[i])
+		if lines == nil {
+			out = append(out, key+": "+inline)
+			continue
+		}
+		out = append(out, key+":")
+		for _, line := range lines {
+			out = append(out, "  "+line)
+		}
+	}
+	return out
+}
+
+// yamlAtom 返回原子的 YAML 标量，必要时使用单引号
+func yamlAtom(value string) string {
+	if yamlPlain.MatchString(value) && !yamlReserved[value] {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"testing"
+)
+
+// TestToYAML tests YAML export of parsed configs
+func TestToYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name: "Proplists become mappings",
+			input: `{erl_opts, [debug_info, warnings_as_errors]}.
+{deps, [recon, {cowboy, "2.9.0"}, {'my-dep', {git, "https://example.com/my-dep.git", {tag, "1.0"}}}]}.`,
+			expected: `erl_opts: [debug_info, warnings_as_errors]
+deps:
+  recon: true
+  cowboy: "2.9.0"
+  'my-dep': [git, "https://example.com/my-dep.git", [tag, "1.0"]]
+`,
+		},
+		{
+			name: "Nested structures and scalars",
+			input: `{relx, [{release, {my_app, "0.1.0"}, [my_app, sasl]}, {dev_mode, true}]}.
+{overrides, [{override, jsx, [{erl_opts, [{d, 'NOERL'}]}]}]}.
+{limits, #{size => 1, ratio => 2.0, <<"key">> => <<"v">>}}.
+{yes, 'yes'}.`,
+			expected: `relx:
+  - [release, [my_app, "0.1.0"], [my_app, sasl]]
+  - [dev_mode, true]
+overrides:
+  - - override
+    - jsx
+    - erl_opts:
+        d: 'NOERL'
+limits:
+  size: 1
+  ratio: 2.0
+  "key": "v"
+'yes': 'yes'
+`,
+		},
+		{
+			name:  "Duplicate keys fall back to a sequence",
+			input: `{pre_hooks, []}. {pre_hooks, [{compile, "make"}]}.`,
+			expected: `- [pre_hooks, []]
+- - pre_hooks
+  - compile: "make"
+`,
+		},
+		{
+			name:     "Empty config",
+			input:    ``,
+			expected: "{}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to parse config: %v", err)
+			}
+			if got := config.ToYAML(); got != tt.expected {
+				t.Errorf("Expected:\n%s\nGot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
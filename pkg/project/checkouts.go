@@ -0,0 +1,113 @@
+package project
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// Dep 表示项目中声明的一个依赖
+type Dep struct {
+	// Name 是依赖的应用名称
+	Name string
+	// Term 是配置中声明该依赖的原始项
+	Term parser.Term
+	// App 是声明该依赖的应用名称，为空表示在顶层 rebar.config 中声明
+	App string
+	// Checkout 是 _checkouts 目录下的本地副本，未被覆盖时为 nil
+	// @pkg rebar3 会优先使用 _checkouts/<dep> 中的代码，忽略配置中声明的来源
+	Checkout *App
+}
+
+// CheckedOut 判断依赖是否被 _checkouts 中的本地副本覆盖
+func (d Dep) CheckedOut() bool {
+	return d.Checkout != nil
+}
+
+// Deps 返回项目中声明的所有依赖
+// @pkg 先列出顶层 rebar.config 中的依赖，再按应用顺序列出各应用配置中的依赖，
+// 同名依赖只保留首次出现的声明。_checkouts 中存在同名应用的依赖会填充 Checkout
+// 输出:
+//   - []Dep: 依赖列表
+//
+// 示例:
+//
+//	for _, dep := range proj.Deps() {
+//	  if dep.CheckedOut() {
+//	    fmt.Println(dep.Name, "使用本地副本", dep.Checkout.Dir)
+//	  }
+//	}
+func (p *Project) Deps() []Dep {
+	var deps []Dep
+	seen := make(map[string]bool)
+	add := func(config *parser.RebarConfig, app string) {
+		if config == nil {
+			return
+		}
+		elements, ok := config.GetDeps()
+		if !ok {
+			return
+		}
+		list, ok := elements[0].(parser.List)
+		if !ok {
+			return
+		}
+		for _, term := range list.Elements {
+			declared, ok := parser.AsDependency(term)
+			name := declared.Name
+			if !ok || seen[name] {
+				continue
+			}
+			seen[name] = true
+			dep := Dep{Name: name, Term: term, App: app}
+			dep.Checkout, _ = p.Checkout(name)
+			deps = append(deps, dep)
+		}
+	}
+
+	add(p.Config, "")
+	for _, app := range p.Apps {
+		if app.Dir != p.Root {
+			add(app.Config, app.Name)
+		}
+	}
+	return deps
+}
+
+// Checkout 按名称查找 _checkouts 中的本地副本
+// 输入:
+//   - name: 应用名称
+//
+// 输出:
+//   - *App: 本地副本
+//   - bool: 是否找到
+func (p *Project) Checkout(name string) (*App, bool) {
+	for _, app := range p.Checkouts {
+		if app.Name == name {
+			return app, true
+		}
+	}
+	return nil, false
+}
+
+// loadCheckouts 加载 root/_checkouts 下的所有应用
+func loadCheckouts(root string, opts []parser.ParseOption) ([]*App, error) {
+	dirs, err := filepath.Glob(filepath.Join(root, "_checkouts", "*"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(dirs)
+
+	var apps []*App
+	for _, dir := range dirs {
+		app, err := loadApp(dir, opts)
+		if err != nil {
+			return nil, err
+		}
+		if app != nil {
+			apps = append(apps, app)
+		}
+	}
+	return apps, nil
+}
@@ -0,0 +1,55 @@
+package project
+
+import (
+	"testing"
+)
+
+// TestCheckouts tests that deps overridden by _checkouts are marked as checked out
+func TestCheckouts(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"rebar.config":                       `{deps, [{cowboy, "2.9.0"}, {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}}]}.`,
+		"apps/web/src/web.app.src":           appSrc("web"),
+		"apps/web/rebar.config":              `{deps, [jsx, cowboy]}.`,
+		"_checkouts/lager/src/lager.app.src": appSrc("lager"),
+		"_checkouts/lager/rebar.config":      `{erl_opts, [debug_info]}.`,
+		"_checkouts/notes/README.md":         "not an app",
+	})
+
+	proj, err := Load(root)
+	if err != nil {
+		t.Fatalf("Failed to load project: %v", err)
+	}
+	if len(proj.Checkouts) != 1 || proj.Checkouts[0].Name != "lager" {
+		t.Fatalf("Unexpected checkouts: %+v", proj.Checkouts)
+	}
+
+	deps := proj.Deps()
+	if len(deps) != 3 {
+		t.Fatalf("Expected 3 deps, got %d: %+v", len(deps), deps)
+	}
+
+	expected := []struct {
+		name       string
+		app        string
+		checkedOut bool
+	}{
+		{"cowboy", "", false},
+		{"lager", "", true},
+		{"jsx", "web", false},
+	}
+	for i, exp := range expected {
+		dep := deps[i]
+		if dep.Name != exp.name || dep.App != exp.app || dep.CheckedOut() != exp.checkedOut {
+			t.Errorf("Dep %d: expected %+v, got %+v", i, exp, dep)
+		}
+	}
+
+	lager := deps[1].Checkout
+	if lager.Config == nil {
+		t.Fatal("Expected the checkout's local config")
+	}
+	if _, ok := lager.Config.GetErlOpts(); !ok {
+		t.Error("Expected erl_opts in the checkout's config")
+	}
+}
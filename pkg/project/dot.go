@@ -0,0 +1,130 @@
+package project
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// depStyles 是各来源类别的依赖节点样式
+var depStyles = map[string]string{
+	"hex":   `color="#6e4a7e"`,
+	"git":   `color="#f05033", style=dashed`,
+	"hg":    `color="#999999", style=dashed`,
+	"path":  `color="#999999", style=dotted`,
+	"other": `color="#999999", style=dotted`,
+}
+
+// DOT 返回项目结构的 Graphviz DOT 描述
+// @pkg 应用是方框节点，依赖是椭圆节点：hex 依赖为实线紫色，git 依赖为虚线橙色，其他来源为灰色；
+// 被 _checkouts 覆盖的依赖加粗显示。边表示声明关系:
+// - 顶层 rebar.config 的依赖从根目录下的应用连出；根目录不是应用时（如 umbrella 项目）从项目节点（文件夹形状）连出
+// - umbrella 项目的应用位于 apps 子图中
+// - 应用自己的 rebar.config 中的依赖从该应用连出
+// - 应用的 .app.src 中 applications 引用项目内的其他应用时，两个应用之间有一条粗线
+//
+// 输出:
+//   - string: DOT 文本，可用 dot -Tsvg 渲染
+//
+// 示例:
+//
+//	proj, _ := project.Load(".")
+//	os.WriteFile("deps.dot", []byte(proj.DOT()), 0644)
+//	// dot -Tsvg deps.dot -o deps.svg
+func (p *Project) DOT() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", dotID(filepath.Base(p.Root)))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [fontname=\"Helvetica\"];\n")
+
+	umbrella := p.IsUmbrella()
+	rootID := dotID("project")
+	for _, app := range p.Apps {
+		if app.Dir == p.Root {
+			rootID = dotID("app:" + app.Name)
+		}
+	}
+	if rootID == dotID("project") {
+		fmt.Fprintf(&b, "  %s [label=%s, shape=folder];\n", rootID, dotID(filepath.Base(p.Root)))
+	}
+
+	indent := "  "
+	if umbrella {
+		b.WriteString("  subgraph cluster_apps {\n    label=\"apps\";\n")
+		indent = "    "
+	}
+	for _, app := range p.Apps {
+		fmt.Fprintf(&b, "%s%s [label=%s, shape=box];\n", indent, dotID("app:"+app.Name), dotID(app.Name))
+	}
+	if umbrella {
+		b.WriteString("  }\n")
+	}
+
+	var edges []string
+	declared := make(map[string]bool)
+	addDeps := func(from string, config *parser.RebarConfig) {
+		if config == nil {
+			return
+		}
+		for _, term := range depTerms(config) {
+			dep, ok := parser.AsDependency(term)
+			if !ok {
+				continue
+			}
+			name := dep.Name
+			id := dotID("dep:" + name)
+			if !declared[name] {
+				declared[name] = true
+				attrs := depStyles[dep.Family()]
+				if _, ok := p.Checkout(name); ok {
+					attrs += ", penwidth=2"
+				}
+				fmt.Fprintf(&b, "  %s [label=%s, shape=ellipse, %s];\n", id, dotID(name), attrs)
+			}
+			edges = append(edges, fmt.Sprintf("  %s -> %s;\n", from, id))
+		}
+	}
+
+	addDeps(rootID, p.Config)
+	for _, app := range p.Apps {
+		if app.Dir != p.Root {
+			addDeps(dotID("app:"+app.Name), app.Config)
+		}
+	}
+
+	for _, app := range p.Apps {
+		if app.AppSrc == nil {
+			continue
+		}
+		for _, name := range app.AppSrc.Applications {
+			if _, ok := p.App(name); ok && name != app.Name {
+				edges = append(edges, fmt.Sprintf("  %s -> %s [penwidth=2];\n", dotID("app:"+app.Name), dotID("app:"+name)))
+			}
+		}
+	}
+
+	for _, edge := range edges {
+		b.WriteString(edge)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// depTerms 返回配置中 deps 列表的元素
+func depTerms(config *parser.RebarConfig) []parser.Term {
+	elements, ok := config.GetDeps()
+	if !ok {
+		return nil
+	}
+	if list, ok := elements[0].(parser.List); ok {
+		return list.Elements
+	}
+	return nil
+}
+
+// dotID 返回加引号的 DOT 标识符
+func dotID(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
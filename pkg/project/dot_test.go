@@ -0,0 +1,75 @@
+package project
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// TestDOT tests the Graphviz output for an umbrella project
+func TestDOT(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"rebar.config":                   `{deps, [{cowboy, "2.9.0"}, {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}}]}.`,
+		"apps/web/src/web.app.src":       `{application, web, [{applications, [kernel, core, cowboy]}]}.`,
+		"apps/web/rebar.config":          `{deps, [jsx, {local, {path, "../local"}}, cowboy]}.`,
+		"apps/core/src/core.app.src":     appSrc("core"),
+		"_checkouts/jsx/src/jsx.app.src": appSrc("jsx"),
+	})
+
+	proj, err := Load(root)
+	if err != nil {
+		t.Fatalf("Failed to load project: %v", err)
+	}
+	proj.Root = "/work/my \"proj\""
+
+	expected := `digraph "my \"proj\"" {
+  rankdir=LR;
+  node [fontname="Helvetica"];
+  "project" [label="my \"proj\"", shape=folder];
+  subgraph cluster_apps {
+    label="apps";
+    "app:core" [label="core", shape=box];
+    "app:web" [label="web", shape=box];
+  }
+  "dep:cowboy" [label="cowboy", shape=ellipse, color="#6e4a7e"];
+  "dep:lager" [label="lager", shape=ellipse, color="#f05033", style=dashed];
+  "dep:jsx" [label="jsx", shape=ellipse, color="#6e4a7e", penwidth=2];
+  "dep:local" [label="local", shape=ellipse, color="#999999", style=dotted];
+  "project" -> "dep:cowboy";
+  "project" -> "dep:lager";
+  "app:web" -> "dep:jsx";
+  "app:web" -> "dep:local";
+  "app:web" -> "dep:cowboy";
+  "app:web" -> "app:core" [penwidth=2];
+}
+`
+	if got := proj.DOT(); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+// TestDOTSingleApp tests that top-level deps hang off the root app
+func TestDOTSingleApp(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "my_app")
+	writeFiles(t, root, map[string]string{
+		"rebar.config":       `{deps, [recon]}.`,
+		"src/my_app.app.src": appSrc("my_app"),
+	})
+
+	proj, err := Load(root)
+	if err != nil {
+		t.Fatalf("Failed to load project: %v", err)
+	}
+
+	expected := `digraph "my_app" {
+  rankdir=LR;
+  node [fontname="Helvetica"];
+  "app:my_app" [label="my_app", shape=box];
+  "dep:recon" [label="recon", shape=ellipse, color="#6e4a7e"];
+  "app:my_app" -> "dep:recon";
+}
+`
+	if got := proj.DOT(); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+}
@@ -0,0 +1,198 @@
+// Package project 提供按 rebar3 的方式加载整个 Erlang 项目的功能。
+// @pkg 给定仓库根目录，该包读取顶层 rebar.config，并按 project_app_dirs
+// （默认为 apps/*、lib/* 和 .）发现各个应用的 .app.src 与 rebar.config，
+// 与 rebar3 看待 umbrella 项目的方式一致。
+//
+// 示例:
+//
+//	proj, err := project.Load(".")
+//	if err != nil {
+//	  log.Fatal(err)
+//	}
+//	for _, app := range proj.Apps {
+//	  fmt.Println(app.Name, app.Dir)
+//	}
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// DefaultAppDirs 是 rebar3 默认的 project_app_dirs
+var DefaultAppDirs = []string{"apps/*", "lib/*", "."}
+
+// Project 表示一个 rebar3 项目
+type Project struct {
+	// Root 是项目根目录的绝对路径
+	Root string
+	// Config 是顶层 rebar.config；根目录没有该文件时为空配置
+	Config *parser.RebarConfig
+	// Apps 是项目中的应用，按 project_app_dirs 的顺序和目录名排列
+	Apps []*App
+	// Checkouts 是 _checkouts 目录下的应用，rebar3 用它们覆盖同名依赖
+	Checkouts []*App
+}
+
+// App 表示项目中的一个应用
+type App struct {
+	// Name 是应用名称，取自 .app.src 中的定义
+	Name string
+	// Dir 是应用目录的绝对路径
+	Dir string
+	// AppSrc 是应用的 .app.src 定义
+	AppSrc *parser.Application
+	// Config 是应用目录下的 rebar.config，应用没有自己的配置时为 nil
+	Config *parser.RebarConfig
+}
+
+// Load 加载项目根目录下的所有配置
+// @pkg 发现规则与 rebar3 一致:
+// - 顶层 rebar.config 中的 {project_app_dirs, [...]} 决定在哪些目录中查找应用，默认为 DefaultAppDirs
+// - 目录下存在 src/*.app.src 时视为一个应用
+// - 应用目录下的 rebar.config 是该应用自己的配置
+// - _checkouts 下的应用是覆盖同名依赖的本地副本
+//
+// 输入:
+//   - root: 项目根目录
+//   - opts: 传给 parser.ParseFile 的解析选项
+//
+// 输出:
+//   - *Project: 项目模型
+//   - error: 读取或解析错误
+func Load(root string, opts ...parser.ParseOption) (*Project, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	proj := &Project{Root: abs, Config: &parser.RebarConfig{Terms: []parser.Term{}}}
+	if config, err := parseOptional(filepath.Join(abs, "rebar.config"), opts); err != nil {
+		return nil, err
+	} else if config != nil {
+		proj.Config = config
+	}
+
+	patterns, err := appDirs(proj.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		dirs, err := filepath.Glob(filepath.Join(abs, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid project_app_dirs pattern %q: %w", pattern, err)
+		}
+		sort.Strings(dirs)
+
+		for _, dir := range dirs {
+			if seen[dir] {
+				continue
+			}
+			app, err := loadApp(dir, opts)
+			if err != nil {
+				return nil, err
+			}
+			if app != nil {
+				seen[dir] = true
+				proj.Apps = append(proj.Apps, app)
+			}
+		}
+	}
+
+	if proj.Checkouts, err = loadCheckouts(abs, opts); err != nil {
+		return nil, err
+	}
+	return proj, nil
+}
+
+// App 按名称查找应用
+// 输入:
+//   - name: 应用名称
+//
+// 输出:
+//   - *App: 找到的应用
+//   - bool: 是否找到
+func (p *Project) App(name string) (*App, bool) {
+	for _, app := range p.Apps {
+		if app.Name == name {
+			return app, true
+		}
+	}
+	return nil, false
+}
+
+// IsUmbrella 判断项目是否为 umbrella 项目，即应用不在根目录下
+func (p *Project) IsUmbrella() bool {
+	for _, app := range p.Apps {
+		if app.Dir != p.Root {
+			return true
+		}
+	}
+	return false
+}
+
+// loadApp 加载 dir 中的应用，dir 不是应用目录时返回 nil
+func loadApp(dir string, opts []parser.ParseOption) (*App, error) {
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, nil
+	}
+	sources, err := filepath.Glob(filepath.Join(dir, "src", "*.app.src"))
+	if err != nil || len(sources) == 0 {
+		return nil, err
+	}
+	if len(sources) > 1 {
+		return nil, fmt.Errorf("multiple .app.src files in %s", dir)
+	}
+
+	appSrc, err := parser.ParseAppSrc(sources[0])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", sources[0], err)
+	}
+	app := &App{Name: appSrc.Name, Dir: dir, AppSrc: appSrc}
+	if app.Config, err = parseOptional(filepath.Join(dir, "rebar.config"), opts); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// parseOptional 解析可能不存在的配置文件，文件不存在时返回 nil
+func parseOptional(path string, opts []parser.ParseOption) (*parser.RebarConfig, error) {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	config, err := parser.ParseFile(path, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// appDirs 返回配置中的 project_app_dirs，未配置时返回 DefaultAppDirs
+func appDirs(config *parser.RebarConfig) ([]string, error) {
+	elements, ok := config.GetTupleElements("project_app_dirs")
+	if !ok {
+		return DefaultAppDirs, nil
+	}
+	list, ok := elements[0].(parser.List)
+	if !ok {
+		return nil, fmt.Errorf("invalid project_app_dirs: expected a list, got %s", elements[0])
+	}
+	dirs := make([]string, 0, len(list.Elements))
+	for _, elem := range list.Elements {
+		dir, ok := elem.(parser.String)
+		if !ok {
+			return nil, fmt.Errorf("invalid project_app_dirs: expected a string, got %s", elem)
+		}
+		dirs = append(dirs, dir.Value)
+	}
+	return dirs, nil
+}
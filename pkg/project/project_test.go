@@ -0,0 +1,124 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFiles creates the given files below root
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func appSrc(name string) string {
+	return `{application, ` + name + `, [{vsn, "0.1.0"}, {applications, [kernel, stdlib]}]}.`
+}
+
+// TestLoadUmbrella tests discovery of apps in an umbrella project
+func TestLoadUmbrella(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"rebar.config":                `{deps, [{cowboy, "2.9.0"}]}.`,
+		"apps/web/src/web.app.src":    appSrc("web"),
+		"apps/web/rebar.config":       `{deps, [jsx]}.`,
+		"apps/core/src/core.app.src":  appSrc("core"),
+		"lib/util/src/util.app.src":   appSrc("util"),
+		"apps/docs/README.md":         "not an app",
+		"apps/broken/src/placeholder": "",
+		"other/skipped/src/x.app.src": appSrc("skipped"),
+	})
+
+	proj, err := Load(root)
+	if err != nil {
+		t.Fatalf("Failed to load project: %v", err)
+	}
+
+	var names []string
+	for _, app := range proj.Apps {
+		names = append(names, app.Name)
+	}
+	if len(names) != 3 || names[0] != "core" || names[1] != "web" || names[2] != "util" {
+		t.Fatalf("Unexpected apps: %v", names)
+	}
+	if !proj.IsUmbrella() {
+		t.Error("Expected an umbrella project")
+	}
+	if _, ok := proj.Config.GetDeps(); !ok {
+		t.Error("Expected top-level deps")
+	}
+
+	web, ok := proj.App("web")
+	if !ok || web.Config == nil || web.Dir != filepath.Join(proj.Root, "apps", "web") {
+		t.Fatalf("Unexpected web app: %+v", web)
+	}
+	if core, _ := proj.App("core"); core.Config != nil || core.AppSrc.Vsn != "0.1.0" {
+		t.Errorf("Unexpected core app: %+v", core)
+	}
+	if _, ok := proj.App("skipped"); ok {
+		t.Error("Expected apps outside project_app_dirs to be skipped")
+	}
+}
+
+// TestLoadSingleApp tests a project whose root is the only app
+func TestLoadSingleApp(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"rebar.config":       `{erl_opts, [debug_info]}.`,
+		"src/my_app.app.src": appSrc("my_app"),
+	})
+
+	proj, err := Load(root)
+	if err != nil {
+		t.Fatalf("Failed to load project: %v", err)
+	}
+	if len(proj.Apps) != 1 || proj.Apps[0].Name != "my_app" || proj.IsUmbrella() {
+		t.Fatalf("Unexpected apps: %+v", proj.Apps)
+	}
+	if proj.Apps[0].Config == nil {
+		t.Error("Expected the root app to share the top-level config")
+	}
+}
+
+// TestLoadProjectAppDirs tests a custom project_app_dirs setting
+func TestLoadProjectAppDirs(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"rebar.config":                 `{project_app_dirs, ["services/*"]}.`,
+		"services/api/src/api.app.src": appSrc("api"),
+		"apps/web/src/web.app.src":     appSrc("web"),
+	})
+
+	proj, err := Load(root)
+	if err != nil {
+		t.Fatalf("Failed to load project: %v", err)
+	}
+	if len(proj.Apps) != 1 || proj.Apps[0].Name != "api" {
+		t.Fatalf("Unexpected apps: %+v", proj.Apps)
+	}
+
+	writeFiles(t, root, map[string]string{"rebar.config": `{project_app_dirs, [apps]}.`})
+	if _, err := Load(root); err == nil {
+		t.Error("Expected error for non-string project_app_dirs")
+	}
+}
+
+// TestLoadErrors tests that invalid files are reported with their path
+func TestLoadErrors(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"apps/web/src/web.app.src": `{application, web, [}.`,
+	})
+	if _, err := Load(root); err == nil {
+		t.Error("Expected error for invalid .app.src")
+	}
+}
@@ -0,0 +1,145 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// Markdown 将概要渲染为 Markdown
+// @pkg 依赖部分总是输出（没有依赖时为说明文字），插件、profiles 和发布部分只在有内容时输出
+// 输出:
+//   - string: Markdown 文档
+func (r *Report) Markdown() string {
+	var b strings.Builder
+	if r.Title != "" {
+		fmt.Fprintf(&b, "# %s\n\n", r.Title)
+	}
+
+	b.WriteString("## Dependencies\n\n")
+	if len(r.Deps) == 0 {
+		b.WriteString("_No dependencies._\n")
+	} else {
+		b.WriteString("| Name | Source | Version | Location |\n|------|--------|---------|----------|\n")
+		for _, dep := range r.Deps {
+			writeRow(&b, dep.Name, dep.Source, dep.Version, dep.Location)
+		}
+	}
+
+	writeList(&b, "Plugins", r.Plugins)
+	writeList(&b, "Project plugins", r.ProjectPlugins)
+
+	if len(r.Profiles) > 0 {
+		b.WriteString("\n## Profiles\n\n| Profile | Overrides | Deps |\n|---------|-----------|------|\n")
+		for _, profile := range r.Profiles {
+			writeRow(&b, profile.Name, strings.Join(profile.Keys, ", "), fmt.Sprint(profile.DepCount))
+		}
+	}
+
+	if len(r.Releases) > 0 {
+		b.WriteString("\n## Releases\n\n| Release | Version | Apps |\n|---------|---------|------|\n")
+		for _, release := range r.Releases {
+			writeRow(&b, release.Name, release.Version, strings.Join(release.Apps, ", "))
+		}
+	}
+	return b.String()
+}
+
+// writeList 输出带标题的列表，没有内容时不输出
+func writeList(b *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n", title)
+	for _, item := range items {
+		fmt.Fprintf(b, "- %s\n", markdownCell(item))
+	}
+}
+
+// writeRow 输出表格的一行
+func writeRow(b *strings.Builder, cells ...string) {
+	for i, cell := range cells {
+		cells[i] = markdownCell(cell)
+	}
+	fmt.Fprintf(b, "| %s |\n", strings.Join(cells, " | "))
+}
+
+// markdownCell 转义表格单元格中的竖线和换行
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// htmlTemplate 是 HTML 输出的模板
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"join": strings.Join,
+}).Parse(`<section class="rebar-report">
+{{- if .Title}}
+<h1>{{.Title}}</h1>
+{{- end}}
+<h2>Dependencies</h2>
+{{- if .Deps}}
+<table>
+<thead><tr><th>Name</th><th>Source</th><th>Version</th><th>Location</th></tr></thead>
+<tbody>
+{{- range .Deps}}
+<tr><td>{{.Name}}</td><td>{{.Source}}</td><td>{{.Version}}</td><td>{{.Location}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>No dependencies.</p>
+{{- end}}
+{{- if .Plugins}}
+<h2>Plugins</h2>
+<ul>
+{{- range .Plugins}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .ProjectPlugins}}
+<h2>Project plugins</h2>
+<ul>
+{{- range .ProjectPlugins}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Profiles}}
+<h2>Profiles</h2>
+<table>
+<thead><tr><th>Profile</th><th>Overrides</th><th>Deps</th></tr></thead>
+<tbody>
+{{- range .Profiles}}
+<tr><td>{{.Name}}</td><td>{{join .Keys ", "}}</td><td>{{.DepCount}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- if .Releases}}
+<h2>Releases</h2>
+<table>
+<thead><tr><th>Release</th><th>Version</th><th>Apps</th></tr></thead>
+<tbody>
+{{- range .Releases}}
+<tr><td>{{.Name}}</td><td>{{.Version}}</td><td>{{join .Apps ", "}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+</section>
+`))
+
+// HTML 将概要渲染为 HTML 片段
+// @pkg 输出为一个 class 为 rebar-report 的 section 元素，不含样式，所有文本都经过转义，便于嵌入现有页面
+// 输出:
+//   - string: HTML 片段
+func (r *Report) HTML() string {
+	var b strings.Builder
+	if err := htmlTemplate.Execute(&b, r); err != nil {
+		// 模板是固定的，数据只包含字符串和整数，执行不会失败
+		panic(err)
+	}
+	return b.String()
+}
@@ -0,0 +1,95 @@
+// Package report 提供将 rebar.config 渲染为 Markdown 或 HTML 概要的功能。
+// @pkg 概要包括依赖表（名称、来源、版本或引用、地址）、插件、profiles 和 relx 发布，
+// 可以直接粘贴到项目文档或嵌入到看板页面中。
+//
+// 示例:
+//
+//	config, _ := parser.ParseFile("./rebar.config")
+//	r := report.New("my_app", config)
+//	os.WriteFile("DEPENDENCIES.md", []byte(r.Markdown()), 0644)
+package report
+
+import (
+	"strings"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// Report 是与输出格式无关的配置概要
+type Report struct {
+	// Title 是概要的标题，通常为项目名称
+	Title string
+	// Deps 是基础配置中声明的依赖，按配置中的顺序排列
+	Deps []Dep
+	// Plugins 是 plugins 中声明的插件名称
+	Plugins []string
+	// ProjectPlugins 是 project_plugins 中声明的插件名称
+	ProjectPlugins []string
+	// Profiles 是各 profile 的概要
+	Profiles []parser.ProfileSummary
+	// Releases 是 relx 中定义的发布
+	Releases []parser.ReleaseSummary
+}
+
+// Dep 是依赖表中的一行
+type Dep struct {
+	// Name 是依赖的应用名称
+	Name string
+	// Source 是来源类别：hex、git、hg、path 或 other
+	Source string
+	// Version 是 hex 依赖的版本要求，或版本库依赖的引用，如 "tag 1.0"、"branch main"；未指定时为空
+	Version string
+	// Location 是版本库地址，或与 Name 不同的 hex 包名；其他情况为空
+	Location string
+}
+
+// New 根据配置生成概要
+// 输入:
+//   - title: 概要标题
+//   - config: 解析后的 rebar.config
+//
+// 输出:
+//   - *Report: 配置概要
+func New(title string, config *parser.RebarConfig) *Report {
+	summary := parser.Explain(config)
+	r := &Report{
+		Title:          title,
+		Plugins:        summary.Plugins,
+		ProjectPlugins: summary.ProjectPlugins,
+		Profiles:       summary.Profiles,
+		Releases:       summary.Releases,
+	}
+
+	if elements, ok := config.GetDeps(); ok {
+		if list, ok := elements[0].(parser.List); ok {
+			for _, term := range list.Elements {
+				if dep, ok := newDep(term); ok {
+					r.Deps = append(r.Deps, dep)
+				}
+			}
+		}
+	}
+	return r
+}
+
+// newDep 从依赖声明构建依赖表的一行
+func newDep(term parser.Term) (Dep, bool) {
+	d, ok := parser.AsDependency(term)
+	if !ok {
+		return Dep{}, false
+	}
+	dep := Dep{Name: d.Name, Source: d.Family()}
+	switch dep.Source {
+	case "hex":
+		dep.Version = d.Version
+		if d.Package != d.Name {
+			dep.Location = d.Package
+		}
+	case "git", "hg":
+		dep.Location = d.URL
+		dep.Version = strings.TrimSpace(d.RefType + " " + d.RefValue)
+	case "path":
+		dep.Location = d.URL
+	}
+	return dep, true
+}
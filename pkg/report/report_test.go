@@ -0,0 +1,130 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+const testConfig = `{deps, [
+    recon,
+    {cowboy, "2.9.0"},
+    {jsx, {pkg, jsx_fork, "3.1.0"}},
+    {lager, {git, "https://github.com/erlang-lager/lager.git", {tag, "3.9.2"}}},
+    {local, {path, "../local"}}
+]}.
+{plugins, [rebar3_hex]}.
+{project_plugins, [erlfmt]}.
+{relx, [{release, {my_app, "0.1.0"}, [my_app, sasl]}]}.
+{profiles, [{test, [{deps, [meck]}, {erl_opts, [nowarn_export_all]}]}]}.`
+
+// TestNew tests dependency extraction
+func TestNew(t *testing.T) {
+	config, err := parser.Parse(testConfig)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	r := New("my_app", config)
+
+	expected := []Dep{
+		{Name: "recon", Source: "hex"},
+		{Name: "cowboy", Source: "hex", Version: "2.9.0"},
+		{Name: "jsx", Source: "hex", Version: "3.1.0", Location: "jsx_fork"},
+		{Name: "lager", Source: "git", Version: "tag 3.9.2", Location: "https://github.com/erlang-lager/lager.git"},
+		{Name: "local", Source: "path", Location: "../local"},
+	}
+	if len(r.Deps) != len(expected) {
+		t.Fatalf("Expected %d deps, got %d", len(expected), len(r.Deps))
+	}
+	for i := range expected {
+		if r.Deps[i] != expected[i] {
+			t.Errorf("Dep %d: expected %+v, got %+v", i, expected[i], r.Deps[i])
+		}
+	}
+}
+
+// TestMarkdown tests Markdown rendering
+func TestMarkdown(t *testing.T) {
+	config, err := parser.Parse(testConfig)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+
+	expected := `# my_app
+
+## Dependencies
+
+| Name | Source | Version | Location |
+|------|--------|---------|----------|
+| recon | hex |  |  |
+| cowboy | hex | 2.9.0 |  |
+| jsx | hex | 3.1.0 | jsx_fork |
+| lager | git | tag 3.9.2 | https://github.com/erlang-lager/lager.git |
+| local | path |  | ../local |
+
+## Plugins
+
+- rebar3_hex
+
+## Project plugins
+
+- erlfmt
+
+## Profiles
+
+| Profile | Overrides | Deps |
+|---------|-----------|------|
+| test | deps, erl_opts | 1 |
+
+## Releases
+
+| Release | Version | Apps |
+|---------|---------|------|
+| my_app | 0.1.0 | my_app, sasl |
+`
+	if got := New("my_app", config).Markdown(); got != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, got)
+	}
+
+	empty := New("", &parser.RebarConfig{}).Markdown()
+	if empty != "## Dependencies\n\n_No dependencies._\n" {
+		t.Errorf("Unexpected output for empty config: %q", empty)
+	}
+	if cell := markdownCell("a|b\nc"); cell != `a\|b c` {
+		t.Errorf("Unexpected escaped cell: %q", cell)
+	}
+}
+
+// TestHTML tests HTML rendering and escaping
+func TestHTML(t *testing.T) {
+	config, err := parser.Parse(`{deps, [{evil, {git, "https://example.com/<script>.git", {branch, "main"}}}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	html := New("a & b", config).HTML()
+
+	for _, want := range []string{
+		`<h1>a &amp; b</h1>`,
+		`<tr><td>evil</td><td>git</td><td>branch main</td><td>https://example.com/&lt;script&gt;.git</td></tr>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected HTML to contain %q, got:\n%s", want, html)
+		}
+	}
+	if strings.Contains(html, "<h2>Plugins</h2>") {
+		t.Errorf("Expected empty sections to be omitted")
+	}
+}
+
+// TestNewEmptyDepTuple tests that an empty tuple in deps is skipped without panicking
+func TestNewEmptyDepTuple(t *testing.T) {
+	config, err := parser.Parse(`{deps, [{}, {cowboy, "2.9.0"}]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	r := New("my_app", config)
+	if len(r.Deps) != 1 || r.Deps[0].Name != "cowboy" {
+		t.Errorf("Expected only cowboy, got %+v", r.Deps)
+	}
+}
@@ -0,0 +1,198 @@
+package sbom
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// cycloneDXBOM 是 CycloneDX 1.5 JSON 文档中用到的字段
+type cycloneDXBOM struct {
+	BOMFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	Version      int                   `json:"version"`
+	Metadata     cycloneDXMetadata     `json:"metadata"`
+	Components   []cycloneDXComponent  `json:"components"`
+	Dependencies []cycloneDXDependency `json:"dependencies"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string             `json:"timestamp,omitempty"`
+	Tools     []cycloneDXTool    `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXTool struct {
+	Name string `json:"name"`
+}
+
+type cycloneDXComponent struct {
+	Type               string              `json:"type"`
+	BOMRef             string              `json:"bom-ref"`
+	Name               string              `json:"name"`
+	Version            string              `json:"version,omitempty"`
+	PURL               string              `json:"purl,omitempty"`
+	Hashes             []cycloneDXHash     `json:"hashes,omitempty"`
+	ExternalReferences []cycloneDXExternal `json:"externalReferences,omitempty"`
+}
+
+type cycloneDXHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cycloneDXExternal struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type cycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// CycloneDX 生成 CycloneDX 1.5 JSON 文档
+// @pkg 每个依赖是一个 library 组件，bom-ref 为其 purl；项目本身是 metadata 中的 application 组件，
+// dependencies 中记录项目对顶层依赖的直接依赖关系
+// 输出:
+//   - []byte: 缩进格式的 JSON 文档
+//   - error: 序列化错误
+func (d *Document) CycloneDX() ([]byte, error) {
+	root := cycloneDXComponent{Type: "application", BOMRef: d.Name, Name: d.Name, Version: d.Version}
+	bom := cycloneDXBOM{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+		Metadata: cycloneDXMetadata{
+			Tools:     []cycloneDXTool{{Name: toolName}},
+			Component: root,
+		},
+		Components:   []cycloneDXComponent{},
+		Dependencies: []cycloneDXDependency{{Ref: root.BOMRef, DependsOn: []string{}}},
+	}
+	if !d.Created.IsZero() {
+		bom.Metadata.Timestamp = d.Created.UTC().Format(time.RFC3339)
+	}
+
+	for _, c := range d.Components {
+		component := cycloneDXComponent{Type: "library", BOMRef: c.PURL, Name: c.Name, Version: c.Version, PURL: c.PURL}
+		if c.SHA256 != "" {
+			component.Hashes = []cycloneDXHash{{Alg: "SHA-256", Content: c.SHA256}}
+		}
+		if c.URL != "" {
+			component.ExternalReferences = []cycloneDXExternal{{Type: "vcs", URL: c.URL}}
+		}
+		bom.Components = append(bom.Components, component)
+		if c.Direct {
+			bom.Dependencies[0].DependsOn = append(bom.Dependencies[0].DependsOn, c.PURL)
+		}
+	}
+	return json.MarshalIndent(bom, "", "  ")
+}
+
+// spdxDocument 是 SPDX 2.3 JSON 文档中用到的字段
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name             string            `json:"name"`
+	SPDXID           string            `json:"SPDXID"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	Checksums        []spdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// SPDX 生成 SPDX 2.3 JSON 文档
+// @pkg 项目本身是文档描述（DESCRIBES）的根包，顶层依赖通过 DEPENDS_ON 关系与根包关联，
+// 每个依赖包通过 PACKAGE-MANAGER 类别的外部引用记录 purl
+// 输出:
+//   - []byte: 缩进格式的 JSON 文档
+//   - error: 序列化错误
+func (d *Document) SPDX() ([]byte, error) {
+	created := d.Created
+	if created.IsZero() {
+		created = time.Now()
+	}
+
+	rootID := spdxID("Package", d.Name)
+	doc := spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              d.Name,
+		DocumentNamespace: "https://spdx.org/spdxdocs/" + sanitize(d.Name+"-"+d.Version) + "-" + created.UTC().Format("20060102T150405Z"),
+		CreationInfo: spdxCreationInfo{
+			Created:  created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: " + toolName},
+		},
+		Packages: []spdxPackage{{
+			Name:             d.Name,
+			SPDXID:           rootID,
+			VersionInfo:      d.Version,
+			DownloadLocation: "NOASSERTION",
+		}},
+		Relationships: []spdxRelationship{{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: rootID}},
+	}
+
+	for _, c := range d.Components {
+		pkg := spdxPackage{
+			Name:             c.Name,
+			SPDXID:           spdxID("Dep", c.Name),
+			VersionInfo:      c.Version,
+			DownloadLocation: downloadLocation(c),
+			ExternalRefs:     []spdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: c.PURL}},
+		}
+		if c.SHA256 != "" {
+			pkg.Checksums = []spdxChecksum{{Algorithm: "SHA256", ChecksumValue: c.SHA256}}
+		}
+		doc.Packages = append(doc.Packages, pkg)
+		if c.Direct {
+			doc.Relationships = append(doc.Relationships, spdxRelationship{SPDXElementID: rootID, RelationshipType: "DEPENDS_ON", RelatedSPDXElement: pkg.SPDXID})
+		}
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// downloadLocation 返回 SPDX 包的下载地址
+func downloadLocation(c Component) string {
+	switch {
+	case c.Kind == "hex" && c.Version != "":
+		return "https://repo.hex.pm/tarballs/" + c.Package + "-" + c.Version + ".tar"
+	case c.URL != "" && c.Version != "":
+		return c.Kind + "+" + c.URL + "@" + c.Version
+	case c.URL != "":
+		return c.Kind + "+" + c.URL
+	}
+	return "NOASSERTION"
+}
@@ -0,0 +1,196 @@
+// Package sbom 提供从 rebar 依赖生成软件物料清单（SBOM）的功能。
+// @pkg 将 rebar.config 中的依赖（以及可选的 rebar.lock）转换为 CycloneDX 或 SPDX JSON 文档，
+// 组件使用 purl 标识，如 pkg:hex/cowboy@2.9.0，便于接入 Go 编写的供应链安全工具。
+//
+// 示例:
+//
+//	doc := sbom.New("my_app", "1.0.0", config, lock)
+//	data, err := doc.CycloneDX()
+//	if err != nil {
+//	  log.Fatal(err)
+//	}
+//	os.WriteFile("bom.json", data, 0644)
+package sbom
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// toolName 是写入文档的生成工具名称
+const toolName = "erlang-rebar-config-parser"
+
+// Document 是与输出格式无关的物料清单
+type Document struct {
+	// Name 是项目名称
+	Name string
+	// Version 是项目版本，可以为空
+	Version string
+	// Created 是文档的创建时间，为零值时 SPDX 文档使用当前时间，CycloneDX 文档省略时间戳
+	Created time.Time
+	// Components 是项目的依赖
+	Components []Component
+}
+
+// Component 是物料清单中的一个依赖
+type Component struct {
+	// Name 是依赖的应用名称
+	Name string
+	// Version 是版本号；hex 包为版本，git/hg 依赖为锁定的提交或配置中的 tag、branch、ref；未知时为空
+	Version string
+	// Kind 是来源类别：hex、git、hg 或 other
+	Kind string
+	// Package 是 hex 包名，使用别名时与 Name 不同；非 hex 依赖为空
+	Package string
+	// PURL 是依赖的 package URL
+	PURL string
+	// URL 是版本库地址，hex 包为空
+	URL string
+	// SHA256 是 hex 包的外部校验和（小写十六进制），来自锁文件的 pkg_hash_ext
+	SHA256 string
+	// Direct 表示是否为顶层依赖
+	Direct bool
+}
+
+// New 根据配置和锁文件构建物料清单
+// @pkg 提供锁文件时使用锁文件中的所有依赖（包括传递依赖）及其精确版本和校验和；
+// 否则使用配置中声明的依赖，hex 依赖只有在版本要求为精确版本时才带有版本号
+// 输入:
+//   - name: 项目名称
+//   - version: 项目版本，可以为空
+//   - config: 解析后的 rebar.config
+//   - lock: 解析后的 rebar.lock，可以为 nil
+//
+// 输出:
+//   - *Document: 物料清单
+func New(name, version string, config *parser.RebarConfig, lock *parser.LockFile) *Document {
+	doc := &Document{Name: name, Version: version}
+	if lock != nil {
+		for _, dep := range lock.Deps {
+			doc.Components = append(doc.Components, lockedComponent(dep))
+		}
+		return doc
+	}
+
+	elements, ok := config.GetDeps()
+	if !ok {
+		return doc
+	}
+	if list, ok := elements[0].(parser.List); ok {
+		for _, term := range list.Elements {
+			if component, ok := declaredComponent(term); ok {
+				doc.Components = append(doc.Components, component)
+			}
+		}
+	}
+	return doc
+}
+
+// lockedComponent 从锁定的依赖构建组件
+func lockedComponent(dep parser.LockedDep) Component {
+	c := Component{Name: dep.Name, Direct: dep.Level == 0}
+	switch dep.Source.Kind {
+	case "pkg":
+		c.Kind = "hex"
+		c.Package = dep.Source.Package
+		c.Version = dep.Source.Version
+		c.SHA256 = strings.ToLower(dep.HashExt)
+		c.PURL = hexPURL(c.Package, c.Version)
+	case "git", "git_subdir", "hg":
+		c.Kind = strings.TrimSuffix(dep.Source.Kind, "_subdir")
+		c.URL = dep.Source.URL
+		c.Version = dep.Source.Ref
+		c.PURL = vcsPURL(c.Name, c.Kind, c.URL, c.Version)
+	default:
+		c.Kind = "other"
+		c.PURL = genericPURL(c.Name, "", "", "")
+	}
+	return c
+}
+
+// declaredComponent 从配置中声明的依赖构建组件
+func declaredComponent(term parser.Term) (Component, bool) {
+	dep, ok := parser.AsDependency(term)
+	if !ok {
+		return Component{}, false
+	}
+	c := Component{Name: dep.Name, Kind: dep.Family(), Direct: true}
+	switch c.Kind {
+	case "hex":
+		c.Package = dep.Package
+		if _, err := parser.ParseVersion(dep.Version); err == nil {
+			c.Version = dep.Version
+		}
+		c.PURL = hexPURL(c.Package, c.Version)
+	case "git", "hg":
+		c.URL = dep.URL
+		if dep.RefType != "" {
+			c.Version = dep.RefValue
+		}
+		c.PURL = vcsPURL(c.Name, c.Kind, c.URL, c.Version)
+	default:
+		c.Kind = "other"
+		c.PURL = genericPURL(c.Name, "", "", "")
+	}
+	return c, true
+}
+
+// hexPURL 返回 hex 包的 purl，包名按 purl 规范转为小写
+func hexPURL(pkg, version string) string {
+	purl := "pkg:hex/" + url.PathEscape(strings.ToLower(pkg))
+	if version != "" {
+		purl += "@" + url.PathEscape(version)
+	}
+	return purl
+}
+
+// githubRepo 匹配 GitHub 版本库地址
+var githubRepo = regexp.MustCompile(`^(?:https?://|git://|ssh://git@|git@)github\.com[:/]([^/]+)/([^/]+?)(?:\.git)?/?$`)
+
+// vcsPURL 返回版本库依赖的 purl
+// @pkg GitHub 上的版本库使用 pkg:github 类型，其他地址使用带 vcs_url 限定符的 pkg:generic 类型
+func vcsPURL(name, kind, repo, version string) string {
+	if m := githubRepo.FindStringSubmatch(repo); m != nil {
+		purl := "pkg:github/" + strings.ToLower(m[1]) + "/" + strings.ToLower(m[2])
+		if version != "" {
+			purl += "@" + url.PathEscape(version)
+		}
+		return purl
+	}
+	return genericPURL(name, version, kind, repo)
+}
+
+// genericPURL 返回 pkg:generic 类型的 purl
+func genericPURL(name, version, kind, repo string) string {
+	purl := "pkg:generic/" + url.PathEscape(name)
+	if version != "" {
+		purl += "@" + url.PathEscape(version)
+	}
+	if repo != "" {
+		purl += "?vcs_url=" + url.QueryEscape(kind+"+"+repo)
+	}
+	return purl
+}
+
+// spdxID 返回合法的 SPDX 标识符
+func spdxID(prefix, name string) string {
+	return fmt.Sprintf("SPDXRef-%s-%s", prefix, sanitize(name))
+}
+
+// sanitize 将字母、数字、点号和连字符以外的字符替换为连字符
+func sanitize(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r < 128 && (r == '.' || r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('-')
+		}
+	}
+	return b.String()
+}
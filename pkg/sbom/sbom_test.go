@@ -0,0 +1,136 @@
+package sbom
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+const testLock = `{"1.2.0",
+[{<<"cowboy">>,{pkg,<<"cowboy">>,<<"2.9.0">>},0},
+ {<<"cowlib">>,{pkg,<<"cowlib">>,<<"2.11.0">>},1},
+ {<<"lager">>,{git,"https://github.com/erlang-lager/lager.git",{ref,"459a3b2"}},0},
+ {<<"my_jsx">>,{pkg,<<"jsx">>,<<"3.1.0">>},0}]}.
+[{pkg_hash_ext,[{<<"cowboy">>,<<"2C729F93">>}]}].
+`
+
+// TestNewFromLock tests component extraction from a lock file
+func TestNewFromLock(t *testing.T) {
+	lock, err := parser.ParseLock(testLock)
+	if err != nil {
+		t.Fatalf("Failed to parse lock: %v", err)
+	}
+	doc := New("my_app", "1.0.0", &parser.RebarConfig{}, lock)
+
+	expected := []Component{
+		{Name: "cowboy", Version: "2.9.0", Kind: "hex", Package: "cowboy", PURL: "pkg:hex/cowboy@2.9.0", SHA256: "2c729f93", Direct: true},
+		{Name: "cowlib", Version: "2.11.0", Kind: "hex", Package: "cowlib", PURL: "pkg:hex/cowlib@2.11.0"},
+		{Name: "lager", Version: "459a3b2", Kind: "git", PURL: "pkg:github/erlang-lager/lager@459a3b2", URL: "https://github.com/erlang-lager/lager.git", Direct: true},
+		{Name: "my_jsx", Version: "3.1.0", Kind: "hex", Package: "jsx", PURL: "pkg:hex/jsx@3.1.0", Direct: true},
+	}
+	if len(doc.Components) != len(expected) {
+		t.Fatalf("Expected %d components, got %d", len(expected), len(doc.Components))
+	}
+	for i := range expected {
+		if doc.Components[i] != expected[i] {
+			t.Errorf("Component %d: expected %+v, got %+v", i, expected[i], doc.Components[i])
+		}
+	}
+}
+
+// TestNewFromConfig tests component extraction from declared deps
+func TestNewFromConfig(t *testing.T) {
+	config, err := parser.Parse(`{deps, [
+    recon,
+    {cowboy, "2.9.0"},
+    {jsx, "~> 3.0"},
+    {lib, {git, "https://gitlab.com/acme/lib.git", {tag, "v1.0"}}}
+]}.`)
+	if err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	doc := New("my_app", "", config, nil)
+
+	purls := []string{
+		"pkg:hex/recon",
+		"pkg:hex/cowboy@2.9.0",
+		"pkg:hex/jsx",
+		"pkg:generic/lib@v1.0?vcs_url=git%2Bhttps%3A%2F%2Fgitlab.com%2Facme%2Flib.git",
+	}
+	if len(doc.Components) != len(purls) {
+		t.Fatalf("Expected %d components, got %d", len(purls), len(doc.Components))
+	}
+	for i, purl := range purls {
+		if doc.Components[i].PURL != purl {
+			t.Errorf("Component %d: expected %s, got %s", i, purl, doc.Components[i].PURL)
+		}
+	}
+}
+
+// TestDocumentFormats tests the CycloneDX and SPDX JSON output
+func TestDocumentFormats(t *testing.T) {
+	lock, err := parser.ParseLock(testLock)
+	if err != nil {
+		t.Fatalf("Failed to parse lock: %v", err)
+	}
+	doc := New("my_app", "1.0.0", &parser.RebarConfig{}, lock)
+	doc.Created = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	data, err := doc.CycloneDX()
+	if err != nil {
+		t.Fatalf("Failed to generate CycloneDX: %v", err)
+	}
+	var bom struct {
+		BOMFormat  string `json:"bomFormat"`
+		Metadata   struct{ Timestamp string }
+		Components []struct {
+			PURL   string
+			Hashes []struct{ Alg, Content string }
+		}
+		Dependencies []struct {
+			Ref       string
+			DependsOn []string
+		}
+	}
+	if err := json.Unmarshal(data, &bom); err != nil {
+		t.Fatalf("Failed to decode CycloneDX: %v", err)
+	}
+	if bom.BOMFormat != "CycloneDX" || bom.Metadata.Timestamp != "2024-01-02T03:04:05Z" || len(bom.Components) != 4 {
+		t.Fatalf("Unexpected CycloneDX document: %s", data)
+	}
+	if bom.Components[0].Hashes[0].Content != "2c729f93" {
+		t.Errorf("Expected hash for cowboy, got %+v", bom.Components[0].Hashes)
+	}
+	if deps := bom.Dependencies[0].DependsOn; len(deps) != 3 || deps[1] != "pkg:github/erlang-lager/lager@459a3b2" {
+		t.Errorf("Unexpected direct dependencies: %v", deps)
+	}
+
+	data, err = doc.SPDX()
+	if err != nil {
+		t.Fatalf("Failed to generate SPDX: %v", err)
+	}
+	var spdx struct {
+		SPDXVersion       string `json:"spdxVersion"`
+		DocumentNamespace string `json:"documentNamespace"`
+		Packages          []struct {
+			SPDXID           string
+			DownloadLocation string
+		}
+		Relationships []struct{ RelationshipType string }
+	}
+	if err := json.Unmarshal(data, &spdx); err != nil {
+		t.Fatalf("Failed to decode SPDX: %v", err)
+	}
+	if spdx.SPDXVersion != "SPDX-2.3" || len(spdx.Packages) != 5 || len(spdx.Relationships) != 4 {
+		t.Fatalf("Unexpected SPDX document: %s", data)
+	}
+	if spdx.Packages[4].SPDXID != "SPDXRef-Dep-my-jsx" || spdx.Packages[4].DownloadLocation != "https://repo.hex.pm/tarballs/jsx-3.1.0.tar" {
+		t.Errorf("Unexpected aliased package: %+v", spdx.Packages[4])
+	}
+	if !strings.HasPrefix(spdx.DocumentNamespace, "https://spdx.org/spdxdocs/my-app-1.0.0-") {
+		t.Errorf("Unexpected namespace: %s", spdx.DocumentNamespace)
+	}
+}
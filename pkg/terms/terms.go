@@ -0,0 +1,71 @@
+// Package terms 提供读取任意 Erlang 项文件的功能。
+// @pkg 与 Erlang 的 file:consult/1 对应：文件由若干以点号结尾的 Erlang 项组成，
+// 如 rebar.config、sys.config、rebar.lock、.app.src 或自定义的配置文件。
+// 该包是 parser 包的通用入口，直接返回顶级项，不带 rebar.config 特有的包装和访问方法。
+//
+// 示例:
+//
+//	items, err := terms.Consult("./priv/routes.config")
+//	if err != nil {
+//	  log.Fatal(err)
+//	}
+//	for _, item := range items {
+//	  fmt.Println(item)
+//	}
+package terms
+
+import (
+	"io"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// Term 及各具体类型是 parser 包中对应类型的别名，两个包的值可以互换使用
+type (
+	Term    = parser.Term
+	Atom    = parser.Atom
+	String  = parser.String
+	Binary  = parser.Binary
+	Integer = parser.Integer
+	Float   = parser.Float
+	Tuple   = parser.Tuple
+	List    = parser.List
+	Map     = parser.Map
+	MapPair = parser.MapPair
+)
+
+// Consult 读取文件中以点号结尾的全部 Erlang 项
+// 输入:
+//   - path: 文件路径
+//   - opts: 可选的解析选项，如 parser.WithMmap()
+//
+// 输出:
+//   - []Term: 顶级项
+//   - error: 读取或解析错误
+func Consult(path string, opts ...parser.ParseOption) ([]Term, error) {
+	return parser.Consult(path, opts...)
+}
+
+// Parse 解析字符串中以点号结尾的全部 Erlang 项
+// 输入:
+//   - input: Erlang 项文本，如 "{a, 1}. [b]."
+//   - opts: 可选的解析选项
+//
+// 输出:
+//   - []Term: 顶级项
+//   - error: 解析错误
+func Parse(input string, opts ...parser.ParseOption) ([]Term, error) {
+	return parser.ParseConsult(input, opts...)
+}
+
+// ParseReader 以流式方式解析 reader 中以点号结尾的全部 Erlang 项
+// 输入:
+//   - r: 提供 Erlang 项文本的 reader
+//   - opts: 可选的解析选项
+//
+// 输出:
+//   - []Term: 顶级项
+//   - error: 读取或解析错误
+func ParseReader(r io.Reader, opts ...parser.ParseOption) ([]Term, error) {
+	return parser.ConsultReader(r, opts...)
+}
@@ -0,0 +1,51 @@
+package terms
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// TestParse tests that the terms entry points return plain terms
+func TestParse(t *testing.T) {
+	input := `{route, "/", index_handler}. #{port => 8080}. <<"bin">>.`
+
+	check := func(name string, items []Term, err error) {
+		if err != nil {
+			t.Fatalf("%s: Failed to parse: %v", name, err)
+		}
+		if len(items) != 3 {
+			t.Fatalf("%s: Expected 3 terms, got %d", name, len(items))
+		}
+		if _, ok := items[0].(Tuple); !ok {
+			t.Errorf("%s: Expected Tuple, got %T", name, items[0])
+		}
+		if port, ok := items[1].(Map).Get(Atom{Value: "port"}); !ok || !port.Compare(Integer{Value: 8080}) {
+			t.Errorf("%s: Unexpected map %s", name, items[1])
+		}
+		// The aliases are the same types as in package parser
+		if _, ok := items[2].(parser.Binary); !ok {
+			t.Errorf("%s: Expected parser.Binary, got %T", name, items[2])
+		}
+	}
+
+	items, err := Parse(input)
+	check("Parse", items, err)
+
+	items, err = ParseReader(strings.NewReader(input))
+	check("ParseReader", items, err)
+
+	path := filepath.Join(t.TempDir(), "routes.config")
+	if err := os.WriteFile(path, []byte(input), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	items, err = Consult(path, parser.WithMmap())
+	check("Consult", items, err)
+
+	if _, err := Parse(`{unterminated`); err == nil {
+		t.Error("Expected syntax error")
+	}
+}
@@ -0,0 +1,193 @@
+// Package termtest 提供用于基于属性测试的随机 Erlang 项生成器。
+// @pkg 生成的项都是合法的、可以被格式化后重新解析的 Erlang 项，
+// 可直接配合 testing/quick 使用，验证诸如 Parse(Format(x)) == x 之类的性质。
+//
+// 示例:
+//
+//	err := quick.Check(func(c termtest.Config) bool {
+//	  reparsed, err := parser.Parse(c.Format(2))
+//	  return err == nil && c.Equal(reparsed)
+//	}, nil)
+package termtest
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// AnyTerm 包装一个随机生成的 Term，实现 quick.Generator
+type AnyTerm struct {
+	parser.Term
+}
+
+// Generate 实现 quick.Generator
+// @pkg size 控制嵌套深度和容器的最大长度
+func (AnyTerm) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(AnyTerm{Term(r, size)})
+}
+
+// Config 包装一个随机生成的 RebarConfig，实现 quick.Generator
+type Config struct {
+	*parser.RebarConfig
+}
+
+// Generate 实现 quick.Generator
+// @pkg 生成的配置包含最多 size 个顶级项，大多数是 rebar.config 风格的 {key, Value} 元组
+func (Config) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(Config{RebarConfig(r, size)})
+}
+
+// Equal 比较两个配置的顶级项是否逐一相等
+func (c Config) Equal(other *parser.RebarConfig) bool {
+	if len(c.Terms) != len(other.Terms) {
+		return false
+	}
+	for i := range c.Terms {
+		if !c.Terms[i].Compare(other.Terms[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// RebarConfig 生成一个随机配置
+// 输入:
+//   - r: 随机数源
+//   - size: 顶级项的最大数量，同时控制每个项的复杂度
+//
+// 输出:
+//   - *parser.RebarConfig: 随机配置，Raw 为空
+func RebarConfig(r *rand.Rand, size int) *parser.RebarConfig {
+	n := 0
+	if size > 0 {
+		n = r.Intn(size + 1)
+	}
+
+	terms := make([]parser.Term, n)
+	for i := range terms {
+		if r.Intn(4) == 0 {
+			terms[i] = Term(r, size)
+		} else {
+			terms[i] = parser.Tuple{Elements: []parser.Term{bareAtom(r), Term(r, size)}}
+		}
+	}
+	return &parser.RebarConfig{Terms: terms}
+}
+
+// Term 生成一个随机项
+// 输入:
+//   - r: 随机数源
+//   - size: 最大嵌套深度和容器的最大长度，为 0 时只生成标量
+//
+// 输出:
+//   - parser.Term: 随机项
+func Term(r *rand.Rand, size int) parser.Term {
+	kinds := 8
+	if size <= 0 {
+		kinds = 5
+	}
+
+	switch r.Intn(kinds) {
+	case 0:
+		return Atom(r)
+	case 1:
+		return String(r)
+	case 2:
+		return parser.Integer{Value: r.Int63() - r.Int63()}
+	case 3:
+		return Float(r)
+	case 4:
+		return Binary(r)
+	case 5:
+		return parser.Tuple{Elements: elements(r, size)}
+	case 6:
+		return parser.List{Elements: elements(r, size)}
+	default:
+		return Map(r, size)
+	}
+}
+
+// Map 生成一个随机映射，键是互不相同的标量
+func Map(r *rand.Rand, size int) parser.Map {
+	m := parser.Map{Pairs: []parser.MapPair{}}
+	for i := r.Intn(size + 1); i > 0; i-- {
+		key := Term(r, 0)
+		if _, dup := m.Get(key); dup {
+			continue
+		}
+		m.Pairs = append(m.Pairs, parser.MapPair{Key: key, Value: Term(r, size/2)})
+	}
+	return m
+}
+
+// elements 生成容器的元素，元素的复杂度逐层递减
+func elements(r *rand.Rand, size int) []parser.Term {
+	elems := make([]parser.Term, r.Intn(size+1))
+	for i := range elems {
+		elems[i] = Term(r, size/2)
+	}
+	return elems
+}
+
+// Atom 生成一个随机原子，可能是普通原子，也可能是需要引号的原子
+func Atom(r *rand.Rand) parser.Atom {
+	if r.Intn(3) == 0 {
+		return parser.Atom{Value: randomText(r, quotedAtomChars), IsQuoted: true}
+	}
+	return bareAtom(r)
+}
+
+// String 生成一个随机字符串，可能包含需要转义的字符
+func String(r *rand.Rand) parser.String {
+	return parser.String{Value: randomText(r, stringChars)}
+}
+
+// Binary 生成一个随机二进制，可能是文本，也可能是任意字节
+func Binary(r *rand.Rand) parser.Binary {
+	if r.Intn(2) == 0 {
+		return parser.Binary{Value: randomText(r, stringChars)}
+	}
+	b := make([]byte, r.Intn(12))
+	r.Read(b)
+	return parser.Binary{Value: string(b)}
+}
+
+// Float 生成一个随机浮点数
+// @pkg 生成的值总有小数部分，保证格式化后仍被解析为浮点数
+func Float(r *rand.Rand) parser.Float {
+	v := r.NormFloat64() * 1000
+	if v == float64(int64(v)) {
+		v += 0.5
+	}
+	return parser.Float{Value: v}
+}
+
+const (
+	atomStartChars  = "abcdefghijklmnopqrstuvwxyz"
+	atomChars       = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_@"
+	quotedAtomChars = "abcXYZ019 -.:/'\\\"_"
+	stringChars     = "abcXYZ019 -.:/'\\\"\n\t{}[],%é中"
+)
+
+// bareAtom 生成一个不需要引号的原子
+func bareAtom(r *rand.Rand) parser.Atom {
+	var b strings.Builder
+	b.WriteByte(atomStartChars[r.Intn(len(atomStartChars))])
+	for i := r.Intn(10); i > 0; i-- {
+		b.WriteByte(atomChars[r.Intn(len(atomChars))])
+	}
+	return parser.Atom{Value: b.String()}
+}
+
+// randomText 从字符集中随机选取字符组成文本
+func randomText(r *rand.Rand, charset string) string {
+	chars := []rune(charset)
+	var b strings.Builder
+	for i := r.Intn(12); i > 0; i-- {
+		b.WriteRune(chars[r.Intn(len(chars))])
+	}
+	return b.String()
+}
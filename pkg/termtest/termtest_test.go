@@ -0,0 +1,60 @@
+package termtest
+
+import (
+	"bytes"
+	"encoding/gob"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/scagogogo/erlang-rebar-config-parser/pkg/parser"
+)
+
+// TestFormatRoundTrip checks Parse(Format(x)) == x for generated configs
+func TestFormatRoundTrip(t *testing.T) {
+	property := func(c Config) bool {
+		formatted := c.Format(2)
+		reparsed, err := parser.Parse(formatted)
+		if err != nil {
+			t.Logf("Formatted output does not parse: %v\n%s", err, formatted)
+			return false
+		}
+		return c.Equal(reparsed)
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 200}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestGobRoundTrip checks that generated configs survive a gob round trip unchanged
+func TestGobRoundTrip(t *testing.T) {
+	property := func(c Config) bool {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(c.Terms); err != nil {
+			t.Logf("Failed to encode: %v", err)
+			return false
+		}
+		var decoded []parser.Term
+		if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+			t.Logf("Failed to decode: %v", err)
+			return false
+		}
+		return len(c.Terms) == 0 && len(decoded) == 0 || reflect.DeepEqual(decoded, c.Terms)
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 200}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestGeneratorDeterminism checks that a seeded source reproduces the same terms
+func TestGeneratorDeterminism(t *testing.T) {
+	a := RebarConfig(rand.New(rand.NewSource(42)), 8)
+	b := RebarConfig(rand.New(rand.NewSource(42)), 8)
+	if !(Config{a}).Equal(b) {
+		t.Error("Expected identical configs from the same seed")
+	}
+	if leaf := Term(rand.New(rand.NewSource(1)), 0); leaf == nil {
+		t.Error("Expected a scalar term for size 0")
+	}
+}